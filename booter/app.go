@@ -15,6 +15,8 @@ type Config struct {
 	Datadir          string
 	VaultPath        string
 	PrivateKey       string
+	DryRun           bool // Resolve and diff the boot sequence against the chain, without applying anything
+	Incremental      bool // Apply only the missing or changed ops when the boot sequence of a booted chain changed
 }
 
 type App struct {
@@ -30,6 +30,10 @@ type booter struct {
 type state struct {
 	Revision string    `json:"revision"`
 	BootedAt time.Time `json:"booted_at"`
+
+	// AppliedOps are the digests of the boot sequence ops applied on chain,
+	// empty for chains booted before ops were recorded
+	AppliedOps []string `json:"applied_ops,omitempty"`
 }
 
 func newBooter(config *Config) *booter {
@@ -76,7 +80,7 @@ func (b *booter) Launch() {
 		return
 	}
 
-	if booterState != nil && booter.Revision() == booterState.Revision {
+	if booterState != nil && booter.Revision() == booterState.Revision && !b.config.DryRun {
 		zlog.Info("chain has already been booted",
 			zap.String("boot_sequence_revision", booterState.Revision),
 			zap.Time("booted_at", booterState.BootedAt),
@@ -85,27 +89,86 @@ func (b *booter) Launch() {
 	}
 
 	b.waitOnNodeosReady()
-	zlog.Info("nodeos is ready, starting injection")
 
-	bootSeqChecksum, err := booter.Run()
+	ctx := context.Background()
+	plan, err := b.resolvePlan(ctx)
 	if err != nil {
-		zlog.Error("failed to boot chain", zap.Error(err))
+		zlog.Error("failed to resolve boot sequence", zap.Error(err))
 		b.Shutdown(err)
 		return
 	}
 
-	zlog.Info("booter successfully ran",
-		zap.String("bootseq_checksum", bootSeqChecksum),
+	if b.config.DryRun {
+		if err := plan.diff(ctx, b.nodeos, booterState); err != nil {
+			zlog.Error("failed to diff boot sequence against chain state", zap.Error(err))
+			b.Shutdown(err)
+			return
+		}
+
+		plan.print(os.Stdout, true)
+		zlog.Info("dry-run completed, nothing was applied")
+		b.Shutdown(nil)
+		return
+	}
+
+	if booterState == nil || !b.config.Incremental {
+		zlog.Info("nodeos is ready, starting injection")
+
+		bootSeqChecksum, err := booter.Run()
+		if err != nil {
+			zlog.Error("failed to boot chain", zap.Error(err))
+			b.Shutdown(err)
+			return
+		}
+
+		zlog.Info("booter successfully ran",
+			zap.String("bootseq_checksum", bootSeqChecksum),
+		)
+
+		if err := b.storeState(bootSeqChecksum, plan.appliedDigests()); err != nil {
+			zlog.Error("failed to store booter state", zap.Error(err))
+			b.Shutdown(err)
+		}
+		return
+	}
+
+	zlog.Info("chain was booted with another boot sequence revision, applying missing or changed ops",
+		zap.String("booted_revision", booterState.Revision),
+		zap.String("boot_sequence_revision", plan.revision),
 	)
 
-	err = b.storeState(bootSeqChecksum)
-	if err != nil {
-		zlog.Error("failed to store booter state", zap.Error(err))
+	if err := plan.diff(ctx, b.nodeos, booterState); err != nil {
+		zlog.Error("failed to diff boot sequence against chain state", zap.Error(err))
 		b.Shutdown(err)
 		return
 	}
 
-	return
+	for _, op := range plan.ops {
+		if op.status == opUnverified {
+			zlog.Warn("boot sequence op cannot be checked against chain state, not applying it",
+				zap.Int("index", op.index),
+				zap.String("op", op.step.Op),
+				zap.String("label", op.step.Label),
+			)
+		}
+	}
+
+	pending := plan.pending()
+	if err := b.pushOps(ctx, plan, keybag, pending); err != nil {
+		zlog.Error("failed to apply boot sequence ops", zap.Error(err))
+		b.Shutdown(err)
+		return
+	}
+
+	zlog.Info("booter successfully applied boot sequence ops",
+		zap.String("bootseq_checksum", plan.revision),
+		zap.Int("applied_op_count", len(pending)),
+	)
+
+	if err := b.storeState(plan.revision, plan.appliedDigests()); err != nil {
+		zlog.Error("failed to store booter state", zap.Error(err))
+		b.Shutdown(err)
+	}
 }
 
 func (b *booter) cleanUp(err error) {
@@ -127,12 +190,13 @@ func (b *booter) waitOnNodeosReady() {
 	}
 }
 
-func (b *booter) storeState(bootSeqChecksum string) error {
+func (b *booter) storeState(bootSeqChecksum string, appliedOps []string) error {
 	zlog.Debug("storing booter state")
 
 	s := &state{
-		Revision: bootSeqChecksum,
-		BootedAt: time.Now(),
+		Revision:   bootSeqChecksum,
+		BootedAt:   time.Now(),
+		AppliedOps: appliedOps,
 	}
 
 	cnt, err := json.Marshal(s)
@@ -0,0 +1,196 @@
+package booter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+// chainReader is the part of the nodeos API used to diff the boot
+// sequence against the chain state
+type chainReader interface {
+	GetAccount(ctx context.Context, name eos.AccountName, opts ...eos.GetAccountOption) (*eos.AccountResp, error)
+	GetCodeHash(ctx context.Context, account eos.AccountName) (eos.Checksum256, error)
+	GetRawABI(ctx context.Context, params eos.GetRawABIRequest) (*eos.GetRawABIResp, error)
+}
+
+type actionCheck struct {
+	verifiable bool
+	inSync     bool
+	reason     string
+}
+
+func inSyncCheck() actionCheck {
+	return actionCheck{verifiable: true, inSync: true}
+}
+
+func outOfSyncCheck(format string, args ...interface{}) actionCheck {
+	return actionCheck{verifiable: true, reason: fmt.Sprintf(format, args...)}
+}
+
+// checkAction compares the effect of an action with the chain state. Only
+// accounts, permissions, privileges, code and ABIs can be compared, other
+// actions are reported as not verifiable.
+func checkAction(ctx context.Context, chain chainReader, action *eos.Action) (actionCheck, error) {
+	if action.Account != "eosio" {
+		return actionCheck{}, nil
+	}
+
+	switch data := dereference(action.ActionData.Data).(type) {
+	case system.NewAccount:
+		account, err := getAccount(ctx, chain, data.Name)
+		if err != nil || account == nil {
+			return outOfSyncCheck("account %s is missing", data.Name), err
+		}
+		return inSyncCheck(), nil
+
+	case system.UpdateAuth:
+		account, err := getAccount(ctx, chain, data.Account)
+		if err != nil || account == nil {
+			return outOfSyncCheck("account %s is missing", data.Account), err
+		}
+
+		for _, permission := range account.Permissions {
+			if permission.PermName != string(data.Permission) {
+				continue
+			}
+
+			if permission.Parent != string(data.Parent) || !sameAuthority(permission.RequiredAuth, data.Auth) {
+				return outOfSyncCheck("permission %s@%s differs", data.Account, data.Permission), nil
+			}
+			return inSyncCheck(), nil
+		}
+		return outOfSyncCheck("permission %s@%s is missing", data.Account, data.Permission), nil
+
+	case system.SetPriv:
+		account, err := getAccount(ctx, chain, data.Account)
+		if err != nil || account == nil {
+			return outOfSyncCheck("account %s is missing", data.Account), err
+		}
+
+		if account.Privileged != bool(data.IsPriv) {
+			return outOfSyncCheck("privileged flag of %s differs", data.Account), nil
+		}
+		return inSyncCheck(), nil
+
+	case system.SetCode:
+		codeHash, err := chain.GetCodeHash(ctx, data.Account)
+		if err == eos.ErrNotFound {
+			return outOfSyncCheck("account %s is missing", data.Account), nil
+		}
+		if err != nil {
+			return actionCheck{}, fmt.Errorf("unable to get code hash of %s: %w", data.Account, err)
+		}
+
+		expected := sha256.Sum256(data.Code)
+		if !bytes.Equal(codeHash, expected[:]) {
+			return outOfSyncCheck("code of %s differs", data.Account), nil
+		}
+		return inSyncCheck(), nil
+
+	case system.SetABI:
+		rawABI, err := chain.GetRawABI(ctx, eos.GetRawABIRequest{AccountName: string(data.Account)})
+		if err == eos.ErrNotFound {
+			return outOfSyncCheck("account %s is missing", data.Account), nil
+		}
+		if err != nil {
+			return actionCheck{}, fmt.Errorf("unable to get ABI of %s: %w", data.Account, err)
+		}
+
+		expected := sha256.Sum256(data.ABI)
+		if !bytes.Equal(rawABI.ABIHash, expected[:]) {
+			return outOfSyncCheck("ABI of %s differs", data.Account), nil
+		}
+		return inSyncCheck(), nil
+	}
+
+	return actionCheck{}, nil
+}
+
+// getAccount returns nil when the account does not exist
+func getAccount(ctx context.Context, chain chainReader, name eos.AccountName) (*eos.AccountResp, error) {
+	account, err := chain.GetAccount(ctx, name)
+	if err == eos.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get account %s: %w", name, err)
+	}
+	return account, nil
+}
+
+// dereference returns the value pointed to by the action data of known
+// types, ops building their actions either by value or by pointer.
+func dereference(data interface{}) interface{} {
+	switch v := data.(type) {
+	case *system.NewAccount:
+		return *v
+	case *system.UpdateAuth:
+		return *v
+	case *system.SetPriv:
+		return *v
+	case *system.SetCode:
+		return *v
+	case *system.SetABI:
+		return *v
+	}
+	return data
+}
+
+// sameAuthority compares two authorities regardless of the order of their
+// keys, accounts and waits.
+func sameAuthority(a, b eos.Authority) bool {
+	if a.Threshold != b.Threshold || len(a.Keys) != len(b.Keys) || len(a.Accounts) != len(b.Accounts) || len(a.Waits) != len(b.Waits) {
+		return false
+	}
+
+	return sameEntries(authorityEntries(a), authorityEntries(b))
+}
+
+func authorityEntries(auth eos.Authority) (out []string) {
+	for _, key := range auth.Keys {
+		out = append(out, fmt.Sprintf("key:%s:%d", key.PublicKey, key.Weight))
+	}
+	for _, account := range auth.Accounts {
+		out = append(out, fmt.Sprintf("account:%s@%s:%d", account.Permission.Actor, account.Permission.Permission, account.Weight))
+	}
+	for _, wait := range auth.Waits {
+		out = append(out, fmt.Sprintf("wait:%d:%d", wait.WaitSec, wait.Weight))
+	}
+	sort.Strings(out)
+	return
+}
+
+func sameEntries(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// describeAction summarizes an action for the dry-run output, the code and
+// ABI content being replaced by their checksum.
+func describeAction(action *eos.Action) string {
+	prefix := fmt.Sprintf("%s:%s", action.Account, action.Name)
+
+	switch data := dereference(action.ActionData.Data).(type) {
+	case system.SetCode:
+		return fmt.Sprintf("%s account=%s code_sha256=%x", prefix, data.Account, sha256.Sum256(data.Code))
+	case system.SetABI:
+		return fmt.Sprintf("%s account=%s abi_sha256=%x", prefix, data.Account, sha256.Sum256(data.ABI))
+	case nil:
+		return fmt.Sprintf("%s %x", prefix, []byte(action.ActionData.HexData))
+	default:
+		return fmt.Sprintf("%s %+v", prefix, data)
+	}
+}
@@ -472,12 +472,15 @@ func (e *exporter) processContractRow(obj *eossnapshot.KeyValueObject) error {
 }
 
 func (e *exporter) decodeTableRow(abi *eos.ABI, obj *eossnapshot.KeyValueObject) ([]byte, error) {
-	tableName := TN(e.currentTable.TableName)
+	return decodeRow(abi, TN(e.currentTable.TableName), obj.Value)
+}
+
+func decodeRow(abi *eos.ABI, tableName eos.TableName, value []byte) ([]byte, error) {
 	tablDef := findTableDefInABI(abi, tableName)
 	if tablDef == nil {
 		return nil, fmt.Errorf("cannot find table definition %q", tableName)
 	}
-	cnt, err := abi.DecodeTableRow(tableName, obj.Value)
+	cnt, err := abi.DecodeTableRow(tableName, value)
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode the data falling back on hex: %w", err)
 	}
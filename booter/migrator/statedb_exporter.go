@@ -0,0 +1,381 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/eoscanada/eos-go"
+	"go.uber.org/zap"
+)
+
+// stateDBExporter exports the migration data of a few contracts from a
+// running statedb at a given block, in the same layout as `exporter` so
+// it can be consumed by the importer.
+//
+// Statedb does not index everything a snapshot contains, the differences
+// with a snapshot export are:
+//   - the contract code is not indexed, an empty `code.wasm` is written
+//     unless the code is provided with `WithContractCode`, the migrated
+//     account then only receives its ABI and tables
+//   - permissions are read from the permission history of statedb, which
+//     only holds the blocks processed since it was indexed: accounts without
+//     any indexed permission get `owner` and `active` using the authority
+//     given by `WithFallbackAuthority` (`eosio@active` by default), as do
+//     the permissions referenced by links but not indexed
+//   - secondary indexes are not indexed, only primary rows are exported
+//   - the accounts paying for the rows are exported without any table so
+//     they get created on the target chain
+type stateDBExporter struct {
+	*exporter
+
+	client            pbstatedb.StateClient
+	permissionsClient pbstatedb.PermissionsClient
+	blockNum          uint64
+	contracts         []eos.AccountName
+	codes             map[eos.AccountName][]byte
+	fallbackAuthority eos.Authority
+}
+
+type StateDBOption func(e *stateDBExporter) *stateDBExporter
+
+func WithStateDBLogger(logger *zap.Logger) StateDBOption {
+	return func(e *stateDBExporter) *stateDBExporter {
+		e.logger = logger
+		return e
+	}
+}
+
+// WithContractCode sets the code written for `contract`, statedb not
+// indexing contract code.
+func WithContractCode(contract eos.AccountName, code []byte) StateDBOption {
+	return func(e *stateDBExporter) *stateDBExporter {
+		e.codes[contract] = code
+		return e
+	}
+}
+
+// WithFallbackAuthority sets the authority of the permissions created for
+// the exported accounts without any permission indexed by statedb.
+func WithFallbackAuthority(authority eos.Authority) StateDBOption {
+	return func(e *stateDBExporter) *stateDBExporter {
+		e.fallbackAuthority = authority
+		return e
+	}
+}
+
+func NewStateDBExporter(client pbstatedb.StateClient, permissionsClient pbstatedb.PermissionsClient, blockNum uint64, contracts []eos.AccountName, dataDir string, opts ...StateDBOption) (*stateDBExporter, error) {
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("at least one contract must be exported")
+	}
+
+	e := &stateDBExporter{
+		exporter: &exporter{
+			logger:        zap.NewNop(),
+			outputDataDir: dataDir,
+			accounts:      map[eos.AccountName]*Account{},
+			tableScopes:   map[string]*tableScope{},
+		},
+		client:            client,
+		permissionsClient: permissionsClient,
+		blockNum:          blockNum,
+		contracts:         contracts,
+		codes:             map[eos.AccountName][]byte{},
+		fallbackAuthority: eos.Authority{
+			Threshold: 1,
+			Accounts: []eos.PermissionLevelWeight{
+				{Permission: eos.PermissionLevel{Actor: AN("eosio"), Permission: PN("active")}, Weight: 1},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		e = opt(e)
+	}
+
+	return e, nil
+}
+
+func (e *stateDBExporter) Export(ctx context.Context) error {
+	for _, contract := range e.contracts {
+		if err := e.processContract(ctx, contract); err != nil {
+			return fmt.Errorf("unable to process contract %q: %w", contract, err)
+		}
+	}
+
+	for name, account := range e.accounts {
+		if err := e.processPermissions(ctx, account); err != nil {
+			return fmt.Errorf("unable to process permissions of account %q: %w", name, err)
+		}
+
+		if err := e.exportAccount(name, account); err != nil {
+			return fmt.Errorf("failed to export account %q: %w", name, err)
+		}
+	}
+
+	for key, tableScope := range e.tableScopes {
+		if err := e.exportTableScope(tableScope); err != nil {
+			return fmt.Errorf("failed to export table-scope %s : %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *stateDBExporter) processContract(ctx context.Context, contract eos.AccountName) error {
+	abiResp, err := e.client.GetABI(ctx, &pbstatedb.GetABIRequest{Contract: string(contract), BlockNum: e.blockNum})
+	if err != nil {
+		return fmt.Errorf("unable to get ABI: %w", err)
+	}
+
+	if len(abiResp.RawAbi) == 0 {
+		return fmt.Errorf("no ABI found at block %d", e.blockNum)
+	}
+
+	abi := new(eos.ABI)
+	if err := eos.UnmarshalBinary(abiResp.RawAbi, abi); err != nil {
+		return fmt.Errorf("unable to decode ABI: %w", err)
+	}
+
+	linksResp, err := e.client.GetPermissionLinks(ctx, &pbstatedb.GetPermissionLinksRequest{Account: string(contract), BlockNum: e.blockNum})
+	if err != nil {
+		return fmt.Errorf("unable to get permission links: %w", err)
+	}
+
+	account, err := e.addAccount(contract)
+	if err != nil {
+		return err
+	}
+
+	account.abi = abi
+	account.ctr = NewContract(abiResp.RawAbi, e.codes[contract])
+	if account.ctr.Code == nil {
+		account.ctr.Code = []byte{}
+	}
+
+	for _, link := range linksResp.Permissions {
+		account.info.LinkAuths = append(account.info.LinkAuths, &LinkAuth{
+			Permission: link.PermissionName,
+			Contract:   link.Contract,
+			Action:     link.Action,
+		})
+	}
+
+	for _, table := range abi.Tables {
+		if err := e.processTable(ctx, account, table.Name); err != nil {
+			return fmt.Errorf("unable to process table %q: %w", table.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *stateDBExporter) processTable(ctx context.Context, account *Account, table eos.TableName) error {
+	stream, err := e.client.StreamMultiScopesTableRows(ctx, &pbstatedb.StreamMultiScopesTableRowsRequest{
+		BlockNum: e.blockNum,
+		Contract: account.name,
+		Table:    string(table),
+		KeyType:  "name",
+		Scopes:   []string{"*"},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to stream table rows: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to receive table rows: %w", err)
+		}
+
+		if len(resp.Rows) == 0 {
+			continue
+		}
+
+		if traceEnable {
+			e.logger.Debug("processing table scope",
+				zap.String("account", account.name),
+				zap.String("table", string(table)),
+				zap.String("scope", resp.Scope),
+				zap.Int("row_count", len(resp.Rows)),
+			)
+		}
+
+		if err := e.processTableScope(account, table, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *stateDBExporter) processTableScope(account *Account, table eos.TableName, resp *pbstatedb.TableRowsScopeResponse) error {
+	key := tableScopeKey(account.name, string(table), resp.Scope)
+	tblScope := &tableScope{
+		account:     AN(account.name),
+		table:       table,
+		scope:       SN(resp.Scope),
+		rows:        make(map[string]*tableRow, len(resp.Rows)),
+		idxToPayers: map[uint64]string{0: resp.Rows[0].Payer},
+	}
+
+	for _, row := range resp.Rows {
+		if _, found := tblScope.rows[row.Key]; found {
+			return fmt.Errorf("failed to process contract table row %s: primary key already seen %q", key, row.Key)
+		}
+
+		tblRow := &tableRow{
+			Key:          row.Key,
+			Payer:        row.Payer,
+			idToSecIndex: map[uint64]*secondaryIndex{},
+		}
+
+		data, err := decodeRow(account.abi, table, row.Data)
+		if err != nil {
+			e.logger.Debug("unable to decode table row",
+				zap.String("account", account.name),
+				zap.String("table", string(table)),
+				zap.String("scope", resp.Scope),
+				zap.String("primary_key", row.Key),
+				zap.String("error", err.Error()),
+			)
+			tblRow.DataHex = row.Data
+		} else {
+			tblRow.DataJSON = data
+		}
+
+		if row.Payer != "" {
+			if _, err := e.addAccount(AN(row.Payer)); err != nil {
+				return err
+			}
+		}
+
+		tblScope.rows[row.Key] = tblRow
+	}
+
+	e.tableScopes[key] = tblScope
+	return nil
+}
+
+// processPermissions sets the permissions of `account` to the ones it has
+// at the exported block, the latest change of each of its permissions.
+func (e *stateDBExporter) processPermissions(ctx context.Context, account *Account) error {
+	permissions, err := e.readPermissions(ctx, account.name)
+	if err != nil {
+		return err
+	}
+
+	if len(permissions) == 0 {
+		e.logger.Debug("no indexed permissions, using fallback authority", zap.String("account", account.name))
+		e.addFallbackPermission(account, PN("owner"), "")
+		e.addFallbackPermission(account, PN("active"), PN("owner"))
+	}
+
+	parents := map[uint64]eos.PermissionName{}
+	for _, permission := range permissions {
+		parents[permission.Id] = PN(permission.Name)
+	}
+
+	for _, permission := range permissions {
+		parent, found := parents[permission.ParentId]
+		if !found && permission.Name != "owner" {
+			parent = PN("owner")
+		}
+
+		authority := eos.Authority{}
+		if permission.Authority != nil {
+			authority = codec.AuthoritiesToEOS(permission.Authority)
+		}
+
+		account.info.Permissions = append(account.info.Permissions, &PermissionObject{
+			Parent:    parent,
+			Owner:     AN(account.name),
+			Name:      PN(permission.Name),
+			Authority: &authority,
+		})
+	}
+
+	for _, link := range account.info.LinkAuths {
+		e.addFallbackPermission(account, PN(link.Permission), PN("active"))
+	}
+
+	return nil
+}
+
+// readPermissions returns the permissions of `account` at the exported block,
+// the history being streamed most recent first, the first change seen of each
+// permission is its current state, removed permissions having no new one.
+func (e *stateDBExporter) readPermissions(ctx context.Context, account string) ([]*pbcodec.PermissionObject, error) {
+	stream, err := e.permissionsClient.StreamPermissionHistory(ctx, &pbstatedb.StreamPermissionHistoryRequest{
+		Account:      account,
+		HighBlockNum: e.blockNum,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to stream permission history: %w", err)
+	}
+
+	var permissions []*pbcodec.PermissionObject
+	seen := map[string]bool{}
+	for {
+		change, err := stream.Recv()
+		if err == io.EOF {
+			return permissions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to receive permission change: %w", err)
+		}
+
+		if seen[change.Permission] {
+			continue
+		}
+		seen[change.Permission] = true
+
+		if change.NewPerm != nil {
+			permissions = append(permissions, change.NewPerm)
+		}
+	}
+}
+
+// addAccount returns the exported account `name`, creating it without any
+// permission when first seen, they are added by `processPermissions`.
+func (e *stateDBExporter) addAccount(name eos.AccountName) (*Account, error) {
+	if account, found := e.accounts[name]; found {
+		return account, nil
+	}
+
+	account, err := newAccount(e.outputDataDir, string(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create account %q: %w", name, err)
+	}
+
+	account.info = &AccountInfo{}
+	account.logger = e.logger
+	e.accounts[name] = account
+	return account, nil
+}
+
+// addFallbackPermission adds the permission `name` using the fallback
+// authority, unless `account` already has it.
+func (e *stateDBExporter) addFallbackPermission(account *Account, name, parent eos.PermissionName) {
+	if name == "eosio.any" {
+		return
+	}
+
+	for _, permission := range account.info.Permissions {
+		if permission.Name == name {
+			return
+		}
+	}
+
+	authority := e.fallbackAuthority
+	account.info.Permissions = append(account.info.Permissions, &PermissionObject{
+		Parent:    parent,
+		Owner:     AN(account.name),
+		Name:      name,
+		Authority: &authority,
+	})
+}
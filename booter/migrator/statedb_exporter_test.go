@@ -0,0 +1,198 @@
+package migrator
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestStateDBExporter_Export(t *testing.T) {
+	abi := &eos.ABI{
+		Version: "eosio::abi/1.1",
+		Structs: []eos.StructDef{
+			{Name: "member", Fields: []eos.FieldDef{{Name: "account", Type: "name"}, {Name: "level", Type: "uint64"}}},
+		},
+		Tables: []eos.TableDef{{Name: "members", IndexType: "i64", Type: "member"}},
+	}
+	rawABI, err := eos.MarshalBinary(abi)
+	require.NoError(t, err)
+
+	rowData, err := abi.EncodeStruct("member", []byte(`{"account":"alice","level":3}`))
+	require.NoError(t, err)
+
+	client := &testStateClient{
+		abis: map[string][]byte{"club": rawABI},
+		links: map[string][]*pbstatedb.LinkedPermission{
+			"club": {{Contract: "club", Action: "join", PermissionName: "joiner"}},
+		},
+		rows: map[string][]*pbstatedb.TableRowsScopeResponse{
+			"club:members": {
+				{Scope: "club", Rows: []*pbstatedb.TableRowResponse{
+					{Key: "alice", Payer: "alice", Data: rowData},
+					{Key: "bob", Payer: "bob", Data: []byte{0x01}},
+				}},
+				{Scope: "empty"},
+			},
+		},
+	}
+
+	clubAuthority := func(actor string) *pbcodec.Authority {
+		return &pbcodec.Authority{Threshold: 1, Accounts: []*pbcodec.PermissionLevelWeight{
+			{Permission: &pbcodec.PermissionLevel{Actor: actor, Permission: "active"}, Weight: 1},
+		}}
+	}
+	clubPermission := func(id, parentID uint64, name, actor string) *pbcodec.PermissionObject {
+		return &pbcodec.PermissionObject{Id: id, ParentId: parentID, Owner: "club", Name: name, Authority: clubAuthority(actor)}
+	}
+
+	// Most recent first, `joiner` was updated and `old` removed
+	permissionsClient := &testPermissionsClient{changes: map[string][]*pbstatedb.PermissionChange{
+		"club": {
+			{BlockNum: 90, Permission: "joiner", OldPerm: clubPermission(3, 2, "joiner", "club"), NewPerm: clubPermission(3, 2, "joiner", "joinbot")},
+			{BlockNum: 80, Permission: "old", OldPerm: clubPermission(4, 2, "old", "club")},
+			{BlockNum: 70, Permission: "old", NewPerm: clubPermission(4, 2, "old", "club")},
+			{BlockNum: 60, Permission: "joiner", NewPerm: clubPermission(3, 2, "joiner", "club")},
+			{BlockNum: 50, Permission: "active", NewPerm: clubPermission(2, 1, "active", "clubadmin")},
+			{BlockNum: 50, Permission: "owner", NewPerm: clubPermission(1, 0, "owner", "clubadmin")},
+		},
+	}}
+
+	dataDir, err := ioutil.TempDir("", "statedb-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	e, err := NewStateDBExporter(client, permissionsClient, 100, []eos.AccountName{"club"}, dataDir, WithContractCode("club", []byte{0xaa}))
+	require.NoError(t, err)
+	require.NoError(t, e.Export(context.Background()))
+
+	importer := newImporter(ecc.PublicKey{}, dataDir, nil, nil)
+	accounts, err := importer.retrieveAccounts(func(account *Account) error { return nil })
+	require.NoError(t, err)
+
+	names := map[string]*Account{}
+	for _, account := range accounts {
+		names[account.name] = account
+	}
+	require.Len(t, names, 3)
+	assert.True(t, names["club"].hasCode)
+	assert.False(t, names["alice"].hasCode)
+
+	club := names["club"]
+	require.NoError(t, club.setupAccountInfo())
+	require.NoError(t, club.setupAbi())
+	assert.Equal(t, []byte{0xaa}, club.ctr.Code)
+	assert.Equal(t, []*LinkAuth{{Permission: "joiner", Contract: "club", Action: "join"}}, club.info.LinkAuths)
+
+	var permissions []string
+	for _, permission := range club.info.sortPermissions() {
+		permissions = append(permissions, string(permission.Parent)+">"+string(permission.Name)+"="+string(permission.Authority.Accounts[0].Permission.Actor))
+	}
+	assert.Equal(t, []string{">owner=clubadmin", "owner>active=clubadmin", "active>joiner=joinbot"}, permissions)
+	assert.Equal(t, uint64(100), permissionsClient.highBlockNums["club"])
+
+	alice := names["alice"]
+	require.NoError(t, alice.setupAccountInfo())
+
+	permissions = nil
+	for _, permission := range alice.info.sortPermissions() {
+		permissions = append(permissions, string(permission.Parent)+">"+string(permission.Name)+"="+string(permission.Authority.Accounts[0].Permission.Actor))
+	}
+	assert.Equal(t, []string{">owner=eosio", "owner>active=eosio"}, permissions)
+
+	tables, err := club.readTableList()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"members"}, tables)
+
+	tblScope, err := club.readTableScope("members", "club")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", tblScope.payer())
+	require.Len(t, tblScope.rows, 2)
+	assert.JSONEq(t, `{"account":"alice","level":3}`, string(tblScope.rows["alice"].DataJSON))
+	assert.Equal(t, eos.HexBytes{0x01}, tblScope.rows["bob"].DataHex)
+
+	_, err = os.Stat(club.scopePath("members", "empty"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestStateDBExporter_MissingABI(t *testing.T) {
+	e, err := NewStateDBExporter(&testStateClient{}, &testPermissionsClient{}, 100, []eos.AccountName{"club"}, "unused")
+	require.NoError(t, err)
+
+	assert.EqualError(t, e.Export(context.Background()), `unable to process contract "club": no ABI found at block 100`)
+}
+
+type testStateClient struct {
+	pbstatedb.StateClient
+
+	abis  map[string][]byte
+	links map[string][]*pbstatedb.LinkedPermission
+	rows  map[string][]*pbstatedb.TableRowsScopeResponse
+}
+
+func (c *testStateClient) GetABI(ctx context.Context, in *pbstatedb.GetABIRequest, opts ...grpc.CallOption) (*pbstatedb.GetABIResponse, error) {
+	return &pbstatedb.GetABIResponse{BlockNum: in.BlockNum, RawAbi: c.abis[in.Contract]}, nil
+}
+
+func (c *testStateClient) GetPermissionLinks(ctx context.Context, in *pbstatedb.GetPermissionLinksRequest, opts ...grpc.CallOption) (*pbstatedb.GetPermissionLinksResponse, error) {
+	return &pbstatedb.GetPermissionLinksResponse{Permissions: c.links[in.Account]}, nil
+}
+
+func (c *testStateClient) StreamMultiScopesTableRows(ctx context.Context, in *pbstatedb.StreamMultiScopesTableRowsRequest, opts ...grpc.CallOption) (pbstatedb.State_StreamMultiScopesTableRowsClient, error) {
+	return &testScopesStream{responses: c.rows[in.Contract+":"+in.Table]}, nil
+}
+
+type testScopesStream struct {
+	grpc.ClientStream
+
+	responses []*pbstatedb.TableRowsScopeResponse
+}
+
+func (s *testScopesStream) Recv() (*pbstatedb.TableRowsScopeResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+type testPermissionsClient struct {
+	changes       map[string][]*pbstatedb.PermissionChange
+	highBlockNums map[string]uint64
+}
+
+func (c *testPermissionsClient) StreamPermissionHistory(ctx context.Context, in *pbstatedb.StreamPermissionHistoryRequest, opts ...grpc.CallOption) (pbstatedb.Permissions_StreamPermissionHistoryClient, error) {
+	if c.highBlockNums == nil {
+		c.highBlockNums = map[string]uint64{}
+	}
+	c.highBlockNums[in.Account] = in.HighBlockNum
+
+	return &testPermissionChangesStream{changes: c.changes[in.Account]}, nil
+}
+
+type testPermissionChangesStream struct {
+	grpc.ClientStream
+
+	changes []*pbstatedb.PermissionChange
+}
+
+func (s *testPermissionChangesStream) Recv() (*pbstatedb.PermissionChange, error) {
+	if len(s.changes) == 0 {
+		return nil, io.EOF
+	}
+
+	change := s.changes[0]
+	s.changes = s.changes[1:]
+	return change, nil
+}
@@ -0,0 +1,405 @@
+package booter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	yaml2json "github.com/bronze1man/go-yaml2json"
+	eosboot "github.com/dfuse-io/eosio-boot"
+	bootconfig "github.com/dfuse-io/eosio-boot/config"
+	"github.com/dfuse-io/eosio-boot/content"
+	"github.com/dfuse-io/eosio-boot/ops"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"go.uber.org/zap"
+)
+
+const maxActionCountPerTrx = 500
+
+// deferredOps are the ops whose `Actions` act on the node directly instead of
+// only emitting actions, `system.enable_protocol_features` scheduling the
+// activation through the producer API and `sleep` waiting for the chain.
+// They are not resolved when planning, so a dry-run never touches the chain,
+// and run only when applied.
+var deferredOps = map[string]bool{
+	"system.enable_protocol_features": true,
+	"sleep":                           true,
+}
+
+type opStatus string
+
+const (
+	// opPending ops are missing or changed on chain, they are applied
+	opPending opStatus = "pending"
+	// opInSync ops are already reflected by the chain state
+	opInSync opStatus = "in-sync"
+	// opApplied ops were recorded as applied by a previous boot
+	opApplied opStatus = "applied"
+	// opUnverified ops cannot be checked against the chain state and the
+	// chain was booted before ops were recorded, they are not applied but
+	// recorded as applied, being assumed to have run at boot
+	opUnverified opStatus = "unverified"
+)
+
+type plannedTransaction struct {
+	actions []*eos.Action
+	signer  ecc.PublicKey
+}
+
+// plannedOp is a boot sequence op resolved into the transactions it pushes
+type plannedOp struct {
+	index        int
+	step         *ops.OperationType
+	signer       ecc.PublicKey
+	deferred     bool
+	transactions []*plannedTransaction
+	digest       string
+	status       opStatus
+	reasons      []string
+}
+
+func (o *plannedOp) actionCount() (count int) {
+	for _, trx := range o.transactions {
+		count += len(trx.actions)
+	}
+	return
+}
+
+// bootPlan is the boot sequence resolved against the target chain
+type bootPlan struct {
+	revision string
+	ops      []*plannedOp
+	keys     map[string]*ecc.PrivateKey
+	opConfig *bootconfig.OpConfig
+}
+
+func (p *bootPlan) pending() (out []*plannedOp) {
+	for _, op := range p.ops {
+		if op.status == opPending {
+			out = append(out, op)
+		}
+	}
+	return
+}
+
+// appliedDigests returns the digests of all the ops of the plan, to be
+// recorded in the booter state once the pending ones are pushed. Unverified
+// ops are included, otherwise the next boot, no longer seeing a legacy state,
+// would push them again.
+func (p *bootPlan) appliedDigests() (out []string) {
+	for _, op := range p.ops {
+		out = append(out, op.digest)
+	}
+	return
+}
+
+// loadBootSeq reads the boot sequence the same way `eosio-boot` does, the
+// checksum of the raw file being its revision.
+func loadBootSeq(filename string) (*eosboot.BootSeq, error) {
+	rawBootSeq, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading boot seq: %w", err)
+	}
+
+	jsonBootSeq, err := yaml2json.Convert(rawBootSeq)
+	if err != nil {
+		return nil, fmt.Errorf("parsing boot seq yaml: %w", err)
+	}
+
+	out := &eosboot.BootSeq{}
+	if err := json.Unmarshal(jsonBootSeq, out); err != nil {
+		return nil, fmt.Errorf("parsing boot seq yaml: %w", err)
+	}
+
+	out.Checksum = fmt.Sprintf("%x", sha256.Sum256(rawBootSeq))
+	return out, nil
+}
+
+// resolvePlan resolves every op of the boot sequence into its actions,
+// without pushing anything. The target nodeos must be reachable, some ops
+// needing the protocol features or ABIs of the chain. Deferred ops are left
+// unresolved, see `deferredOps`.
+func (b *booter) resolvePlan(ctx context.Context) (*bootPlan, error) {
+	bootSeq, err := loadBootSeq(b.config.BootSeqFile)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &bootPlan{revision: bootSeq.Checksum, keys: map[string]*ecc.PrivateKey{}}
+	for label, key := range bootSeq.Keys {
+		privKey, err := ecc.NewPrivateKey(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %q private key: %w", label, err)
+		}
+		plan.keys[label] = privKey
+	}
+
+	contentManager := content.NewManager(filepath.Join(b.config.Datadir, "cache"))
+	contentManager.SetLogger(zlog)
+	if err := contentManager.Download(bootSeq.Contents); err != nil {
+		return nil, fmt.Errorf("unable to download content references: %w", err)
+	}
+
+	features, err := b.nodeos.GetProducerProtocolFeatures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get producer protocol features: %w", err)
+	}
+
+	plan.opConfig = bootconfig.NewOpConfig(bootSeq.Contents, contentManager, plan.keys, b.nodeos, features, zlog)
+	for i, step := range bootSeq.BootSequence {
+		signer, err := opSigner(step, plan.keys)
+		if err != nil {
+			return nil, fmt.Errorf("op #%d (%s): %w", i, step.Op, err)
+		}
+
+		op := &plannedOp{index: i, step: step, signer: signer, deferred: deferredOps[step.Op], status: opPending}
+		if !op.deferred {
+			op.transactions, err = resolveOp(step, signer, plan.opConfig)
+			if err != nil {
+				return nil, fmt.Errorf("op #%d (%s): unable to resolve actions: %w", i, step.Op, err)
+			}
+		}
+
+		// Deferred ops emit no actions, their digest is the same as when they
+		// were resolved upfront, keeping the recorded states valid
+		op.digest, err = opDigest(op.transactions)
+		if err != nil {
+			return nil, fmt.Errorf("op #%d (%s): %w", i, step.Op, err)
+		}
+
+		plan.ops = append(plan.ops, op)
+	}
+
+	return plan, nil
+}
+
+// opSigner returns the key signing the transactions of an op, the one of
+// its `signer` label, defaulting to the `boot` then `ephemeral` keys.
+func opSigner(step *ops.OperationType, keys map[string]*ecc.PrivateKey) (ecc.PublicKey, error) {
+	labels := []string{"boot", "ephemeral"}
+	if step.Signer != "" {
+		labels = []string{step.Signer}
+	}
+
+	for _, label := range labels {
+		if privKey, found := keys[label]; found {
+			return privKey.PublicKey(), nil
+		}
+	}
+
+	return ecc.PublicKey{}, fmt.Errorf("cannot find private key in boot sequence with label %q", strings.Join(labels, " or "))
+}
+
+func resolveOp(step *ops.OperationType, signer ecc.PublicKey, opConfig *bootconfig.OpConfig) (out []*plannedTransaction, err error) {
+	events := make(chan interface{}, 500)
+	done := make(chan error, 1)
+	go func() {
+		defer close(events)
+		done <- step.Data.Actions(signer, opConfig, events)
+	}()
+
+	current := &plannedTransaction{}
+	for event := range events {
+		switch v := event.(type) {
+		case ops.TransactionBoundary:
+			if len(current.actions) > 0 {
+				current.signer = v.Signer
+				out = append(out, current)
+			}
+			current = &plannedTransaction{}
+
+		case *ops.TransactionAction:
+			current.actions = append(current.actions, (*eos.Action)(v))
+			if len(current.actions) >= maxActionCountPerTrx {
+				current.signer = signer
+				out = append(out, current)
+				current = &plannedTransaction{}
+			}
+
+		default:
+			err = fmt.Errorf("unexpected op event of type %T", event)
+		}
+	}
+
+	if actionsErr := <-done; actionsErr != nil {
+		return nil, actionsErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(current.actions) > 0 {
+		current.signer = signer
+		out = append(out, current)
+	}
+
+	return out, nil
+}
+
+// opDigest identifies the content of an op by the binary encoding of its
+// actions, an op being changed as soon as one of its actions changes.
+func opDigest(transactions []*plannedTransaction) (string, error) {
+	hash := sha256.New()
+	for _, trx := range transactions {
+		for _, action := range trx.actions {
+			action.SetToServer(true)
+			data, err := eos.MarshalBinary(action)
+			if err != nil {
+				return "", fmt.Errorf("unable to encode action %s:%s: %w", action.Account, action.Name, err)
+			}
+			hash.Write(data)
+		}
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// diff sets the status of every op of the plan. Ops recorded as applied
+// by `state` are skipped, the others being checked against the chain
+// state. Ops that cannot be checked are applied, unless the chain was
+// booted before ops were recorded in which case there is no way to tell
+// whether they already ran.
+func (p *bootPlan) diff(ctx context.Context, chain chainReader, state *state) error {
+	recorded := map[string]int{}
+	if state != nil {
+		for _, digest := range state.AppliedOps {
+			recorded[digest]++
+		}
+	}
+	legacyState := state != nil && len(state.AppliedOps) == 0
+
+	for _, op := range p.ops {
+		if recorded[op.digest] > 0 {
+			recorded[op.digest]--
+			op.status = opApplied
+			continue
+		}
+
+		verified, inSync := 0, 0
+		op.reasons = nil
+		for _, trx := range op.transactions {
+			for _, action := range trx.actions {
+				check, err := checkAction(ctx, chain, action)
+				if err != nil {
+					return fmt.Errorf("op #%d (%s): %w", op.index, op.step.Op, err)
+				}
+
+				if !check.verifiable {
+					continue
+				}
+
+				verified++
+				if check.inSync {
+					inSync++
+				} else {
+					op.reasons = append(op.reasons, check.reason)
+				}
+			}
+		}
+
+		switch {
+		case op.deferred && legacyState:
+			op.status = opUnverified
+			op.reasons = []string{"acts on the node when applied, cannot be checked against chain state"}
+		case op.deferred:
+			op.status = opPending
+			op.reasons = []string{"not recorded as applied, acts on the node when applied, cannot be checked against chain state"}
+		case verified > 0 && inSync == verified:
+			op.status = opInSync
+		case verified > 0:
+			op.status = opPending
+		case legacyState:
+			op.status = opUnverified
+			op.reasons = []string{"cannot be checked against chain state"}
+		default:
+			op.status = opPending
+			op.reasons = []string{"not recorded as applied"}
+		}
+	}
+
+	return nil
+}
+
+// print writes a human readable description of the plan, with every
+// action of the pending ops when `verbose`.
+func (p *bootPlan) print(w io.Writer, verbose bool) {
+	counts := map[opStatus]int{}
+	for _, op := range p.ops {
+		counts[op.status]++
+	}
+
+	fmt.Fprintf(w, "Boot sequence revision %s: %d ops, %d pending, %d in sync, %d applied, %d unverified\n",
+		p.revision, len(p.ops), counts[opPending], counts[opInSync], counts[opApplied], counts[opUnverified])
+
+	for _, op := range p.ops {
+		label := ""
+		if op.step.Label != "" {
+			label = fmt.Sprintf(" %q", op.step.Label)
+		}
+
+		fmt.Fprintf(w, "  #%-3d %-10s %s%s (%d actions)\n", op.index, op.status, op.step.Op, label, op.actionCount())
+		for _, reason := range op.reasons {
+			fmt.Fprintf(w, "         - %s\n", reason)
+		}
+
+		if verbose && op.status == opPending {
+			for _, trx := range op.transactions {
+				for _, action := range trx.actions {
+					fmt.Fprintf(w, "         > %s\n", describeAction(action))
+				}
+			}
+		}
+	}
+}
+
+// pushOps pushes the transactions of `ops`, in order, deferred ops being
+// resolved, and so acting on the node, right before
+func (b *booter) pushOps(ctx context.Context, plan *bootPlan, keybag *eos.KeyBag, pending []*plannedOp) error {
+	for _, privKey := range plan.keys {
+		keybag.Add(privKey.String())
+	}
+	b.nodeos.SetSigner(keybag)
+
+	for _, op := range pending {
+		zlog.Info("applying boot sequence op",
+			zap.Int("index", op.index),
+			zap.String("op", op.step.Op),
+			zap.String("label", op.step.Label),
+			zap.Strings("reasons", op.reasons),
+		)
+
+		if op.deferred {
+			transactions, err := resolveOp(op.step, op.signer, plan.opConfig)
+			if err != nil {
+				return fmt.Errorf("op #%d (%s): unable to resolve actions: %w", op.index, op.step.Op, err)
+			}
+			op.transactions = transactions
+		}
+
+		for _, trx := range op.transactions {
+			signer := trx.signer
+			b.nodeos.SetCustomGetRequiredKeys(func(ctx context.Context, tx *eos.Transaction) ([]ecc.PublicKey, error) {
+				return []ecc.PublicKey{signer}, nil
+			})
+
+			err := eosboot.Retry(25, time.Second, func() error {
+				_, err := b.nodeos.SignPushActions(ctx, trx.actions...)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("op #%d (%s): unable to push transaction: %w", op.index, op.step.Op, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package booter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/dfuse-io/eosio-boot/ops"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/eoscanada/eos-go/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChain struct {
+	accounts map[eos.AccountName]*eos.AccountResp
+	codes    map[eos.AccountName][]byte
+}
+
+func (c *fakeChain) GetAccount(ctx context.Context, name eos.AccountName, opts ...eos.GetAccountOption) (*eos.AccountResp, error) {
+	if account, found := c.accounts[name]; found {
+		return account, nil
+	}
+	return nil, eos.ErrNotFound
+}
+
+func (c *fakeChain) GetCodeHash(ctx context.Context, account eos.AccountName) (eos.Checksum256, error) {
+	if _, found := c.accounts[account]; !found {
+		return nil, eos.ErrNotFound
+	}
+
+	hash := sha256.Sum256(c.codes[account])
+	return hash[:], nil
+}
+
+func (c *fakeChain) GetRawABI(ctx context.Context, params eos.GetRawABIRequest) (*eos.GetRawABIResp, error) {
+	return &eos.GetRawABIResp{ABIHash: make([]byte, 32)}, nil
+}
+
+func testOp(t *testing.T, index int, actions ...*eos.Action) *plannedOp {
+	op := &plannedOp{
+		index:        index,
+		step:         &ops.OperationType{Op: "test"},
+		transactions: []*plannedTransaction{{actions: actions}},
+		status:       opPending,
+	}
+
+	var err error
+	op.digest, err = opDigest(op.transactions)
+	require.NoError(t, err)
+	return op
+}
+
+func TestBootPlanDiff(t *testing.T) {
+	key, err := ecc.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	activeAuth := eos.Authority{Threshold: 1, Keys: []eos.KeyWeight{{PublicKey: key.PublicKey(), Weight: 1}}}
+	chain := &fakeChain{
+		accounts: map[eos.AccountName]*eos.AccountResp{
+			"eosio.token": {
+				AccountName: "eosio.token",
+				Permissions: []eos.Permission{{PermName: "active", Parent: "owner", RequiredAuth: activeAuth}},
+			},
+		},
+		codes: map[eos.AccountName][]byte{"eosio.token": []byte("token code")},
+	}
+
+	otherAuth := eos.Authority{Threshold: 2, Keys: activeAuth.Keys}
+	transfer := &eos.Action{Account: "eosio.token", Name: "transfer", ActionData: eos.NewActionDataFromHexData([]byte{1})}
+
+	plan := &bootPlan{ops: []*plannedOp{
+		testOp(t, 0, system.NewNewAccount("eosio", "eosio.token", key.PublicKey())),
+		testOp(t, 1, system.NewNewAccount("eosio", "battlefield", key.PublicKey())),
+		testOp(t, 2, system.NewUpdateAuth("eosio.token", "active", "owner", activeAuth, "owner")),
+		testOp(t, 3, system.NewUpdateAuth("eosio.token", "active", "owner", otherAuth, "owner")),
+		testOp(t, 4, system.NewSetCodeContent("eosio.token", []byte("token code"))),
+		testOp(t, 5, system.NewSetCodeContent("eosio.token", []byte("new token code"))),
+		testOp(t, 6, transfer),
+	}}
+
+	require.NoError(t, plan.diff(context.Background(), chain, nil))
+	assert.Equal(t, []opStatus{opInSync, opPending, opInSync, opPending, opInSync, opPending, opPending}, planStatuses(plan))
+	assert.Equal(t, []string{"account battlefield is missing"}, plan.ops[1].reasons)
+	assert.Equal(t, []string{"permission eosio.token@active differs"}, plan.ops[3].reasons)
+	assert.Equal(t, []string{"code of eosio.token differs"}, plan.ops[5].reasons)
+
+	// Chain booted before ops were recorded, unverifiable ops are left alone
+	require.NoError(t, plan.diff(context.Background(), chain, &state{Revision: "abc"}))
+	assert.Equal(t, opUnverified, plan.ops[6].status)
+	assert.Len(t, plan.appliedDigests(), 7)
+
+	// Recorded ops are skipped, whatever the chain state
+	require.NoError(t, plan.diff(context.Background(), chain, &state{Revision: "abc", AppliedOps: []string{plan.ops[5].digest, plan.ops[6].digest}}))
+	assert.Equal(t, []opStatus{opInSync, opPending, opInSync, opPending, opInSync, opApplied, opApplied}, planStatuses(plan))
+	assert.Len(t, plan.pending(), 2)
+
+	out := &bytes.Buffer{}
+	plan.print(out, true)
+	assert.Contains(t, out.String(), "7 ops, 2 pending, 3 in sync, 2 applied, 0 unverified")
+	assert.Contains(t, out.String(), "eosio:newaccount")
+}
+
+func TestBootPlanDiff_LegacyStateRecordsUnverifiedOps(t *testing.T) {
+	key, err := ecc.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	chain := &fakeChain{accounts: map[eos.AccountName]*eos.AccountResp{"eosio.token": {AccountName: "eosio.token"}}}
+	transfer := &eos.Action{Account: "eosio.token", Name: "transfer", ActionData: eos.NewActionDataFromHexData([]byte{1})}
+
+	newPlan := func() *bootPlan {
+		return &bootPlan{ops: []*plannedOp{
+			testOp(t, 0, system.NewNewAccount("eosio", "eosio.token", key.PublicKey())),
+			testOp(t, 1, transfer),
+		}}
+	}
+
+	// First run against a chain booted before ops were recorded
+	plan := newPlan()
+	require.NoError(t, plan.diff(context.Background(), chain, &state{Revision: "abc"}))
+	assert.Equal(t, []opStatus{opInSync, opUnverified}, planStatuses(plan))
+	assert.Len(t, plan.pending(), 0)
+
+	stored := &state{Revision: "def", AppliedOps: plan.appliedDigests()}
+
+	// Second run, the unverified op must not be pushed now that ops are recorded
+	plan = newPlan()
+	require.NoError(t, plan.diff(context.Background(), chain, stored))
+	assert.Equal(t, []opStatus{opApplied, opApplied}, planStatuses(plan))
+	assert.Len(t, plan.pending(), 0)
+}
+
+func TestSameAuthority(t *testing.T) {
+	auth := func(accounts ...string) eos.Authority {
+		out := eos.Authority{Threshold: 1}
+		for _, account := range accounts {
+			out.Accounts = append(out.Accounts, eos.PermissionLevelWeight{Permission: eos.PermissionLevel{Actor: eos.AccountName(account), Permission: "active"}, Weight: 1})
+		}
+		return out
+	}
+
+	assert.True(t, sameAuthority(auth("a", "b"), auth("b", "a")))
+	assert.False(t, sameAuthority(auth("a", "b"), auth("a", "c")))
+	assert.False(t, sameAuthority(auth("a"), auth("a", "b")))
+}
+
+func planStatuses(plan *bootPlan) (out []opStatus) {
+	for _, op := range plan.ops {
+		out = append(out, op.status)
+	}
+	return
+}
+
+func TestResolvePlan_DryRunDoesNotWriteToChain(t *testing.T) {
+	var lock sync.Mutex
+	var calls []string
+	nodeos := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls = append(calls, r.URL.Path)
+		lock.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/producer/get_supported_protocol_features":
+			w.Write([]byte(`[{"feature_digest":"0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd","specification":[{"name":"builtin_feature_codename","value":"PREACTIVATE_FEATURE"}]}]`))
+		case "/v1/producer/schedule_protocol_feature_activations":
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer nodeos.Close()
+
+	bootSeqFile := filepath.Join(t.TempDir(), "bootseq.yaml")
+	require.NoError(t, ioutil.WriteFile(bootSeqFile, []byte(`
+keys:
+  boot: 5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3
+
+boot_sequence:
+- op: system.enable_protocol_features
+  label: Enabling protocol features
+- op: sleep
+  label: Waiting for a block
+  data:
+    duration: 10s
+`), 0644))
+
+	b := newBooter(&Config{NodeosAPIAddress: nodeos.URL, BootSeqFile: bootSeqFile, Datadir: t.TempDir()})
+	plan, err := b.resolvePlan(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, plan.diff(context.Background(), b.nodeos, &state{Revision: "abc", AppliedOps: []string{"other"}}))
+
+	assert.Equal(t, []string{"/v1/producer/get_supported_protocol_features"}, calls, "dry-run must only read from the chain")
+	assert.Equal(t, []opStatus{opPending, opPending}, planStatuses(plan))
+	assert.Contains(t, plan.ops[0].reasons[0], "cannot be checked against chain state")
+
+	// Against a chain booted before ops were recorded, they are unverifiable
+	require.NoError(t, plan.diff(context.Background(), b.nodeos, &state{Revision: "abc"}))
+	assert.Equal(t, []opStatus{opUnverified, opUnverified}, planStatuses(plan))
+
+	// The deferred op acts on the node only once applied
+	require.NoError(t, b.pushOps(context.Background(), plan, eos.NewKeyBag(), plan.ops[:1]))
+	assert.Equal(t, []string{"/v1/producer/get_supported_protocol_features", "/v1/producer/schedule_protocol_feature_activations"}, calls)
+}
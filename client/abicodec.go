@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+)
+
+// DecodeAction decodes the binary `payload` of an `action` of `account`,
+// with the ABI active at `blockNum`, into `out`
+func (c *Client) DecodeAction(ctx context.Context, account, action string, blockNum uint32, payload []byte, out interface{}) error {
+	if c.Decoder == nil {
+		return notConfiguredError("abicodec")
+	}
+
+	resp, err := c.Decoder.DecodeAction(ctx, &pbabicodec.DecodeActionRequest{
+		Account:    account,
+		Action:     action,
+		AtBlockNum: blockNum,
+		Payload:    payload,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to decode action %s::%s: %w", account, action, err)
+	}
+
+	if err := json.Unmarshal([]byte(resp.JsonPayload), out); err != nil {
+		return fmt.Errorf("unable to unmarshal action %s::%s: %w", account, action, err)
+	}
+	return nil
+}
+
+// DecodeTableRow decodes the binary `payload` of a row of the `table` of
+// `account`, with the ABI active at `blockNum`, into `out`
+func (c *Client) DecodeTableRow(ctx context.Context, account, table string, blockNum uint32, payload []byte, out interface{}) error {
+	if c.Decoder == nil {
+		return notConfiguredError("abicodec")
+	}
+
+	resp, err := c.Decoder.DecodeTable(ctx, &pbabicodec.DecodeTableRequest{
+		Account:    account,
+		Table:      table,
+		AtBlockNum: blockNum,
+		Payload:    payload,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to decode row of table %s:%s: %w", account, table, err)
+	}
+
+	if err := json.Unmarshal([]byte(resp.JsonPayload), out); err != nil {
+		return fmt.Errorf("unable to unmarshal row of table %s:%s: %w", account, table, err)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	pbaccounthist "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/accounthist/v1"
+	"github.com/eoscanada/eos-go"
+	"go.uber.org/zap"
+)
+
+type historyOptions struct {
+	pageSize   uint32
+	limit      int
+	cursor     *pbaccounthist.Cursor
+	maxRetries int
+	backoff    time.Duration
+}
+
+type HistoryOption func(*historyOptions)
+
+// WithPageSize sets how many actions are requested per call, defaults to 100
+func WithPageSize(pageSize uint32) HistoryOption {
+	return func(o *historyOptions) { o.pageSize = pageSize }
+}
+
+// WithLimit stops the iteration after `limit` actions, defaults to the
+// whole history
+func WithLimit(limit int) HistoryOption {
+	return func(o *historyOptions) { o.limit = limit }
+}
+
+// FromCursor starts the iteration after the action of `cursor`
+func FromCursor(cursor *pbaccounthist.Cursor) HistoryOption {
+	return func(o *historyOptions) { o.cursor = cursor }
+}
+
+// WithRetries sets how many times in a row a failed call is retried from
+// the cursor of the last received action, waiting `backoff` doubled on
+// each attempt. Defaults to 3 retries starting at 500ms.
+func WithRetries(maxRetries int, backoff time.Duration) HistoryOption {
+	return func(o *historyOptions) {
+		o.maxRetries = maxRetries
+		o.backoff = backoff
+	}
+}
+
+type actionsFetcher func(ctx context.Context, limit uint32, cursor *pbaccounthist.Cursor) (actionsStream, error)
+
+type actionsStream interface {
+	Recv() (*pbaccounthist.ActionResponse, error)
+}
+
+// ActionIterator pages through an account history, most recent action
+// first. It is used like a `sql.Rows`:
+//
+//	it := client.AccountActions(ctx, "eoscanadacom")
+//	for it.Next() {
+//	    fmt.Println(it.Action().ActionTrace.Name())
+//	}
+//	if err := it.Err(); err != nil {
+//	    ...
+//	}
+type ActionIterator struct {
+	ctx     context.Context
+	fetch   actionsFetcher
+	options *historyOptions
+
+	stream       actionsStream
+	cancelStream context.CancelFunc
+	pageLimit    uint32
+	pageCount    uint32
+	attempt      int
+
+	current  *pbaccounthist.ActionResponse
+	cursor   *pbaccounthist.Cursor
+	received int
+	done     bool
+	err      error
+}
+
+// AccountActions iterates over the actions of `account`, served by
+// accounthist in `account` mode
+func (c *Client) AccountActions(ctx context.Context, account string, opts ...HistoryOption) *ActionIterator {
+	if c.AccountHistory == nil {
+		return &ActionIterator{err: notConfiguredError("accounthist")}
+	}
+
+	accountName, err := eos.StringToName(account)
+	if err != nil {
+		return &ActionIterator{err: fmt.Errorf("invalid account %q: %w", account, err)}
+	}
+
+	return newActionIterator(ctx, opts, func(ctx context.Context, limit uint32, cursor *pbaccounthist.Cursor) (actionsStream, error) {
+		return c.AccountHistory.GetActions(ctx, &pbaccounthist.GetActionsRequest{Account: accountName, Limit: limit, Cursor: cursor})
+	})
+}
+
+// AccountContractActions iterates over the actions of `account` on
+// `contract`, served by accounthist in `account-contract` mode
+func (c *Client) AccountContractActions(ctx context.Context, account, contract string, opts ...HistoryOption) *ActionIterator {
+	if c.AccountContractHistory == nil {
+		return &ActionIterator{err: notConfiguredError("accounthist")}
+	}
+
+	accountName, err := eos.StringToName(account)
+	if err != nil {
+		return &ActionIterator{err: fmt.Errorf("invalid account %q: %w", account, err)}
+	}
+
+	contractName, err := eos.StringToName(contract)
+	if err != nil {
+		return &ActionIterator{err: fmt.Errorf("invalid contract %q: %w", contract, err)}
+	}
+
+	return newActionIterator(ctx, opts, func(ctx context.Context, limit uint32, cursor *pbaccounthist.Cursor) (actionsStream, error) {
+		return c.AccountContractHistory.GetAccountContractActions(ctx, &pbaccounthist.GetTokenActionsRequest{
+			Account:  accountName,
+			Contract: contractName,
+			Limit:    limit,
+			Cursor:   cursor,
+		})
+	})
+}
+
+func newActionIterator(ctx context.Context, opts []HistoryOption, fetch actionsFetcher) *ActionIterator {
+	o := &historyOptions{
+		pageSize:   100,
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &ActionIterator{
+		ctx:     ctx,
+		fetch:   fetch,
+		options: o,
+		cursor:  o.cursor,
+	}
+}
+
+// Next advances to the next action, returning false at the end of the
+// history or on error
+func (it *ActionIterator) Next() bool {
+	for {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		if it.options.limit > 0 && it.received >= it.options.limit {
+			it.finish()
+			return false
+		}
+
+		if it.stream == nil {
+			if err := it.openPage(); err != nil {
+				if !it.retry(err) {
+					return false
+				}
+				continue
+			}
+		}
+
+		resp, err := it.stream.Recv()
+		if err == nil {
+			it.current = resp
+			it.cursor = resp.Cursor
+			it.received++
+			it.pageCount++
+			it.attempt = 0
+			return true
+		}
+
+		it.closeStream()
+		if err == io.EOF {
+			// A page shorter than requested is the last one
+			if it.pageCount < it.pageLimit {
+				it.finish()
+				return false
+			}
+			continue
+		}
+
+		if !it.retry(err) {
+			return false
+		}
+	}
+}
+
+// Action returns the current action
+func (it *ActionIterator) Action() *pbaccounthist.ActionResponse {
+	return it.current
+}
+
+// Cursor returns the cursor of the current action, to resume the
+// iteration later on with `FromCursor`
+func (it *ActionIterator) Cursor() *pbaccounthist.Cursor {
+	return it.cursor
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ActionIterator) Err() error {
+	return it.err
+}
+
+func (it *ActionIterator) openPage() error {
+	it.pageLimit = it.options.pageSize
+	if it.options.limit > 0 {
+		if remaining := uint32(it.options.limit - it.received); remaining < it.pageLimit {
+			it.pageLimit = remaining
+		}
+	}
+
+	ctx, cancel := context.WithCancel(it.ctx)
+	stream, err := it.fetch(ctx, it.pageLimit, it.cursor)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	it.stream = stream
+	it.cancelStream = cancel
+	it.pageCount = 0
+	return nil
+}
+
+func (it *ActionIterator) closeStream() {
+	if it.cancelStream != nil {
+		it.cancelStream()
+	}
+	it.stream = nil
+	it.cancelStream = nil
+}
+
+func (it *ActionIterator) finish() {
+	it.closeStream()
+	it.done = true
+}
+
+// retry waits before the next attempt at `err`, returning false when the
+// iteration must stop instead
+func (it *ActionIterator) retry(err error) bool {
+	if it.ctx.Err() != nil {
+		it.err = it.ctx.Err()
+		return false
+	}
+
+	if !IsRetryableError(err) || it.attempt >= it.options.maxRetries {
+		it.err = fmt.Errorf("unable to get actions: %w", err)
+		return false
+	}
+
+	backoff := it.options.backoff << uint(it.attempt)
+	it.attempt++
+	zlog.Info("account history call failed, retrying from last cursor", zap.Error(err), zap.Int("attempt", it.attempt), zap.Duration("backoff", backoff))
+
+	select {
+	case <-it.ctx.Done():
+		it.err = it.ctx.Err()
+		return false
+	case <-time.After(backoff):
+		return true
+	}
+}
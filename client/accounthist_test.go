@@ -0,0 +1,98 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/client"
+	"github.com/dfuse-io/dfuse-eosio/client/clienttest"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClient_AccountActions(t *testing.T) {
+	services := clienttest.New()
+	for i := 1; i <= 5; i++ {
+		services.AccountHistory.AddAction("alice", testAction("eosio.token", i))
+	}
+	services.AccountHistory.AddAction("alice", testAction("eosio", 6))
+
+	it := services.Client().AccountActions(context.Background(), "alice", client.WithPageSize(2))
+	assert.Equal(t, []string{"6", "5", "4", "3", "2", "1"}, testActionIDs(t, it))
+	assert.Equal(t, 4, services.AccountHistory.Calls(), "3 full pages and an empty one")
+	assert.Equal(t, uint64(1), it.Cursor().SequenceNumber)
+
+	it = services.Client().AccountContractActions(context.Background(), "alice", "eosio.token", client.WithPageSize(10))
+	assert.Equal(t, []string{"5", "4", "3", "2", "1"}, testActionIDs(t, it))
+}
+
+func TestClient_AccountActions_LimitAndCursor(t *testing.T) {
+	services := clienttest.New()
+	for i := 1; i <= 5; i++ {
+		services.AccountHistory.AddAction("alice", testAction("eosio.token", i))
+	}
+	c := services.Client()
+
+	it := c.AccountActions(context.Background(), "alice", client.WithLimit(2))
+	assert.Equal(t, []string{"5", "4"}, testActionIDs(t, it))
+
+	it = c.AccountActions(context.Background(), "alice", client.FromCursor(it.Cursor()), client.WithPageSize(2))
+	assert.Equal(t, []string{"3", "2", "1"}, testActionIDs(t, it))
+}
+
+func TestClient_AccountActions_Resume(t *testing.T) {
+	services := clienttest.New()
+	for i := 1; i <= 5; i++ {
+		services.AccountHistory.AddAction("alice", testAction("eosio.token", i))
+	}
+	services.AccountHistory.FailNextCall(2, status.Error(codes.Unavailable, "accounthist restarting"))
+
+	it := services.Client().AccountActions(context.Background(), "alice", client.WithRetries(1, time.Millisecond))
+	assert.Equal(t, []string{"5", "4", "3", "2", "1"}, testActionIDs(t, it))
+	assert.Equal(t, 2, services.AccountHistory.Calls())
+}
+
+func TestClient_AccountActions_ResumeTransportError(t *testing.T) {
+	services := clienttest.New()
+	for i := 1; i <= 3; i++ {
+		services.AccountHistory.AddAction("alice", testAction("eosio.token", i))
+	}
+	services.AccountHistory.FailNextCall(1, io.ErrUnexpectedEOF)
+
+	it := services.Client().AccountActions(context.Background(), "alice", client.WithRetries(1, time.Millisecond))
+	assert.Equal(t, []string{"3", "2", "1"}, testActionIDs(t, it))
+	assert.Equal(t, 2, services.AccountHistory.Calls())
+}
+
+func TestClient_AccountActions_NotRetryable(t *testing.T) {
+	services := clienttest.New()
+	services.AccountHistory.AddAction("alice", testAction("eosio.token", 1))
+	services.AccountHistory.FailNextCall(0, status.Error(codes.InvalidArgument, "invalid"))
+
+	it := services.Client().AccountActions(context.Background(), "alice")
+	assert.False(t, it.Next())
+	assert.Equal(t, codes.InvalidArgument, status.Code(errors.Unwrap(it.Err())))
+	assert.Equal(t, 1, services.AccountHistory.Calls())
+}
+
+func testAction(contract string, id int) *pbcodec.ActionTrace {
+	return &pbcodec.ActionTrace{
+		Receiver: contract,
+		Action:   &pbcodec.Action{Account: contract, Name: "transfer", JsonData: fmt.Sprintf(`{"id":"%d"}`, id)},
+	}
+}
+
+func testActionIDs(t *testing.T, it *client.ActionIterator) (out []string) {
+	for it.Next() {
+		out = append(out, it.Action().ActionTrace.GetData("id").String())
+	}
+	require.NoError(t, it.Err())
+	return out
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// TokenSource returns the token sent as a bearer token with each call, it
+// is called on every call so it can refresh expiring tokens
+type TokenSource func(ctx context.Context) (string, error)
+
+// WithToken authenticates the calls with a fixed token
+func WithToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithTokenSource authenticates the calls with the tokens returned by
+// `source`, for example the `GetAPITokenInfo` of a dfuse `client-go`
+// client, which caches the token until it is about to expire
+func WithTokenSource(source TokenSource) Option {
+	return func(o *options) { o.tokenSource = source }
+}
+
+// tokenCredentials implements `credentials.PerRPCCredentials`
+type tokenCredentials struct {
+	source                   TokenSource
+	requireTransportSecurity bool
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.source(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token: %w", err)
+	}
+
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTransportSecurity
+}
@@ -0,0 +1,179 @@
+// Package client is a Go SDK for the dfuse for EOSIO gRPC services: statedb,
+// tokenmeta, accounthist and abicodec.
+//
+// The raw gRPC clients are exposed as fields of `Client`, the typed helpers
+// decode table rows and actions into Go structs and page through account
+// histories. The `clienttest` package provides in-memory implementations of
+// the services to build a `Client` in tests.
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbaccounthist "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/accounthist/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/streamingfast/dgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client holds the gRPC clients of the services, nil for the services
+// without a configured address
+type Client struct {
+	State                  pbstatedb.StateClient
+	TokenMeta              pbtokenmeta.TokenMetaClient
+	AccountHistory         pbaccounthist.AccountHistoryClient
+	AccountContractHistory pbaccounthist.AccountContractHistoryClient
+	Decoder                pbabicodec.DecoderClient
+
+	conns []*grpc.ClientConn
+}
+
+type options struct {
+	stateDBAddr     string
+	tokenMetaAddr   string
+	accountHistAddr string
+	abiCodecAddr    string
+
+	plainText   bool
+	insecure    bool
+	tokenSource TokenSource
+	dialOptions []grpc.DialOption
+}
+
+type Option func(*options)
+
+// WithStateDBAddr sets the address of the statedb gRPC service
+func WithStateDBAddr(addr string) Option {
+	return func(o *options) { o.stateDBAddr = addr }
+}
+
+// WithTokenMetaAddr sets the address of the tokenmeta gRPC service
+func WithTokenMetaAddr(addr string) Option {
+	return func(o *options) { o.tokenMetaAddr = addr }
+}
+
+// WithAccountHistAddr sets the address of the accounthist gRPC service,
+// serving either the account or the account-contract history depending on
+// its mode
+func WithAccountHistAddr(addr string) Option {
+	return func(o *options) { o.accountHistAddr = addr }
+}
+
+// WithABICodecAddr sets the address of the abicodec gRPC service
+func WithABICodecAddr(addr string) Option {
+	return func(o *options) { o.abiCodecAddr = addr }
+}
+
+// WithPlainText dials the services without TLS
+func WithPlainText() Option {
+	return func(o *options) { o.plainText = true }
+}
+
+// WithInsecure dials the services with TLS, skipping the verification of
+// their certificate
+func WithInsecure() Option {
+	return func(o *options) { o.insecure = true }
+}
+
+// WithDialOptions appends gRPC dial options to the ones used by `dgrpc`
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+// Dial connects to the services whose address is set in `opts`
+func Dial(opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	c := &Client{}
+	dial := func(service, addr string) (*grpc.ClientConn, error) {
+		zlog.Debug("dialing service", zap.String("service", service), zap.String("address", addr))
+		conn, err := dialService(addr, o)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("unable to dial %s at %q: %w", service, addr, err)
+		}
+
+		c.conns = append(c.conns, conn)
+		return conn, nil
+	}
+
+	if o.stateDBAddr != "" {
+		conn, err := dial("statedb", o.stateDBAddr)
+		if err != nil {
+			return nil, err
+		}
+		c.State = pbstatedb.NewStateClient(conn)
+	}
+
+	if o.tokenMetaAddr != "" {
+		conn, err := dial("tokenmeta", o.tokenMetaAddr)
+		if err != nil {
+			return nil, err
+		}
+		c.TokenMeta = pbtokenmeta.NewTokenMetaClient(conn)
+	}
+
+	if o.accountHistAddr != "" {
+		conn, err := dial("accounthist", o.accountHistAddr)
+		if err != nil {
+			return nil, err
+		}
+		c.AccountHistory = pbaccounthist.NewAccountHistoryClient(conn)
+		c.AccountContractHistory = pbaccounthist.NewAccountContractHistoryClient(conn)
+	}
+
+	if o.abiCodecAddr != "" {
+		conn, err := dial("abicodec", o.abiCodecAddr)
+		if err != nil {
+			return nil, err
+		}
+		c.Decoder = pbabicodec.NewDecoderClient(conn)
+	}
+
+	return c, nil
+}
+
+func dialService(addr string, o *options) (*grpc.ClientConn, error) {
+	if o.plainText && o.tokenSource == nil && len(o.dialOptions) == 0 {
+		return dgrpc.NewInternalClient(addr)
+	}
+
+	var dialOptions []grpc.DialOption
+	if o.plainText {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else if o.insecure {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	}
+
+	if o.tokenSource != nil {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(&tokenCredentials{source: o.tokenSource, requireTransportSecurity: !o.plainText}))
+	}
+
+	return dgrpc.NewExternalClient(addr, append(dialOptions, o.dialOptions...)...)
+}
+
+// Close closes the connections opened by `Dial`
+func (c *Client) Close() error {
+	var firstErr error
+	for _, conn := range c.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	c.conns = nil
+	return firstErr
+}
+
+func notConfiguredError(service string) error {
+	return fmt.Errorf("no %s address configured for this client", service)
+}
@@ -0,0 +1,166 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/client"
+	"github.com/dfuse-io/dfuse-eosio/client/clienttest"
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `{
+	"structs": [
+		{"name": "account", "fields": [{"name": "balance", "type": "asset"}]},
+		{"name": "transfer", "fields": [{"name": "from", "type": "name"}, {"name": "to", "type": "name"}, {"name": "quantity", "type": "asset"}]}
+	],
+	"actions": [{"name": "transfer", "type": "transfer"}],
+	"tables": [{"name": "accounts", "index_type": "i64", "type": "account"}]
+}`
+
+type account struct {
+	Balance eos.Asset `json:"balance"`
+}
+
+func TestClient_TableRows(t *testing.T) {
+	services := newTestServices(t)
+	require.NoError(t, services.State.SetRow("eosio.token", "accounts", "bob", "EOS", "bob", account{Balance: eos.NewEOSAsset(20000)}))
+	require.NoError(t, services.State.SetRow("eosio.token", "accounts", "alice", "EOS", "alice", account{Balance: eos.NewEOSAsset(10000)}))
+	require.NoError(t, services.State.SetRow("eosio.token", "accounts", "alice", "WAX", "alice", account{Balance: eos.Asset{Amount: 5, Symbol: eos.Symbol{Precision: 8, Symbol: "WAX"}}}))
+	c := services.Client()
+
+	rows, err := c.TableRows(context.Background(), "eosio.token", "accounts", "alice", client.AtBlock(10))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "EOS", rows[0].Key)
+	assert.Equal(t, "alice", rows[0].Payer)
+	assert.Equal(t, uint64(10), rows[0].BlockNum)
+
+	var accounts []account
+	require.NoError(t, client.DecodeRows(rows, &accounts))
+	assert.Equal(t, "1.0000 EOS", accounts[0].Balance.String())
+	assert.Equal(t, "0.00000005 WAX", accounts[1].Balance.String())
+
+	row, err := c.TableRow(context.Background(), "eosio.token", "accounts", "bob", "EOS")
+	require.NoError(t, err)
+	var bob account
+	require.NoError(t, row.Decode(&bob))
+	assert.Equal(t, "2.0000 EOS", bob.Balance.String())
+
+	row, err = c.TableRow(context.Background(), "eosio.token", "accounts", "carol", "EOS")
+	require.NoError(t, err)
+	assert.Nil(t, row)
+}
+
+func TestClient_TableRows_ABIAtReadBlock(t *testing.T) {
+	services := newTestServices(t)
+	require.NoError(t, services.State.SetRow("eosio.token", "accounts", "alice", "EOS", "alice", account{Balance: eos.NewEOSAsset(10000)}))
+
+	abi, err := eos.NewABI(strings.NewReader(strings.Replace(testABI, `"type": "account"`, `"type": "transfer"`, 1)))
+	require.NoError(t, err)
+	services.State.SetABIAt("eosio.token", 20, abi)
+	services.State.SetBlocks(30, 10)
+	c := services.Client()
+
+	rows, err := c.TableRows(context.Background(), "eosio.token", "accounts", "alice", client.IrreversibleOnly())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint64(10), rows[0].BlockNum)
+	assert.JSONEq(t, `{"balance":"1.0000 EOS"}`, string(rows[0].JSON))
+
+	row, err := c.TableRow(context.Background(), "eosio.token", "accounts", "alice", "EOS", client.IrreversibleOnly())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"balance":"1.0000 EOS"}`, string(row.JSON))
+
+	_, err = c.TableRows(context.Background(), "eosio.token", "accounts", "alice")
+	assert.Error(t, err, "rows read at head should be decoded with the head ABI")
+}
+
+func TestClient_TableRows_NoABI(t *testing.T) {
+	services := newTestServices(t)
+	c := services.Client()
+
+	rows, err := c.TableRows(context.Background(), "unknown", "accounts", "alice")
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	abi, err := c.ABI(context.Background(), "unknown", 0)
+	require.NoError(t, err)
+	assert.Nil(t, abi)
+}
+
+func TestClient_DecodeAction(t *testing.T) {
+	services := newTestServices(t)
+	abi, err := eos.NewABI(strings.NewReader(testABI))
+	require.NoError(t, err)
+
+	payload, err := abi.EncodeAction("transfer", []byte(`{"from":"alice","to":"bob","quantity":"1.0000 EOS"}`))
+	require.NoError(t, err)
+
+	var transfer struct {
+		From     eos.AccountName `json:"from"`
+		Quantity eos.Asset       `json:"quantity"`
+	}
+	require.NoError(t, services.Client().DecodeAction(context.Background(), "eosio.token", "transfer", 10, payload, &transfer))
+	assert.Equal(t, eos.AccountName("alice"), transfer.From)
+	assert.Equal(t, "1.0000 EOS", transfer.Quantity.String())
+
+	err = services.Client().DecodeAction(context.Background(), "unknown", "transfer", 10, payload, &transfer)
+	assert.Error(t, err)
+}
+
+func TestClient_TokenMeta(t *testing.T) {
+	services := newTestServices(t)
+	services.TokenMeta.AddToken(&pbtokenmeta.Token{Contract: "eosio.token", Symbol: "EOS", Precision: 4})
+	services.TokenMeta.AddBalance(&pbtokenmeta.AccountBalance{TokenContract: "eosio.token", Account: "alice", Amount: 10000, Precision: 4, Symbol: "EOS"})
+	services.TokenMeta.AddBalance(&pbtokenmeta.AccountBalance{TokenContract: "eosio.token", Account: "bob", Amount: 5, Precision: 4, Symbol: "EOS"})
+	c := services.Client()
+
+	tokens, err := c.Tokens(context.Background(), "eosio.token")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+
+	balances, err := c.AccountBalances(context.Background(), "alice", true)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.Equal(t, "1.0000 EOS", client.BalanceAsset(balances[0]).String())
+
+	holders, err := c.TokenHolders(context.Background(), "eosio.token", "EOS")
+	require.NoError(t, err)
+	assert.Len(t, holders, 2)
+}
+
+func TestClient_NotConfigured(t *testing.T) {
+	c := &client.Client{}
+
+	_, err := c.TableRows(context.Background(), "eosio.token", "accounts", "alice")
+	assert.EqualError(t, err, "no statedb address configured for this client")
+
+	it := c.AccountActions(context.Background(), "alice")
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "no accounthist address configured for this client")
+}
+
+func newTestServices(t *testing.T) *clienttest.Services {
+	abi, err := eos.NewABI(strings.NewReader(testABI))
+	require.NoError(t, err)
+
+	services := clienttest.New()
+	services.SetABI("eosio.token", abi)
+	return services
+}
+
+func TestDial(t *testing.T) {
+	c, err := client.Dial(client.WithStateDBAddr("localhost:1"), client.WithPlainText(), client.WithToken("token"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.NotNil(t, c.State)
+	assert.Nil(t, c.TokenMeta)
+	assert.Nil(t, c.AccountHistory)
+	assert.Nil(t, c.Decoder)
+}
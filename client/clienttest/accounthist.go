@@ -0,0 +1,122 @@
+package clienttest
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	pbaccounthist "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/accounthist/v1"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/eoscanada/eos-go"
+	"google.golang.org/grpc"
+)
+
+// AccountHistory is an in-memory accounthist, serving both the account
+// and the account-contract histories. Like accounthist, it sends the most
+// recent actions first and resumes after the action of the request cursor.
+type AccountHistory struct {
+	lock    sync.Mutex
+	actions map[historyKey][]*pbcodec.ActionTrace
+	failure *historyFailure
+	calls   int
+}
+
+type historyKey struct {
+	account  uint64
+	contract uint64
+}
+
+type historyFailure struct {
+	after int
+	err   error
+}
+
+func NewAccountHistory() *AccountHistory {
+	return &AccountHistory{actions: map[historyKey][]*pbcodec.ActionTrace{}}
+}
+
+// AddAction appends `trace` to the history of `account`, and to the one of
+// `account` on the contract of the action
+func (h *AccountHistory) AddAction(account string, trace *pbcodec.ActionTrace) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	accountName := eos.MustStringToName(account)
+	h.actions[historyKey{account: accountName}] = append(h.actions[historyKey{account: accountName}], trace)
+
+	contractKey := historyKey{account: accountName, contract: eos.MustStringToName(trace.Account())}
+	h.actions[contractKey] = append(h.actions[contractKey], trace)
+}
+
+// FailNextCall makes the next call fail with `err` after sending `after`
+// actions
+func (h *AccountHistory) FailNextCall(after int, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.failure = &historyFailure{after: after, err: err}
+}
+
+// Calls returns how many calls were received
+func (h *AccountHistory) Calls() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.calls
+}
+
+func (h *AccountHistory) GetActions(ctx context.Context, in *pbaccounthist.GetActionsRequest, opts ...grpc.CallOption) (pbaccounthist.AccountHistory_GetActionsClient, error) {
+	return h.stream(historyKey{account: in.Account}, in.Limit, in.Cursor), nil
+}
+
+func (h *AccountHistory) GetAccountContractActions(ctx context.Context, in *pbaccounthist.GetTokenActionsRequest, opts ...grpc.CallOption) (pbaccounthist.AccountContractHistory_GetAccountContractActionsClient, error) {
+	return h.stream(historyKey{account: in.Account, contract: in.Contract}, in.Limit, in.Cursor), nil
+}
+
+func (h *AccountHistory) stream(key historyKey, limit uint32, cursor *pbaccounthist.Cursor) *actionsStream {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.calls++
+	actions := h.actions[key]
+
+	// Sequence numbers start at 1, from the oldest action
+	seqNum := uint64(len(actions))
+	if cursor != nil && cursor.SequenceNumber <= seqNum {
+		seqNum = cursor.SequenceNumber - 1
+	}
+
+	stream := &actionsStream{failure: h.failure}
+	for ; seqNum > 0 && (limit == 0 || len(stream.responses) < int(limit)); seqNum-- {
+		stream.responses = append(stream.responses, &pbaccounthist.ActionResponse{
+			Cursor:      &pbaccounthist.Cursor{SequenceNumber: seqNum},
+			ActionTrace: actions[seqNum-1],
+		})
+	}
+
+	h.failure = nil
+	return stream
+}
+
+type actionsStream struct {
+	grpc.ClientStream
+
+	responses []*pbaccounthist.ActionResponse
+	failure   *historyFailure
+	sent      int
+}
+
+func (s *actionsStream) Recv() (*pbaccounthist.ActionResponse, error) {
+	if s.failure != nil && s.sent >= s.failure.after {
+		return nil, s.failure.err
+	}
+
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	s.sent++
+	return response, nil
+}
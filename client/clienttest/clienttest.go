@@ -0,0 +1,43 @@
+// Package clienttest provides in-memory implementations of the gRPC
+// services wrapped by the `client` package, to test code using a
+// `client.Client` without running the services.
+package clienttest
+
+import (
+	"github.com/dfuse-io/dfuse-eosio/client"
+	"github.com/eoscanada/eos-go"
+)
+
+// Services groups the in-memory services backing a `client.Client`
+type Services struct {
+	State          *State
+	TokenMeta      *TokenMeta
+	AccountHistory *AccountHistory
+	Decoder        *Decoder
+}
+
+func New() *Services {
+	return &Services{
+		State:          NewState(),
+		TokenMeta:      NewTokenMeta(),
+		AccountHistory: NewAccountHistory(),
+		Decoder:        NewDecoder(),
+	}
+}
+
+// Client returns a client calling the in-memory services
+func (s *Services) Client() *client.Client {
+	return &client.Client{
+		State:                  s.State,
+		TokenMeta:              s.TokenMeta,
+		AccountHistory:         s.AccountHistory,
+		AccountContractHistory: s.AccountHistory,
+		Decoder:                s.Decoder,
+	}
+}
+
+// SetABI sets the ABI of `contract` in both statedb and abicodec
+func (s *Services) SetABI(contract string, abi *eos.ABI) {
+	s.State.SetABI(contract, abi)
+	s.Decoder.SetABI(contract, abi)
+}
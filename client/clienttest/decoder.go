@@ -0,0 +1,83 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	"github.com/eoscanada/eos-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Decoder is an in-memory abicodec decoding with the ABIs set on it,
+// whatever the requested block
+type Decoder struct {
+	lock sync.Mutex
+	abis map[string]*eos.ABI
+}
+
+func NewDecoder() *Decoder {
+	return &Decoder{abis: map[string]*eos.ABI{}}
+}
+
+func (d *Decoder) SetABI(account string, abi *eos.ABI) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.abis[account] = abi
+}
+
+func (d *Decoder) DecodeTable(ctx context.Context, in *pbabicodec.DecodeTableRequest, opts ...grpc.CallOption) (*pbabicodec.Response, error) {
+	abi, err := d.getABI(in.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := abi.DecodeTableRow(eos.TableName(in.Table), in.Payload)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode table row: %s", err)
+	}
+	return &pbabicodec.Response{AbiBlockNum: in.AtBlockNum, JsonPayload: string(out)}, nil
+}
+
+func (d *Decoder) DecodeAction(ctx context.Context, in *pbabicodec.DecodeActionRequest, opts ...grpc.CallOption) (*pbabicodec.Response, error) {
+	abi, err := d.getABI(in.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := abi.DecodeAction(in.Payload, eos.ActionName(in.Action))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode action: %s", err)
+	}
+	return &pbabicodec.Response{AbiBlockNum: in.AtBlockNum, JsonPayload: string(out)}, nil
+}
+
+func (d *Decoder) GetAbi(ctx context.Context, in *pbabicodec.GetAbiRequest, opts ...grpc.CallOption) (*pbabicodec.Response, error) {
+	abi, err := d.getABI(in.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	cnt, err := json.Marshal(abi)
+	if err != nil {
+		return nil, err
+	}
+	return &pbabicodec.Response{AbiBlockNum: in.AtBlockNum, JsonPayload: string(cnt)}, nil
+}
+
+// getABI returns the ABI of `account`, failing like abicodec when there is
+// none
+func (d *Decoder) getABI(account string) (*eos.ABI, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	abi, ok := d.abis[account]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ABI found for account: %s", account)
+	}
+	return abi, nil
+}
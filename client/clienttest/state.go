@@ -0,0 +1,240 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/eoscanada/eos-go"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// State is an in-memory statedb serving the ABIs and table rows set on
+// it. Rows are served whatever the requested block, ABIs are served at the
+// version set for the read block.
+type State struct {
+	pbstatedb.StateClient
+
+	lock         sync.Mutex
+	headBlockNum uint64
+	libNum       uint64
+	abis         map[string][]*abiVersion
+	rows         map[tableScope]map[string]*pbstatedb.TableRowResponse
+}
+
+type abiVersion struct {
+	blockNum uint64
+	abi      *eos.ABI
+}
+
+type tableScope struct {
+	contract string
+	table    string
+	scope    string
+}
+
+func NewState() *State {
+	return &State{
+		abis: map[string][]*abiVersion{},
+		rows: map[tableScope]map[string]*pbstatedb.TableRowResponse{},
+	}
+}
+
+func (s *State) SetABI(contract string, abi *eos.ABI) {
+	s.SetABIAt(contract, 0, abi)
+}
+
+// SetABIAt sets the ABI of `contract` from `blockNum` onward
+func (s *State) SetABIAt(contract string, blockNum uint64, abi *eos.ABI) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	versions := append(s.abis[contract], &abiVersion{blockNum, abi})
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].blockNum < versions[j].blockNum })
+	s.abis[contract] = versions
+}
+
+// SetBlocks sets the head and last irreversible blocks reported with the
+// rows, both default to 0
+func (s *State) SetBlocks(headBlockNum, libNum uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.headBlockNum = headBlockNum
+	s.libNum = libNum
+}
+
+// abiAt returns the ABI of `contract` at `blockNum` (0 for the head block)
+func (s *State) abiAt(contract string, blockNum uint64) *eos.ABI {
+	if blockNum == 0 {
+		blockNum = s.headBlockNum
+	}
+
+	var out *eos.ABI
+	for _, version := range s.abis[contract] {
+		if version.blockNum > blockNum {
+			break
+		}
+		out = version.abi
+	}
+	return out
+}
+
+func (s *State) readBlockNum(blockNum uint64, irreversibleOnly bool) uint64 {
+	if irreversibleOnly {
+		return s.libNum
+	}
+	if blockNum == 0 {
+		return s.headBlockNum
+	}
+	return blockNum
+}
+
+func blockRef(num uint64) *pbbstream.BlockRef {
+	return &pbbstream.BlockRef{Num: num, Id: fmt.Sprintf("%08x", num)}
+}
+
+// SetRow sets the row of `key`, encoding `row` with the ABI of `contract`
+// at the head block
+func (s *State) SetRow(contract, table, scope, key, payer string, row interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	abi := s.abiAt(contract, 0)
+	if abi == nil {
+		return fmt.Errorf("no ABI set for contract %q", contract)
+	}
+
+	cnt, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	data, err := abi.EncodeTable(eos.TableName(table), cnt)
+	if err != nil {
+		return fmt.Errorf("unable to encode row: %w", err)
+	}
+
+	ts := tableScope{contract, table, scope}
+	if s.rows[ts] == nil {
+		s.rows[ts] = map[string]*pbstatedb.TableRowResponse{}
+	}
+	s.rows[ts][key] = &pbstatedb.TableRowResponse{Key: key, Payer: payer, Data: data, Json: string(cnt)}
+	return nil
+}
+
+// DeleteRow deletes the row of `key`
+func (s *State) DeleteRow(contract, table, scope, key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.rows[tableScope{contract, table, scope}], key)
+}
+
+func (s *State) GetABI(ctx context.Context, in *pbstatedb.GetABIRequest, opts ...grpc.CallOption) (*pbstatedb.GetABIResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	abi := s.abiAt(in.Contract, in.BlockNum)
+	if abi == nil {
+		return &pbstatedb.GetABIResponse{}, nil
+	}
+
+	if in.ToJson {
+		cnt, err := json.Marshal(abi)
+		if err != nil {
+			return nil, err
+		}
+		return &pbstatedb.GetABIResponse{BlockNum: in.BlockNum, JsonAbi: string(cnt)}, nil
+	}
+
+	raw, err := eos.MarshalBinary(abi)
+	if err != nil {
+		return nil, err
+	}
+	return &pbstatedb.GetABIResponse{BlockNum: in.BlockNum, RawAbi: raw}, nil
+}
+
+func (s *State) GetTableRow(ctx context.Context, in *pbstatedb.GetTableRowRequest, opts ...grpc.CallOption) (*pbstatedb.GetTableRowResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	blockNum := s.readBlockNum(in.BlockNum, in.IrreversibleOnly)
+	out := &pbstatedb.GetTableRowResponse{LastIrreversibleBlock: blockRef(s.libNum)}
+	if !in.IrreversibleOnly {
+		out.UpToBlock = blockRef(blockNum)
+	}
+
+	if row, ok := s.rows[tableScope{in.Contract, in.Table, in.Scope}][in.PrimaryKey]; ok {
+		out.Row = tableRowResponse(row, in.ToJson, in.WithBlockNum, blockNum)
+	}
+	return out, nil
+}
+
+// StreamTableRows sends the rows ordered by key
+func (s *State) StreamTableRows(ctx context.Context, in *pbstatedb.StreamTableRowsRequest, opts ...grpc.CallOption) (pbstatedb.State_StreamTableRowsClient, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	rows := s.rows[tableScope{in.Contract, in.Table, in.Scope}]
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	blockNum := s.readBlockNum(in.BlockNum, in.IrreversibleOnly)
+	stream := &tableRowsStream{header: metadata.Pairs(
+		pbstatedb.MetdataLastIrrBlockID, blockRef(s.libNum).Id,
+		pbstatedb.MetdataLastIrrBlockNum, strconv.FormatUint(s.libNum, 10),
+	)}
+	if !in.IrreversibleOnly {
+		stream.header.Set(pbstatedb.MetdataUpToBlockID, blockRef(blockNum).Id)
+		stream.header.Set(pbstatedb.MetdataUpToBlockNum, strconv.FormatUint(blockNum, 10))
+	}
+
+	for _, key := range keys {
+		stream.rows = append(stream.rows, tableRowResponse(rows[key], in.ToJson, in.WithBlockNum, blockNum))
+	}
+	return stream, nil
+}
+
+func tableRowResponse(row *pbstatedb.TableRowResponse, toJSON, withBlockNum bool, blockNum uint64) *pbstatedb.TableRowResponse {
+	out := &pbstatedb.TableRowResponse{Key: row.Key, Payer: row.Payer}
+	if toJSON {
+		out.Json = row.Json
+	} else {
+		out.Data = row.Data
+	}
+	if withBlockNum {
+		out.BlockNumber = blockNum
+	}
+	return out
+}
+
+type tableRowsStream struct {
+	grpc.ClientStream
+	header metadata.MD
+	rows   []*pbstatedb.TableRowResponse
+}
+
+func (s *tableRowsStream) Header() (metadata.MD, error) {
+	return s.header, nil
+}
+
+func (s *tableRowsStream) Recv() (*pbstatedb.TableRowResponse, error) {
+	if len(s.rows) == 0 {
+		return nil, io.EOF
+	}
+
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
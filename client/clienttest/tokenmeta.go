@@ -0,0 +1,111 @@
+package clienttest
+
+import (
+	"context"
+	"sync"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"google.golang.org/grpc"
+)
+
+// TokenMeta is an in-memory tokenmeta serving the tokens and balances
+// added to it, in insertion order. Sorting options are ignored.
+type TokenMeta struct {
+	lock     sync.Mutex
+	tokens   []*pbtokenmeta.Token
+	balances []*pbtokenmeta.AccountBalance
+}
+
+func NewTokenMeta() *TokenMeta {
+	return &TokenMeta{}
+}
+
+func (m *TokenMeta) AddToken(token *pbtokenmeta.Token) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.tokens = append(m.tokens, token)
+}
+
+func (m *TokenMeta) AddBalance(balance *pbtokenmeta.AccountBalance) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.balances = append(m.balances, balance)
+}
+
+func (m *TokenMeta) GetTokens(ctx context.Context, in *pbtokenmeta.GetTokensRequest, opts ...grpc.CallOption) (*pbtokenmeta.TokensResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	out := &pbtokenmeta.TokensResponse{}
+	for _, token := range m.tokens {
+		if inFilter(token.Contract, in.FilterTokenContracts) && inFilter(token.Symbol, in.FilterTokenSymbols) {
+			out.Tokens = append(out.Tokens, token)
+		}
+	}
+	if in.Limit > 0 && len(out.Tokens) > int(in.Limit) {
+		out.Tokens = out.Tokens[:in.Limit]
+	}
+	return out, nil
+}
+
+func (m *TokenMeta) GetAccountBalances(ctx context.Context, in *pbtokenmeta.GetAccountBalancesRequest, opts ...grpc.CallOption) (*pbtokenmeta.AccountBalancesResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	out := &pbtokenmeta.AccountBalancesResponse{}
+	for _, balance := range m.balances {
+		if balance.Account == in.Account && inFilter(balance.TokenContract, in.FilterTokenContracts) && inFilter(balance.Symbol, in.FilterTokenSymbols) {
+			out.Balances = append(out.Balances, balance)
+		}
+	}
+	if in.Limit > 0 && len(out.Balances) > int(in.Limit) {
+		out.Balances = out.Balances[:in.Limit]
+	}
+	return out, nil
+}
+
+// GetTokenBalances groups the balances by symbol, like tokenmeta
+func (m *TokenMeta) GetTokenBalances(ctx context.Context, in *pbtokenmeta.GetTokenBalancesRequest, opts ...grpc.CallOption) (*pbtokenmeta.TokenBalancesResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var balances []*pbtokenmeta.AccountBalance
+	for _, balance := range m.balances {
+		if balance.TokenContract == in.TokenContract && inFilter(balance.Symbol, in.FilterTokenSymbols) && inFilter(balance.Account, in.FilterHolderAccounts) {
+			balances = append(balances, balance)
+		}
+	}
+	if in.Limit > 0 && len(balances) > int(in.Limit) {
+		balances = balances[:in.Limit]
+	}
+
+	out := &pbtokenmeta.TokenBalancesResponse{}
+	symbolIndex := map[string]int{}
+	for _, balance := range balances {
+		index, ok := symbolIndex[balance.Symbol]
+		if !ok {
+			out.Tokens = append(out.Tokens, &pbtokenmeta.TokenContractBalancesResponse{
+				Token: &pbtokenmeta.Token{Contract: balance.TokenContract, Symbol: balance.Symbol, Precision: balance.Precision},
+			})
+			index = len(out.Tokens) - 1
+			symbolIndex[balance.Symbol] = index
+		}
+		out.Tokens[index].Balances = append(out.Tokens[index].Balances, balance)
+	}
+	return out, nil
+}
+
+func inFilter(value string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, candidate := range filter {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsRetryableError returns whether a call failing with `err` may succeed when
+// retried. Status codes reporting a faulty request are final, any other error
+// is treated as transient, including the transport ones that carry no gRPC
+// status (connection reset, stream closed by a proxy, ...).
+func IsRetryableError(err error) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return true
+	}
+
+	switch grpcErr.GRPCStatus().Code() {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied, codes.Unauthenticated,
+		codes.FailedPrecondition, codes.OutOfRange, codes.Unimplemented, codes.Canceled:
+		return false
+	}
+	return true
+}
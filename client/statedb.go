@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/eoscanada/eos-go"
+)
+
+// TableRow is a row of a contract table, with its data decoded to JSON
+// through the ABI of the contract
+type TableRow struct {
+	Key      string
+	Payer    string
+	BlockNum uint64
+	Data     []byte
+	JSON     json.RawMessage
+}
+
+// Decode unmarshals the JSON of the row into `out`
+func (r *TableRow) Decode(out interface{}) error {
+	if err := json.Unmarshal(r.JSON, out); err != nil {
+		return fmt.Errorf("unable to decode row %q: %w", r.Key, err)
+	}
+	return nil
+}
+
+// DecodeRows unmarshals the JSON of `rows` into `out`, a pointer to a slice
+func DecodeRows(rows []*TableRow, out interface{}) error {
+	array := make([]json.RawMessage, len(rows))
+	for i, row := range rows {
+		array[i] = row.JSON
+	}
+
+	cnt, err := json.Marshal(array)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(cnt, out); err != nil {
+		return fmt.Errorf("unable to decode rows: %w", err)
+	}
+	return nil
+}
+
+type tableOptions struct {
+	blockNum         uint64
+	keyType          string
+	irreversibleOnly bool
+}
+
+type TableOption func(*tableOptions)
+
+// AtBlock reads the table as it was at `blockNum`, defaults to the head
+// block
+func AtBlock(blockNum uint64) TableOption {
+	return func(o *tableOptions) { o.blockNum = blockNum }
+}
+
+// WithKeyType sets how the row keys are rendered (`name`, `uint64`,
+// `symbol`, ...), defaults to `name`
+func WithKeyType(keyType string) TableOption {
+	return func(o *tableOptions) { o.keyType = keyType }
+}
+
+// IrreversibleOnly reads the table at the last irreversible block
+func IrreversibleOnly() TableOption {
+	return func(o *tableOptions) { o.irreversibleOnly = true }
+}
+
+// abiBlockNum returns the block at which the rows were read, as reported by
+// statedb, so they are decoded with the ABI they were written with even if
+// the head moved in between. Falls back to 0 (the head block) when statedb
+// did not report it.
+func (o *tableOptions) abiBlockNum(upToBlockNum, libNum uint64) uint64 {
+	if o.irreversibleOnly {
+		return libNum
+	}
+	if o.blockNum != 0 {
+		return o.blockNum
+	}
+	if upToBlockNum != 0 {
+		return upToBlockNum
+	}
+	return libNum
+}
+
+func newTableOptions(opts []TableOption) *tableOptions {
+	o := &tableOptions{keyType: "name"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// ABI returns the ABI of `contract` at `blockNum` (0 for the head block),
+// nil when the contract has none
+func (c *Client) ABI(ctx context.Context, contract string, blockNum uint64) (*eos.ABI, error) {
+	if c.State == nil {
+		return nil, notConfiguredError("statedb")
+	}
+
+	resp, err := c.State.GetABI(ctx, &pbstatedb.GetABIRequest{Contract: contract, BlockNum: blockNum, ToJson: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get abi of %q: %w", contract, err)
+	}
+
+	if resp.JsonAbi == "" {
+		return nil, nil
+	}
+
+	abi := &eos.ABI{}
+	if err := json.Unmarshal([]byte(resp.JsonAbi), abi); err != nil {
+		return nil, fmt.Errorf("unable to decode abi of %q: %w", contract, err)
+	}
+	return abi, nil
+}
+
+// TableRows returns the rows of the `scope` of a contract table
+func (c *Client) TableRows(ctx context.Context, contract, table, scope string, opts ...TableOption) ([]*TableRow, error) {
+	if c.State == nil {
+		return nil, notConfiguredError("statedb")
+	}
+
+	o := newTableOptions(opts)
+	stream, err := c.State.StreamTableRows(ctx, &pbstatedb.StreamTableRowsRequest{
+		Contract:         contract,
+		Table:            table,
+		Scope:            scope,
+		BlockNum:         o.blockNum,
+		KeyType:          o.keyType,
+		IrreversibleOnly: o.irreversibleOnly,
+		WithBlockNum:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to stream table rows: %w", err)
+	}
+
+	ref, err := pbstatedb.ExtractStreamReference(stream)
+	if err != nil && err != pbstatedb.ErrStreamReferenceNotFound {
+		return nil, fmt.Errorf("unable to read table rows stream reference: %w", err)
+	}
+
+	var responses []*pbstatedb.TableRowResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to stream table rows: %w", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if len(responses) == 0 {
+		return nil, nil
+	}
+
+	var upToBlockNum, libNum uint64
+	if ref != nil {
+		libNum = ref.LastIrreversibleBlock.Num()
+		if ref.UpToBlock != nil {
+			upToBlockNum = ref.UpToBlock.Num()
+		}
+	}
+
+	abi, err := c.tableABI(ctx, contract, o.abiBlockNum(upToBlockNum, libNum))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*TableRow, len(responses))
+	for i, resp := range responses {
+		if rows[i], err = newTableRow(abi, table, resp); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// TableRow returns the row of `primaryKey` in the `scope` of a contract
+// table, nil when there is no such row
+func (c *Client) TableRow(ctx context.Context, contract, table, scope, primaryKey string, opts ...TableOption) (*TableRow, error) {
+	if c.State == nil {
+		return nil, notConfiguredError("statedb")
+	}
+
+	o := newTableOptions(opts)
+	resp, err := c.State.GetTableRow(ctx, &pbstatedb.GetTableRowRequest{
+		Contract:         contract,
+		Table:            table,
+		Scope:            scope,
+		PrimaryKey:       primaryKey,
+		BlockNum:         o.blockNum,
+		KeyType:          o.keyType,
+		IrreversibleOnly: o.irreversibleOnly,
+		WithBlockNum:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get table row: %w", err)
+	}
+
+	if resp.Row == nil {
+		return nil, nil
+	}
+
+	abi, err := c.tableABI(ctx, contract, o.abiBlockNum(resp.UpToBlock.GetNum(), resp.LastIrreversibleBlock.GetNum()))
+	if err != nil {
+		return nil, err
+	}
+	return newTableRow(abi, table, resp.Row)
+}
+
+func (c *Client) tableABI(ctx context.Context, contract string, blockNum uint64) (*eos.ABI, error) {
+	abi, err := c.ABI(ctx, contract, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if abi == nil {
+		return nil, fmt.Errorf("contract %q has no ABI", contract)
+	}
+	return abi, nil
+}
+
+func newTableRow(abi *eos.ABI, table string, resp *pbstatedb.TableRowResponse) (*TableRow, error) {
+	out, err := abi.DecodeTableRow(eos.TableName(table), resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode row %q of table %q: %w", resp.Key, table, err)
+	}
+
+	return &TableRow{
+		Key:      resp.Key,
+		Payer:    resp.Payer,
+		BlockNum: resp.BlockNumber,
+		Data:     resp.Data,
+		JSON:     out,
+	}, nil
+}
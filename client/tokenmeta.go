@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/eoscanada/eos-go"
+)
+
+// Tokens returns the tokens known to tokenmeta, restricted to the ones of
+// `contracts` when not empty
+func (c *Client) Tokens(ctx context.Context, contracts ...string) ([]*pbtokenmeta.Token, error) {
+	if c.TokenMeta == nil {
+		return nil, notConfiguredError("tokenmeta")
+	}
+
+	resp, err := c.TokenMeta.GetTokens(ctx, &pbtokenmeta.GetTokensRequest{FilterTokenContracts: contracts})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tokens: %w", err)
+	}
+	return resp.Tokens, nil
+}
+
+// AccountBalances returns the token balances of `account`, including its
+// staked EOS when `includeStaked` is set
+func (c *Client) AccountBalances(ctx context.Context, account string, includeStaked bool) ([]*pbtokenmeta.AccountBalance, error) {
+	if c.TokenMeta == nil {
+		return nil, notConfiguredError("tokenmeta")
+	}
+
+	req := &pbtokenmeta.GetAccountBalancesRequest{Account: account}
+	if includeStaked {
+		req.Options = []pbtokenmeta.GetAccountBalancesRequest_Option{pbtokenmeta.GetAccountBalancesRequest_EOS_INCLUDE_STAKED}
+	}
+
+	resp, err := c.TokenMeta.GetAccountBalances(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get balances of %q: %w", account, err)
+	}
+	return resp.Balances, nil
+}
+
+// TokenHolders returns the balances of the holders of the tokens of
+// `contract`, restricted to the tokens of `symbols` when not empty
+func (c *Client) TokenHolders(ctx context.Context, contract string, symbols ...string) ([]*pbtokenmeta.AccountBalance, error) {
+	if c.TokenMeta == nil {
+		return nil, notConfiguredError("tokenmeta")
+	}
+
+	resp, err := c.TokenMeta.GetTokenBalances(ctx, &pbtokenmeta.GetTokenBalancesRequest{
+		TokenContract:      contract,
+		FilterTokenSymbols: symbols,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get holders of %q: %w", contract, err)
+	}
+
+	var out []*pbtokenmeta.AccountBalance
+	for _, token := range resp.Tokens {
+		out = append(out, token.Balances...)
+	}
+	return out, nil
+}
+
+// BalanceAsset returns the amount of `balance` as an asset
+func BalanceAsset(balance *pbtokenmeta.AccountBalance) eos.Asset {
+	return eos.Asset{
+		Amount: eos.Int64(balance.Amount),
+		Symbol: eos.Symbol{Precision: uint8(balance.Precision), Symbol: balance.Symbol},
+	}
+}
@@ -22,6 +22,8 @@ func init() {
 			cmd.Flags().String("booter-data-dir", "{dfuse-data-dir}/booter", "Booter's working directory")
 			cmd.Flags().String("booter-vault-file", "", "Wallet file that contains encrypted key material")
 			cmd.Flags().String("booter-private-key", "", "Genesis private key")
+			cmd.Flags().Bool("booter-dry-run", false, "Print the actions of every boot sequence op along with its status against the chain state (pending, in-sync, applied or unverified), then stop without applying anything")
+			cmd.Flags().Bool("booter-incremental", true, "When the boot sequence of an already booted chain changed, apply only the ops missing or changed on chain instead of the whole boot sequence")
 
 			return nil
 		},
@@ -34,6 +36,8 @@ func init() {
 				Datadir:          mustReplaceDataDir(dfuseDataDir, viper.GetString("booter-data-dir")),
 				VaultPath:        viper.GetString("booter-vault-file"),
 				PrivateKey:       viper.GetString("booter-private-key"),
+				DryRun:           viper.GetBool("booter-dry-run"),
+				Incremental:      viper.GetBool("booter-incremental"),
 			}), nil
 		},
 	})
@@ -48,6 +48,8 @@ const (
 	AccountHistGRPCServingAddr  string = ":13034"
 	FirehoseGRPCServingAddr     string = ":13035"
 	TokenmetaGRPCServingAddr    string = ":14001"
+	NftmetaGRPCServingAddr      string = ":14002"
+	DBOpFeedGRPCServingAddr     string = ":14003"
 	DashboardHTTPListenAddr     string = ":8081"
 	APIProxyHTTPListenAddr      string = ":8080"
 	MindreaderNodeosAPIAddr     string = ":9888"
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	dbopfeedApp "github.com/dfuse-io/dfuse-eosio/dbopfeed/app/dbopfeed"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/dlauncher/launcher"
+)
+
+func init() {
+	launcher.RegisterApp(&launcher.AppDef{
+		ID:          "dbopfeed",
+		Title:       "DB op feed",
+		Description: "Streams the ABI decoded db ops of a contract's tables, read from the firehose",
+		MetricsID:   "dbopfeed",
+		Logger:      launcher.NewLoggingDef("github.com/dfuse-io/dfuse-eosio/dbopfeed.*", nil),
+		RegisterFlags: func(cmd *cobra.Command) error {
+			cmd.Flags().String("dbopfeed-grpc-listen-addr", DBOpFeedGRPCServingAddr, "Address to listen for incoming gRPC requests")
+			cmd.Flags().String("dbopfeed-firehose-addr", FirehoseGRPCServingAddr, "Firehose gRPC address to stream blocks from")
+			cmd.Flags().Bool("dbopfeed-firehose-insecure", true, "Skip the verification of the firehose TLS certificate, the local firehose using a self-signed one")
+			cmd.Flags().Bool("dbopfeed-firehose-plaintext", false, "Reach the firehose without TLS")
+			cmd.Flags().String("dbopfeed-abi-codec-addr", ABICodecServingAddr, "ABI Codec URL")
+			return nil
+		},
+		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
+			return dbopfeedApp.New(&dbopfeedApp.Config{
+				GRPCListenAddr:    viper.GetString("dbopfeed-grpc-listen-addr"),
+				FirehoseAddr:      viper.GetString("dbopfeed-firehose-addr"),
+				FirehoseInsecure:  viper.GetBool("dbopfeed-firehose-insecure"),
+				FirehosePlaintext: viper.GetBool("dbopfeed-firehose-plaintext"),
+				ABICodecAddr:      viper.GetString("dbopfeed-abi-codec-addr"),
+			}), nil
+		},
+	})
+}
@@ -26,6 +26,8 @@ func init() {
 			cmd.Flags().String("dgraphql-auth-url", JWTIssuerURL, "Auth URL used to configure the dfuse js client")
 			cmd.Flags().String("dgraphql-api-key", DgraphqlAPIKey, "API key used in graphiql")
 			cmd.Flags().String("dgraphql-tokenmeta-addr", TokenmetaGRPCServingAddr, "Tokenmeta client endpoint url")
+			cmd.Flags().String("dgraphql-nftmeta-addr", NftmetaGRPCServingAddr, "NFTmeta client endpoint url")
+			cmd.Flags().String("dgraphql-statedb-addr", StateDBGRPCServingAddr, "StateDB client endpoint url")
 			cmd.Flags().String("dgraphql-accounthist-account-addr", AccountHistGRPCServingAddr, "Account history account indexed server client endpoint url, empty string disables the operation")
 			cmd.Flags().String("dgraphql-accounthist-account-contract-addr", "", "Account history account-contract indexed server client endpoint url, empty string disables the operation")
 
@@ -40,6 +42,8 @@ func init() {
 				ABICodecAddr:                   viper.GetString("dgraphql-abi-addr"),
 				BlockMetaAddr:                  viper.GetString("common-blockmeta-addr"),
 				TokenmetaAddr:                  viper.GetString("dgraphql-tokenmeta-addr"),
+				NftmetaAddr:                    viper.GetString("dgraphql-nftmeta-addr"),
+				StateDBAddr:                    viper.GetString("dgraphql-statedb-addr"),
 				AccountHistAccountAddr:         viper.GetString("dgraphql-accounthist-account-addr"),
 				AccountHistAccountContractAddr: viper.GetString("dgraphql-accounthist-account-contract-addr"),
 				KVDBDSN:                        mustReplaceDataDir(dfuseDataDir, viper.GetString("common-trxdb-dsn")),
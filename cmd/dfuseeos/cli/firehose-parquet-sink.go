@@ -0,0 +1,47 @@
+package cli
+
+import (
+	parquetsinkApp "github.com/dfuse-io/dfuse-eosio/parquetsink/app/parquetsink"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/dlauncher/launcher"
+)
+
+func init() {
+	launcher.RegisterApp(&launcher.AppDef{
+		ID:          "firehose-parquet-sink",
+		Title:       "Firehose Parquet sink",
+		Description: "Writes irreversible firehose blocks as Parquet files of blocks, transactions, actions, db ops and RAM ops",
+		MetricsID:   "firehose-parquet-sink",
+		Logger:      launcher.NewLoggingDef("github.com/dfuse-io/dfuse-eosio/parquetsink.*", nil),
+		RegisterFlags: func(cmd *cobra.Command) error {
+			cmd.Flags().String("firehose-parquet-sink-firehose-addr", FirehoseGRPCServingAddr, "Firehose gRPC address to stream blocks from")
+			cmd.Flags().Bool("firehose-parquet-sink-firehose-insecure", true, "Skip the verification of the firehose TLS certificate, the local firehose using a self-signed one")
+			cmd.Flags().Bool("firehose-parquet-sink-firehose-plaintext", false, "Reach the firehose without TLS")
+			cmd.Flags().String("firehose-parquet-sink-store-url", "{dfuse-data-dir}/storage/parquet", "Store URL where Parquet files, the schema and the checkpoint are written")
+			cmd.Flags().Int64("firehose-parquet-sink-start-block-num", 0, "Block to start at when the store holds no checkpoint, negative values being relative to the head block")
+			cmd.Flags().Uint64("firehose-parquet-sink-stop-block-num", 0, "Last block to write, the app stops once it is written (0 to follow the chain)")
+			cmd.Flags().String("firehose-parquet-sink-include-filter-expr", "", "CEL filter expression of the actions to include, same syntax as the firehose include filter")
+			cmd.Flags().String("firehose-parquet-sink-exclude-filter-expr", "", "CEL filter expression of the actions to exclude, same syntax as the firehose exclude filter")
+			cmd.Flags().String("firehose-parquet-sink-details", "full", "Block details requested, 'full' or 'light' (light blocks have no db ops nor RAM ops)")
+			cmd.Flags().Uint64("firehose-parquet-sink-segment-size", 10000, "Number of blocks written in each Parquet file, segments being aligned on multiples of it")
+			return nil
+		},
+		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
+			dfuseDataDir := runtime.AbsDataDir
+
+			return parquetsinkApp.New(&parquetsinkApp.Config{
+				FirehoseAddr:      viper.GetString("firehose-parquet-sink-firehose-addr"),
+				FirehoseInsecure:  viper.GetBool("firehose-parquet-sink-firehose-insecure"),
+				FirehosePlaintext: viper.GetBool("firehose-parquet-sink-firehose-plaintext"),
+				StoreURL:          mustReplaceDataDir(dfuseDataDir, viper.GetString("firehose-parquet-sink-store-url")),
+				StartBlockNum:     viper.GetInt64("firehose-parquet-sink-start-block-num"),
+				StopBlockNum:      viper.GetUint64("firehose-parquet-sink-stop-block-num"),
+				IncludeFilterExpr: viper.GetString("firehose-parquet-sink-include-filter-expr"),
+				ExcludeFilterExpr: viper.GetString("firehose-parquet-sink-exclude-filter-expr"),
+				Details:           viper.GetString("firehose-parquet-sink-details"),
+				SegmentSize:       viper.GetUint64("firehose-parquet-sink-segment-size"),
+			}), nil
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	sqlsinkApp "github.com/dfuse-io/dfuse-eosio/sqlsink/app/sqlsink"
+	_ "github.com/dfuse-io/dfuse-eosio/sqlsink/postgres"
+	_ "github.com/dfuse-io/dfuse-eosio/sqlsink/sqlite"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/dlauncher/launcher"
+)
+
+func init() {
+	launcher.RegisterApp(&launcher.AppDef{
+		ID:          "firehose-sql-sink",
+		Title:       "Firehose SQL sink",
+		Description: "Writes firehose blocks as rows of blocks, transactions, actions and db ops in a SQLite or PostgreSQL database",
+		MetricsID:   "firehose-sql-sink",
+		Logger:      launcher.NewLoggingDef("github.com/dfuse-io/dfuse-eosio/sqlsink.*", nil),
+		RegisterFlags: func(cmd *cobra.Command) error {
+			cmd.Flags().String("firehose-sql-sink-firehose-addr", FirehoseGRPCServingAddr, "Firehose gRPC address to stream blocks from")
+			cmd.Flags().Bool("firehose-sql-sink-firehose-insecure", true, "Skip the verification of the firehose TLS certificate, the local firehose using a self-signed one")
+			cmd.Flags().Bool("firehose-sql-sink-firehose-plaintext", false, "Reach the firehose without TLS")
+			cmd.Flags().String("firehose-sql-sink-dsn", "sqlite3://{dfuse-data-dir}/storage/sqlsink.db", "Database DSN, 'sqlite3://<path>' or 'postgres://<user>:<password>@<host>/<db>?sslmode=disable'")
+			cmd.Flags().String("firehose-sql-sink-abi-codec-addr", ABICodecServingAddr, "ABI codec gRPC address used to decode db op rows as JSON, leave empty to not decode them")
+			cmd.Flags().Int64("firehose-sql-sink-start-block-num", 0, "Block to start at when the database holds no cursor, negative values being relative to the head block")
+			cmd.Flags().Uint64("firehose-sql-sink-stop-block-num", 0, "Last block to write, the app stops once it is written (0 to follow the chain)")
+			cmd.Flags().String("firehose-sql-sink-include-filter-expr", "", "CEL filter expression of the actions to include, same syntax as the firehose include filter")
+			cmd.Flags().String("firehose-sql-sink-exclude-filter-expr", "", "CEL filter expression of the actions to exclude, same syntax as the firehose exclude filter")
+			cmd.Flags().String("firehose-sql-sink-details", "full", "Block details requested, 'full' or 'light' (light blocks have no db ops)")
+			cmd.Flags().Bool("firehose-sql-sink-irreversible-only", false, "Only write irreversible blocks, nothing written being ever undone")
+			return nil
+		},
+		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
+			dfuseDataDir := runtime.AbsDataDir
+
+			return sqlsinkApp.New(&sqlsinkApp.Config{
+				FirehoseAddr:      viper.GetString("firehose-sql-sink-firehose-addr"),
+				FirehoseInsecure:  viper.GetBool("firehose-sql-sink-firehose-insecure"),
+				FirehosePlaintext: viper.GetBool("firehose-sql-sink-firehose-plaintext"),
+				DSN:               mustReplaceDataDir(dfuseDataDir, viper.GetString("firehose-sql-sink-dsn")),
+				ABICodecAddr:      viper.GetString("firehose-sql-sink-abi-codec-addr"),
+				StartBlockNum:     viper.GetInt64("firehose-sql-sink-start-block-num"),
+				StopBlockNum:      viper.GetUint64("firehose-sql-sink-stop-block-num"),
+				IncludeFilterExpr: viper.GetString("firehose-sql-sink-include-filter-expr"),
+				ExcludeFilterExpr: viper.GetString("firehose-sql-sink-exclude-filter-expr"),
+				Details:           viper.GetString("firehose-sql-sink-details"),
+				IrreversibleOnly:  viper.GetBool("firehose-sql-sink-irreversible-only"),
+			}), nil
+		},
+	})
+}
@@ -69,7 +69,7 @@ func dfuseInitE(cmd *cobra.Command, args []string) (err error) {
 }
 
 func Init(runProducer bool, configFile string) error {
-	toRun := []string{"all", "-mindreader-stdin", "-merged-filter", "-accounthist", "-firehose"}
+	toRun := []string{"all", "-mindreader-stdin", "-merged-filter", "-accounthist", "-firehose", "-nftmeta"}
 	if !runProducer {
 		toRun = append(toRun, "-node-manager")
 	}
@@ -59,6 +59,9 @@ func Main() {
 
 	derr.Check("registering application flags", launcher.RegisterFlags(StartCmd))
 
+	// Replay shares the very same flags, both commands binding them under the same names
+	ReplayCmd.Flags().AddFlagSet(StartCmd.Flags())
+
 	var availableCmds []string
 	for app := range launcher.AppRegistry {
 		availableCmds = append(availableCmds, app)
@@ -15,9 +15,18 @@
 package cli
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
 	"github.com/dfuse-io/dfuse-eosio/booter/migrator"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/streamingfast/dgrpc"
 )
 
 var migrateCmd = &cobra.Command{Use: "migrate", Short: "Create chain migration data", RunE: dfuseMigrateE}
@@ -27,6 +36,11 @@ func init() {
 
 	migrateCmd.Flags().StringP("export-dir", "e", "migration-data", "The directory where to export all the migration data.")
 	migrateCmd.Flags().StringP("snapshot-path", "s", "", "The path to the snapshot file used to export the data")
+	migrateCmd.Flags().String("statedb-addr", "", "StateDB gRPC address to export the data from instead of a snapshot, only the contracts of --statedb-contracts are exported")
+	migrateCmd.Flags().Uint64("statedb-block-num", 0, "Block at which the StateDB data is exported, 0 meaning the head block of StateDB")
+	migrateCmd.Flags().StringSlice("statedb-contracts", nil, "Contracts exported from StateDB, with their tables, ABI and permission links")
+	migrateCmd.Flags().StringSlice("statedb-contract-code", nil, "Code of the contracts exported from StateDB, as '<account>=<path to wasm file>', StateDB not indexing contract code")
+	migrateCmd.Flags().String("statedb-authority", "eosio@active", "Authority of the permissions of the accounts exported from StateDB without any indexed permission, a public key or an '<actor>@<permission>'")
 }
 
 func dfuseMigrateE(cmd *cobra.Command, _ []string) error {
@@ -37,9 +51,13 @@ func dfuseMigrateE(cmd *cobra.Command, _ []string) error {
 		cliErrorAndExit("The export-dir flag must be set")
 	}
 
+	if statedbAddr := viper.GetString("statedb-addr"); statedbAddr != "" {
+		return migrateFromStateDB(statedbAddr, exportDir)
+	}
+
 	snapshotPath := viper.GetString("snapshot-path")
 	if snapshotPath == "" {
-		cliErrorAndExit("The snapshot-path flag must be set")
+		cliErrorAndExit("One of the snapshot-path or statedb-addr flags must be set")
 	}
 
 	userLog.Printf("Starting migration with snapshot %q into directory %q", snapshotPath, exportDir)
@@ -56,3 +74,71 @@ func dfuseMigrateE(cmd *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+func migrateFromStateDB(statedbAddr, exportDir string) error {
+	var contracts []eos.AccountName
+	for _, contract := range viper.GetStringSlice("statedb-contracts") {
+		contracts = append(contracts, eos.AccountName(contract))
+	}
+	if len(contracts) == 0 {
+		cliErrorAndExit("The statedb-contracts flag must be set when exporting from StateDB")
+	}
+
+	authority, err := parseMigrateAuthority(viper.GetString("statedb-authority"))
+	if err != nil {
+		cliErrorAndExit("Invalid statedb-authority flag: %s", err)
+	}
+
+	opts := []migrator.StateDBOption{migrator.WithStateDBLogger(zlog), migrator.WithFallbackAuthority(authority)}
+	for _, contractCode := range viper.GetStringSlice("statedb-contract-code") {
+		parts := strings.SplitN(contractCode, "=", 2)
+		if len(parts) != 2 {
+			cliErrorAndExit("Invalid statedb-contract-code %q, expecting '<account>=<path to wasm file>'", contractCode)
+		}
+
+		code, err := ioutil.ReadFile(parts[1])
+		if err != nil {
+			cliErrorAndExit("Unable to read code of contract %q: %s", parts[0], err)
+		}
+		opts = append(opts, migrator.WithContractCode(eos.AccountName(parts[0]), code))
+	}
+
+	conn, err := dgrpc.NewInternalClient(statedbAddr)
+	if err != nil {
+		cliErrorAndExit("Unable to create StateDB client: %s", err)
+	}
+	defer conn.Close()
+
+	blockNum := viper.GetUint64("statedb-block-num")
+	userLog.Printf("Starting migration of %d contracts from StateDB %q at block %d into directory %q", len(contracts), statedbAddr, blockNum, exportDir)
+
+	exporter, err := migrator.NewStateDBExporter(pbstatedb.NewStateClient(conn), pbstatedb.NewPermissionsClient(conn), blockNum, contracts, exportDir, opts...)
+	if err != nil {
+		cliErrorAndExit("Started migration failed: %s", err)
+	}
+
+	err = exporter.Export(context.Background())
+	if err != nil {
+		cliErrorAndExit("Exporting migration data failed: %s", err)
+	}
+
+	return nil
+}
+
+// parseMigrateAuthority parses either a public key or an
+// `<actor>@<permission>` permission level into a single entry authority
+func parseMigrateAuthority(in string) (eos.Authority, error) {
+	if strings.Contains(in, "@") {
+		level, err := eos.NewPermissionLevel(in)
+		if err != nil {
+			return eos.Authority{}, err
+		}
+		return eos.Authority{Threshold: 1, Accounts: []eos.PermissionLevelWeight{{Permission: level, Weight: 1}}}, nil
+	}
+
+	key, err := ecc.NewPublicKey(in)
+	if err != nil {
+		return eos.Authority{}, fmt.Errorf("expecting a public key or an '<actor>@<permission>': %w", err)
+	}
+	return eos.Authority{Threshold: 1, Keys: []eos.KeyWeight{{PublicKey: key, Weight: 1}}}, nil
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	replayApp "github.com/dfuse-io/dfuse-eosio/replay/app/replay"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dlauncher/launcher"
+	"github.com/streamingfast/logging"
+	nodeManager "github.com/streamingfast/node-manager"
+	"github.com/streamingfast/node-manager/metrics"
+	"github.com/streamingfast/node-manager/mindreader"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	appLogger := zap.NewNop()
+	logging.Register("github.com/dfuse-io/dfuse-eosio/mindreader_replay", &appLogger)
+
+	launcher.RegisterApp(&launcher.AppDef{
+		ID:          "mindreader-replay",
+		Title:       "deep-mind reader (replay)",
+		Description: "Blocks reading node, unmanaged, replays a recorded deep-mind log file",
+		MetricsID:   "mindreader-replay",
+		Logger:      launcher.NewLoggingDef("github.com/dfuse-io/dfuse-eosio/mindreader_replay$", []zapcore.Level{zap.WarnLevel, zap.WarnLevel, zap.InfoLevel, zap.DebugLevel}),
+		RegisterFlags: func(cmd *cobra.Command) error {
+			cmd.Flags().String("mindreader-replay-file", "", "Recorded deep-mind log file to replay, optionally gzip or zstd compressed (see 'dfuseeos tools record-deepmind')")
+			cmd.Flags().Float64("mindreader-replay-speed", 0, "Replay speed relative to the chain production rate of 2 blocks per second, 0 replays as fast as possible")
+			cmd.Flags().Duration("mindreader-replay-eof-grace-period", 10*time.Second, "Time given to downstream apps to process the last blocks once the end of the replayed file is reached, before shutting down")
+			return nil
+		},
+		InitFunc: func(runtime *launcher.Runtime) error {
+			if viper.GetString("mindreader-replay-file") == "" {
+				return fmt.Errorf("the deep-mind log file to replay must be specified with --mindreader-replay-file")
+			}
+			return nil
+		},
+		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
+			dfuseDataDir := runtime.AbsDataDir
+			archiveStoreURL := mustReplaceDataDir(dfuseDataDir, viper.GetString("common-oneblock-store-url"))
+			mergeArchiveStoreURL := mustReplaceDataDir(dfuseDataDir, viper.GetString("common-blocks-store-url"))
+
+			maxConsoleLengthInBytes := viper.GetInt("mindreader-max-console-length-in-bytes")
+			consoleReaderFactory := func(reader io.Reader) (mindreader.ConsolerReader, error) {
+				var options []codec.ConsoleReaderOption
+				if maxConsoleLengthInBytes > 0 {
+					options = append(options, codec.LimitConsoleLength(maxConsoleLengthInBytes))
+				}
+
+				return codec.NewConsoleReader(reader, options...)
+			}
+
+			consoleReaderBlockTransformer := func(obj interface{}) (*bstream.Block, error) {
+				blk, ok := obj.(*pbcodec.Block)
+				if !ok {
+					return nil, fmt.Errorf("expected *pbcodec.Block, got %T", obj)
+				}
+
+				return codec.BlockFromProto(blk)
+			}
+
+			metricID := "mindreader-replay"
+			headBlockTimeDrift := metrics.NewHeadBlockTimeDrift(metricID)
+			headBlockNumber := metrics.NewHeadBlockNumber(metricID)
+			metricsAndReadinessManager := nodeManager.NewMetricsAndReadinessManager(headBlockTimeDrift, headBlockNumber, viper.GetDuration("mindreader-readiness-max-latency"))
+
+			return replayApp.New(&replayApp.Config{
+				LogPath:                      viper.GetString("mindreader-replay-file"),
+				Speed:                        viper.GetFloat64("mindreader-replay-speed"),
+				EOFGracePeriod:               viper.GetDuration("mindreader-replay-eof-grace-period"),
+				GRPCAddr:                     viper.GetString("mindreader-grpc-listen-addr"),
+				ArchiveStoreURL:              archiveStoreURL,
+				MergeArchiveStoreURL:         mergeArchiveStoreURL,
+				BatchMode:                    viper.GetBool("mindreader-batch-mode"),
+				MergeThresholdBlockAge:       viper.GetDuration("mindreader-merge-threshold-block-age"),
+				MindReadBlocksChanCapacity:   viper.GetInt("mindreader-blocks-chan-capacity"),
+				StartBlockNum:                viper.GetUint64("mindreader-start-block-num"),
+				StopBlockNum:                 viper.GetUint64("mindreader-stop-block-num"),
+				FailOnNonContinuousBlocks:    viper.GetBool("mindreader-fail-on-non-contiguous-block"),
+				WorkingDir:                   mustReplaceDataDir(dfuseDataDir, viper.GetString("mindreader-working-dir")),
+				WaitUploadCompleteOnShutdown: viper.GetDuration("mindreader-wait-upload-complete-on-shutdown"),
+				OneblockSuffix:               viper.GetString("mindreader-oneblock-suffix"),
+			}, &replayApp.Modules{
+				ConsoleReaderFactory:       consoleReaderFactory,
+				ConsoleReaderTransformer:   consoleReaderBlockTransformer,
+				MetricsAndReadinessManager: metricsAndReadinessManager,
+				Tracker:                    runtime.Tracker.Clone(),
+			}, appLogger), nil
+		},
+	})
+}
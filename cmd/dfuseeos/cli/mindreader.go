@@ -65,9 +65,13 @@ func init() {
 			cmd.Flags().Duration("mindreader-auto-backup-modulo", 0, "If non-zero, takes pitreos backups at each interval of <modulo> blocks")
 			cmd.Flags().String("mindreader-auto-snapshot-hostname-match", "", "If non-empty, auto-snapshots will only trigger if os.Hostname() return this value")
 			cmd.Flags().String("mindreader-auto-backup-hostname-match", "", "If non-empty, auto-backups will only trigger if os.Hostname() return this value")
-			cmd.Flags().Int("mindreader-number-of-snapshots-to-keep", 0, "If non-zero, after a successful snapshot, older snapshots will be deleted to only keep that number of recent snapshots")
+			cmd.Flags().Int("mindreader-number-of-snapshots-to-keep", 0, "If non-zero, after a successful snapshot, older verified snapshots will be deleted to only keep that number of recent ones, unverified snapshots (legacy or still uploading) are never deleted")
+			cmd.Flags().Int("mindreader-number-of-hourly-snapshots-to-keep", 0, "If non-zero, snapshot cleanup also retains the most recent verified snapshot of each of the last {n} hours")
+			cmd.Flags().Int("mindreader-number-of-daily-snapshots-to-keep", 0, "If non-zero, snapshot cleanup also retains the most recent verified snapshot of each of the last {n} days")
+			cmd.Flags().Int("mindreader-number-of-weekly-snapshots-to-keep", 0, "If non-zero, snapshot cleanup also retains the most recent verified snapshot of each of the last {n} weeks")
+			cmd.Flags().String("mindreader-snapshot-catalog-listen-addr", "", "If non-empty, serves the snapshot catalog (GET /v1/snapshots) of the snapshot store on this address")
 			cmd.Flags().String("mindreader-restore-backup-name", "", "If non-empty, the node will be restored from that backup every time it starts, even when 'nodeos' data dir is non-empty.")
-			cmd.Flags().String("mindreader-restore-snapshot-name", "", "If non-empty, the node will be restored from that snapshot when it starts, even when 'nodeos' data dir is non-empty.")
+			cmd.Flags().String("mindreader-restore-snapshot-name", "", "If non-empty, the node will be restored from that snapshot when it starts, even when 'nodeos' data dir is non-empty. Either a snapshot name, 'latest' for the most recent verified snapshot or 'below:<block num>' for the closest verified snapshot at or below that block")
 			cmd.Flags().Duration("mindreader-shutdown-delay", 0, "Delay before shutting manager when sigterm received")
 			cmd.Flags().Bool("mindreader-batch-mode", false, "Always write merged-block files directly, overwriting existing files. Use this flag for reprocessing, with a stop-block-num that stops before possible chain reorgs")
 			cmd.Flags().String("mindreader-oneblock-suffix", "", "If non-empty, the oneblock files will be appended with that suffix, so that mindreaders can each write their file for a given block instead of competing for writes.")
@@ -145,6 +149,11 @@ func init() {
 					TrustedProducer:   viper.GetString("mindreader-trusted-producer"),
 					AdditionalArgs:    viper.GetStringSlice("mindreader-nodeos-args"),
 					LogToZap:          viper.GetBool("mindreader-log-to-zap"),
+					SnapshotRetention: superviser.SnapshotRetention{
+						Hourly: viper.GetInt("mindreader-number-of-hourly-snapshots-to-keep"),
+						Daily:  viper.GetInt("mindreader-number-of-daily-snapshots-to-keep"),
+						Weekly: viper.GetInt("mindreader-number-of-weekly-snapshots-to-keep"),
+					},
 				},
 				appLogger,
 			)
@@ -204,7 +213,7 @@ func init() {
 			chainSuperviser.RegisterPostRestoreHandler(mindreaderPlugin.ResetContinuityChecker)
 			chainSuperviser.RegisterLogPlugin(mindreaderPlugin)
 
-			return nodeMindreaderApp.New(&nodeMindreaderApp.Config{
+			app := nodeMindreaderApp.New(&nodeMindreaderApp.Config{
 				ManagerAPIAddress:         viper.GetString("mindreader-manager-api-addr"),
 				ConnectionWatchdog:        viper.GetBool("mindreader-connection-watchdog"),
 				AutoBackupHostnameMatch:   viper.GetString("mindreader-auto-backup-hostname-match"),
@@ -220,7 +229,9 @@ func init() {
 				MindreaderPlugin:             mindreaderPlugin,
 				LaunchConnectionWatchdogFunc: chainSuperviser.LaunchConnectionWatchdog,
 				StartFailureHandlerFunc:      startUpFunc,
-			}, appLogger), nil
+			}, appLogger)
+
+			return withSnapshotCatalog(app, viper.GetString("mindreader-snapshot-catalog-listen-addr"), mustReplaceDataDir(dfuseDataDir, viper.GetString("mindreader-snapshot-store-url")), appLogger), nil
 		},
 	})
 
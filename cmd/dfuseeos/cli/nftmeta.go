@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta"
+	nftmetaApp "github.com/dfuse-io/dfuse-eosio/nftmeta/app/nftmeta"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/dlauncher/launcher"
+)
+
+func init() {
+	launcher.RegisterApp(&launcher.AppDef{
+		ID:          "nftmeta",
+		Title:       "NFTmeta",
+		Description: "Serves ownership and history of non-fungible assets on a given network",
+		MetricsID:   "nftmeta",
+		Logger:      launcher.NewLoggingDef("github.com/dfuse-io/dfuse-eosio/nftmeta.*", nil),
+		RegisterFlags: func(cmd *cobra.Command) error {
+			cmd.Flags().String("nftmeta-grpc-listen-addr", NftmetaGRPCServingAddr, "Address to listen for incoming gRPC requests")
+			cmd.Flags().String("nftmeta-statedb-grpc-addr", StateDBGRPCServingAddr, "StateDB GRPC address, used to bootstrap the index")
+			cmd.Flags().String("nftmeta-abi-codec-addr", ABICodecServingAddr, "ABI Codec URL")
+			cmd.Flags().StringSlice("nftmeta-contracts", []string{"atomicassets@atomicassets", "simpleassets@simpleassets"}, "Indexed asset contracts, as '<kind>@<contract>' (kinds: "+strings.Join(nftmeta.LayoutKinds(), ", ")+")")
+			cmd.Flags().String("nftmeta-cache-file", "{dfuse-data-dir}/nftmeta/nft-index.gob", "Path to GOB file containing the nftmeta index. will try to Load and Save to that cache file")
+			cmd.Flags().Uint32("nftmeta-save-every-n-block", 900, "Save the index after N irreversible blocks processed")
+			cmd.Flags().Int("nftmeta-history-size", 100, "Number of events (mint, transfer, burn, update) kept for each asset (0 for unbounded)")
+			cmd.Flags().Duration("nftmeta-readiness-max-latency", 5*time.Minute, "Healthcheck will return NotServing until last processed block time (HEAD) is within that duration to now (0 to disable)")
+			return nil
+		},
+		FactoryFunc: func(runtime *launcher.Runtime) (app launcher.App, e error) {
+			dfuseDataDir := runtime.AbsDataDir
+
+			return nftmetaApp.New(&nftmetaApp.Config{
+				GRPCListenAddr:      viper.GetString("nftmeta-grpc-listen-addr"),
+				StateDBGRPCAddr:     viper.GetString("nftmeta-statedb-grpc-addr"),
+				BlockStreamAddr:     viper.GetString("common-blockstream-addr"),
+				ABICodecAddr:        viper.GetString("nftmeta-abi-codec-addr"),
+				BlocksStoreURL:      mustReplaceDataDir(dfuseDataDir, viper.GetString("common-blocks-store-url")),
+				Contracts:           viper.GetStringSlice("nftmeta-contracts"),
+				CacheFile:           mustReplaceDataDir(dfuseDataDir, viper.GetString("nftmeta-cache-file")),
+				SaveEveryNBlock:     viper.GetUint32("nftmeta-save-every-n-block"),
+				HistorySize:         viper.GetInt("nftmeta-history-size"),
+				ReadinessMaxLatency: viper.GetDuration("nftmeta-readiness-max-latency"),
+			}, &nftmetaApp.Modules{
+				BlockFilter: runtime.BlockFilter.TransformInPlace,
+				BlockMeta:   runtime.BlockMeta,
+			}), nil
+		},
+	})
+}
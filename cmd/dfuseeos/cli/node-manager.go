@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/dfuse-io/dfuse-eosio/node-manager/election"
 	"github.com/dfuse-io/dfuse-eosio/node-manager/superviser"
 	"github.com/streamingfast/logging"
 	"github.com/spf13/cobra"
@@ -44,7 +45,7 @@ func init() {
 			cmd.Flags().Bool("node-manager-debug-deep-mind", false, "Whether to print all Deepming log lines or not")
 			cmd.Flags().String("node-manager-auto-restore-source", "snapshot", "Enables restore from the latest source. Can be either, 'snapshot' or 'backup'. Do not use 'backup' on single block producing node")
 			cmd.Flags().String("node-manager-restore-backup-name", "", "If non-empty, the node will be restored from that backup every time it starts.")
-			cmd.Flags().String("node-manager-restore-snapshot-name", "", "If non-empty, the node will be restored from that snapshot when it starts.")
+			cmd.Flags().String("node-manager-restore-snapshot-name", "", "If non-empty, the node will be restored from that snapshot when it starts. Either a snapshot name, 'latest' for the most recent verified snapshot or 'below:<block num>' for the closest verified snapshot at or below that block")
 			cmd.Flags().Duration("node-manager-shutdown-delay", 0, "Delay before shutting manager when sigterm received")
 			cmd.Flags().String("node-manager-backup-tag", "default", "tag to identify the backup")
 			cmd.Flags().Bool("node-manager-disable-profiler", true, "Disables the node-manager profiler")
@@ -56,8 +57,17 @@ func init() {
 			cmd.Flags().Duration("node-manager-auto-backup-period", 0, "If non-zero, a backup will be taken every period of {auto-backup-period}. Specify 1h, 2h...")
 			cmd.Flags().Int("node-manager-auto-snapshot-modulo", 0, "If non-zero, a snapshot will be taken every {auto-snapshot-modulo} block.")
 			cmd.Flags().Duration("node-manager-auto-snapshot-period", 0, "If non-zero, a snapshot will be taken every period of {auto-snapshot-period}. Specify 1h, 2h...")
-			cmd.Flags().Int("node-manager-number-of-snapshots-to-keep", 0, "if non-zero, after a successful snapshot, older snapshots will be deleted to only keep that number of recent snapshots")
+			cmd.Flags().Int("node-manager-number-of-snapshots-to-keep", 0, "if non-zero, after a successful snapshot, older verified snapshots will be deleted to only keep that number of recent ones, unverified snapshots (legacy or still uploading) are never deleted")
+			cmd.Flags().Int("node-manager-number-of-hourly-snapshots-to-keep", 0, "If non-zero, snapshot cleanup also retains the most recent verified snapshot of each of the last {n} hours")
+			cmd.Flags().Int("node-manager-number-of-daily-snapshots-to-keep", 0, "If non-zero, snapshot cleanup also retains the most recent verified snapshot of each of the last {n} days")
+			cmd.Flags().Int("node-manager-number-of-weekly-snapshots-to-keep", 0, "If non-zero, snapshot cleanup also retains the most recent verified snapshot of each of the last {n} weeks")
+			cmd.Flags().String("node-manager-snapshot-catalog-listen-addr", "", "If non-empty, serves the snapshot catalog (GET /v1/snapshots) of the snapshot store on this address")
 			cmd.Flags().Bool("node-manager-force-production", true, "Forces the production of blocks")
+			cmd.Flags().String("node-manager-producer-election-dsn", "", "If non-empty, the active producer is elected among the node managers sharing this election instead of using --node-manager-producer-hostname and --node-manager-force-production. Ex: etcd://etcd:2379/bp-failover?ttl=10 or local://bp (same process only)")
+			cmd.Flags().Duration("node-manager-producer-election-max-head-block-lag", 30*time.Second, "The elected producer hands off its role, and candidates do not campaign nor take over, when their head block time is older than this, 0 to disable")
+			cmd.Flags().Duration("node-manager-producer-election-max-production-gap", 0, "If non-zero, the elected producer hands off its role when it did not produce any block for this long, must exceed the interval between two production rounds")
+			cmd.Flags().Duration("node-manager-producer-election-takeover-delay", 15*time.Second, "Delay waited after being elected before resuming production, must exceed the election TTL")
+			cmd.Flags().Duration("node-manager-producer-election-handoff-timeout", 2*time.Minute, "Max time waited for the end of the production round when handing off the producer role")
 			return nil
 		},
 		InitFunc: func(*launcher.Runtime) error {
@@ -80,6 +90,23 @@ func init() {
 			}
 
 			metricsAndReadinessManager := nodeManager.NewMetricsAndReadinessManager(headBlockTimeDrift, headBlockNumber, viper.GetDuration("node-manager-readiness-max-latency"))
+
+			var producerFailover *superviser.ProducerFailoverOptions
+			if dsn := viper.GetString("node-manager-producer-election-dsn"); dsn != "" {
+				elector, err := election.New(dsn)
+				if err != nil {
+					return nil, fmt.Errorf("unable to create producer elector: %w", err)
+				}
+
+				producerFailover = &superviser.ProducerFailoverOptions{
+					Elector:          elector,
+					MaxHeadBlockLag:  viper.GetDuration("node-manager-producer-election-max-head-block-lag"),
+					MaxProductionGap: viper.GetDuration("node-manager-producer-election-max-production-gap"),
+					TakeoverDelay:    viper.GetDuration("node-manager-producer-election-takeover-delay"),
+					HandoffTimeout:   viper.GetDuration("node-manager-producer-election-handoff-timeout"),
+				}
+			}
+
 			chainSuperviser, err := superviser.NewSuperviser(
 				viper.GetBool("node-manager-debug-deep-mind"),
 				metricsAndReadinessManager.UpdateHeadBlock,
@@ -94,6 +121,12 @@ func init() {
 					AdditionalArgs:    viper.GetStringSlice("node-manager-nodeos-args"),
 					ForceProduction:   viper.GetBool("node-manager-force-production"),
 					LogToZap:          viper.GetBool("node-manager-log-to-zap"),
+					ProducerFailover:  producerFailover,
+					SnapshotRetention: superviser.SnapshotRetention{
+						Hourly: viper.GetInt("node-manager-number-of-hourly-snapshots-to-keep"),
+						Daily:  viper.GetInt("node-manager-number-of-daily-snapshots-to-keep"),
+						Weekly: viper.GetInt("node-manager-number-of-weekly-snapshots-to-keep"),
+					},
 				}, appLogger)
 			if err != nil {
 				return nil, fmt.Errorf("unable to create nodeos chain superviser: %w", err)
@@ -122,7 +155,7 @@ func init() {
 				return nil, fmt.Errorf("unable to create chain operator: %w", err)
 			}
 
-			return nodeManagerApp.New(&nodeManagerApp.Config{
+			app := nodeManagerApp.New(&nodeManagerApp.Config{
 				ManagerAPIAddress:         viper.GetString("node-manager-http-listen-addr"),
 				ConnectionWatchdog:        viper.GetBool("node-manager-connection-watchdog"),
 				AutoBackupModulo:          viper.GetInt("node-manager-auto-backup-modulo"),
@@ -135,7 +168,9 @@ func init() {
 				Operator:                     chainOperator,
 				MetricsAndReadinessManager:   metricsAndReadinessManager,
 				LaunchConnectionWatchdogFunc: chainSuperviser.LaunchConnectionWatchdog,
-			}, appLogger), nil
+			}, appLogger)
+
+			return withSnapshotCatalog(app, viper.GetString("node-manager-snapshot-catalog-listen-addr"), mustReplaceDataDir(dfuseDataDir, viper.GetString("node-manager-snapshot-store-url")), appLogger), nil
 
 		},
 	})
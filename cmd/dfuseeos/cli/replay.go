@@ -0,0 +1,97 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/dlauncher/launcher"
+	"go.uber.org/zap"
+)
+
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replays a recorded deep-mind log file through all services, stopping at the end of the file",
+	Long: `Replays a deep-mind log file, as recorded by 'dfuseeos tools record-deepmind', in place of a live node.
+
+Applications are selected like for 'start', the live node ones (mindreader, mindreader-stdin, node-manager
+and booter) being replaced by 'mindreader-replay'. All the flags of 'start' are accepted and the 'start'
+section of the config file applies. All applications are stopped once the whole file was processed.
+
+Usage:
+  dfuseeos replay <file> [all|command1 [command2...]]`,
+	Example: `dfuseeos replay deep-mind.log.zst relayer merger blockmeta --mindreader-replay-speed=10`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    dfuseReplayE,
+}
+
+// replacedByReplay are the apps requiring a live node, not started on replay
+var replacedByReplay = []string{"mindreader", "mindreader-stdin", "node-manager", "booter", "mindreader-replay"}
+
+func init() {
+	RootCmd.AddCommand(ReplayCmd)
+}
+
+func dfuseReplayE(cmd *cobra.Command, args []string) (err error) {
+	dataDir := viper.GetString("global-data-dir")
+	userLog.Debug("dfuseeos binary started", zap.String("data_dir", dataDir))
+
+	file, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid deep-mind log file %q: %w", args[0], err)
+	}
+	viper.Set("mindreader-replay-file", file)
+
+	startArgs := args[1:]
+	if len(startArgs) == 0 {
+		if config := launcher.DfuseConfig["start"]; config != nil {
+			startArgs = config.Args
+		}
+	}
+
+	apps := replayApps(startArgs)
+	userLog.Printf("Replaying deep-mind log file '%s'", file)
+
+	err = Start(dataDir, apps)
+	if err != nil {
+		return fmt.Errorf("unable to launch: %w", err)
+	}
+
+	userLog.Printf("Goodbye")
+	return
+}
+
+// replayApps resolves the apps to start from `args`, as given to `start`,
+// swapping the live node apps for `mindreader-replay`.
+func replayApps(args []string) []string {
+	apps := launcher.ParseAppsFromArgs(args, func(string) bool { return true })
+	for _, app := range replacedByReplay {
+		apps = removeApp(apps, app)
+	}
+
+	return append([]string{"mindreader-replay"}, apps...)
+}
+
+func removeApp(apps []string, app string) (out []string) {
+	for _, candidate := range apps {
+		if candidate != app {
+			out = append(out, candidate)
+		}
+	}
+	return
+}
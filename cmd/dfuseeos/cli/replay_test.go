@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_replayApps(t *testing.T) {
+	assert.Equal(t, []string{"mindreader-replay", "merger", "relayer"}, replayApps([]string{"mindreader,relayer", "merger", "node-manager"}))
+	assert.Equal(t, []string{"mindreader-replay", "merger"}, replayApps([]string{"mindreader-replay", "merger"}))
+
+	all := replayApps(nil)
+	assert.Equal(t, "mindreader-replay", all[0])
+	assert.Contains(t, all, "merger")
+	assert.NotContains(t, all, "mindreader")
+	assert.NotContains(t, all, "booter")
+	assert.NotContains(t, all[1:], "mindreader-replay")
+}
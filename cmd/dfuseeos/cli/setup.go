@@ -37,7 +37,7 @@ func setupCmd(cmd *cobra.Command) error {
 	subCommand := cmds[len(cmds)-1]
 
 	forceConfigOn := []*cobra.Command{StartCmd}
-	logToFileOn := []*cobra.Command{StartCmd}
+	logToFileOn := []*cobra.Command{StartCmd, ReplayCmd}
 
 	if configFile := viper.GetString("global-config-file"); configFile != "" {
 		exists, err := fileExists(configFile)
@@ -56,6 +56,11 @@ func setupCmd(cmd *cobra.Command) error {
 		}
 	}
 
+	// Replay starts the same applications as 'start' and shares its config section
+	if isMatchingCommand(cmds, []*cobra.Command{ReplayCmd}) {
+		subCommand = StartCmd.Use
+	}
+
 	subconf := launcher.DfuseConfig[subCommand]
 	if subconf != nil {
 		for k, v := range subconf.Flags {
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dfuse-io/dfuse-eosio/node-manager/superviser"
+	"github.com/gorilla/mux"
+	"github.com/streamingfast/dlauncher/launcher"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+// snapshotCatalogApp wraps a node manager app to serve the snapshot catalog
+// alongside it, the node manager apps not accepting extra routes on their
+// manager API.
+type snapshotCatalogApp struct {
+	launcher.App
+
+	listenAddr string
+	storeURL   string
+	logger     *zap.Logger
+}
+
+func withSnapshotCatalog(app launcher.App, listenAddr, storeURL string, logger *zap.Logger) launcher.App {
+	if listenAddr == "" || storeURL == "" {
+		return app
+	}
+
+	return &snapshotCatalogApp{App: app, listenAddr: listenAddr, storeURL: storeURL, logger: logger}
+}
+
+func (a *snapshotCatalogApp) Run() error {
+	snapshotStore, err := dstore.NewSimpleStore(a.storeURL)
+	if err != nil {
+		return fmt.Errorf("unable to create snapshot store from url %q: %w", a.storeURL, err)
+	}
+
+	if err := a.App.Run(); err != nil {
+		return err
+	}
+
+	router := mux.NewRouter()
+	superviser.SnapshotCatalogHTTPOption(snapshotStore, a.logger)(router)
+
+	srv := &http.Server{Addr: a.listenAddr, Handler: router}
+	go func() {
+		a.logger.Info("serving snapshot catalog", zap.String("http_addr", a.listenAddr))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			a.Shutdown(fmt.Errorf("snapshot catalog server: %w", err))
+		}
+	}()
+	go func() {
+		<-a.Terminating()
+		srv.Close()
+	}()
+
+	return nil
+}
+
+func (a *snapshotCatalogApp) IsReady() bool {
+	if readiable, ok := a.App.(interface{ IsReady() bool }); ok {
+		return readiable.IsReady()
+	}
+	return true
+}
@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"strings"
 	"time"
 
 	tokenmetaApp "github.com/dfuse-io/dfuse-eosio/tokenmeta/app/tokenmeta"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/pricing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/streamingfast/dlauncher/launcher"
@@ -26,6 +28,13 @@ func init() {
 			cmd.Flags().Uint32("tokenmeta-save-every-n-block", 900, "Save the cache after N blocks processed")
 			cmd.Flags().Uint64("tokenmeta-bootstrap-block-offset", 20, "Block offset to ensure that we are not bootstrapping from statedb on a reversible fork")
 			cmd.Flags().Duration("tokenmeta-readiness-max-latency", 5*time.Minute, "Healthcheck will return NotServing until last processed block time (HEAD) is within that duration to now (0 to disable)")
+			cmd.Flags().StringSlice("tokenmeta-price-sources", nil, "DEX pool sources used to price tokens, as '<kind>@<contract>' (kinds: "+strings.Join(pricing.SourceKinds(), ", ")+"), token prices are disabled when empty")
+			cmd.Flags().String("tokenmeta-price-reference-token", "eosio.token:EOS", "Token in which prices are expressed by default and recorded in history, as '<contract>:<symbol>'")
+			cmd.Flags().String("tokenmeta-price-cache-file", "{dfuse-data-dir}/tokenmeta/price-cache.gob", "Path to GOB file containing the price sources pools and price history. will try to Load and Save to that cache file")
+			cmd.Flags().Int("tokenmeta-price-history-size", 1000, "Number of price changes kept in history for each token")
+			cmd.Flags().Uint64("tokenmeta-holder-history-sample-every-n-block", 7200, "Record the holders count of every token each N blocks, saved along the cache file (0 to disable)")
+			cmd.Flags().Int("tokenmeta-holder-history-size", 2160, "Number of holders count samples kept for each token (0 for unbounded)")
+			cmd.Flags().String("tokenmeta-token-mappings-file", "", "Path to YAML file declaring the table layout (balances, supply) of token contracts not following the 'eosio.token' one, see tokenmeta.TokenMappings")
 			return nil
 		},
 		FactoryFunc: func(runtime *launcher.Runtime) (app launcher.App, e error) {
@@ -43,6 +52,15 @@ func init() {
 				BlocksStoreURL:       mustReplaceDataDir(dfuseDataDir, viper.GetString("common-blocks-store-url")),
 				BootstrapBlockOffset: viper.GetUint64("tokenmeta-bootstrap-block-offset"),
 				ReadinessMaxLatency:  viper.GetDuration("tokenmeta-readiness-max-latency"),
+				PriceSources:         viper.GetStringSlice("tokenmeta-price-sources"),
+				PriceReferenceToken:  viper.GetString("tokenmeta-price-reference-token"),
+				PriceCacheFile:       mustReplaceDataDir(dfuseDataDir, viper.GetString("tokenmeta-price-cache-file")),
+				PriceHistorySize:     viper.GetInt("tokenmeta-price-history-size"),
+
+				HolderHistorySampleEveryNBlock: viper.GetUint64("tokenmeta-holder-history-sample-every-n-block"),
+				HolderHistorySize:              viper.GetInt("tokenmeta-holder-history-size"),
+
+				TokenMappingsFile: mustReplaceDataDir(dfuseDataDir, viper.GetString("tokenmeta-token-mappings-file")),
 			}, &tokenmetaApp.Modules{
 				BlockFilter: runtime.BlockFilter.TransformInPlace,
 				BlockMeta:   runtime.BlockMeta,
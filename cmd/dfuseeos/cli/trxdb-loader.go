@@ -28,6 +28,7 @@ func init() {
 			cmd.Flags().Bool("trxdb-loader-truncation-enabled", false, "Write truncation markers, and enable the automated purge of blocks past the window")
 			cmd.Flags().Uint64("trxdb-loader-truncation-purge-interval", 1000, "Interval of blocks between each purge.")
 			cmd.Flags().Uint64("trxdb-loader-truncation-window", 0, "When truncating, purge blocks older than this amount of blocks.")
+			cmd.Flags().String("trxdb-loader-row-compression", "none", "Compression applied to the rows written to trxdb, either 'zstd' or 'none'. Readers transparently decode both, use 'dfuseeos tools trxdb recompress' to convert existing rows")
 			return nil
 		},
 		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
@@ -49,6 +50,7 @@ func init() {
 				EnableTruncationMarker:    viper.GetBool("trxdb-loader-truncation-enabled"),
 				TruncationWindow:          viper.GetUint64("trxdb-loader-truncation-window"),
 				PurgerInterval:            viper.GetUint64("trxdb-loader-truncation-purge-interval"),
+				RowCompression:            viper.GetString("trxdb-loader-row-compression"),
 			}, &trxdbLoaderApp.Modules{
 				BlockFilter: runtime.BlockFilter.TransformInPlace,
 				BlockMeta:   runtime.BlockMeta,
@@ -106,6 +106,8 @@ func Block(t testing.T, blkID string, components ...interface{}) *pbcodec.Block
 			pbblock.UnfilteredTransactionTraces = append(pbblock.UnfilteredTransactionTraces, v)
 		case *pbcodec.TrxOp:
 			pbblock.UnfilteredImplicitTransactionOps = append(pbblock.UnfilteredImplicitTransactionOps, v)
+		case *pbcodec.RlimitOp:
+			pbblock.RlimitOps = append(pbblock.RlimitOps, v)
 		case *autoGlobalSequence:
 		case FilteredBlock:
 			// Performed at the very end
@@ -207,6 +209,12 @@ func TrxTrace(t testing.T, components ...interface{}) *pbcodec.TransactionTrace
 			trace.DtrxOps = append(trace.DtrxOps, v)
 		case *pbcodec.TableOp:
 			trace.TableOps = append(trace.TableOps, v)
+		case *pbcodec.RlimitOp:
+			trace.RlimitOps = append(trace.RlimitOps, v)
+		case *pbcodec.FeatureOp:
+			trace.FeatureOps = append(trace.FeatureOps, v)
+		case *pbcodec.PermOp:
+			trace.PermOps = append(trace.PermOps, v)
 		case pbcodec.TransactionStatus:
 			trace.Receipt.Status = v
 		default:
@@ -615,7 +623,7 @@ func PermOp(t testing.T, op string, components ...interface{}) *pbcodec.PermOp {
 		case OldPerm:
 			permOp.OldPerm = v
 		case NewPerm:
-			permOp.OldPerm = v
+			permOp.NewPerm = v
 		case ActionIndex:
 			permOp.ActionIndex = uint32(v)
 		default:
@@ -0,0 +1,103 @@
+package dbopfeed
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/dbopfeed"
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	"github.com/streamingfast/dgrpc"
+	"github.com/streamingfast/dmetrics"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	pbhealth "github.com/streamingfast/pbgo/grpc/health/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type Config struct {
+	GRPCListenAddr    string // address the db op feed listens on
+	FirehoseAddr      string // gRPC address of the firehose
+	FirehoseInsecure  bool   // skip the verification of the firehose TLS certificate
+	FirehosePlaintext bool   // reach the firehose without TLS
+	ABICodecAddr      string // gRPC address of the ABI codec decoding rows
+}
+
+type App struct {
+	*shutter.Shutter
+	config *Config
+
+	readinessProbe pbhealth.HealthClient
+}
+
+func New(config *Config) *App {
+	return &App{
+		Shutter: shutter.New(),
+		config:  config,
+	}
+}
+
+func (a *App) Run() error {
+	zlog.Info("running db op feed", zap.Reflect("config", a.config))
+	dmetrics.Register(dbopfeed.MetricsSet)
+
+	firehoseConn, err := a.dialFirehose()
+	if err != nil {
+		return fmt.Errorf("unable to create firehose client to %q: %w", a.config.FirehoseAddr, err)
+	}
+
+	abiCodecConn, err := dgrpc.NewInternalClient(a.config.ABICodecAddr)
+	if err != nil {
+		return fmt.Errorf("unable to create abicodec client to %q: %w", a.config.ABICodecAddr, err)
+	}
+
+	server := dbopfeed.NewServer(pbbstream.NewBlockStreamV2Client(firehoseConn), pbabicodec.NewDecoderClient(abiCodecConn))
+	server.OnTerminated(a.Shutdown)
+	a.OnTerminating(func(err error) {
+		server.Shutdown(err)
+		firehoseConn.Close()
+		abiCodecConn.Close()
+	})
+
+	go server.Serve(a.config.GRPCListenAddr)
+
+	gs, err := dgrpc.NewInternalClient(a.config.GRPCListenAddr)
+	if err != nil {
+		return fmt.Errorf("cannot create readiness probe: %w", err)
+	}
+	a.readinessProbe = pbhealth.NewHealthClient(gs)
+
+	return nil
+}
+
+func (a *App) IsReady() bool {
+	if a.readinessProbe == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	resp, err := a.readinessProbe.Check(ctx, &pbhealth.HealthCheckRequest{})
+	if err != nil {
+		zlog.Info("db op feed readiness probe error", zap.Error(err))
+		return false
+	}
+
+	return resp.Status == pbhealth.HealthCheckResponse_SERVING
+}
+
+func (a *App) dialFirehose() (*grpc.ClientConn, error) {
+	if a.config.FirehosePlaintext {
+		return dgrpc.NewInternalClient(a.config.FirehoseAddr)
+	}
+
+	if a.config.FirehoseInsecure {
+		return dgrpc.NewExternalClient(a.config.FirehoseAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	}
+
+	return dgrpc.NewExternalClient(a.config.FirehoseAddr)
+}
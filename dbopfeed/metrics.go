@@ -0,0 +1,10 @@
+package dbopfeed
+
+import (
+	"github.com/streamingfast/dmetrics"
+)
+
+var MetricsSet = dmetrics.NewSet()
+
+var activeStreamCount = MetricsSet.NewGauge("active_stream_count")
+var streamedOpCount = MetricsSet.NewCounter("streamed_op_count")
@@ -0,0 +1,72 @@
+package dbopfeed
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbdbopfeed "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/dbopfeed/v1"
+	"github.com/streamingfast/dgrpc"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	pbhealth "github.com/streamingfast/pbgo/grpc/health/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Server serves the db op feed, each stream reading full blocks from the
+// firehose and sending only the db ops matching its request
+type Server struct {
+	*shutter.Shutter
+
+	grpcServer  *grpc.Server
+	firehose    pbbstream.BlockStreamV2Client
+	abiCodecCli pbabicodec.DecoderClient
+}
+
+func NewServer(firehose pbbstream.BlockStreamV2Client, abiCodecCli pbabicodec.DecoderClient) *Server {
+	s := &Server{
+		Shutter:     shutter.New(),
+		grpcServer:  dgrpc.NewServer(dgrpc.WithLogger(zlog)),
+		firehose:    firehose,
+		abiCodecCli: abiCodecCli,
+	}
+
+	pbdbopfeed.RegisterDBOpFeedServer(s.grpcServer, s)
+	pbhealth.RegisterHealthServer(s.grpcServer, s)
+
+	s.OnTerminating(func(_ error) {
+		s.grpcServer.Stop()
+	})
+
+	return s
+}
+
+func (s *Server) Check(ctx context.Context, in *pbhealth.HealthCheckRequest) (*pbhealth.HealthCheckResponse, error) {
+	status := pbhealth.HealthCheckResponse_SERVING
+	if s.IsTerminating() {
+		status = pbhealth.HealthCheckResponse_NOT_SERVING
+	}
+
+	return &pbhealth.HealthCheckResponse{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) Serve(listenAddr string) {
+	zlog.Info("starting grpc server", zap.String("address", listenAddr))
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		s.Shutdown(fmt.Errorf("unable to listen on %q: %w", listenAddr, err))
+		return
+	}
+
+	err = s.grpcServer.Serve(listener)
+	if err == nil || err == grpc.ErrServerStopped {
+		zlog.Info("server shut down cleanly, nothing to do")
+		return
+	}
+
+	s.Shutdown(err)
+}
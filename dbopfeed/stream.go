@@ -0,0 +1,255 @@
+package dbopfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbdbopfeed "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/dbopfeed/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/streamingfast/derr"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var accountNameRegex = regexp.MustCompile(`^[a-z1-5.]{1,13}$`)
+
+func (s *Server) StreamDBOps(req *pbdbopfeed.StreamDBOpsRequest, stream pbdbopfeed.DBOpFeed_StreamDBOpsServer) error {
+	ctx := stream.Context()
+	if !accountNameRegex.MatchString(req.Contract) {
+		return derr.Statusf(codes.InvalidArgument, "invalid contract %q, expecting an account name", req.Contract)
+	}
+
+	forkSteps := req.ForkSteps
+	if len(forkSteps) == 0 {
+		forkSteps = []pbbstream.ForkStep{pbbstream.ForkStep_STEP_NEW, pbbstream.ForkStep_STEP_UNDO, pbbstream.ForkStep_STEP_IRREVERSIBLE}
+	}
+
+	logger := zlog.With(zap.String("contract", req.Contract), zap.Strings("tables", req.Tables), zap.Strings("scopes", req.Scopes))
+	logger.Info("streaming db ops", zap.Int64("start_block_num", req.StartBlockNum), zap.Uint64("stop_block_num", req.StopBlockNum), zap.Bool("with_cursor", req.StartCursor != ""))
+
+	activeStreamCount.Inc()
+	defer activeStreamCount.Dec()
+
+	blocks, err := s.firehose.Blocks(ctx, &pbbstream.BlocksRequestV2{
+		StartBlockNum:     req.StartBlockNum,
+		StartCursor:       req.StartCursor,
+		StopBlockNum:      req.StopBlockNum,
+		ForkSteps:         forkSteps,
+		IncludeFilterExpr: includeFilterExpr(req.Contract),
+		Details:           pbbstream.BlockDetails_BLOCK_DETAILS_FULL,
+	})
+	if err != nil {
+		return err
+	}
+
+	filter := newOpFilter(req)
+	abis := newABICache(s.abiCodecCli, req.Contract)
+	for {
+		response, err := blocks.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		blk := &pbcodec.Block{}
+		if err := ptypes.UnmarshalAny(response.Block, blk); err != nil {
+			return fmt.Errorf("unable to decode block: %w", err)
+		}
+
+		responses, err := blockResponses(ctx, blk, response.Step, response.Cursor, filter, abis)
+		if err != nil {
+			return err
+		}
+
+		for _, resp := range responses {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+		streamedOpCount.AddInt(len(responses))
+	}
+}
+
+// includeFilterExpr keeps the actions of `contract`, the only ones able to
+// write its tables, and the ones setting its ABI
+func includeFilterExpr(contract string) string {
+	return fmt.Sprintf(`receiver == "%s" || (receiver == "eosio" && action == "setabi" && data.account == "%s")`, contract, contract)
+}
+
+// opFilter matches the db ops of a request
+type opFilter struct {
+	contract string
+	tables   map[string]bool
+	scopes   map[string]bool
+}
+
+func newOpFilter(req *pbdbopfeed.StreamDBOpsRequest) *opFilter {
+	return &opFilter{
+		contract: req.Contract,
+		tables:   stringSet(req.Tables),
+		scopes:   stringSet(req.Scopes),
+	}
+}
+
+func (f *opFilter) matches(op *pbcodec.DBOp) bool {
+	if op.Code != f.contract {
+		return false
+	}
+	if len(f.tables) != 0 && !f.tables[op.TableName] {
+		return false
+	}
+	return len(f.scopes) == 0 || f.scopes[op.Scope]
+}
+
+// blockResponses returns the responses of the ops of `blk` matching
+// `filter`, in reverse order for undo steps
+func blockResponses(ctx context.Context, blk *pbcodec.Block, step pbbstream.ForkStep, cursor string, filter *opFilter, abis *abiCache) ([]*pbdbopfeed.DBOpResponse, error) {
+	if step == pbbstream.ForkStep_STEP_UNDO {
+		// The ABI of the contract could have been set by the undone block, it
+		// must be used for the ops of this block but not for the blocks of the
+		// new fork
+		abis.reset()
+		defer abis.reset()
+	} else {
+		abis.invalidateSetABI(blk)
+	}
+
+	blockRef := &pbbstream.BlockRef{Num: uint64(blk.Number), Id: blk.Id}
+	var out []*pbdbopfeed.DBOpResponse
+	for _, trx := range blk.TransactionTraces() {
+		matcher := blk.FilteringActionMatcher(trx)
+		for _, op := range trx.DbOps {
+			if !matcher.Matched(op.ActionIndex) || !filter.matches(op) {
+				continue
+			}
+
+			resp := &pbdbopfeed.DBOpResponse{
+				Step:          step,
+				Cursor:        cursor,
+				Block:         blockRef,
+				BlockTime:     blk.Header.Timestamp,
+				TransactionId: trx.Id,
+				Op:            op,
+			}
+
+			abi, err := abis.getABI(ctx, blk.Number)
+			if err != nil {
+				return nil, err
+			}
+
+			var decodeErrs []string
+			if resp.OldJson, err = decodeRow(abi, op.TableName, op.OldData); err != nil {
+				decodeErrs = append(decodeErrs, fmt.Sprintf("old row: %s", err))
+			}
+			if resp.NewJson, err = decodeRow(abi, op.TableName, op.NewData); err != nil {
+				decodeErrs = append(decodeErrs, fmt.Sprintf("new row: %s", err))
+			}
+			resp.DecodeError = strings.Join(decodeErrs, ", ")
+
+			out = append(out, resp)
+		}
+	}
+
+	if step == pbbstream.ForkStep_STEP_UNDO {
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+	if len(out) != 0 {
+		out[len(out)-1].LastInBlock = true
+	}
+	return out, nil
+}
+
+// abiCache holds the ABI of a contract, until the contract sets a new one
+type abiCache struct {
+	client   pbabicodec.DecoderClient
+	contract string
+	abi      *eos.ABI
+	loaded   bool
+}
+
+func newABICache(client pbabicodec.DecoderClient, contract string) *abiCache {
+	return &abiCache{client: client, contract: contract}
+}
+
+// getABI returns the ABI of the contract at `blockNum`, nil when it has none
+func (c *abiCache) getABI(ctx context.Context, blockNum uint32) (*eos.ABI, error) {
+	if c.loaded {
+		return c.abi, nil
+	}
+
+	resp, err := c.client.GetAbi(ctx, &pbabicodec.GetAbiRequest{
+		Account:    c.contract,
+		AtBlockNum: blockNum,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, fmt.Errorf("unable to get abi for contract %q: %w", c.contract, err)
+	}
+
+	c.abi = nil
+	if err == nil && resp.JsonPayload != "" {
+		if err := json.Unmarshal([]byte(resp.JsonPayload), &c.abi); err != nil {
+			zlog.Info("unable to decode abi", zap.String("contract", c.contract), zap.Error(err))
+			c.abi = nil
+		}
+	}
+
+	c.loaded = true
+	return c.abi, nil
+}
+
+// invalidateSetABI drops the cached ABI when `blk` sets a new one
+func (c *abiCache) invalidateSetABI(blk *pbcodec.Block) {
+	for _, trx := range blk.TransactionTraces() {
+		for _, act := range trx.ActionTraces {
+			if act.Receiver == "eosio" && act.Account() == "eosio" && act.Name() == "setabi" && act.GetData("account").String() == c.contract {
+				c.reset()
+				return
+			}
+		}
+	}
+}
+
+func (c *abiCache) reset() {
+	c.abi = nil
+	c.loaded = false
+}
+
+// decodeRow returns the JSON of a row, empty for empty rows
+func decodeRow(abi *eos.ABI, table string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	if abi == nil {
+		return "", fmt.Errorf("contract has no ABI")
+	}
+
+	out, err := abi.DecodeTableRow(eos.TableName(table), data)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func stringSet(values []string) map[string]bool {
+	out := make(map[string]bool, len(values))
+	for _, value := range values {
+		out[value] = true
+	}
+	return out
+}
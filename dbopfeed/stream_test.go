@@ -0,0 +1,243 @@
+package dbopfeed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbdbopfeed "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/dbopfeed/v1"
+	"github.com/golang/protobuf/ptypes"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testABI = `{"tables":[{"name":"accounts","type":"account"},{"name":"stat","type":"account"}],"structs":[{"name":"account","fields":[{"name":"balance","type":"uint8"}]}]}`
+
+func TestServer_StreamDBOps(t *testing.T) {
+	firehose := &testFirehoseClient{responses: []*pbbstream.BlockResponseV2{
+		testResponse(t, testBlock(1, testDBOp("eosio.token", "accounts", "alice", 0x01, 0x02), testDBOp("eosio.token", "stat", "alice", 0, 0x03)), pbbstream.ForkStep_STEP_NEW),
+		testResponse(t, testBlock(2, testDBOp("other", "accounts", "alice", 0, 0x04)), pbbstream.ForkStep_STEP_NEW),
+		testResponse(t, testBlock(3, testDBOp("eosio.token", "accounts", "bob", 0, 0x05), testDBOp("eosio.token", "accounts", "alice", 0, 0xff)), pbbstream.ForkStep_STEP_NEW),
+		testResponse(t, testBlock(3, testDBOp("eosio.token", "accounts", "bob", 0, 0x05), testDBOp("eosio.token", "accounts", "alice", 0, 0xff)), pbbstream.ForkStep_STEP_UNDO),
+	}}
+	abiCodec := &testABICodecClient{abis: map[string]string{"eosio.token": testABI}}
+	stream := &testDBOpsStream{ctx: context.Background()}
+
+	server := NewServer(firehose, abiCodec)
+	require.NoError(t, server.StreamDBOps(&pbdbopfeed.StreamDBOpsRequest{
+		StartBlockNum: 1,
+		Contract:      "eosio.token",
+		Tables:        []string{"accounts"},
+	}, stream))
+
+	assert.Equal(t, []pbbstream.ForkStep{pbbstream.ForkStep_STEP_NEW, pbbstream.ForkStep_STEP_UNDO, pbbstream.ForkStep_STEP_IRREVERSIBLE}, firehose.request.ForkSteps)
+	assert.Equal(t, pbbstream.BlockDetails_BLOCK_DETAILS_FULL, firehose.request.Details)
+	assert.Equal(t, `receiver == "eosio.token" || (receiver == "eosio" && action == "setabi" && data.account == "eosio.token")`, firehose.request.IncludeFilterExpr)
+
+	var got []string
+	for _, resp := range stream.sent {
+		got = append(got, fmt.Sprintf("%s #%d %s %s/%s old=%s new=%s err=%q last=%t", stepName(resp.Step), resp.Block.Num, resp.Cursor, resp.Op.TableName, resp.Op.Scope, resp.OldJson, resp.NewJson, resp.DecodeError, resp.LastInBlock))
+	}
+	assert.Equal(t, []string{
+		`new #1 cursor-1-new accounts/alice old={"balance":1} new={"balance":2} err="" last=true`,
+		`new #3 cursor-3-new accounts/bob old= new={"balance":5} err="" last=false`,
+		`new #3 cursor-3-new accounts/alice old= new={"balance":255} err="" last=true`,
+		`undo #3 cursor-3-undo accounts/alice old= new={"balance":255} err="" last=false`,
+		`undo #3 cursor-3-undo accounts/bob old= new={"balance":5} err="" last=true`,
+	}, got)
+	assert.Equal(t, 2, abiCodec.calls, "ABI is reloaded after an undo")
+}
+
+func TestServer_StreamDBOps_UndoSetABI(t *testing.T) {
+	const newABI = `{"tables":[{"name":"accounts","type":"account"}],"structs":[{"name":"account","fields":[{"name":"amount","type":"uint8"}]}]}`
+
+	setABIBlock := testBlock(3, testDBOp("eosio.token", "accounts", "alice", 0, 0x02))
+	setABIBlock.UnfilteredTransactionTraces[0].ActionTraces = []*pbcodec.ActionTrace{{
+		Receiver: "eosio",
+		Action:   &pbcodec.Action{Account: "eosio", Name: "setabi", JsonData: `{"account":"eosio.token"}`},
+	}}
+
+	forkBlock := testBlock(3, testDBOp("eosio.token", "accounts", "alice", 0, 0x03))
+	forkBlock.Id = "00000003b"
+
+	firehose := &testFirehoseClient{responses: []*pbbstream.BlockResponseV2{
+		testResponse(t, testBlock(2, testDBOp("eosio.token", "accounts", "alice", 0, 0x01)), pbbstream.ForkStep_STEP_NEW),
+		testResponse(t, setABIBlock, pbbstream.ForkStep_STEP_NEW),
+		testResponse(t, setABIBlock, pbbstream.ForkStep_STEP_UNDO),
+		testResponse(t, forkBlock, pbbstream.ForkStep_STEP_NEW),
+	}}
+
+	// The ABI service follows the chain, serving the ABI set by the undone block
+	// until it is undone
+	abiCodec := &testABICodecClient{sequence: []string{testABI, newABI, newABI, testABI}}
+	stream := &testDBOpsStream{ctx: context.Background()}
+
+	server := NewServer(firehose, abiCodec)
+	require.NoError(t, server.StreamDBOps(&pbdbopfeed.StreamDBOpsRequest{Contract: "eosio.token"}, stream))
+
+	var got []string
+	for _, resp := range stream.sent {
+		got = append(got, fmt.Sprintf("%s %s %s", stepName(resp.Step), resp.Block.Id, resp.NewJson))
+	}
+	assert.Equal(t, []string{
+		`new 00000002a {"balance":1}`,
+		`new 00000003a {"amount":2}`,
+		`undo 00000003a {"amount":2}`,
+		`new 00000003b {"balance":3}`,
+	}, got)
+}
+
+func TestServer_StreamDBOps_Scopes(t *testing.T) {
+	firehose := &testFirehoseClient{responses: []*pbbstream.BlockResponseV2{
+		testResponse(t, testBlock(1, testDBOp("eosio.token", "accounts", "alice", 0, 0x01), testDBOp("eosio.token", "accounts", "bob", 0, 0x02)), pbbstream.ForkStep_STEP_IRREVERSIBLE),
+	}}
+	stream := &testDBOpsStream{ctx: context.Background()}
+
+	server := NewServer(firehose, &testABICodecClient{})
+	require.NoError(t, server.StreamDBOps(&pbdbopfeed.StreamDBOpsRequest{
+		Contract:  "eosio.token",
+		Scopes:    []string{"bob"},
+		ForkSteps: []pbbstream.ForkStep{pbbstream.ForkStep_STEP_IRREVERSIBLE},
+	}, stream))
+
+	require.Len(t, stream.sent, 1)
+	assert.Equal(t, []pbbstream.ForkStep{pbbstream.ForkStep_STEP_IRREVERSIBLE}, firehose.request.ForkSteps)
+	assert.Equal(t, "bob", stream.sent[0].Op.Scope)
+	assert.Equal(t, "", stream.sent[0].NewJson)
+	assert.Equal(t, "new row: contract has no ABI", stream.sent[0].DecodeError)
+}
+
+func TestServer_StreamDBOps_InvalidContract(t *testing.T) {
+	server := NewServer(&testFirehoseClient{}, &testABICodecClient{})
+	err := server.StreamDBOps(&pbdbopfeed.StreamDBOpsRequest{Contract: `a" || true`}, &testDBOpsStream{ctx: context.Background()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestABICache_InvalidateSetABI(t *testing.T) {
+	abiCodec := &testABICodecClient{abis: map[string]string{"eosio.token": testABI}}
+	abis := newABICache(abiCodec, "eosio.token")
+
+	_, err := abis.getABI(context.Background(), 1)
+	require.NoError(t, err)
+
+	blk := testBlock(2)
+	blk.UnfilteredTransactionTraces[0].ActionTraces = []*pbcodec.ActionTrace{{
+		Receiver: "eosio",
+		Action:   &pbcodec.Action{Account: "eosio", Name: "setabi", JsonData: `{"account":"other"}`},
+	}}
+	abis.invalidateSetABI(blk)
+	assert.True(t, abis.loaded)
+
+	blk.UnfilteredTransactionTraces[0].ActionTraces[0].Action.JsonData = `{"account":"eosio.token"}`
+	abis.invalidateSetABI(blk)
+	assert.False(t, abis.loaded)
+}
+
+func stepName(step pbbstream.ForkStep) string {
+	switch step {
+	case pbbstream.ForkStep_STEP_NEW:
+		return "new"
+	case pbbstream.ForkStep_STEP_UNDO:
+		return "undo"
+	}
+	return "irreversible"
+}
+
+func testDBOp(code, table, scope string, oldData, newData byte) *pbcodec.DBOp {
+	op := &pbcodec.DBOp{Code: code, TableName: table, Scope: scope, NewData: []byte{newData}}
+	if oldData != 0 {
+		op.OldData = []byte{oldData}
+	}
+	return op
+}
+
+func testBlock(num uint32, ops ...*pbcodec.DBOp) *pbcodec.Block {
+	return &pbcodec.Block{
+		Id:     fmt.Sprintf("%08da", num),
+		Number: num,
+		Header: &pbcodec.BlockHeader{},
+		UnfilteredTransactionTraces: []*pbcodec.TransactionTrace{{
+			Id:    fmt.Sprintf("trx-%d", num),
+			DbOps: ops,
+		}},
+	}
+}
+
+func testResponse(t *testing.T, blk *pbcodec.Block, step pbbstream.ForkStep) *pbbstream.BlockResponseV2 {
+	payload, err := ptypes.MarshalAny(blk)
+	require.NoError(t, err)
+
+	return &pbbstream.BlockResponseV2{Block: payload, Step: step, Cursor: fmt.Sprintf("cursor-%d-%s", blk.Number, stepName(step))}
+}
+
+type testFirehoseClient struct {
+	request   *pbbstream.BlocksRequestV2
+	responses []*pbbstream.BlockResponseV2
+}
+
+func (c *testFirehoseClient) Blocks(ctx context.Context, in *pbbstream.BlocksRequestV2, opts ...grpc.CallOption) (pbbstream.BlockStreamV2_BlocksClient, error) {
+	c.request = in
+	return &testBlocksStream{responses: c.responses}, nil
+}
+
+type testBlocksStream struct {
+	grpc.ClientStream
+	responses []*pbbstream.BlockResponseV2
+}
+
+func (s *testBlocksStream) Recv() (*pbbstream.BlockResponseV2, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
+
+type testDBOpsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pbdbopfeed.DBOpResponse
+}
+
+func (s *testDBOpsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testDBOpsStream) Send(resp *pbdbopfeed.DBOpResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+type testABICodecClient struct {
+	pbabicodec.DecoderClient
+
+	abis  map[string]string
+	calls int
+
+	// sequence, when set, holds the ABI returned by each successive call
+	sequence []string
+}
+
+func (c *testABICodecClient) GetAbi(ctx context.Context, in *pbabicodec.GetAbiRequest, opts ...grpc.CallOption) (*pbabicodec.Response, error) {
+	c.calls++
+	if len(c.sequence) != 0 {
+		abi := c.sequence[0]
+		c.sequence = c.sequence[1:]
+		return &pbabicodec.Response{JsonPayload: abi}, nil
+	}
+
+	abi, ok := c.abis[in.Account]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ABI found for account: %s", in.Account)
+	}
+	return &pbabicodec.Response{JsonPayload: abi}, nil
+}
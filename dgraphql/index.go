@@ -21,6 +21,8 @@ import (
 	eosResolver "github.com/dfuse-io/dfuse-eosio/dgraphql/resolvers"
 	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
 	pbaccounthist "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/accounthist/v1"
+	pbnftmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/nftmeta/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
 	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
 	"github.com/dfuse-io/dfuse-eosio/trxdb"
 	"github.com/streamingfast/dgrpc"
@@ -40,6 +42,8 @@ type Config struct {
 	ABICodecAddr                   string
 	BlockMetaAddr                  string
 	TokenmetaAddr                  string
+	NftmetaAddr                    string
+	StateDBAddr                    string
 	AccountHistAccountAddr         string
 	AccountHistAccountContractAddr string
 	KVDBDSN                        string
@@ -93,6 +97,25 @@ func (f *SchemaFactory) Schemas() (*dgraphql.Schemas, error) {
 		return nil, fmt.Errorf("unable to create tokenmeta client connection: %w", err)
 	}
 	tokenmetaClient := pbtokenmeta.NewTokenMetaClient(tokenmetaConn)
+	tokenPricesClient := pbtokenmeta.NewTokenPricesClient(tokenmetaConn)
+	tokenHoldersClient := pbtokenmeta.NewTokenHoldersClient(tokenmetaConn)
+
+	zlog.Info("creating nftmeta grpc client", zap.String("nftmeta_addr", f.config.NftmetaAddr))
+	nftmetaConn, err := dgrpc.NewInternalClient(f.config.NftmetaAddr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create nftmeta client connection: %w", err)
+	}
+	nftmetaClient := pbnftmeta.NewNFTMetaClient(nftmetaConn)
+
+	zlog.Info("creating statedb grpc client", zap.String("statedb_addr", f.config.StateDBAddr))
+	statedbConn, err := dgrpc.NewInternalClient(f.config.StateDBAddr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create statedb client connection: %w", err)
+	}
+	statedbClient := pbstatedb.NewStateClient(statedbConn)
+	resourcesClient := pbstatedb.NewResourcesClient(statedbConn)
+	protocolFeaturesClient := pbstatedb.NewProtocolFeaturesClient(statedbConn)
+	permissionsClient := pbstatedb.NewPermissionsClient(statedbConn)
 
 	rateLimiter, err := drateLimiter.New(f.config.RatelimiterPlugin)
 	derr.Check("unable to initialize rate limiter", err)
@@ -124,7 +147,22 @@ func (f *SchemaFactory) Schemas() (*dgraphql.Schemas, error) {
 	}
 
 	zlog.Info("configuring resolver and parsing schemas")
-	resolver, err := eosResolver.NewRoot(searchRouterClient, dbReader, blockMetaClient, abiClient, rateLimiter, tokenmetaClient, accounthistClient)
+	resolver, err := eosResolver.NewRoot(eosResolver.RootOptions{
+		SearchClient:           searchRouterClient,
+		DBReader:               dbReader,
+		BlockMetaClient:        blockMetaClient,
+		ABICodecClient:         abiClient,
+		RequestRateLimiter:     rateLimiter,
+		TokenMetaClient:        tokenmetaClient,
+		TokenPricesClient:      tokenPricesClient,
+		TokenHoldersClient:     tokenHoldersClient,
+		NFTMetaClient:          nftmetaClient,
+		AccounthistClients:     accounthistClient,
+		StateDBClient:          statedbClient,
+		ResourcesClient:        resourcesClient,
+		ProtocolFeaturesClient: protocolFeaturesClient,
+		PermissionsClient:      permissionsClient,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create root resolver: %w", err)
 	}
@@ -0,0 +1,265 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbnftmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/nftmeta/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/streamingfast/dgraphql"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+type NFTMode string
+
+func (m NFTMode) proto() pbnftmeta.Mode {
+	if mode, ok := pbnftmeta.Mode_value[string(m)]; ok {
+		return pbnftmeta.Mode(mode)
+	}
+	return pbnftmeta.Mode_IRREVERSIBLE
+}
+
+type AssetsByOwnerRequest struct {
+	Owner       string
+	Contracts   *[]string
+	Collections *[]string
+	Mode        NFTMode
+	Limit       *commonTypes.Uint32
+}
+
+func (r *Root) QueryAssetsByOwner(ctx context.Context, args *AssetsByOwnerRequest) (*NFTAssets, error) {
+	if err := r.RateLimit(ctx, "nft"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query assets by owner", zap.Reflect("request", args))
+
+	request := &pbnftmeta.GetAssetsByOwnerRequest{
+		Owner: args.Owner,
+		Mode:  args.Mode.proto(),
+	}
+
+	if args.Contracts != nil {
+		request.FilterContracts = *args.Contracts
+	}
+
+	if args.Collections != nil {
+		request.FilterCollections = *args.Collections
+	}
+
+	if args.Limit != nil {
+		request.Limit = uint32(*args.Limit)
+	}
+
+	resp, err := r.nftmetaClient.GetAssetsByOwner(ctx, request)
+	if err != nil {
+		zlogger.Info("unable to get assets by owner", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents ???
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "AssetsByOwner",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(resp.Assets)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := &NFTAssets{
+		BlockRef: newBlockRef(resp.AtBlockId, resp.AtBlockNum),
+		Assets:   []*NFTAsset{},
+	}
+	for _, asset := range resp.Assets {
+		out.Assets = append(out.Assets, &NFTAsset{a: asset})
+	}
+
+	return out, nil
+}
+
+type AssetHistoryRequest struct {
+	Contract string
+	AssetId  types.Uint64
+	Mode     NFTMode
+}
+
+func (r *Root) QueryAssetHistory(ctx context.Context, args *AssetHistoryRequest) (*NFTAssetHistory, error) {
+	if err := r.RateLimit(ctx, "nft"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query asset history", zap.Reflect("request", args))
+
+	resp, err := r.nftmetaClient.GetAssetHistory(ctx, &pbnftmeta.GetAssetHistoryRequest{
+		Contract: args.Contract,
+		AssetId:  uint64(args.AssetId),
+		Mode:     args.Mode.proto(),
+	})
+	if err != nil {
+		zlogger.Info("unable to get asset history", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents ???
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "AssetHistory",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(resp.Events)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := &NFTAssetHistory{
+		BlockRef: newBlockRef(resp.AtBlockId, resp.AtBlockNum),
+		Events:   []*NFTAssetEvent{},
+	}
+	if resp.Asset != nil {
+		out.Asset = &NFTAsset{a: resp.Asset}
+	}
+	for _, event := range resp.Events {
+		out.Events = append(out.Events, &NFTAssetEvent{e: event})
+	}
+
+	return out, nil
+}
+
+type CollectionStatsRequest struct {
+	Contract   string
+	Collection string
+	Mode       NFTMode
+}
+
+func (r *Root) QueryCollectionStats(ctx context.Context, args *CollectionStatsRequest) (*NFTCollectionStats, error) {
+	if err := r.RateLimit(ctx, "nft"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query collection stats", zap.Reflect("request", args))
+
+	resp, err := r.nftmetaClient.GetCollectionStats(ctx, &pbnftmeta.GetCollectionStatsRequest{
+		Contract:   args.Contract,
+		Collection: args.Collection,
+		Mode:       args.Mode.proto(),
+	})
+	if err != nil {
+		zlogger.Info("unable to get collection stats", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents ???
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "CollectionStats",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return &NFTCollectionStats{s: resp}, nil
+}
+
+//----------------------------
+// NFT Assets
+//----------------------------
+type NFTAssets struct {
+	BlockRef *BlockRef
+	Assets   []*NFTAsset
+}
+
+type NFTAsset struct {
+	a *pbnftmeta.Asset
+}
+
+func (a *NFTAsset) Contract() string      { return a.a.Contract }
+func (a *NFTAsset) AssetId() types.Uint64 { return types.Uint64(a.a.AssetId) }
+func (a *NFTAsset) Owner() string         { return a.a.Owner }
+func (a *NFTAsset) Collection() string    { return a.a.Collection }
+func (a *NFTAsset) Schema() string        { return a.a.Schema }
+func (a *NFTAsset) TemplateId() *types.Int64 {
+	if a.a.TemplateId < 0 {
+		return nil
+	}
+	id := types.Int64(a.a.TemplateId)
+	return &id
+}
+func (a *NFTAsset) MintedAtBlockNum() *types.Uint64  { return optionalUint64(a.a.MintedAtBlockNum) }
+func (a *NFTAsset) UpdatedAtBlockNum() *types.Uint64 { return optionalUint64(a.a.UpdatedAtBlockNum) }
+
+//----------------------------
+// NFT Asset History
+//----------------------------
+type NFTAssetHistory struct {
+	BlockRef *BlockRef
+	Asset    *NFTAsset
+	Events   []*NFTAssetEvent
+}
+
+type NFTAssetEvent struct {
+	e *pbnftmeta.AssetEvent
+}
+
+func (e *NFTAssetEvent) Type() string           { return e.e.Type.String() }
+func (e *NFTAssetEvent) From() *string          { return optionalString(e.e.From) }
+func (e *NFTAssetEvent) To() *string            { return optionalString(e.e.To) }
+func (e *NFTAssetEvent) BlockNum() types.Uint64 { return types.Uint64(e.e.BlockNum) }
+func (e *NFTAssetEvent) BlockId() string        { return e.e.BlockId }
+func (e *NFTAssetEvent) TransactionId() string  { return e.e.TransactionId }
+func (e *NFTAssetEvent) Irreversible() bool     { return e.e.Irreversible }
+func (e *NFTAssetEvent) BlockTime() graphql.Time {
+	blockTime, _ := ptypes.Timestamp(e.e.BlockTime)
+	return graphql.Time{Time: blockTime}
+}
+
+//----------------------------
+// NFT Collection Stats
+//----------------------------
+type NFTCollectionStats struct {
+	s *pbnftmeta.CollectionStatsResponse
+}
+
+func (s *NFTCollectionStats) BlockRef() *BlockRef {
+	return newBlockRef(s.s.AtBlockId, s.s.AtBlockNum)
+}
+func (s *NFTCollectionStats) Contract() string        { return s.s.Contract }
+func (s *NFTCollectionStats) Collection() string      { return s.s.Collection }
+func (s *NFTCollectionStats) Assets() types.Uint64    { return types.Uint64(s.s.Assets) }
+func (s *NFTCollectionStats) Holders() types.Uint64   { return types.Uint64(s.s.Holders) }
+func (s *NFTCollectionStats) Schemas() types.Uint64   { return types.Uint64(s.s.Schemas) }
+func (s *NFTCollectionStats) Templates() types.Uint64 { return types.Uint64(s.s.Templates) }
+func (s *NFTCollectionStats) Minted() types.Uint64    { return types.Uint64(s.s.Minted) }
+func (s *NFTCollectionStats) Burned() types.Uint64    { return types.Uint64(s.s.Burned) }
+func (s *NFTCollectionStats) Transfers() types.Uint64 { return types.Uint64(s.s.Transfers) }
+
+func optionalUint64(in uint64) *types.Uint64 {
+	if in == 0 {
+		return nil
+	}
+	out := types.Uint64(in)
+	return &out
+}
+
+func optionalString(in string) *string {
+	if in == "" {
+		return nil
+	}
+	return &in
+}
@@ -0,0 +1,171 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"math"
+
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/streamingfast/dgraphql"
+	"github.com/streamingfast/dgraphql/analytics"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	maxProducerBlocksLimit = 1000
+	// maxProducerStatsBlockRange bounds the amount of blocks loaded to compute producer stats, one day of blocks
+	maxProducerStatsBlockRange = 172800
+)
+
+type BlocksByProducerRequest struct {
+	Producer     string
+	LowBlockNum  *commonTypes.Uint32
+	HighBlockNum *commonTypes.Uint32
+	Limit        *commonTypes.Uint32
+}
+
+func (r *Root) QueryBlocksByProducer(ctx context.Context, req BlocksByProducerRequest) ([]*Block, error) {
+	if err := r.RateLimit(ctx, "block"); err != nil {
+		return nil, err
+	}
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("querying blocks by producer", zap.Reflect("request", req))
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "QueryBlocksByProducer", "BlocksByProducerRequest", req)
+	/////////////////////////////////////////////////////////////////////////
+
+	lowBlockNum := uint32(0)
+	if req.LowBlockNum != nil {
+		lowBlockNum = uint32(*req.LowBlockNum)
+	}
+
+	highBlockNum := uint32(math.MaxUint32)
+	if req.HighBlockNum != nil {
+		highBlockNum = uint32(*req.HighBlockNum)
+	}
+
+	if lowBlockNum > highBlockNum {
+		return nil, dgraphql.Errorf(ctx, "Invalid request, 'lowBlockNum' must be lower or equal to 'highBlockNum'")
+	}
+
+	limit := 100
+	if req.Limit != nil {
+		limit = int(*req.Limit)
+	}
+
+	if limit <= 0 || limit > maxProducerBlocksLimit {
+		return nil, dgraphql.Errorf(ctx, "Invalid 'limit' field, must be between 1 and %d", maxProducerBlocksLimit)
+	}
+
+	blocks, err := r.producersReader.ListProducerBlocks(ctx, req.Producer, lowBlockNum, highBlockNum, limit)
+	if err != nil {
+		zlogger.Error("failed to list producer blocks", zap.Error(err))
+		return nil, dgraphql.Errorf(ctx, "failed to list blocks by producer: %s", err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents
+	// Additional outbound docs are counted in TransactionTrace
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "BlocksByProducer",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(blocks)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := make([]*Block, len(blocks))
+	for i, blk := range blocks {
+		out[i] = newBlock(blk, r)
+	}
+
+	return out, nil
+}
+
+type ProducerStatsRequest struct {
+	LowBlockNum  commonTypes.Uint32
+	HighBlockNum commonTypes.Uint32
+}
+
+func (r *Root) QueryProducerStats(ctx context.Context, req ProducerStatsRequest) ([]*ProducerStats, error) {
+	if err := r.RateLimit(ctx, "block"); err != nil {
+		return nil, err
+	}
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("querying producer stats", zap.Reflect("request", req))
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "QueryProducerStats", "ProducerStatsRequest", req)
+	/////////////////////////////////////////////////////////////////////////
+
+	if req.LowBlockNum > req.HighBlockNum {
+		return nil, dgraphql.Errorf(ctx, "Invalid request, 'lowBlockNum' must be lower or equal to 'highBlockNum'")
+	}
+
+	if req.HighBlockNum-req.LowBlockNum >= maxProducerStatsBlockRange {
+		return nil, dgraphql.Errorf(ctx, "Invalid request, block range cannot span more than %d blocks", maxProducerStatsBlockRange)
+	}
+
+	stats, err := r.producersReader.GetProducerStats(ctx, uint32(req.LowBlockNum), uint32(req.HighBlockNum))
+	if err != nil {
+		zlogger.Error("failed to get producer stats", zap.Error(err))
+		return nil, dgraphql.Errorf(ctx, "failed to get producer stats: %s", err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "ProducerStats",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(stats)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := make([]*ProducerStats, len(stats))
+	for i, s := range stats {
+		out[i] = &ProducerStats{stats: s}
+	}
+
+	return out, nil
+}
+
+//---------------------------
+// ProducerStats
+//----------------------------
+type ProducerStats struct {
+	stats *trxdb.ProducerStats
+}
+
+func (s *ProducerStats) Producer() string { return s.stats.Producer }
+func (s *ProducerStats) ProducedBlocks() commonTypes.Uint32 {
+	return commonTypes.Uint32(s.stats.ProducedBlocks)
+}
+func (s *ProducerStats) MissedSlots() commonTypes.Uint32 {
+	return commonTypes.Uint32(s.stats.MissedSlots)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func init() {
-	services := []string{"search", "block", "blockmeta", "token", "accounthist"}
+	services := []string{"search", "block", "blockmeta", "token", "accounthist", "statedb", "nft"}
 	ratelimiter.RegisterServices(services)
 }
 
@@ -31,7 +31,9 @@ import (
 	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
 	pbaccounthist "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/accounthist/v1"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbnftmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/nftmeta/v1"
 	pbsearcheos "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/search/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
 	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
 	"github.com/dfuse-io/dfuse-eosio/trxdb"
 	"github.com/eoscanada/eos-go"
@@ -61,34 +63,61 @@ type Root struct {
 	trxsReader                    trxdb.TransactionsReader
 	blocksReader                  trxdb.BlocksReader
 	accountsReader                trxdb.AccountsReader
+	producersReader               trxdb.ProducersReader
 	blockmetaClient               *pbblockmeta.Client
 	chainDiscriminatorClient      *pbblockmeta.ChainDiscriminatorClient
 	abiCodecClient                pbabicodec.DecoderClient
 	tokenmetaClient               pbtokenmeta.TokenMetaClient
+	tokenPricesClient             pbtokenmeta.TokenPricesClient
+	tokenHoldersClient            pbtokenmeta.TokenHoldersClient
+	nftmetaClient                 pbnftmeta.NFTMetaClient
 	accounthistClients            *AccounthistClient
+	statedbClient                 pbstatedb.StateClient
+	resourcesClient               pbstatedb.ResourcesClient
+	protocolFeaturesClient        pbstatedb.ProtocolFeaturesClient
+	permissionsClient             pbstatedb.PermissionsClient
 	requestRateLimiter            rateLimiter.RateLimiter
 	requestRateLimiterLastLogTime time.Time
 }
 
-func NewRoot(
-	searchClient pbsearch.RouterClient,
-	dbReader trxdb.DBReader,
-	blockMetaClient *pbblockmeta.Client,
-	abiCodecClient pbabicodec.DecoderClient,
-	requestRateLimiter rateLimiter.RateLimiter,
-	tokenmetaClient pbtokenmeta.TokenMetaClient,
-	accounthistClients *AccounthistClient,
-) (interface{}, error) {
+// RootOptions holds the clients and readers the root resolver is built
+// from.
+type RootOptions struct {
+	SearchClient           pbsearch.RouterClient
+	DBReader               trxdb.DBReader
+	BlockMetaClient        *pbblockmeta.Client
+	ABICodecClient         pbabicodec.DecoderClient
+	RequestRateLimiter     rateLimiter.RateLimiter
+	TokenMetaClient        pbtokenmeta.TokenMetaClient
+	TokenPricesClient      pbtokenmeta.TokenPricesClient
+	TokenHoldersClient     pbtokenmeta.TokenHoldersClient
+	NFTMetaClient          pbnftmeta.NFTMetaClient
+	AccounthistClients     *AccounthistClient
+	StateDBClient          pbstatedb.StateClient
+	ResourcesClient        pbstatedb.ResourcesClient
+	ProtocolFeaturesClient pbstatedb.ProtocolFeaturesClient
+	PermissionsClient      pbstatedb.PermissionsClient
+}
+
+func NewRoot(opts RootOptions) (interface{}, error) {
 	return &Root{
-		searchClient:       searchClient,
-		trxsReader:         dbReader,
-		blocksReader:       dbReader,
-		accountsReader:     dbReader,
-		tokenmetaClient:    tokenmetaClient,
-		blockmetaClient:    blockMetaClient,
-		abiCodecClient:     abiCodecClient,
-		requestRateLimiter: requestRateLimiter,
-		accounthistClients: accounthistClients,
+		searchClient:           opts.SearchClient,
+		trxsReader:             opts.DBReader,
+		blocksReader:           opts.DBReader,
+		accountsReader:         opts.DBReader,
+		producersReader:        opts.DBReader,
+		tokenmetaClient:        opts.TokenMetaClient,
+		blockmetaClient:        opts.BlockMetaClient,
+		abiCodecClient:         opts.ABICodecClient,
+		requestRateLimiter:     opts.RequestRateLimiter,
+		accounthistClients:     opts.AccounthistClients,
+		statedbClient:          opts.StateDBClient,
+		tokenPricesClient:      opts.TokenPricesClient,
+		tokenHoldersClient:     opts.TokenHoldersClient,
+		nftmetaClient:          opts.NFTMetaClient,
+		resourcesClient:        opts.ResourcesClient,
+		protocolFeaturesClient: opts.ProtocolFeaturesClient,
+		permissionsClient:      opts.PermissionsClient,
 	}, nil
 }
 
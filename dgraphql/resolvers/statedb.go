@@ -0,0 +1,706 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/dgraphql"
+	"github.com/streamingfast/dgraphql/analytics"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"github.com/streamingfast/opaque"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultStatedbPageSize = 100
+	maxStatedbPageSize     = 1000
+
+	tableRowCursorPrefix   = "tr"
+	tableScopeCursorPrefix = "ts"
+)
+
+var eosNameRegex = regexp.MustCompile(`^[a-z1-5.]{1,13}$`)
+
+//----------------------------
+// Table Rows
+//----------------------------
+
+type TableRowsRequest struct {
+	Contract         string
+	Table            string
+	Scope            string
+	BlockNum         *commonTypes.Uint32
+	IrreversibleOnly bool
+	KeyType          *string
+	Limit            *commonTypes.Uint32
+	Cursor           *string
+}
+
+func (r *Root) QueryTableRows(ctx context.Context, args TableRowsRequest) (*TableRowConnection, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query table rows", zap.Reflect("request", args))
+
+	pager, err := newStatedbPager(tableRowCursorPrefix, args.BlockNum, args.Limit, args.Cursor)
+	if err != nil {
+		return nil, dgraphql.Errorf(ctx, "%s", err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.statedbClient.StreamTableRows(streamCtx, &pbstatedb.StreamTableRowsRequest{
+		BlockNum:         pager.blockNum,
+		KeyType:          stringOr(args.KeyType, "name"),
+		ToJson:           true,
+		WithBlockNum:     true,
+		IrreversibleOnly: args.IrreversibleOnly,
+		Contract:         args.Contract,
+		Table:            args.Table,
+		Scope:            args.Scope,
+		AfterKey:         pager.afterKey,
+	})
+	if err != nil {
+		zlogger.Info("unable to stream table rows", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	ref, err := pbstatedb.ExtractStreamReference(stream)
+	if err != nil {
+		zlogger.Info("unable to extract stream reference", zap.Error(err))
+		return nil, dgraphql.Errorf(ctx, "backend error")
+	}
+
+	blockRef := ref.UpToBlock
+	if blockRef == nil || args.IrreversibleOnly {
+		blockRef = ref.LastIrreversibleBlock
+	}
+
+	out := &TableRowConnection{
+		Edges: []*TableRowEdge{},
+	}
+	if blockRef != nil {
+		pager.pin(blockRef.Num())
+		out.BlockRef = &BlockRef{blk: blockRef}
+	}
+
+	for {
+		row, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			zlogger.Info("error receiving message from statedb stream client", zap.Error(err))
+			return nil, dgraphql.UnwrapError(ctx, err)
+		}
+
+		cursor, done := pager.next(row.Key)
+		if done {
+			break
+		}
+
+		out.Edges = append(out.Edges, &TableRowEdge{Cursor: cursor, Node: &TableRow{row: row}})
+	}
+
+	out.PageInfo = pager.pageInfo()
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "TableRows", "Args", args, "Edges", len(out.Edges))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One  Outbound Document per edge
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "TableRows",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(out.Edges)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return out, nil
+}
+
+type TableRowRequest struct {
+	Contract         string
+	Table            string
+	Scope            string
+	PrimaryKey       string
+	BlockNum         *commonTypes.Uint32
+	IrreversibleOnly bool
+	KeyType          *string
+}
+
+func (r *Root) QueryTableRow(ctx context.Context, args TableRowRequest) (*TableRowResult, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query table row", zap.Reflect("request", args))
+
+	resp, err := r.statedbClient.GetTableRow(ctx, &pbstatedb.GetTableRowRequest{
+		BlockNum:         uint64(args.BlockNum.Native()),
+		KeyType:          stringOr(args.KeyType, "name"),
+		ToJson:           true,
+		WithBlockNum:     true,
+		IrreversibleOnly: args.IrreversibleOnly,
+		Contract:         args.Contract,
+		Table:            args.Table,
+		Scope:            args.Scope,
+		PrimaryKey:       args.PrimaryKey,
+	})
+	if err != nil {
+		zlogger.Info("unable to get table row", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "TableRow", "Args", args)
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One Outbound Document
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "TableRow",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := &TableRowResult{
+		UpToBlock:             newBlockRefFromProto(resp.UpToBlock),
+		LastIrreversibleBlock: newBlockRefFromProto(resp.LastIrreversibleBlock),
+	}
+	if resp.Row != nil {
+		out.Row = &TableRow{row: resp.Row}
+	}
+
+	return out, nil
+}
+
+type TableRowConnection struct {
+	BlockRef *BlockRef
+	Edges    []*TableRowEdge
+	PageInfo PageInfo
+}
+
+type TableRowEdge struct {
+	Cursor string
+	Node   *TableRow
+}
+
+type TableRowResult struct {
+	UpToBlock             *BlockRef
+	LastIrreversibleBlock *BlockRef
+	Row                   *TableRow
+}
+
+type TableRow struct {
+	row *pbstatedb.TableRowResponse
+}
+
+func (t *TableRow) Key() string   { return t.row.Key }
+func (t *TableRow) Payer() string { return t.row.Payer }
+func (t *TableRow) Hex() string   { return hex.EncodeToString(t.row.Data) }
+
+func (t *TableRow) BlockNum() types.Uint64 { return types.Uint64(t.row.BlockNumber) }
+
+func (t *TableRow) JSON() *commonTypes.JSON {
+	if t.row.Json == "" {
+		return nil
+	}
+
+	out := commonTypes.JSON(t.row.Json)
+	return &out
+}
+
+//----------------------------
+// Table Scopes
+//----------------------------
+
+type TableScopesRequest struct {
+	Contract string
+	Table    string
+	BlockNum *commonTypes.Uint32
+	Limit    *commonTypes.Uint32
+	Cursor   *string
+}
+
+func (r *Root) QueryTableScopes(ctx context.Context, args TableScopesRequest) (*TableScopeConnection, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query table scopes", zap.Reflect("request", args))
+
+	pager, err := newStatedbPager(tableScopeCursorPrefix, args.BlockNum, args.Limit, args.Cursor)
+	if err != nil {
+		return nil, dgraphql.Errorf(ctx, "%s", err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.statedbClient.StreamTableScopes(streamCtx, &pbstatedb.StreamTableScopesRequest{
+		BlockNum: pager.blockNum,
+		Contract: args.Contract,
+		Table:    args.Table,
+		AfterKey: pager.afterKey,
+	})
+	if err != nil {
+		zlogger.Info("unable to stream table scopes", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	out := &TableScopeConnection{
+		BlockNum: types.Uint64(pager.blockNum),
+		Edges:    []*TableScopeEdge{},
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			zlogger.Info("error receiving message from statedb stream client", zap.Error(err))
+			return nil, dgraphql.UnwrapError(ctx, err)
+		}
+
+		// Scopes are all served at the same block, the first one pins the following pages
+		pager.pin(response.BlockNum)
+		out.BlockNum = types.Uint64(response.BlockNum)
+
+		cursor, done := pager.next(response.Scope)
+		if done {
+			break
+		}
+
+		out.Edges = append(out.Edges, &TableScopeEdge{Cursor: cursor, Node: response.Scope})
+	}
+
+	out.PageInfo = pager.pageInfo()
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "TableScopes", "Args", args, "Edges", len(out.Edges))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One  Outbound Document per edge
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "TableScopes",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(out.Edges)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return out, nil
+}
+
+type TableScopeConnection struct {
+	BlockNum types.Uint64
+	Edges    []*TableScopeEdge
+	PageInfo PageInfo
+}
+
+type TableScopeEdge struct {
+	Cursor string
+	Node   string
+}
+
+//----------------------------
+// Key Accounts & Permission Links
+//----------------------------
+
+type KeyAccountsRequest struct {
+	PublicKey string
+	BlockNum  *commonTypes.Uint32
+}
+
+type KeyAccounts struct {
+	BlockNum types.Uint64
+	Accounts []string
+}
+
+func (r *Root) QueryKeyAccounts(ctx context.Context, args KeyAccountsRequest) (*KeyAccounts, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query key accounts", zap.Reflect("request", args))
+
+	resp, err := r.statedbClient.GetKeyAccounts(ctx, &pbstatedb.GetKeyAccountsRequest{
+		PublicKey: args.PublicKey,
+		BlockNum:  uint64(args.BlockNum.Native()),
+	})
+	if err != nil {
+		zlogger.Info("unable to get key accounts", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "KeyAccounts", "Args", args, "AccountsCount", len(resp.Accounts))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One Outbound Document
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "KeyAccounts",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	accounts := resp.Accounts
+	if accounts == nil {
+		accounts = []string{}
+	}
+
+	return &KeyAccounts{BlockNum: types.Uint64(resp.BlockNum), Accounts: accounts}, nil
+}
+
+type PermissionLinksRequest struct {
+	Account  string
+	BlockNum *commonTypes.Uint32
+}
+
+type PermissionLinks struct {
+	UpToBlock             *BlockRef
+	LastIrreversibleBlock *BlockRef
+	Permissions           []*LinkedPermission
+}
+
+type LinkedPermission struct {
+	Contract       string
+	Action         string
+	PermissionName string
+}
+
+func (r *Root) QueryPermissionLinks(ctx context.Context, args PermissionLinksRequest) (*PermissionLinks, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query permission links", zap.Reflect("request", args))
+
+	resp, err := r.statedbClient.GetPermissionLinks(ctx, &pbstatedb.GetPermissionLinksRequest{
+		Account:  args.Account,
+		BlockNum: uint64(args.BlockNum.Native()),
+	})
+	if err != nil {
+		zlogger.Info("unable to get permission links", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "PermissionLinks", "Args", args, "PermissionsCount", len(resp.Permissions))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One Outbound Document
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "PermissionLinks",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := &PermissionLinks{
+		UpToBlock:             newBlockRefFromProto(resp.UpToBlock),
+		LastIrreversibleBlock: newBlockRefFromProto(resp.LastIrreversibleBlock),
+		Permissions:           []*LinkedPermission{},
+	}
+	for _, permission := range resp.Permissions {
+		out.Permissions = append(out.Permissions, &LinkedPermission{
+			Contract:       permission.Contract,
+			Action:         permission.Action,
+			PermissionName: permission.PermissionName,
+		})
+	}
+
+	return out, nil
+}
+
+//----------------------------
+// Table Changes
+//----------------------------
+
+type TableChangesArgs struct {
+	Contract         string
+	Table            string
+	Scope            string
+	LowBlockNum      *types.Int64
+	Cursor           *string
+	IrreversibleOnly bool
+}
+
+// TableChange is a single row modification of the followed table, all the changes
+// of a given transaction share the same cursor, resuming from it replays them all.
+type TableChange struct {
+	Undo     bool
+	Cursor   string
+	BlockNum types.Uint64
+	BlockID  string
+	TrxID    string
+	DBOp     *DBOp
+
+	err error
+}
+
+func (c *TableChange) SubscriptionError() error {
+	return c.err
+}
+
+func (r *Root) SubscriptionTableChanges(ctx context.Context, args TableChangesArgs) (<-chan *TableChange, error) {
+	if err := r.RateLimit(ctx, "search"); err != nil {
+		return nil, err
+	}
+
+	// Arguments end up in a search query, anything but a valid name could alter it
+	for _, arg := range []struct{ name, value string }{{"contract", args.Contract}, {"table", args.Table}, {"scope", args.Scope}} {
+		if !eosNameRegex.MatchString(arg.value) {
+			return nil, dgraphql.Errorf(ctx, "invalid %s %q, must be a valid EOS name", arg.name, arg.value)
+		}
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "SubscriptionTableChanges", "TableChangesArgs", args)
+	/////////////////////////////////////////////////////////////////////////
+
+	responses, err := r.streamSearchTracesBoth(true, ctx, StreamSearchArgs{
+		Query:            fmt.Sprintf("receiver:%s db.table:%s/%s", args.Contract, args.Table, args.Scope),
+		LowBlockNum:      args.LowBlockNum,
+		Cursor:           args.Cursor,
+		IrreversibleOnly: args.IrreversibleOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := make(chan *TableChange)
+	go func() {
+		defer close(c)
+
+		for resp := range responses {
+			for _, change := range tableChangesFromSearchResponse(resp, args, r.abiCodecClient) {
+				select {
+				case <-ctx.Done():
+					return
+				case c <- change:
+				}
+			}
+		}
+	}()
+
+	return c, nil
+}
+
+func tableChangesFromSearchResponse(resp *SearchTransactionForwardResponse, args TableChangesArgs, abiCodecClient pbabicodec.DecoderClient) (out []*TableChange) {
+	if resp.err != nil {
+		return []*TableChange{{err: resp.err}}
+	}
+
+	trace := resp.trxTrace
+	if trace == nil {
+		return nil
+	}
+
+	for _, op := range trace.DbOps {
+		if op.Code != args.Contract || op.TableName != args.Table || op.Scope != args.Scope {
+			continue
+		}
+
+		out = append(out, &TableChange{
+			Undo:     resp.Undo,
+			Cursor:   resp.cursor,
+			BlockNum: types.Uint64(trace.BlockNum),
+			BlockID:  resp.blockID,
+			TrxID:    trace.Id,
+			DBOp:     newDBOp(op, trace.BlockNum, abiCodecClient),
+		})
+	}
+
+	return out
+}
+
+//----------------------------
+// Pagination
+//----------------------------
+
+// statedbPager paginates over statedb streams, which are ordered by key. The cursor
+// records the last key served along with the block at which the first page was
+// served, the following pages are requested at that same block and start right
+// after the cursor's key.
+type statedbPager struct {
+	prefix   string
+	blockNum uint64
+	limit    int
+
+	afterKey  string
+	count     int
+	edgeStart string
+	edgeEnd   string
+	hasNext   bool
+}
+
+func newStatedbPager(prefix string, blockNum *commonTypes.Uint32, limit *commonTypes.Uint32, cursor *string) (*statedbPager, error) {
+	pager := &statedbPager{
+		prefix:   prefix,
+		blockNum: uint64(blockNum.Native()),
+		limit:    defaultStatedbPageSize,
+	}
+
+	if limit != nil {
+		pager.limit = int(limit.Native())
+		if pager.limit <= 0 || pager.limit > maxStatedbPageSize {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxStatedbPageSize)
+		}
+	}
+
+	if cursor != nil && *cursor != "" {
+		cursorBlockNum, key, err := decodeStatedbCursor(prefix, *cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if blockNum != nil && uint64(blockNum.Native()) != cursorBlockNum {
+			return nil, fmt.Errorf("cursor was obtained at block %d, it cannot be used with block %d", cursorBlockNum, blockNum.Native())
+		}
+
+		pager.blockNum = cursorBlockNum
+		pager.afterKey = key
+	}
+
+	return pager, nil
+}
+
+// pin records the block the stream was served at, when no block was requested
+func (p *statedbPager) pin(blockNum uint64) {
+	if p.blockNum == 0 {
+		p.blockNum = blockNum
+	}
+}
+
+// next returns the cursor of `key` and `done` once the page is full and the stream can
+// be dropped.
+func (p *statedbPager) next(key string) (cursor string, done bool) {
+	if p.count >= p.limit {
+		p.hasNext = true
+		return "", true
+	}
+
+	cursor = encodeStatedbCursor(p.prefix, p.blockNum, key)
+	if p.count == 0 {
+		p.edgeStart = cursor
+	}
+	p.edgeEnd = cursor
+	p.count++
+
+	return cursor, false
+}
+
+func (p *statedbPager) pageInfo() PageInfo {
+	return PageInfo{
+		StartCursor:     p.edgeStart,
+		EndCursor:       p.edgeEnd,
+		HasNextPage:     p.hasNext,
+		HasPreviousPage: p.afterKey != "",
+	}
+}
+
+func encodeStatedbCursor(prefix string, blockNum uint64, key string) string {
+	cursor, _ := opaque.ToOpaque(fmt.Sprintf("%s:%d:%s", prefix, blockNum, key))
+	return cursor
+}
+
+func decodeStatedbCursor(prefix string, cursor string) (blockNum uint64, key string, err error) {
+	raw, err := opaque.FromOpaque(cursor)
+	if err != nil {
+		return 0, "", fmt.Errorf("unpacking cursor: %w", err)
+	}
+
+	parts := strings.SplitN(raw, ":", 3)
+	if len(parts) != 3 || parts[0] != prefix {
+		return 0, "", fmt.Errorf("invalid cursor, is this a cursor obtained through this same GraphQL Query?")
+	}
+
+	blockNum, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid cursor block num: %w", err)
+	}
+
+	return blockNum, parts[2], nil
+}
+
+func newBlockRefFromProto(ref *pbbstream.BlockRef) *BlockRef {
+	if ref == nil {
+		return nil
+	}
+	return newBlockRef(ref.Id, ref.Num)
+}
+
+func stringOr(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+	return *value
+}
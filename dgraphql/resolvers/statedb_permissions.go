@@ -0,0 +1,189 @@
+package resolvers
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/dgraphql"
+	"github.com/streamingfast/dgraphql/analytics"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+type PermissionHistoryRequest struct {
+	Account      string
+	Permission   *string
+	LowBlockNum  *commonTypes.Uint32
+	HighBlockNum *commonTypes.Uint32
+	Limit        *commonTypes.Uint32
+}
+
+func (r *Root) QueryPermissionHistory(ctx context.Context, args PermissionHistoryRequest) ([]*PermissionChange, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query permission history", zap.Reflect("request", args))
+
+	limit := uint32(defaultStatedbPageSize)
+	if args.Limit != nil {
+		limit = uint32(args.Limit.Native())
+		if limit == 0 || limit > maxStatedbPageSize {
+			return nil, dgraphql.Errorf(ctx, "limit must be between 1 and %d", maxStatedbPageSize)
+		}
+	}
+
+	permission := ""
+	if args.Permission != nil {
+		permission = *args.Permission
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.permissionsClient.StreamPermissionHistory(ctx, &pbstatedb.StreamPermissionHistoryRequest{
+		Account:      args.Account,
+		Permission:   permission,
+		LowBlockNum:  uint64(args.LowBlockNum.Native()),
+		HighBlockNum: uint64(args.HighBlockNum.Native()),
+		Limit:        limit,
+	})
+	if err != nil {
+		zlogger.Info("unable to stream permission history", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	out := []*PermissionChange{}
+	for {
+		change, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			zlogger.Info("unable to receive permission change", zap.Error(err))
+			return nil, dgraphql.UnwrapError(ctx, err)
+		}
+
+		out = append(out, &PermissionChange{c: change})
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "PermissionHistory", "Args", args, "ResultsCount", len(out))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "PermissionHistory",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(out)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return out, nil
+}
+
+//----------------------------
+// Permission Change
+//----------------------------
+
+type PermissionChange struct {
+	c *pbstatedb.PermissionChange
+}
+
+func (c *PermissionChange) BlockNum() types.Uint64 { return types.Uint64(c.c.BlockNum) }
+func (c *PermissionChange) TrxID() string          { return c.c.TrxId }
+func (c *PermissionChange) Account() string        { return c.c.Account }
+func (c *PermissionChange) Permission() string     { return c.c.Permission }
+
+func (c *PermissionChange) Operation() string {
+	return strings.TrimPrefix(c.c.Operation.String(), "OPERATION_")
+}
+
+func (c *PermissionChange) OldAuthority() *Authority { return newAuthority(c.c.OldPerm) }
+func (c *PermissionChange) NewAuthority() *Authority { return newAuthority(c.c.NewPerm) }
+
+type Authority struct {
+	a *pbcodec.Authority
+}
+
+func newAuthority(perm *pbcodec.PermissionObject) *Authority {
+	if perm == nil {
+		return nil
+	}
+
+	authority := perm.Authority
+	if authority == nil {
+		authority = &pbcodec.Authority{}
+	}
+
+	return &Authority{a: authority}
+}
+
+func (a *Authority) Threshold() commonTypes.Uint32 { return commonTypes.Uint32(a.a.Threshold) }
+
+func (a *Authority) Keys() (out []*KeyWeight) {
+	out = make([]*KeyWeight, len(a.a.Keys))
+	for i, key := range a.a.Keys {
+		out[i] = &KeyWeight{k: key}
+	}
+	return
+}
+
+func (a *Authority) Accounts() (out []*PermissionLevelWeight) {
+	out = make([]*PermissionLevelWeight, len(a.a.Accounts))
+	for i, account := range a.a.Accounts {
+		out[i] = &PermissionLevelWeight{p: account}
+	}
+	return
+}
+
+func (a *Authority) Waits() (out []*WaitWeight) {
+	out = make([]*WaitWeight, len(a.a.Waits))
+	for i, wait := range a.a.Waits {
+		out[i] = &WaitWeight{w: wait}
+	}
+	return
+}
+
+type KeyWeight struct {
+	k *pbcodec.KeyWeight
+}
+
+func (k *KeyWeight) PublicKey() string          { return k.k.PublicKey }
+func (k *KeyWeight) Weight() commonTypes.Uint32 { return commonTypes.Uint32(k.k.Weight) }
+
+type PermissionLevelWeight struct {
+	p *pbcodec.PermissionLevelWeight
+}
+
+func (p *PermissionLevelWeight) Permission() *PermissionLevel {
+	level := p.p.Permission
+	if level == nil {
+		level = &pbcodec.PermissionLevel{}
+	}
+
+	return &PermissionLevel{pl: level}
+}
+
+func (p *PermissionLevelWeight) Weight() commonTypes.Uint32 { return commonTypes.Uint32(p.p.Weight) }
+
+type WaitWeight struct {
+	w *pbcodec.WaitWeight
+}
+
+func (w *WaitWeight) WaitSec() commonTypes.Uint32 { return commonTypes.Uint32(w.w.WaitSec) }
+func (w *WaitWeight) Weight() commonTypes.Uint32  { return commonTypes.Uint32(w.w.Weight) }
@@ -0,0 +1,84 @@
+package resolvers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestQueryPermissionHistory(t *testing.T) {
+	client := &testPermissionsClient{changes: []*pbstatedb.PermissionChange{
+		{
+			BlockNum:   8,
+			TrxId:      "a2",
+			Account:    "alice",
+			Permission: "owner",
+			Operation:  pbcodec.PermOp_OPERATION_UPDATE,
+			OldPerm:    &pbcodec.PermissionObject{Authority: &pbcodec.Authority{Threshold: 1, Keys: []*pbcodec.KeyWeight{{PublicKey: "PUB1", Weight: 1}}}},
+			NewPerm: &pbcodec.PermissionObject{Authority: &pbcodec.Authority{
+				Threshold: 2,
+				Accounts:  []*pbcodec.PermissionLevelWeight{{Permission: &pbcodec.PermissionLevel{Actor: "bob", Permission: "active"}, Weight: 1}},
+				Waits:     []*pbcodec.WaitWeight{{WaitSec: 3600, Weight: 1}},
+			}},
+		},
+		{BlockNum: 4, TrxId: "a1", Account: "alice", Permission: "owner", Operation: pbcodec.PermOp_OPERATION_INSERT, NewPerm: &pbcodec.PermissionObject{}},
+	}}
+	root := &Root{permissionsClient: client}
+
+	permission := "owner"
+	history, err := root.QueryPermissionHistory(context.Background(), PermissionHistoryRequest{Account: "alice", Permission: &permission})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "owner", client.lastRequest.Permission)
+	assert.Equal(t, uint32(defaultStatedbPageSize), client.lastRequest.Limit)
+
+	assert.Equal(t, types.Uint64(8), history[0].BlockNum())
+	assert.Equal(t, "UPDATE", history[0].Operation())
+	assert.Equal(t, "PUB1", history[0].OldAuthority().Keys()[0].PublicKey())
+	assert.Equal(t, commonTypes.Uint32(2), history[0].NewAuthority().Threshold())
+	assert.Equal(t, "bob", history[0].NewAuthority().Accounts()[0].Permission().Actor())
+	assert.Equal(t, commonTypes.Uint32(3600), history[0].NewAuthority().Waits()[0].WaitSec())
+
+	assert.Equal(t, "INSERT", history[1].Operation())
+	assert.Nil(t, history[1].OldAuthority())
+	assert.Empty(t, history[1].NewAuthority().Keys())
+
+	limit := commonTypes.Uint32(0)
+	_, err = root.QueryPermissionHistory(context.Background(), PermissionHistoryRequest{Account: "alice", Limit: &limit})
+	assert.Error(t, err)
+}
+
+type testPermissionsClient struct {
+	changes []*pbstatedb.PermissionChange
+
+	lastRequest *pbstatedb.StreamPermissionHistoryRequest
+}
+
+func (c *testPermissionsClient) StreamPermissionHistory(ctx context.Context, in *pbstatedb.StreamPermissionHistoryRequest, opts ...grpc.CallOption) (pbstatedb.Permissions_StreamPermissionHistoryClient, error) {
+	c.lastRequest = in
+	return &testPermissionHistoryStream{changes: c.changes}, nil
+}
+
+type testPermissionHistoryStream struct {
+	grpc.ClientStream
+
+	changes []*pbstatedb.PermissionChange
+}
+
+func (s *testPermissionHistoryStream) Recv() (*pbstatedb.PermissionChange, error) {
+	if len(s.changes) == 0 {
+		return nil, io.EOF
+	}
+
+	change := s.changes[0]
+	s.changes = s.changes[1:]
+	return change, nil
+}
@@ -0,0 +1,89 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/dgraphql"
+	"github.com/streamingfast/dgraphql/analytics"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+type ProtocolFeaturesRequest struct {
+	BlockNum *commonTypes.Uint32
+}
+
+func (r *Root) QueryProtocolFeatures(ctx context.Context, args ProtocolFeaturesRequest) ([]*ProtocolFeature, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query protocol features", zap.Reflect("request", args))
+
+	resp, err := r.protocolFeaturesClient.GetProtocolFeatures(ctx, &pbstatedb.GetProtocolFeaturesRequest{
+		BlockNum: uint64(args.BlockNum.Native()),
+	})
+	if err != nil {
+		zlogger.Info("unable to get protocol features", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	out := make([]*ProtocolFeature, len(resp.Features))
+	for i, feature := range resp.Features {
+		out[i] = &ProtocolFeature{f: feature}
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "ProtocolFeatures", "Args", args, "ResultsCount", len(out))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One Outbound Document
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "ProtocolFeatures",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return out, nil
+}
+
+//----------------------------
+// Protocol Feature
+//----------------------------
+
+type ProtocolFeature struct {
+	f *pbstatedb.ProtocolFeature
+}
+
+func (f *ProtocolFeature) FeatureDigest() string       { return f.f.FeatureDigest }
+func (f *ProtocolFeature) Name() *string               { return optionalString(f.f.Name) }
+func (f *ProtocolFeature) PreActivationTrxID() *string { return optionalString(f.f.PreActivationTrxId) }
+func (f *ProtocolFeature) ActivationTrxID() *string    { return optionalString(f.f.ActivationTrxId) }
+func (f *ProtocolFeature) DescriptionDigest() string   { return f.f.Feature.GetDescriptionDigest() }
+
+func (f *ProtocolFeature) PreActivationBlockNum() *types.Uint64 {
+	return optionalUint64(f.f.PreActivationBlockNum)
+}
+
+func (f *ProtocolFeature) ActivationBlockNum() *types.Uint64 {
+	return optionalUint64(f.f.ActivationBlockNum)
+}
+func (f *ProtocolFeature) Dependencies() []string {
+	if f.f.Feature == nil || f.f.Feature.Dependencies == nil {
+		return []string{}
+	}
+
+	return f.f.Feature.Dependencies
+}
@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestQueryProtocolFeatures(t *testing.T) {
+	client := &testProtocolFeaturesClient{response: &pbstatedb.GetProtocolFeaturesResponse{
+		Features: []*pbstatedb.ProtocolFeature{
+			{
+				FeatureDigest:      "0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd",
+				Name:               "PREACTIVATE_FEATURE",
+				ActivationBlockNum: 2,
+				ActivationTrxId:    "a1",
+				Feature:            &pbcodec.Feature{DescriptionDigest: "64fe7df32e9b86be2b296b3f81dfd527f84e82b98e363bc97e40bc7a83733310"},
+			},
+			{
+				FeatureDigest:         "8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405",
+				PreActivationBlockNum: 3,
+				PreActivationTrxId:    "a2",
+				Feature:               &pbcodec.Feature{Dependencies: []string{"0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd"}},
+			},
+		},
+	}}
+	root := &Root{protocolFeaturesClient: client}
+
+	blockNum := commonTypes.Uint32(10)
+	features, err := root.QueryProtocolFeatures(context.Background(), ProtocolFeaturesRequest{BlockNum: &blockNum})
+	require.NoError(t, err)
+	require.Len(t, features, 2)
+	assert.Equal(t, uint64(10), client.lastBlockNum)
+
+	assert.Equal(t, "PREACTIVATE_FEATURE", *features[0].Name())
+	assert.Equal(t, types.Uint64(2), *features[0].ActivationBlockNum())
+	assert.Equal(t, "a1", *features[0].ActivationTrxID())
+	assert.Nil(t, features[0].PreActivationBlockNum())
+	assert.Equal(t, []string{}, features[0].Dependencies())
+
+	// Not a builtin feature and only pre-activated
+	assert.Nil(t, features[1].Name())
+	assert.Nil(t, features[1].ActivationBlockNum())
+	assert.Equal(t, types.Uint64(3), *features[1].PreActivationBlockNum())
+	assert.Equal(t, []string{"0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd"}, features[1].Dependencies())
+}
+
+type testProtocolFeaturesClient struct {
+	response *pbstatedb.GetProtocolFeaturesResponse
+
+	lastBlockNum uint64
+}
+
+func (c *testProtocolFeaturesClient) GetProtocolFeatures(ctx context.Context, in *pbstatedb.GetProtocolFeaturesRequest, opts ...grpc.CallOption) (*pbstatedb.GetProtocolFeaturesResponse, error) {
+	c.lastBlockNum = in.BlockNum
+	return c.response, nil
+}
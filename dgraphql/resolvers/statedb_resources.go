@@ -0,0 +1,227 @@
+package resolvers
+
+import (
+	"context"
+	"io"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/dgraphql"
+	"github.com/streamingfast/dgraphql/analytics"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+type AccountResourcesRequest struct {
+	Account  string
+	BlockNum *commonTypes.Uint32
+}
+
+func (r *Root) QueryAccountResources(ctx context.Context, args AccountResourcesRequest) (*AccountResources, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query account resources", zap.Reflect("request", args))
+
+	resp, err := r.resourcesClient.GetAccountResources(ctx, &pbstatedb.GetAccountResourcesRequest{
+		Account:  args.Account,
+		BlockNum: uint64(args.BlockNum.Native()),
+	})
+	if err != nil {
+		zlogger.Info("unable to get account resources", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "AccountResources", "Args", args)
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, One Outbound Document
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "AccountResources",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return &AccountResources{r: resp}, nil
+}
+
+type AccountResourcesHistoryRequest struct {
+	Account      string
+	LowBlockNum  *commonTypes.Uint32
+	HighBlockNum *commonTypes.Uint32
+	Limit        *commonTypes.Uint32
+}
+
+func (r *Root) QueryAccountResourcesHistory(ctx context.Context, args AccountResourcesHistoryRequest) ([]*AccountResources, error) {
+	if err := r.RateLimit(ctx, "statedb"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query account resources history", zap.Reflect("request", args))
+
+	limit := uint32(defaultStatedbPageSize)
+	if args.Limit != nil {
+		limit = uint32(args.Limit.Native())
+		if limit == 0 || limit > maxStatedbPageSize {
+			return nil, dgraphql.Errorf(ctx, "limit must be between 1 and %d", maxStatedbPageSize)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.resourcesClient.StreamAccountResourcesHistory(ctx, &pbstatedb.StreamAccountResourcesHistoryRequest{
+		Account:      args.Account,
+		LowBlockNum:  uint64(args.LowBlockNum.Native()),
+		HighBlockNum: uint64(args.HighBlockNum.Native()),
+		Limit:        limit,
+	})
+	if err != nil {
+		zlogger.Info("unable to stream account resources history", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	out := []*AccountResources{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			zlogger.Info("unable to receive account resources", zap.Error(err))
+			return nil, dgraphql.UnwrapError(ctx, err)
+		}
+
+		out = append(out, &AccountResources{r: resp})
+	}
+
+	/////////////////////////////////////////////////////////////////////////
+	// DO NOT change this without updating BigQuery analytics
+	analytics.TrackUserEvent(ctx, "dgraphql", "AccountResourcesHistory", "Args", args, "ResultsCount", len(out))
+	/////////////////////////////////////////////////////////////////////////
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "AccountResourcesHistory",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(out)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return out, nil
+}
+
+//----------------------------
+// Account Resources
+//----------------------------
+
+type AccountResources struct {
+	r *pbstatedb.AccountResourcesResponse
+}
+
+func (a *AccountResources) BlockNum() types.Uint64 { return types.Uint64(a.r.BlockNum) }
+func (a *AccountResources) Account() string        { return a.r.Account }
+
+func (a *AccountResources) LimitsBlockNum() *types.Uint64 {
+	if a.r.Limits == nil {
+		return nil
+	}
+	blockNum := types.Uint64(a.r.LimitsBlockNum)
+	return &blockNum
+}
+
+func (a *AccountResources) UsageBlockNum() *types.Uint64 {
+	if a.r.Usage == nil {
+		return nil
+	}
+	blockNum := types.Uint64(a.r.UsageBlockNum)
+	return &blockNum
+}
+
+func (a *AccountResources) CpuWeight() *types.Int64 {
+	if a.r.Limits == nil {
+		return nil
+	}
+	weight := types.Int64(a.r.Limits.CpuWeight)
+	return &weight
+}
+
+func (a *AccountResources) NetWeight() *types.Int64 {
+	if a.r.Limits == nil {
+		return nil
+	}
+	weight := types.Int64(a.r.Limits.NetWeight)
+	return &weight
+}
+
+func (a *AccountResources) RamQuota() *types.Int64 {
+	if a.r.Limits == nil {
+		return nil
+	}
+	quota := types.Int64(a.r.Limits.RamBytes)
+	return &quota
+}
+
+func (a *AccountResources) RamUsage() types.Uint64 {
+	return types.Uint64(a.r.Usage.GetRamUsage())
+}
+
+func (a *AccountResources) Cpu() *AccountResourceLimit { return newAccountResourceLimit(a.r.CpuLimit) }
+func (a *AccountResources) Net() *AccountResourceLimit { return newAccountResourceLimit(a.r.NetLimit) }
+
+func (a *AccountResources) ElasticLimits() *ElasticLimits {
+	if a.r.State == nil {
+		return nil
+	}
+	return &ElasticLimits{
+		TotalCpuWeight:  types.Uint64(a.r.State.TotalCpuWeight),
+		TotalNetWeight:  types.Uint64(a.r.State.TotalNetWeight),
+		TotalRamBytes:   types.Uint64(a.r.State.TotalRamBytes),
+		VirtualCpuLimit: types.Uint64(a.r.State.VirtualCpuLimit),
+		VirtualNetLimit: types.Uint64(a.r.State.VirtualNetLimit),
+	}
+}
+
+type AccountResourceLimit struct {
+	Used      types.Int64
+	Available types.Int64
+	Max       types.Int64
+}
+
+func newAccountResourceLimit(limit *pbstatedb.AccountResourceLimit) *AccountResourceLimit {
+	if limit == nil {
+		return nil
+	}
+	return &AccountResourceLimit{
+		Used:      types.Int64(limit.Used),
+		Available: types.Int64(limit.Available),
+		Max:       types.Int64(limit.Max),
+	}
+}
+
+type ElasticLimits struct {
+	TotalCpuWeight  types.Uint64
+	TotalNetWeight  types.Uint64
+	TotalRamBytes   types.Uint64
+	VirtualCpuLimit types.Uint64
+	VirtualNetLimit types.Uint64
+}
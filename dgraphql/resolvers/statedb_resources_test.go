@@ -0,0 +1,107 @@
+package resolvers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestQueryAccountResources(t *testing.T) {
+	client := &testResourcesClient{responses: []*pbstatedb.AccountResourcesResponse{
+		{
+			BlockNum:       10,
+			Account:        "alice",
+			LimitsBlockNum: 4,
+			Limits:         &pbcodec.RlimitAccountLimits{Owner: "alice", CpuWeight: 5, NetWeight: -1, RamBytes: 8000},
+			UsageBlockNum:  8,
+			Usage:          &pbcodec.RlimitAccountUsage{Owner: "alice", RamUsage: 3000},
+			State:          &pbcodec.RlimitState{TotalCpuWeight: 100, VirtualCpuLimit: 200000},
+			CpuLimit:       &pbstatedb.AccountResourceLimit{Used: 10, Available: 90, Max: 100},
+			NetLimit:       &pbstatedb.AccountResourceLimit{Used: -1, Available: -1, Max: -1},
+		},
+		{BlockNum: 10, Account: "bob"},
+	}}
+	root := &Root{resourcesClient: client}
+
+	blockNum := commonTypes.Uint32(10)
+	resources, err := root.QueryAccountResources(context.Background(), AccountResourcesRequest{Account: "alice", BlockNum: &blockNum})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), client.lastBlockNum)
+
+	assert.Equal(t, types.Uint64(4), *resources.LimitsBlockNum())
+	assert.Equal(t, types.Uint64(8), *resources.UsageBlockNum())
+	assert.Equal(t, types.Int64(5), *resources.CpuWeight())
+	assert.Equal(t, types.Int64(8000), *resources.RamQuota())
+	assert.Equal(t, types.Uint64(3000), resources.RamUsage())
+	assert.Equal(t, &AccountResourceLimit{Used: 10, Available: 90, Max: 100}, resources.Cpu())
+	assert.Equal(t, types.Int64(-1), resources.Net().Max)
+	assert.Equal(t, types.Uint64(200000), resources.ElasticLimits().VirtualCpuLimit)
+
+	// An account never seen has no limits nor usage
+	resources = &AccountResources{r: client.responses[1]}
+	assert.Nil(t, resources.LimitsBlockNum())
+	assert.Nil(t, resources.CpuWeight())
+	assert.Nil(t, resources.Cpu())
+	assert.Nil(t, resources.ElasticLimits())
+	assert.Equal(t, types.Uint64(0), resources.RamUsage())
+}
+
+func TestQueryAccountResourcesHistory(t *testing.T) {
+	client := &testResourcesClient{responses: []*pbstatedb.AccountResourcesResponse{
+		{BlockNum: 8, Account: "alice"},
+		{BlockNum: 4, Account: "alice"},
+	}}
+	root := &Root{resourcesClient: client}
+
+	history, err := root.QueryAccountResourcesHistory(context.Background(), AccountResourcesHistoryRequest{Account: "alice"})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, types.Uint64(8), history[0].BlockNum())
+	assert.Equal(t, types.Uint64(4), history[1].BlockNum())
+	assert.Equal(t, uint32(defaultStatedbPageSize), client.lastLimit)
+
+	limit := commonTypes.Uint32(maxStatedbPageSize + 1)
+	_, err = root.QueryAccountResourcesHistory(context.Background(), AccountResourcesHistoryRequest{Account: "alice", Limit: &limit})
+	assert.Error(t, err)
+}
+
+type testResourcesClient struct {
+	responses []*pbstatedb.AccountResourcesResponse
+
+	lastBlockNum uint64
+	lastLimit    uint32
+}
+
+func (c *testResourcesClient) GetAccountResources(ctx context.Context, in *pbstatedb.GetAccountResourcesRequest, opts ...grpc.CallOption) (*pbstatedb.AccountResourcesResponse, error) {
+	c.lastBlockNum = in.BlockNum
+	return c.responses[0], nil
+}
+
+func (c *testResourcesClient) StreamAccountResourcesHistory(ctx context.Context, in *pbstatedb.StreamAccountResourcesHistoryRequest, opts ...grpc.CallOption) (pbstatedb.Resources_StreamAccountResourcesHistoryClient, error) {
+	c.lastLimit = in.Limit
+	return &testResourcesHistoryStream{responses: c.responses}, nil
+}
+
+type testResourcesHistoryStream struct {
+	grpc.ClientStream
+
+	responses []*pbstatedb.AccountResourcesResponse
+}
+
+func (s *testResourcesHistoryStream) Recv() (*pbstatedb.AccountResourcesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTableRows_Pagination(t *testing.T) {
+	ctx := context.Background()
+	client := pbstatedb.NewMockStateClient()
+	root := &Root{statedbClient: client}
+
+	setRows := func() {
+		client.SetStreamTableRows(&pbstatedb.MockStreamTableRows{
+			LastIrrBlockID:  "00000009a",
+			LastIrrBlockNum: 9,
+			UpToBlockID:     "0000000aa",
+			UpToBlockNum:    10,
+			Rows: []*pbstatedb.TableRowResponse{
+				{Key: "alice", Payer: "alice", Json: `{"balance":"1.0000 EOS"}`, BlockNumber: 4},
+				{Key: "bob", Payer: "bob", Json: `{"balance":"2.0000 EOS"}`, BlockNumber: 5},
+				{Key: "carol", Payer: "carol", BlockNumber: 6},
+			},
+		})
+	}
+
+	limit := commonTypes.Uint32(2)
+	args := TableRowsRequest{Contract: "eosio.token", Table: "accounts", Scope: "eosio", Limit: &limit}
+
+	setRows()
+	page, err := root.QueryTableRows(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 2)
+	assert.Equal(t, uint64(10), uint64(page.BlockRef.Number()))
+	assert.Equal(t, "alice", page.Edges[0].Node.Key())
+	assert.Equal(t, `{"balance":"1.0000 EOS"}`, string(*page.Edges[0].Node.JSON()))
+	assert.True(t, page.PageInfo.HasNextPage)
+	assert.False(t, page.PageInfo.HasPreviousPage)
+
+	blockNum, key, err := decodeStatedbCursor(tableRowCursorPrefix, page.PageInfo.EndCursor)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), blockNum)
+	assert.Equal(t, "bob", key)
+
+	setRows()
+	args.Cursor = &page.PageInfo.EndCursor
+	page, err = root.QueryTableRows(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 1)
+	assert.Equal(t, "carol", page.Edges[0].Node.Key())
+	assert.Nil(t, page.Edges[0].Node.JSON())
+	assert.False(t, page.PageInfo.HasNextPage)
+	assert.True(t, page.PageInfo.HasPreviousPage)
+
+	otherBlockNum := commonTypes.Uint32(8)
+	args.BlockNum = &otherBlockNum
+	_, err = root.QueryTableRows(ctx, args)
+	require.Error(t, err)
+}
+
+func TestSubscriptionTableChanges_InvalidNames(t *testing.T) {
+	root := &Root{}
+
+	for _, args := range []TableChangesArgs{
+		{Contract: "eosio.token receiver:eosio", Table: "accounts", Scope: "eosio"},
+		{Contract: "eosio.token", Table: "accounts/eosio", Scope: "eosio"},
+		{Contract: "eosio.token", Table: "accounts", Scope: ""},
+	} {
+		_, err := root.SubscriptionTableChanges(context.Background(), args)
+		assert.Error(t, err, "args %v", args)
+	}
+}
+
+func TestTableChangesFromSearchResponse(t *testing.T) {
+	args := TableChangesArgs{Contract: "eosio.token", Table: "accounts", Scope: "alice"}
+
+	resp := &SearchTransactionForwardResponse{
+		SearchTransactionBackwardResponse: SearchTransactionBackwardResponse{
+			cursor:  "cursor.1",
+			blockID: "0000000aa",
+			trxTrace: &pbcodec.TransactionTrace{
+				Id:       "trx1",
+				BlockNum: 10,
+				DbOps: []*pbcodec.DBOp{
+					{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", TableName: "accounts", Scope: "alice", PrimaryKey: "eos"},
+					{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", TableName: "accounts", Scope: "bob", PrimaryKey: "eos"},
+					{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", TableName: "stat", Scope: "alice", PrimaryKey: "eos"},
+				},
+			},
+		},
+		Undo: true,
+	}
+
+	changes := tableChangesFromSearchResponse(resp, args, nil)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Undo)
+	assert.Equal(t, "cursor.1", changes[0].Cursor)
+	assert.Equal(t, "trx1", changes[0].TrxID)
+	assert.Equal(t, "alice", changes[0].DBOp.Key().Scope(struct{ Encoding string }{"NAME"}))
+}
@@ -3,7 +3,9 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
 
 	"github.com/streamingfast/bstream"
 	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
@@ -13,6 +15,10 @@ import (
 	"github.com/streamingfast/dgraphql"
 	commonTypes "github.com/streamingfast/dgraphql/types"
 	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var accountBalanceCursorDecoder = dgraphql.NewOpaqueProtoCursorDecoder(func() proto.Message { return &pbtokenmeta.AccountBalanceCursor{} })
@@ -234,9 +240,10 @@ func (r *Root) QueryAccountBalances(ctx context.Context, args *AccountBalancesRe
 	}
 	accountBalances = paginatedAccountBalances.(PagineableAcccountBalances)
 
+	prices := newTokenPriceLoader(r.tokenPricesClient)
 	edges := []*AccountBalanceEdge{}
 	for _, item := range accountBalances {
-		edges = append(edges, newAccountBalanceEdge(newAccountBalance(item), dgraphql.MustProtoToOpaqueCursor(&pbtokenmeta.AccountBalanceCursor{
+		edges = append(edges, newAccountBalanceEdge(newAccountBalance(item, prices), dgraphql.MustProtoToOpaqueCursor(&pbtokenmeta.AccountBalanceCursor{
 			Ver:      1,
 			Contract: item.TokenContract,
 			Symbol:   item.Symbol,
@@ -356,9 +363,10 @@ func (r *Root) QueryTokenBalances(ctx context.Context, args *TokenBalancesReques
 	}
 	accountBalances = paginatedAccountBalances.(PagineableAcccountBalances)
 
+	prices := newTokenPriceLoader(r.tokenPricesClient)
 	edges := []*AccountBalanceEdge{}
 	for _, item := range accountBalances {
-		edges = append(edges, newAccountBalanceEdge(newAccountBalance(item), dgraphql.MustProtoToOpaqueCursor(&pbtokenmeta.AccountBalanceCursor{
+		edges = append(edges, newAccountBalanceEdge(newAccountBalance(item, prices), dgraphql.MustProtoToOpaqueCursor(&pbtokenmeta.AccountBalanceCursor{
 			Ver:      1,
 			Contract: item.TokenContract,
 			Symbol:   item.Symbol,
@@ -529,12 +537,14 @@ func (e *AccountBalanceEdge) Node() *AccountBalance { return e.node }
 // Account Balance
 //----------------------------
 type AccountBalance struct {
-	a *pbtokenmeta.AccountBalance
+	a      *pbtokenmeta.AccountBalance
+	prices *tokenPriceLoader
 }
 
-func newAccountBalance(a *pbtokenmeta.AccountBalance) *AccountBalance {
+func newAccountBalance(a *pbtokenmeta.AccountBalance, prices *tokenPriceLoader) *AccountBalance {
 	return &AccountBalance{
-		a: a,
+		a:      a,
+		prices: prices,
 	}
 }
 
@@ -547,6 +557,96 @@ func (a *AccountBalance) Balance(args *AssetArgs) string {
 	return assetToString(a.a.Amount, a.a.Precision, a.a.Symbol, args)
 }
 
+func (a *AccountBalance) Price(ctx context.Context) (*TokenPrice, error) {
+	price, err := a.prices.load(ctx, a.a.TokenContract, a.a.Symbol)
+	if err != nil || price == nil {
+		return nil, err
+	}
+	return newTokenPrice(price), nil
+}
+
+func (a *AccountBalance) Value(ctx context.Context) (*float64, error) {
+	price, err := a.prices.load(ctx, a.a.TokenContract, a.a.Symbol)
+	if err != nil || price == nil {
+		return nil, err
+	}
+
+	value := float64(a.a.Amount) / math.Pow10(int(a.a.Precision)) * price.Price
+	return &value, nil
+}
+
+//----------------------------
+// Token Price
+//----------------------------
+type TokenPrice struct {
+	p *pbtokenmeta.TokenPrice
+}
+
+func newTokenPrice(p *pbtokenmeta.TokenPrice) *TokenPrice {
+	return &TokenPrice{
+		p: p,
+	}
+}
+
+func (p *TokenPrice) ReferenceContract() string { return p.p.ReferenceContract }
+func (p *TokenPrice) ReferenceSymbol() string   { return p.p.ReferenceSymbol }
+func (p *TokenPrice) Price() float64            { return p.p.Price }
+func (p *TokenPrice) Route() []string {
+	if p.p.Route == nil {
+		return []string{}
+	}
+	return p.p.Route
+}
+
+// tokenPriceLoader fetches token prices lazily, once per token, so that all
+// balances of a query share the same lookups.
+type tokenPriceLoader struct {
+	client pbtokenmeta.TokenPricesClient
+
+	lock    sync.Mutex
+	results map[string]*tokenPriceResult
+}
+
+type tokenPriceResult struct {
+	price *pbtokenmeta.TokenPrice
+	err   error
+}
+
+func newTokenPriceLoader(client pbtokenmeta.TokenPricesClient) *tokenPriceLoader {
+	return &tokenPriceLoader{
+		client:  client,
+		results: map[string]*tokenPriceResult{},
+	}
+}
+
+func (l *tokenPriceLoader) load(ctx context.Context, contract, symbol string) (*pbtokenmeta.TokenPrice, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := contract + ":" + symbol
+	if result, found := l.results[key]; found {
+		return result.price, result.err
+	}
+
+	result := &tokenPriceResult{}
+	resp, err := l.client.GetTokenPrice(ctx, &pbtokenmeta.GetTokenPriceRequest{
+		TokenContract: contract,
+		TokenSymbol:   symbol,
+	})
+	switch {
+	case err == nil:
+		result.price = resp.Price
+	case status.Code(err) == codes.NotFound:
+		// Token cannot be priced through the known pools, `null` it is
+	default:
+		logging.Logger(ctx, zlog).Debug("unable to fetch token price", zap.String("token", key), zap.Error(err))
+		result.err = dgraphql.Errorf(ctx, "unable to fetch token price for %s", key)
+	}
+
+	l.results[key] = result
+	return result.price, result.err
+}
+
 //----------------------------
 // EOS Token Collection
 //----------------------------
@@ -0,0 +1,218 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/dfuse-io/dfuse-eosio/dgraphql/types"
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/streamingfast/dgraphql"
+	commonTypes "github.com/streamingfast/dgraphql/types"
+	"github.com/streamingfast/dmetering"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+)
+
+type TokenHolderDistributionRequest struct {
+	Contract     string
+	Symbol       string
+	BucketBounds *[]float64
+	TopN         *commonTypes.Uint32
+	Options      *[]AccountBalanceOption
+}
+
+func (r *Root) QueryTokenHolderDistribution(ctx context.Context, args *TokenHolderDistributionRequest) (*TokenHolderDistribution, error) {
+	if err := r.RateLimit(ctx, "token"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query token holder distribution", zap.Reflect("request", args))
+
+	request := &pbtokenmeta.GetHolderDistributionRequest{
+		TokenContract: args.Contract,
+		TokenSymbol:   args.Symbol,
+		Options:       []pbtokenmeta.GetTokenBalancesRequest_Option{},
+	}
+
+	if args.BucketBounds != nil {
+		request.BucketBounds = *args.BucketBounds
+	}
+
+	if args.TopN != nil {
+		request.TopN = uint32(*args.TopN)
+	}
+
+	if args.Options != nil {
+		for _, option := range *args.Options {
+			if o, ok := pbtokenmeta.GetTokenBalancesRequest_Option_value[string(option)]; ok {
+				request.Options = append(request.Options, pbtokenmeta.GetTokenBalancesRequest_Option(o))
+			}
+		}
+	}
+
+	resp, err := r.tokenHoldersClient.GetHolderDistribution(ctx, request)
+	if err != nil {
+		zlogger.Info("unable to get holder distribution", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents ???
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "TokenHolderDistribution",
+		RequestsCount:  1,
+		ResponsesCount: 1,
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	return &TokenHolderDistribution{d: resp, prices: newTokenPriceLoader(r.tokenPricesClient)}, nil
+}
+
+type TokenHolderHistoryRequest struct {
+	Contract string
+	Symbol   string
+	Since    *graphql.Time
+}
+
+func (r *Root) QueryTokenHolderHistory(ctx context.Context, args *TokenHolderHistoryRequest) (*TokenHolderHistory, error) {
+	if err := r.RateLimit(ctx, "token"); err != nil {
+		return nil, err
+	}
+
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("query token holder history", zap.Reflect("request", args))
+
+	request := &pbtokenmeta.GetHolderCountHistoryRequest{
+		TokenContract: args.Contract,
+		TokenSymbol:   args.Symbol,
+	}
+
+	if args.Since != nil {
+		since, err := ptypes.TimestampProto(args.Since.Time)
+		if err != nil {
+			return nil, dgraphql.Errorf(ctx, "invalid since: %s", err)
+		}
+		request.Since = since
+	}
+
+	resp, err := r.tokenHoldersClient.GetHolderCountHistory(ctx, request)
+	if err != nil {
+		zlogger.Info("unable to get holder count history", zap.Error(err))
+		return nil, dgraphql.UnwrapError(ctx, err)
+	}
+
+	//////////////////////////////////////////////////////////////////////
+	// Billable event on GraphQL Query - One Request, Many Outbound Documents ???
+	// WARNING: Ingress / Egress bytess is taken care by the middleware
+	//////////////////////////////////////////////////////////////////////
+	dmetering.EmitWithContext(dmetering.Event{
+		Source:         "dgraphql",
+		Kind:           "GraphQL Query",
+		Method:         "TokenHolderHistory",
+		RequestsCount:  1,
+		ResponsesCount: countMinOne(len(resp.Samples)),
+	}, ctx)
+	//////////////////////////////////////////////////////////////////////
+
+	out := &TokenHolderHistory{
+		BlockRef: newBlockRef(resp.AtBlockId, resp.AtBlockNum),
+		Samples:  []*TokenHolderSample{},
+	}
+	for _, sample := range resp.Samples {
+		out.Samples = append(out.Samples, newTokenHolderSample(sample))
+	}
+
+	return out, nil
+}
+
+//----------------------------
+// Token Holder Distribution
+//----------------------------
+type TokenHolderDistribution struct {
+	d      *pbtokenmeta.HolderDistributionResponse
+	prices *tokenPriceLoader
+}
+
+func (d *TokenHolderDistribution) BlockRef() *BlockRef {
+	return newBlockRef(d.d.AtBlockId, d.d.AtBlockNum)
+}
+func (d *TokenHolderDistribution) Contract() string { return d.d.TokenContract }
+func (d *TokenHolderDistribution) Symbol() string   { return d.d.TokenSymbol }
+func (d *TokenHolderDistribution) Precision() commonTypes.Uint32 {
+	return commonTypes.Uint32(d.d.Precision)
+}
+func (d *TokenHolderDistribution) Holders() types.Uint64    { return types.Uint64(d.d.Holders) }
+func (d *TokenHolderDistribution) TopHoldersShare() float64 { return d.d.TopHoldersShare }
+
+func (d *TokenHolderDistribution) TotalAmount(args *AssetArgs) string {
+	return assetToString(d.d.TotalAmount, d.d.Precision, d.d.TokenSymbol, args)
+}
+
+func (d *TokenHolderDistribution) TopHoldersAmount(args *AssetArgs) string {
+	return assetToString(d.d.TopHoldersAmount, d.d.Precision, d.d.TokenSymbol, args)
+}
+
+func (d *TokenHolderDistribution) Buckets() (out []*TokenHolderBucket) {
+	out = make([]*TokenHolderBucket, len(d.d.Buckets))
+	for i, bucket := range d.d.Buckets {
+		out[i] = &TokenHolderBucket{b: bucket, precision: d.d.Precision, symbol: d.d.TokenSymbol}
+	}
+	return
+}
+
+func (d *TokenHolderDistribution) TopHolders() (out []*AccountBalance) {
+	out = make([]*AccountBalance, len(d.d.TopHolders))
+	for i, balance := range d.d.TopHolders {
+		out[i] = newAccountBalance(balance, d.prices)
+	}
+	return
+}
+
+type TokenHolderBucket struct {
+	b         *pbtokenmeta.HolderBucket
+	precision uint32
+	symbol    string
+}
+
+func (b *TokenHolderBucket) MinBalance() float64 { return b.b.MinBalance }
+func (b *TokenHolderBucket) MaxBalance() *float64 {
+	// The last bucket is unbounded
+	if b.b.MaxBalance == 0 {
+		return nil
+	}
+	return &b.b.MaxBalance
+}
+func (b *TokenHolderBucket) Holders() types.Uint64 { return types.Uint64(b.b.Holders) }
+func (b *TokenHolderBucket) Amount(args *AssetArgs) string {
+	return assetToString(b.b.Amount, b.precision, b.symbol, args)
+}
+
+//----------------------------
+// Token Holder History
+//----------------------------
+type TokenHolderHistory struct {
+	BlockRef *BlockRef
+	Samples  []*TokenHolderSample
+}
+
+type TokenHolderSample struct {
+	BlockNum  types.Uint64
+	BlockTime graphql.Time
+	Holders   types.Uint64
+}
+
+func newTokenHolderSample(sample *pbtokenmeta.HolderCountSample) *TokenHolderSample {
+	blockTime, _ := ptypes.Timestamp(sample.BlockTime)
+
+	return &TokenHolderSample{
+		BlockNum:  types.Uint64(sample.BlockNum),
+		BlockTime: graphql.Time{Time: blockTime},
+		Holders:   types.Uint64(sample.Holders),
+	}
+}
@@ -3,10 +3,12 @@
 // accounthist.graphql
 // block.graphql
 // blockmeta.graphql
+// nftmeta.graphql
 // query.graphql
 // query_alpha.graphql
 // schema.graphql
 // search_transaction.graphql
+// statedb.graphql
 // subscription.graphql
 // tokenmeta.graphql
 // transactions.graphql
@@ -97,7 +99,7 @@ func accounthistGraphql() (*asset, error) {
 	return a, nil
 }
 
-var _blockGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\x4d\x6f\xe3\x36\x10\xbd\xfb\x57\xcc\xfa\x92\x16\x30\xf6\xd0\xde\x74\x6b\x92\x05\xd6\xc8\xc7\x06\x75\xb6\x97\x60\x51\x8c\xc5\x91\x35\x88\x44\x2a\x24\x65\xc7\x58\xe4\xbf\x17\x24\x45\x91\x56\x94\x4d\xdb\xbd\x89\x22\xe7\xcd\x9b\xaf\x47\xda\x63\x47\x70\xde\xa8\xf2\x11\xbe\x2f\x00\x00\x96\xcb\xe5\xfa\x12\x54\x05\xb6\x66\x03\x5b\xb7\xf3\x11\xd6\x16\xd8\x00\x42\x2f\xf9\xa9\x27\xe8\x14\x4b\x4b\x1a\xac\xca\x4f\x2d\x97\x4b\x8f\xc0\xa2\x80\x8d\xd5\x2c\x77\x1f\x16\x11\x72\x43\x4f\x3d\x49\xcb\xd8\x80\xec\xdb\x2d\xe9\x53\x0f\xa0\x24\xd8\x9a\xa0\xac\x91\xe5\x47\xf8\x2a\x1b\x7e\x24\xff\xa7\x46\x53\xaf\x80\xbd\x7f\xa9\x6c\x64\xb0\xa5\x12\x7b\x43\x0e\xa5\x52\xfa\x91\x44\xc0\x31\x23\x09\xd9\xb7\x05\x7c\x65\x69\x7f\xff\x2d\xb1\xb8\xaf\x09\xae\xd1\x58\x58\x6b\x4d\x7b\xd2\x86\xb7\x4d\x8c\x7e\xa0\xe5\x39\x79\x1a\xce\xe3\x16\x0d\x09\x40\xeb\xa9\x5c\xde\xa9\x0d\x94\x4a\x1a\x92\xa6\x37\xd0\xe0\x91\xf4\xe8\x4f\x74\xca\x5c\xaf\xcf\x6f\xe7\xdc\xae\xa5\xe0\x12\x2d\x19\x38\xd4\x64\xeb\xe8\x25\x44\xce\x06\x38\x63\x93\xb2\x98\xfd\x2c\xe0\x5c\xa9\x86\x50\x26\xcc\xcf\x84\x82\x34\xfc\xc7\xd2\xd4\xde\xaa\x08\x21\x07\x88\x04\x79\x97\x9b\x12\xb0\x2c\x35\xb5\x24\x2d\x36\xd0\x92\x7e\x6c\x08\xb4\x52\x16\x04\x5a\x04\x63\x75\x5f\xda\x5e\x93\x4b\x7f\xe6\x29\x3a\x0a\x06\x7f\x2a\x65\x07\x67\xee\xf3\xc6\xff\x4c\x0e\x6f\x53\x27\x68\x94\x06\x4b\xcb\x4a\x42\xa5\x7a\x29\x80\x65\x86\x6a\x56\x61\xc1\xc6\x33\x4b\x1d\x74\x46\xcf\x54\xf6\x96\xc4\xd9\x09\x44\xa9\x7a\x69\xc7\xa0\xe3\x99\xfb\x74\xe2\xc2\x1d\x78\x5d\xa8\x6b\x36\x76\x42\xc7\xb8\x8a\x5b\x64\x49\x13\x4e\x23\x7c\x76\xf8\x5e\x63\x49\xe6\x97\x8a\xb5\x19\xd1\x57\xd0\x60\xbe\xda\x52\xa5\x34\xc5\x09\x59\x01\x56\xd6\x55\x24\x2c\x7f\x85\x02\xee\x27\x78\x17\x4a\x4a\xf2\xcb\x0f\x8b\x97\xc5\xc2\xb9\xcd\xca\x17\x73\x12\x2a\xeb\xc8\x63\xe0\xb7\x02\x7a\x2e\x9b\x5e\xb0\xdc\xf9\x03\x27\x31\xf9\x48\xd0\x0e\x91\x78\xcc\x24\x04\x03\xf0\xf7\x7c\x6a\xa6\x92\x10\x10\xe2\xc0\xfe\x68\xf2\x9d\xf5\x36\x9f\xb0\x59\xcb\xf9\x71\xe5\x96\x8c\xc5\xb6\x3b\xf5\x7d\xda\x0c\x58\xda\x1e\x1b\xb0\xdc\x92\x1b\xd4\x43\xcd\x65\xed\x37\x82\xd3\x03\x1a\xe8\xb4\x2a\x7b\x41\x22\x95\x2c\x02\x17\xe0\x7c\xcc\x91\xed\xb4\x12\x7d\x49\x1a\x0e\xb5\x4a\x8b\x99\xf2\xc7\xbd\xd7\x91\x7f\x56\x07\x68\xf1\xe8\x1a\xa8\x62\xdd\x62\x48\xbd\xaa\x60\x4f\x9a\x2b\xa7\x08\xac\xf2\x8e\x72\x5a\x07\x9a\x4a\xe2\x3d\x09\xa8\xb4\x6a\x41\x79\xb5\x38\x65\x94\x34\x6e\x00\x26\x31\xaf\x74\x9d\xa6\x3d\xab\x3e\xa2\xaf\x2f\x63\xea\xc3\x3a\x88\xdc\xa9\x12\x05\xbd\x53\x32\x8b\x2e\x60\xcc\xd7\x35\x9f\x39\x4f\xbc\xb3\x70\x93\x49\x85\x54\x22\x28\xf8\x8f\x0a\x78\x96\xa1\xfc\xdd\x3a\xbb\x33\x47\xf4\xd3\x97\xcd\xfa\xcb\xdc\x90\xdd\x04\x55\x99\xe3\xf3\xd3\x54\xde\x61\xf1\x2e\x81\xb1\x51\x4c\x59\x93\xe8\x1b\x02\x2f\xe1\xea\xcd\x3c\x83\xb3\x1a\x0f\x0f\x34\x9a\x41\x86\x9c\xbb\x7d\x06\x8a\x52\xb8\x7d\xd6\x80\xc6\xf0\x4e\xc6\x5b\x6f\x38\xe1\x63\x37\x8d\x4a\xca\x17\x81\xff\x0a\x24\x52\x9b\xf8\x91\xa3\xc3\x5d\x6c\xa9\x02\xe2\xe7\x66\x30\x39\x91\x9a\x24\xde\x13\xa9\x48\x1b\xff\x47\x2e\x5c\x4d\x26\x4a\x1c\xb3\xbc\xa7\x5b\x25\xc8\x14\xf0\x30\xa4\xf9\x9b\x17\x3f\xef\x79\x4a\x75\x70\xbd\x9f\x0b\xb2\x4b\x11\x3e\x44\xbb\x2b\x3a\xce\xc1\x5d\xd1\x71\x40\x8a\x46\xb7\xd8\x52\xaa\xb3\xdb\xf1\xc1\x6c\x78\x27\x59\xee\xae\xe8\x98\x36\x5f\x16\x03\xda\xdb\xea\x1d\x33\xf4\xc7\x58\x5f\x12\x3b\x32\xfe\xba\xcd\xe6\xc8\xfa\x3b\x64\xb8\xb9\xdc\x81\x02\x1e\xa6\xa0\x9f\xc4\x8e\xbe\xc5\xce\x5b\xcb\x4a\x0d\xea\xe2\xb0\x90\x05\x74\xb8\x63\xe9\xff\x0c\x53\x8c\x3b\x72\xc7\x0a\xb8\x1b\xbe\x52\xf8\x73\xd8\x59\x31\x35\x9e\x10\x83\xb2\xd7\x46\xe9\x51\x81\xfc\x6a\x66\x16\x26\x66\x79\xc5\x5f\xdf\x70\x21\x7d\xcb\xe5\xf2\xc2\xc3\x39\x11\x7c\xea\x59\x93\x70\xf1\xb8\xeb\x97\x65\x4f\x40\xec\xc5\xb0\x52\xfa\x80\x5a\x80\xd2\xb0\xc5\xf2\xd1\x7d\x9b\x20\x96\x38\x26\x76\x88\x9f\x04\x50\xe3\x9f\x30\x66\x6c\xdb\x98\x80\x14\x62\x88\x21\xf4\x2b\x81\xbf\xbb\xa3\x59\xfc\xe9\x70\x57\xe0\xde\x9b\x6c\x1d\x27\x43\xa8\xcb\x3a\x36\xb5\xea\x3a\x65\xd8\x12\x08\xd6\xa1\xd4\xe3\xfc\x59\xd4\xf6\x62\x92\xa3\x59\xb7\xee\x8d\xf0\x9e\xd7\x98\x89\xf1\x5d\x23\xc5\x1b\xd8\x3c\xf3\xe0\x24\x4d\xe1\xa1\x28\xe9\xd9\xfa\x8e\x18\x1f\x85\x68\x6e\xe9\xd9\xba\xcc\x64\x0f\xcd\x7f\x03\x35\xde\x30\x13\xb8\xbb\xe1\xff\x04\xf2\x65\xf1\x4f\x00\x00\x00\xff\xff\x38\x48\x5f\xa2\x6e\x0c\x00\x00")
+var _blockGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x57\x4b\x6f\x1b\x37\x10\xbe\xeb\x57\x4c\x7c\x71\x0b\x08\x39\xb4\x37\xdd\x6a\x27\x40\x84\xda\x8e\x51\x39\xbd\x04\x41\x41\x91\x23\x2d\xe1\x5d\x52\x21\xb9\x52\x0c\x23\xff\xbd\xc3\xd7\x92\xbb\x5e\xdb\x4d\x6a\xf8\xb0\x7c\x7d\xf3\xcd\x83\x1f\x47\xee\xe1\x80\x70\xd1\x6a\x7e\x0f\x8f\x0b\xa0\xbf\xb3\xb3\xb3\xf5\x3b\xd0\x3b\x70\x8d\xb4\xb0\xf5\x2b\x6f\x61\xed\x80\x06\x0c\x7a\x25\xbf\xf6\x08\x07\x2d\x95\x43\x03\x4e\xd7\xbb\xe8\x64\x40\x90\x62\x05\x1b\x67\xa4\xda\xbf\x59\x64\xc8\x0d\xd2\x39\xe5\x24\x6b\x41\xf5\xdd\x96\xce\x8e\x2c\x80\x56\x34\x42\xe0\x0d\x93\xea\x2d\x7c\x52\xad\xbc\xc7\x30\xd3\x30\xdb\x2c\x41\x06\xfb\x4a\xbb\xcc\x60\x8b\x9c\xf5\x16\x3d\xca\x4e\x9b\x7b\x14\x11\xc7\x0e\x24\xc8\xca\x0a\x3e\x11\xcd\xdf\x7f\x2b\x2c\xee\x08\xf0\x8a\x59\x07\x6b\x63\xf0\x88\xc6\xca\x6d\x9b\xbd\x4f\xb4\x02\xa7\x40\xc3\x5b\xdc\x32\x4b\xd0\xcc\x05\x2a\xef\x6e\xf5\x06\xb8\x56\x16\x95\xed\x2d\xb4\xec\x01\xcd\x60\x4f\x1c\xb4\xbd\x5a\x5f\xdc\xcc\x99\x5d\x2b\x21\x39\x73\x68\xe1\xd4\x20\x21\x99\xda\x73\xfa\x90\x15\x9b\x12\xc5\x6a\x72\x05\x17\x5a\xb7\xc8\x54\xc1\xfc\x80\x4c\x10\xd0\x0f\xa6\xa6\x09\xa7\x56\xd1\xe5\x08\x51\x20\x6f\xeb\xa3\x08\x52\x71\x83\x1d\x25\x8d\x72\xd6\xa1\xb9\xa7\x48\x19\x4d\x19\x10\xcc\x31\xb0\xce\xf4\xdc\xf5\x06\x7d\xf8\x2b\x4b\xd9\x50\x3c\xf0\x17\xed\x4f\xc6\xfc\xe7\x75\x98\x2c\x06\x6f\x4a\x25\x18\xa6\x2c\xe3\x4e\x52\x1d\xec\x74\xaf\x04\x59\xaf\x50\xed\x32\x0e\xe8\xdf\x33\x2b\x15\x74\x8e\xdf\x90\xf7\x0e\xc5\xf9\x08\x82\x13\x84\x1b\x9c\xce\x7b\xee\xca\x8e\x4b\xbf\xe1\x69\xa2\xae\x24\xd5\xc6\x98\x8e\xf5\x19\x77\x54\x0e\x38\xe1\x34\xc0\x57\x9b\xc9\x02\x47\xfb\xcb\x4e\x1a\x3b\xa0\x2f\xa9\x50\xea\xd1\x16\x29\x62\x98\x6f\xc8\x12\xd8\xce\xf9\x8c\xc4\xe1\xaf\xb0\x82\xbb\x09\xde\xa5\x56\x0a\xc3\xf0\xcd\xe2\xfb\x62\xe1\xcd\x56\xe9\xcb\x31\x89\x99\xf5\xe4\x59\xe4\xb7\x24\xbf\x79\xdb\x0b\x42\x0d\x1b\x46\x3e\x05\x4f\xa8\xac\xa3\x27\x01\xd3\x0d\x42\x90\x80\x1f\xeb\x5b\x33\x95\x84\x88\x90\x2f\xec\x4b\x37\xdf\x9f\xde\xd6\x37\x6c\xf6\xe4\xfc\x75\x95\x1d\x5a\xc7\xba\xc3\xd8\xf6\xb8\x18\xc8\xa5\x9e\x2a\xd4\xd1\x5e\x7f\x51\x4f\x8d\xe4\x4d\x58\x88\x46\x4f\xcc\xc2\xc1\x68\xde\x0b\x14\x25\x65\x19\x98\xa2\x4d\x9f\x73\x64\xe9\x8c\xe8\x39\xd1\x3d\x35\xba\x0c\x66\xd2\x9f\xd7\x9e\x7a\xfe\x41\x9f\xa0\x63\x0f\xbe\x80\xa8\x22\x3a\x16\x43\x4f\xae\xd0\xb5\x96\x3b\xaf\x08\x52\xd7\x15\xe5\xb5\x0e\x0c\x72\x94\x47\x2a\xb6\x9d\xd1\x1d\xe8\xa0\x16\x63\x46\x45\xe3\x12\x30\x8a\x79\xa5\x3b\x90\x80\x48\xdd\x67\x74\x4a\x61\x0a\x7d\x1c\x47\x91\x1b\x2b\x51\xd4\x3b\xad\x2a\xef\x22\xc6\x7c\x5e\xeb\x3b\x17\x88\x1f\x1c\x5c\x57\x52\xa1\xb4\x88\x0a\xfe\x52\x02\xcf\x2b\x94\x7f\x3a\x7f\xee\xdc\x13\x7d\xff\x71\xb3\xfe\x38\x77\xc9\xae\xa3\xaa\xcc\xf1\xf9\xdf\x54\x5e\x61\xf1\x2a\x81\xa1\x50\x2c\x6f\x50\xf4\x64\x3c\x48\xb8\x7e\x36\xce\xe0\x4f\x0d\x9b\x13\x8d\x36\xc9\x90\x37\x77\xac\x40\x19\xe9\x22\xad\x4b\xfa\xb2\x56\xee\x55\x7e\xf5\xd2\x8e\xe0\xbb\x6d\x75\x51\xbe\x0c\xfc\x77\x24\x51\xca\x24\x5c\x39\x3c\xdd\xe6\x92\x5a\x41\xfe\xdc\xa4\x23\x23\xa9\x29\xe2\x3d\x91\x8a\xb2\xf0\x33\x72\xe1\x73\x32\x51\xe2\x1c\xe5\x23\xde\xd0\x22\xf1\xfa\x9c\xc2\xfc\x25\x88\x5f\xb0\x3c\xa5\x9a\x4c\x1f\xe7\x9c\x3c\x14\x0f\x3f\xe7\x73\x7f\xe2\xc3\x1c\x1c\x4d\x27\xa4\x7c\xe8\x86\x75\x58\xf2\xec\x57\x82\x33\x1b\x0a\x3d\xcd\xd0\xfe\xb2\x98\xa2\x35\x50\x73\xcc\x59\x68\x74\x2b\x6c\x75\xe3\xea\x34\xd1\x06\xca\xb2\xe4\xb6\x92\xec\x92\x69\x7d\xf4\xe9\x06\x2a\xfa\x7d\xe8\x71\x52\x73\x53\xa2\x3f\x36\xf4\xf8\x82\x7e\x31\x3e\x7e\x0d\x9f\xd7\xab\xf2\x1c\x47\x73\x79\x2b\x55\xd9\x43\xcc\xe5\x00\x9a\xd2\x19\xf8\x4d\x91\x45\xa8\x0c\xfb\x54\x93\x26\xf8\xd3\xaa\xa5\x47\x3e\xd7\xab\x28\x5d\x45\x36\xb9\x0c\x53\x51\xdd\x95\x1e\x8b\x7b\xb0\x3a\xd0\xe8\xa4\xa5\xcb\xb5\xf1\x88\x85\x03\xe5\x27\x06\xee\xf9\xd7\x35\x47\xf1\x8f\xe1\xfe\xa1\xd8\x53\xcf\xe6\xdb\xa1\x4a\xe7\x5c\x78\xe3\x53\x67\xe1\x37\x50\x65\x4d\x41\xdf\xd3\xfc\x97\xec\xf8\x5a\x11\xf3\x2e\x89\xbd\x06\x26\x05\x1c\xd8\x5e\xaa\x30\x93\x22\xc7\xf6\xe8\xb7\xd1\x35\x4c\x5f\xa5\x3c\xe7\xb0\xab\x84\x1b\x36\x22\x06\xbc\x37\x56\x9b\xe1\x85\x08\xa3\x19\xad\x9a\x1c\xab\x6f\xe4\xd3\x0e\x24\x86\x8f\xf6\x5c\x06\x38\xff\x48\x7d\xed\xa5\xa1\x34\x91\x3f\xbe\x3d\x92\x8a\x3a\x4f\x94\xe1\xb1\x22\x67\x4f\xcc\x90\xb8\xd1\xbb\xc5\xf8\xbd\xff\xb6\xf1\x31\x63\x43\x60\x93\xff\x04\x80\x6d\x68\x31\x6d\x29\xec\x14\x80\xe2\x62\xf4\x21\xea\x09\xb5\x9b\xbe\xb7\xca\xc7\xf2\xa4\xc7\x5d\x82\xff\x3d\x40\x3f\x14\x88\x93\x45\x66\xa8\x4e\x52\x95\xea\x03\xf5\xe6\xd2\x21\x08\xe2\x1c\xbc\x1a\xf4\xd1\x31\xe3\x2e\x27\x31\x9a\x35\xeb\x7b\xb8\xd7\xac\xe6\x48\x0c\x7d\xa7\x12\xcf\x60\xcb\x99\x1f\x04\x68\x30\x36\xf2\x0a\xbf\xb9\x50\x11\x43\xd3\xce\xec\x0d\xcd\xf9\xc8\x54\x3f\x04\xfe\x0b\xd4\xd0\x01\x4c\xe0\x6e\xd3\xfc\x04\xf2\xfb\xe2\x5f\xa9\x54\xd8\x8a\x0e\x0e\x00\x00")
 
 func blockGraphqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -112,7 +114,7 @@ func blockGraphql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "block.graphql", size: 3182, mode: os.FileMode(436), modTime: time.Unix(1619013861, 0)}
+	info := bindataFileInfo{name: "block.graphql", size: 3598, mode: os.FileMode(436), modTime: time.Unix(1792393603, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -137,6 +139,26 @@ func blockmetaGraphql() (*asset, error) {
 	return a, nil
 }
 
+var _nftmetaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x55\xc1\x6e\xda\x40\x10\xbd\xfb\x2b\x06\xce\xf4\x56\xf5\xc0\x0d\x12\x47\x41\x6a\x48\x04\x4e\xab\xaa\xaa\x92\xc5\x1e\xc7\xab\xda\xbb\xd4\xbb\x2e\x45\x55\xfe\xbd\x33\xbb\x5e\x83\xc1\x55\xe9\xa5\xcd\x81\xd8\xcb\xec\x9b\x99\x37\x6f\x1e\xe3\xf1\xf8\x63\x21\xd3\x02\x36\xa5\x4e\xbf\x1a\xb0\x05\x82\xd2\xea\x4d\xde\xa8\x17\xb9\x29\x11\x84\x31\x68\x0d\x7c\x6b\xb0\x96\x68\x40\xa4\xa9\x6e\x94\x85\x5c\xd7\xe3\xf1\x38\x42\xd5\x54\xb0\xbc\x49\x9e\xee\xee\xaf\x63\xf8\x19\x01\xfd\xd1\xf9\xbd\x2a\xf7\x90\x16\x42\xbd\xd0\x95\xbc\xd6\x15\xc8\xba\xc6\xef\x58\x1b\x87\xe9\x73\xf1\x7d\x8e\x5f\xac\x56\xf1\x87\x78\xb5\x5e\xcc\xdf\xc7\x51\x40\xb8\x3a\xbe\x7c\x76\x15\xac\xd6\x13\x68\xb6\xf4\xdf\x55\x5c\xa0\xc8\xfc\x57\x01\xf4\x36\x9e\x5d\x47\xaf\x51\x44\xef\xcb\x81\x76\x0a\x2c\xe9\xc2\x1e\x84\x0a\x1d\xf1\x45\xbb\xdf\x22\x77\x33\xf3\x41\x5d\x3b\x73\x46\x6e\xd3\xed\x1c\x5b\x2a\xb7\x15\x5a\x11\x2e\x63\xc6\x84\x84\x8e\x27\x90\xe1\x16\x55\x26\xd5\x0b\x68\xe5\x0a\xac\x74\x86\xa1\x34\x57\xe7\x0a\xf3\x29\xcc\xdb\xa7\x51\xd7\xb6\xcf\x3c\x01\xa3\x6b\x06\xa5\x0a\x53\xad\x6c\x2d\x52\xcb\x30\xca\x97\x0f\x32\x0b\x58\xbe\x9d\x29\x7c\x0e\x55\x8f\xbe\x8c\xb8\xed\x5e\x27\x6d\x23\x01\x69\x0a\x6b\x5b\x53\x6d\xa3\x03\xc2\x22\x9b\xc2\xa3\x54\xf6\xdd\x5b\x7f\xa8\x77\x0a\xeb\x7e\x5c\xaa\xcb\x12\x53\x2b\xb5\xea\x9f\x9b\xb4\xc0\x4a\x1c\xce\x42\x27\xcf\xaa\x29\xcb\x67\xa2\x0b\x3d\x03\x6d\xe5\x86\xd4\x65\x61\x43\xa4\x65\xcc\xa6\x00\x8b\xd5\xb6\x14\xb6\x63\x27\xbc\x73\x49\x0b\xae\xe8\x0f\x88\x3b\x61\xa0\x92\x6e\x04\x1b\xa4\x21\x60\x37\x1b\x63\x85\x23\x51\xaa\x0c\x7f\x50\x6d\x21\x83\x8f\x9e\x59\xc7\xfe\xb2\xa9\x42\xeb\x51\x7f\xdc\x3a\x77\x59\x4a\x61\x6c\x3b\x58\x30\x48\xa9\x69\x26\x6d\x86\x09\x1c\x97\x44\x5b\x83\xae\x1a\x8e\x0a\xb9\x9a\x6d\x26\x86\x93\x79\x6d\xde\x4a\x63\x75\xbd\xe7\x64\x62\x60\xef\xce\x44\x19\xe2\xff\x83\x34\xaf\x1a\x5a\x61\xda\x7c\xa2\xd5\x62\x60\xc7\x55\xd9\xe7\x41\xfa\x99\x6c\x9a\x5a\x61\x5f\xa7\xd3\xae\x8d\x0e\xf4\x4e\x13\xbb\x35\xa6\x0c\x4c\x4b\xae\x68\xeb\xfa\xc8\xba\xcc\x90\x42\x72\x59\x1b\x1b\xc0\x7c\xe0\x91\xe8\x63\x3e\x68\x95\xdf\x39\x12\x79\xca\x32\x79\x4a\x3e\x3d\x04\x5f\xba\x5b\x2c\x13\xf7\x90\xac\x66\xcb\xf5\x4d\xbc\x72\x2f\xf3\xc7\xd5\xd2\x3d\x3c\x3e\x5c\xcf\x92\xf8\x6c\x79\x1c\x76\x8b\xc0\x5f\x4c\x4f\xc0\x0f\x04\xdd\xf3\xd2\x04\x11\x72\x0b\xae\xce\x8e\x1c\x9e\x02\x4b\xaf\xf3\x3d\xb6\xb6\xb0\x37\x27\x20\x22\xb7\xf4\x39\x8c\xc1\xc4\x76\x18\x56\xf7\x11\x36\x27\x32\x1b\x1d\x4e\x79\xa1\x8e\x17\xd7\x1d\x26\xb2\xa2\x8e\xf8\xd3\x1f\x92\x41\x28\x23\xdc\x9a\x9f\xc6\x1f\x3b\x38\xe9\x43\xeb\x12\x85\x1a\xb5\x3a\x5e\x93\x28\x48\x9a\x32\x35\x5e\xca\x07\xb7\xe0\xf7\x81\x1f\x94\x63\x65\x5f\x75\xc1\x0c\xf3\xef\x7d\x77\xd8\x18\x87\x0c\xaf\x6f\xd3\xe4\x2c\x90\xca\x3a\x6d\xc8\xaf\x28\xec\xd4\x93\xc3\x04\xba\x4b\xbe\x66\xfa\xf1\x21\x51\x73\x8d\xc2\x02\x71\x48\xea\x66\xe7\xf0\x6e\xd6\x8a\xff\x90\x3b\x80\xf2\x1d\x22\xff\x1c\x75\xed\xfc\xd7\xc0\x4e\xda\x62\x08\x71\xb8\x46\xef\xda\x03\x70\x49\x6b\xbf\x7f\x0b\x18\x6c\x7b\xa8\x6f\x4f\x56\x6b\xd2\x46\xaa\xf4\x52\x8f\xfe\x2d\x96\x37\x97\x0b\xb1\x7c\xf0\x40\xab\xac\xf5\x9c\x48\xbd\x10\xc7\x86\xf8\x03\xd4\x6b\xf4\x0b\x88\xfc\x2f\x46\x40\x09\x00\x00")
+
+func nftmetaGraphqlBytes() ([]byte, error) {
+	return bindataRead(
+		_nftmetaGraphql,
+		"nftmeta.graphql",
+	)
+}
+
+func nftmetaGraphql() (*asset, error) {
+	bytes, err := nftmetaGraphqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "nftmeta.graphql", size: 2368, mode: os.FileMode(436), modTime: time.Unix(1792396471, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _queryGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x57\x41\x6f\xdb\xc6\x12\xbe\xeb\x57\x4c\xfc\x2e\x76\x20\x0b\xb2\x5f\x92\x83\x80\x1c\x24\x45\x2f\x16\x9e\x2d\xb5\xb2\xda\x00\xb9\x58\x23\x72\x48\x2e\xb2\xdc\x65\x76\x97\x52\x98\xa2\xff\xbd\x98\xdd\xa5\x44\xbb\x36\x1a\xb4\x29\x92\x43\x72\x89\x24\xce\xee\x7c\xf3\xcd\x37\xdf\x98\xae\xa9\x08\x7e\xae\xc9\x34\xf0\x5b\x0f\xe0\xe4\xe4\xa4\x07\xf0\x6e\xbc\x5a\xcc\x17\x6f\x47\xb0\x2e\x84\x05\x61\x01\x61\x32\x5b\x8f\x43\xdc\x00\xe6\x6b\xb8\x99\xbf\xbd\x5a\xc3\xed\x7a\x7e\x7d\x0d\xd3\xab\xf1\xe2\xed\x6c\xd0\xeb\x01\xac\xc8\x19\x41\x3b\x02\x57\x10\x48\xb4\x0e\x30\x71\x42\x2b\xdb\x07\x57\xa0\x03\x34\x04\xc2\x18\xda\x91\xb1\x62\x2b\xa9\x0f\xa8\x52\xff\x68\xd4\x03\xb8\x38\x03\xa5\x9d\xc8\x04\xa5\x80\x0a\x30\x49\x74\xad\x5c\x1f\xb4\xe9\x01\x5c\x9e\xc1\x1e\x2d\x60\xed\x0a\x6d\xc4\x67\x4a\x61\xdb\x74\xa2\x62\x7a\x5b\x4b\x67\x7d\x9a\xbb\x98\xf9\xae\x0f\x86\x5c\x6d\x14\xa5\x20\x14\x84\xdc\x04\xda\xa4\x64\xe0\x34\x33\xba\x04\x43\x09\x29\x07\x4e\x83\x96\xfc\x6b\x3c\x79\xe6\xef\x5c\x2c\xd7\xb3\x11\xd4\xb6\x46\x29\x9b\xbe\x2f\x6c\x8b\xc9\x07\xa1\x72\xb0\x64\x76\x22\x21\xd0\x19\x38\x26\xaa\x24\x57\xe8\x14\x0a\x2d\x53\xa6\xec\xce\x99\x5a\x25\xe8\x28\xbd\x83\xbd\x50\xa9\xde\x73\x64\x21\xac\xd3\xa6\xe9\x43\xe5\x33\x75\xc1\x63\xea\xaf\xdf\x24\xb5\xb1\xda\x6c\x18\x2e\x7f\x37\x64\x2b\xad\x6c\x24\xab\x42\x6b\x41\x38\x0f\x82\x21\x1f\xa2\x9d\x86\x44\x2b\x27\x54\x4d\x50\x61\x2e\x14\x3a\xa1\xf2\x41\xa7\x87\xb6\xd0\xc6\x9d\x4b\xb1\xa3\x14\xc2\xa9\x3e\x90\xad\x28\x11\x5c\x1b\xa0\xf5\xe9\x22\x6a\xa1\x55\x8b\x3a\xd7\x64\x61\xdb\x0c\x0e\xfa\xc8\xc9\x8d\x03\xf2\xab\x50\xcd\x38\x30\x76\xda\x03\x00\x38\x89\xcf\x40\x61\x49\x0c\xeb\xa3\x97\x57\xa6\x0f\xcc\x9e\xf8\xb8\x58\xfc\x08\x6e\x9d\x11\x2a\x7f\xd6\x0b\xa7\xa7\x5a\x39\x83\xc9\x5f\x1e\x4f\x62\x5c\x7b\x3e\x1e\xbf\xc1\x4f\xa2\xac\x4b\x50\x75\xb9\x25\xc3\x8c\xc7\x53\xf7\x64\xe0\xfb\x55\x61\x4e\x03\x80\x1b\xfc\x04\x52\x94\xc2\x01\x4a\xa9\xf7\x94\xfa\x5c\x3e\x22\x41\x29\x99\xbb\x8b\xe1\x70\x18\xb2\xfa\xc0\x11\xcc\x95\x7b\xf5\x02\x5e\xf3\x83\x98\x77\x59\x71\x16\x94\x91\xd9\x7b\xed\xd8\x17\x64\x08\x1a\x5d\x83\xa4\xcc\x81\xce\xb2\x3e\x38\xfc\x40\x0a\xa2\xfe\x82\x6c\x19\x2b\x54\x86\x76\x42\xd7\x31\xb7\xd3\x01\xc8\xc6\x53\xee\xeb\xd8\x04\x42\x06\x91\x05\x9f\xed\xc0\x01\xc0\xd9\x08\x1e\xed\xcd\x54\x2b\x45\xfe\xe3\xb3\x5e\x84\x7c\x12\xae\xb8\x25\x34\x49\x11\x94\x2d\x75\xf2\x21\x29\x50\x28\xe6\x60\x8f\x26\x72\x61\x50\xd9\x40\x23\x3c\xa7\x4f\x94\xd4\xfe\x23\xd3\x4f\xf6\x39\x6c\xd1\x52\x0a\x5a\xc1\xc6\x23\xdb\x0c\xc2\xfd\xef\x0a\x6a\x05\x1c\x89\x3f\x2a\xdb\x02\x95\x95\x6b\xfa\xac\xe4\x92\x50\x59\xcf\x4e\x81\x3b\x8e\xc6\xa4\xa0\x30\x0a\xa4\xd2\x30\x5d\x04\x5e\xa7\xde\x1a\x3c\x48\x30\xa8\x72\x6a\x33\x8d\x57\x8b\x11\xa0\xdc\x63\x63\x99\x75\x2b\x78\x8c\xfd\x2c\xd5\x2a\xd5\x1b\xc8\x04\x49\xdf\xf7\xb6\x2a\xeb\x6b\x26\xdb\x87\x7d\x21\x92\x02\xac\xc8\xb9\x77\xde\xa4\xf8\x5c\x89\x2e\x29\x78\xc6\x49\x52\xc9\xe6\xc0\xde\xc3\xe7\x59\x98\xab\xd9\xcd\xf2\xd7\xd9\x9b\xd0\x3c\x8e\x0e\x8c\x6d\x29\xc1\xda\x7a\x3b\xf0\x10\x59\x71\xda\xe4\xa8\xc4\x67\x3f\x4e\x11\xec\x2d\x11\xa0\xb4\x3a\x54\xe5\x0c\x61\xc9\x89\xbc\x25\x6a\x05\xb5\x62\xec\x9b\xdb\x7a\x6b\x13\x23\xbc\xa8\x36\xf7\xda\x15\xa0\xaf\x8f\x2d\xb1\xff\x0b\x45\x85\xe9\xf3\xa1\x69\xc6\x40\x62\x63\x83\xbb\x5f\xa3\xca\x6b\xcc\x7d\x4a\xa1\xf2\x93\x43\xb0\xef\xd9\x83\x21\xf4\x97\x5c\xeb\x3d\x99\xc8\xb6\xaa\x4b\xd8\xea\x5a\xa5\xc8\xd6\x25\x54\x22\x6b\x2b\x76\x24\x9b\x01\x8c\x41\x51\x8e\x4e\xec\x08\x76\x28\x6b\x8a\xfd\xc4\xb6\x4f\x24\xc3\x43\x17\x2a\x2e\xd8\xe6\xb4\x09\xdb\xa1\xbb\x0b\x62\xfc\x69\x4a\x15\xa9\x94\x29\x61\x45\x75\x23\x96\x4a\x36\x9b\xb3\xc1\x11\xba\xd4\xfb\x09\x1f\x5a\xd4\x65\x1c\xc9\x0e\xfc\x2b\x91\x17\x5f\x86\xff\x33\x19\xcd\x90\xbe\x59\x1d\x85\xc8\x8b\xa7\x0b\x59\x56\xf8\xb1\x26\x48\xd1\x21\x54\x82\x12\x0a\x32\xe5\x81\x49\x50\x85\x85\xd0\x6e\x83\x83\xe5\x34\xba\x36\x51\x2a\x20\x32\x1e\x33\x4e\x0f\xa9\xb0\x49\x30\x02\x4a\x07\xc7\x75\x2d\xdc\x51\xcc\x87\x21\x3d\x0c\x4d\x77\x09\xd9\xc3\xb6\x63\x7f\x1a\xc0\xdc\xf1\x30\x5b\xcc\x3c\x31\xac\x3a\x2f\x6b\xb6\xee\x68\x84\x42\xc1\x64\xb9\xbe\x82\x54\x18\x8a\x4e\x7c\xda\x8e\x21\x2f\x34\x86\xce\x5f\xba\x84\x3c\x70\xb5\x8e\x26\xbd\x4f\x73\x8a\xa3\xbf\xb7\xf6\xc9\x0b\x95\x2d\xbd\xfb\xdb\x69\x4a\x19\xfa\x4f\x4e\xb3\x59\xdf\x53\xcf\x13\x56\xee\x13\x05\xf3\x32\x35\xf5\x41\x2b\xd9\xc4\x41\x0d\x3c\x1f\xfc\x5a\xf9\x5e\x50\x13\x7a\xc0\xa8\x8e\x6d\x16\x52\xb8\xe6\xa0\xb9\x01\x2c\x5d\x41\x66\x2f\xfc\x1a\xe7\x35\x03\x19\x45\x8b\x69\xaf\xab\xab\x7b\xda\xf2\x32\xea\xc0\x7d\xa8\xa0\x11\x4c\xb4\x96\x84\x0a\x5e\x43\x86\xd2\x92\x8f\x3c\x1b\xc5\xb1\x7f\xc4\x20\x56\xb1\x87\xcf\xbe\xc4\xff\xdb\xb6\x7c\xaf\x0b\xc0\x67\xe8\x2e\x81\xaf\xef\xab\x93\x48\xc1\x37\x33\xd6\xe0\x43\x3a\x83\x61\xe4\xc8\xf7\x88\x72\xa1\x94\xf7\x95\xec\xb8\x7d\x7e\xf8\xf0\x0f\x1f\xfe\xe1\xc3\xdf\xb9\x0f\xb7\x86\xf2\xc0\x88\xff\x03\xe7\x7f\xeb\x5f\x3c\x3c\xb9\x5e\x4e\xff\x0f\x37\xb3\xf5\xf8\x9f\xdf\xd6\x9a\xe1\xca\x3b\xf6\x71\x27\xc0\xfc\x0d\x64\xcc\x21\xa0\xf1\xff\xf1\x93\x5c\xec\x48\xc1\xc6\x89\x92\x36\xfd\xe3\x12\x08\xe2\xd5\x65\x85\x06\x1d\x0b\xb8\x32\x7a\x27\x52\x4a\x07\xf7\x52\xf8\x7b\xe7\x6f\x26\xcd\x5a\x94\xd4\xb1\x58\xfe\x6a\x1d\x96\x95\xdf\x3c\xe1\x1e\x61\xb5\xea\xc7\xbf\xdf\x4b\x74\x70\x39\x1c\xbe\x3a\x1f\x5e\x9c\x0f\x2f\xd7\x17\x2f\x47\xc3\x17\xa3\xe1\xcb\xf7\x6c\x02\x8f\xfc\x3e\xb8\xb8\xfc\xef\xfb\x63\xf7\x18\xec\x08\x38\x47\xd7\x91\x3b\x8a\x3f\xe0\x1e\xc1\x74\x79\xf3\xd3\x78\x35\x5e\x2f\x57\xf0\x1a\xae\xd7\xb3\xb6\xb1\x93\x80\xfc\xeb\x76\x71\x3c\x9d\x2e\x7f\x59\xac\xff\xed\x3e\x2e\xc2\xb8\x86\x37\xd2\xd8\xc0\xf8\x22\xbe\xf1\x2f\x39\x89\x21\x74\x4f\xf4\x6a\xdc\xbe\xf6\x4f\x39\x48\x68\x75\xfa\x18\x85\x7f\x7a\xaf\x7f\x8a\xb6\xdf\x7b\x7f\x04\x00\x00\xff\xff\xb2\x12\xab\xb2\x7e\x12\x00\x00")
 
 func queryGraphqlBytes() ([]byte, error) {
@@ -157,7 +179,7 @@ func queryGraphql() (*asset, error) {
 	return a, nil
 }
 
-var _query_alphaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x57\xc1\x8e\xdb\x36\x10\xbd\xfb\x2b\x66\x7d\x68\xbd\x40\xe0\x43\x7b\x33\x90\x83\xd6\x52\xba\x46\x14\x2b\x5d\x69\x0f\x45\x11\x18\x94\x34\xb6\x88\xa5\x48\x83\x1c\x25\x15\x82\xfc\x7b\x41\x4a\xb4\x2d\x47\xad\x37\x49\x61\xa0\x41\x74\x59\x81\x3b\x7c\xef\x71\x34\x8f\x33\xa6\x76\x8f\xf0\x7b\x83\xba\x85\x8f\x13\x00\x80\xe9\x74\x1a\xc4\x6f\xef\x03\xf8\x0d\x09\x18\x18\x2e\x77\x02\x21\x17\xaa\x78\x82\xbc\x05\x4e\x06\x56\x21\x28\xed\xde\x64\x53\xe7\xa8\xe7\xf0\x87\x6a\xa0\x60\x52\x2a\x02\xb3\xc7\x82\x6f\x5b\xc8\x15\x55\xf3\xe9\x74\xea\x40\xdd\xf6\x99\x7b\xb5\x0f\x2f\x17\x90\x92\xe6\x72\xf7\xe2\xb0\x26\x9b\x7a\x01\x8f\x5c\xd2\xaf\xbf\xb8\xb5\xdb\x05\xdc\xd9\x5d\x13\xaf\xca\xfd\x3d\x95\x26\xb8\x21\x50\x5b\x28\x94\x24\xcd\x0a\x02\x52\x4f\x28\x0d\xcc\x18\x41\xcc\x0c\xc1\x4a\x6b\x7c\x8f\xda\xf0\x5c\x60\x07\x06\x15\xf2\x5d\x45\x30\x8b\x57\x77\xb7\xa0\xa4\x68\x6f\x07\xf0\x1d\xc2\x51\xa8\x5f\xb7\x4f\xdc\xd3\xb9\x18\x30\x6d\x9d\x2b\x61\x60\x16\x25\xe9\x2d\x90\x82\x2d\x17\x84\x1a\xa8\x42\xd0\x68\x1a\x41\x06\xd8\x8e\x71\x69\x68\x14\xcd\xa1\xa4\x1d\xc8\x02\xfe\xec\xb2\x71\xf3\x6e\xf2\x0c\x6a\x7f\x5e\x03\x33\x54\x86\xab\xb9\x5b\xfe\x6a\x11\x4b\x0f\x77\x51\xc6\xb2\xd1\x46\x69\x68\x0c\x96\xb0\x55\x1a\xf6\x6c\xc7\x25\x23\xae\xe4\x68\x78\xe1\xc2\xfd\x97\x1e\x87\x7c\xc3\xfe\xe2\x75\x53\xf7\x85\x64\xcf\xe8\x75\x93\x02\x2e\x0b\xd1\x94\x08\x5c\x02\xeb\xd7\x47\x41\x04\xaf\x39\x1d\x8a\x67\x34\x24\x73\x99\x63\x44\x9a\xe7\x0d\x61\x77\x06\x52\x60\x94\xa6\xd3\x74\x8d\x6e\xb6\x41\xaf\x38\x8a\x72\x01\x59\xf2\x3a\x5a\xa7\x9b\x34\x79\xc8\x36\xaf\x56\x51\x1c\xc2\x4b\xb8\x4f\xe2\x30\x7a\x48\xc7\x89\x43\xae\xb1\xb0\x29\xb2\xa7\xf8\x50\xf1\xa2\xfa\x22\xda\x44\x97\x68\x53\x68\xf9\x92\x87\x30\x7a\x80\x97\x10\x46\xe9\xd2\x5b\x24\xeb\xbf\xa0\xec\x48\x6e\x2e\xbb\xa5\xab\xa1\x9c\x09\x26\x0b\x34\xee\x3b\xb2\xde\xb4\xbc\x00\x56\x14\xaa\x91\xf4\x2d\x1e\xea\x21\xee\x7a\x86\x71\x33\x65\x15\x1e\xb8\x3e\x54\xca\xe0\x51\x51\xab\x1a\x60\xda\xa6\x86\x34\xc7\xf7\x68\x6b\x67\x0c\xa2\xdf\xee\xeb\xeb\xe6\x6a\x35\xfb\xe3\x22\xf8\xbf\xb9\x36\x58\x2e\x93\xc7\x75\xb6\xb9\x0b\xe2\x60\xbd\x8c\xce\xfc\x1b\xbc\xb1\xff\xbc\xae\x7d\x0f\x51\x6a\x6f\xd1\x6d\xca\xcf\x44\x6e\x92\xb7\xd9\x2a\x59\xdf\xbc\xf3\x56\x0f\x06\xbe\xfa\x0f\x3d\x7f\xe8\x9f\x3f\xf5\xc5\xfc\xcd\x1d\xf4\xb2\xf7\x5d\xd8\xcf\xe6\xc8\x7d\xe6\xfa\x7f\x32\xbd\x8f\xbf\xe0\xfa\x53\x8a\xfe\x4c\xcf\x24\xe8\xa2\x2f\xc0\x0f\x6d\x58\x29\x51\xa2\x36\x5f\x6b\xbb\xfb\x6e\xfb\x8f\xee\xfb\xcc\xee\xfb\xbd\xba\xf8\xd3\x64\x32\x41\xd9\xd4\xa7\x3c\xdd\x48\x1e\xf4\xfd\xde\x71\x7e\xea\xa3\x3e\x9f\x44\x3e\x0e\x8c\xe8\x4e\x97\xb7\x83\x2e\x05\x33\x3e\xc7\x39\xb8\x4e\xc5\xc4\xbe\x62\xb2\xa9\x51\xf3\x82\x09\xd1\x7e\x7e\x89\x8c\xc2\x1d\x4b\xa5\x9f\xb6\xfb\xea\x1f\x04\xfb\x89\xc8\x6b\xfd\xb7\xfb\xf7\x2a\xaa\x59\xed\x26\x8d\x13\xd5\x28\xca\xe1\xde\xae\x7c\x06\xd9\xfd\x02\xbd\x7e\x96\x91\xac\xc6\xab\x89\x1c\xaf\xb5\x33\x85\xde\xc6\xa8\x0c\x18\x62\x4f\x58\x7a\x22\x7e\x68\x09\x2f\x80\x2a\x6e\xec\x2f\x38\x60\xdb\x2d\x16\x04\xca\x96\xbc\xbf\x23\x3d\x54\x94\xa4\x9b\xd5\x7a\x19\x3f\x86\xd1\x26\xcd\x82\xd7\x51\x68\xa5\xfc\x1d\x00\x00\xff\xff\xb3\x7a\xff\xc8\x42\x0e\x00\x00")
+var _query_alphaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x5a\xdd\x6f\xe3\xb8\x11\x7f\xcf\x5f\xc1\xec\x43\x37\x01\xbc\xc1\x5e\x5b\xf4\x21\xc0\x3d\xd8\x8e\xd3\x18\xeb\xb5\xd3\xd8\xdb\x43\xb1\x38\xd8\xb4\x44\xdb\x44\x24\x52\x10\xa9\x64\x8d\xc3\xfd\xef\x9d\xe1\x87\x3e\x6c\xd9\x52\x36\xd9\x1c\x7a\x4d\x5e\x62\x49\xd4\xcc\x70\xe6\xf7\x1b\xce\x50\xd4\xdb\x84\x91\x7f\x65\x2c\xdd\x92\xdf\x4e\x08\xfc\xbd\x7b\xf7\xae\x3b\xba\xbd\xe9\x92\x7f\x32\x4d\x28\x51\x5c\xac\x23\x46\x96\x91\x0c\xee\xc9\x72\x4b\xb8\x56\x64\x78\x45\x64\x6a\x7e\x89\x2c\x5e\xb2\xf4\x82\xfc\x47\x66\x24\xa0\x42\x48\x4d\x54\xc2\x02\xbe\xda\x92\xa5\xd4\x9b\x0b\x10\x66\x84\x9a\xd7\xcf\xcc\x4f\xfc\xe3\xe1\x25\x99\xea\x14\x44\x77\xf2\x7b\x20\xea\x92\x7c\xe1\x42\xff\xed\xaf\xe6\xde\xf9\x25\xe9\xe1\x5b\x27\xde\x2a\xf3\xbf\x6c\x5a\xc4\x95\x26\x72\x45\x02\x29\x74\x4a\x03\x4d\xb4\xbc\x67\x42\x91\x33\xaa\xc9\x88\xc2\xb3\x61\x9a\xb2\x07\x96\x2a\xbe\x84\x19\x18\x61\x64\xc3\xf8\x7a\xa3\xc9\xd9\x68\xd8\x3b\x27\x52\x44\xdb\xf3\x8a\x78\x2b\xa1\x30\xd4\xdf\xc7\xbf\x91\x53\x67\xc6\x10\xb5\x8d\x97\x32\x02\x65\x83\xc9\xf4\x1c\xee\x91\x15\x8f\x34\x4b\x89\xde\x30\x92\x32\x95\x45\xe0\x1d\xba\xa6\x5c\x28\x5d\x2b\xcd\x48\x99\x5a\x21\x97\xe4\xab\xf5\xc6\xe9\xaf\x27\x2d\x54\xfb\xf9\x82\x72\x26\x15\x97\x17\xe6\xf6\x77\x1b\xd1\xf7\xe2\x1a\xcd\xe8\x67\xa9\x82\xc0\x67\x8a\x85\x64\x05\x3f\x12\xba\xe6\x82\x6a\x2e\x45\xed\xf0\xc0\x0c\xf7\x91\xae\x17\xf9\x99\x7e\xe3\x71\x16\x3b\x20\xe1\x1c\xbd\xdd\x30\x1b\x2e\x82\x28\x0b\x19\xfc\x87\x68\xdb\xfb\xb5\x42\x22\x1e\x73\x9d\x83\xa7\x76\xc8\xcc\x78\x8e\x6a\x30\x65\x99\x69\x66\xe7\x00\x2a\xc0\x40\x5d\x76\x57\xed\xcb\x38\xe8\x9a\xb3\x08\x50\x3b\x9b\x7c\x1a\x8c\xa7\xf3\xe9\xe4\x6e\x36\xbf\x1e\x0e\x46\x57\xe4\x67\x72\x33\x19\x5d\x0d\xee\xa6\xf5\x8a\xaf\x78\xca\x02\x74\x11\xce\xe2\x71\xc3\x83\xcd\x93\xd4\x4e\xd2\x90\xa1\x0b\x51\xdf\xe4\x0e\xd4\x80\xbe\xab\xc1\xb4\xef\x29\x32\x73\x11\x14\x56\xc9\x69\x33\x5b\x2c\x86\x96\x34\xa2\x22\x60\xca\xc4\x91\x3a\xd2\xf2\x80\xd0\x20\x90\x99\xd0\xcf\xe1\x90\x13\xd1\x73\x1a\xea\xc9\x34\x83\xb9\x7b\x5d\x8f\x1b\xa9\x58\x61\xd1\x16\x72\x09\x4d\xd1\x35\x10\x2c\xd0\x8d\xd8\xa9\x13\xe1\x5e\xf7\xf8\x3a\x7d\x35\xcc\xbe\x25\x82\xff\x35\xd6\x76\xfb\xfd\xc9\x97\xf1\x6c\xde\xeb\x8e\xba\xe3\xfe\x60\x87\xbf\xdd\xcf\xf8\xf0\x75\xe9\x9b\x8f\x92\x09\x4a\x47\x97\xef\x18\x39\x9f\xdc\xce\x86\x93\x31\x84\x00\x87\x01\xd5\xbb\x15\x5e\xbd\x20\xe7\xf3\xf5\xf3\x2f\x0e\xcc\xcf\x5e\x41\x9b\xb9\x6f\x86\xbd\x57\x85\xee\x1d\xd6\x1f\x22\xbd\x1f\xdf\xc0\xfa\xb2\x0a\x37\xa7\x96\x0a\xec\xe8\x06\xf1\x55\x1a\x6e\x64\x04\x51\x56\xdf\x4b\xbb\x1b\xfb\xfa\xdb\xea\xdb\x72\xf5\xfd\x3f\x63\xf1\x46\x3e\x1a\x23\x3d\xca\x20\x48\xd4\x01\x0f\xe1\x1c\x02\x14\xad\x57\x43\x2c\xcf\x1d\xc7\x3b\x84\x8a\xd0\xbc\x0a\x84\x09\x18\x72\x06\x07\xa0\x1c\x95\x25\x49\x04\x75\x3c\x20\x33\x96\x62\x6d\xca\xf8\x88\xa6\x6b\xa6\x74\xae\xe3\xb9\xfc\xb7\x90\xbe\xf2\xa6\xc1\xf4\xfe\xac\x99\xa0\xab\xc0\xbb\x21\x3c\x27\x91\x7c\x04\x0a\x2d\x21\xb8\xa1\x09\x12\xfa\xda\x45\x83\x2c\xb3\xe0\x9e\x69\xd5\x41\xf8\xd9\xd0\x65\x82\xe3\x75\xc8\x56\xd4\xf3\x2d\x41\x01\xf6\x55\x2c\x2b\x34\x4d\x35\xca\x85\x50\x7c\xac\x55\x6d\x85\xf6\x8c\x42\x00\xde\x75\x24\xa9\x3e\x94\x3b\xc6\x39\xc1\x77\x43\xed\xa9\x18\xc8\x38\x41\x6a\xa2\xd9\x05\x66\x90\x32\x67\xce\x48\xf2\xd3\xc7\xf3\x03\x59\x2c\x19\xef\xf3\xbf\x3d\x25\x66\xf5\x90\x39\xc8\x88\x32\x1b\x6c\x05\x59\xe2\x84\x7c\xc0\xfc\xcb\x63\xa4\x00\x44\x9c\xc6\x49\x64\x99\x61\x1e\xc7\x4c\x53\x82\x88\xde\x92\x15\x7b\xb4\x6d\xa9\x3a\x84\xdf\x1b\x30\x46\xa6\xdb\x3f\x2b\x74\x27\xc0\x5d\xe7\x20\xc0\x1f\xb5\x19\x1a\x1b\x7b\xba\xb2\x6b\x18\xa4\x08\x74\xa4\x57\x98\xa5\x82\x85\xf5\xea\x60\xa9\x60\x10\x47\x18\x5c\x13\x53\xe7\xc6\xa3\xe1\x14\x52\x7c\x58\x65\x62\x6d\xf2\x0c\x55\x8a\x61\x4d\xdb\xd5\x32\xe6\x41\xd7\x5c\x75\xc8\x94\xa3\xa5\xf6\xea\x1c\xb2\x50\x64\xa2\x4a\x85\xef\x03\x4c\xbc\xb9\x08\xd9\x37\x1b\x6f\xb1\xd2\x18\xed\x6a\x6b\x62\xde\xee\x6d\x27\x8f\x82\xa5\xcd\x8d\x09\x82\x0c\x29\x88\x16\xda\x57\x6b\x5f\x91\x28\xad\x8d\xb3\xad\x13\xfd\xfc\x6c\x8e\x50\xac\x28\xe3\x8f\x82\xa5\xb9\x40\x38\xae\x23\x8a\xec\x42\x73\x48\x4b\xfe\xbc\x51\xcf\x2f\x1b\x06\x42\x53\x12\x6c\xa8\x58\x63\x39\x99\xca\x98\x94\xd6\x09\xcb\x2a\x03\x1b\xe7\x49\x5b\xb1\xd4\x0a\x8b\x65\x08\xd0\x19\x5f\xcf\xe6\x9f\x27\x57\x03\x58\x58\x87\x77\x77\x83\x7f\x43\x3b\x3d\xec\x8d\x06\x2f\x53\xb5\x54\xd3\x2c\x8d\x22\xe7\x96\xb8\x45\x39\x83\x77\xce\x8d\x7d\x16\x79\x47\x51\x1c\x73\x84\x21\x44\x4b\xa8\x15\x4b\x3b\x90\xa1\x31\x12\xb0\x10\x67\x49\x08\xeb\x2f\xd9\x58\x26\xd8\x74\xb5\x0f\xf9\xd6\x08\x6e\x4c\x4c\x66\x54\x29\x31\x3d\x37\x0b\x79\x79\x3c\x24\x8f\x1c\x12\x83\x30\x55\xc3\x51\xe9\xe6\x95\x61\x68\x1d\xf9\x8f\xbf\x1f\x10\xfe\x8a\x50\xda\x89\x64\x9b\xac\x04\x6b\xb0\x86\x61\x3c\x70\x55\x57\x41\x12\xbc\xae\xc9\x59\x6d\x22\x58\x08\x99\x82\xf8\x23\x2d\x52\x31\xf0\x05\x23\x59\x9a\x82\xa0\xb8\x48\x9a\xdc\x96\xe9\x0d\xe4\x7d\x6c\x2a\xca\x59\xb6\x21\x51\x34\x68\xfb\x43\x42\xdb\xaf\x3a\xf7\x68\x74\x9d\x01\x49\x2a\xc3\x2c\xb0\xf1\xa2\x64\xcd\x1f\xb0\x53\x36\x95\xae\x7b\x02\x44\x36\xb6\x9b\xb2\x03\x6a\x5f\xac\x9e\x20\x8f\xe0\x25\xd6\x7c\x70\x65\x87\xdb\x74\x54\x51\x68\x55\xf4\xb6\xb7\x4e\xd2\xe1\x60\x57\x35\xee\x6e\x8d\x59\x4b\x5b\x2e\xfe\x5e\x48\x53\x0f\xbb\x6f\x3b\x39\x33\xd9\x53\x81\x0f\xce\x6d\x4d\x28\x14\x0f\xd1\x01\xe5\x04\x5a\x5f\x8d\x82\x2b\x4c\x7f\x30\x2e\x6d\xe3\xd7\x0e\xbc\x71\x2e\x7c\xba\x62\x13\x00\x46\x43\x5f\x5d\x03\xb2\x78\x7d\xef\x8b\x61\x6a\x67\xcd\xf3\xd6\x92\x9f\x3e\x1e\x70\x46\xdd\x1a\xf2\xd5\x58\x74\xfa\xeb\x41\x50\x26\x2c\xfd\x90\x23\xa0\x0c\x08\x43\xd7\x72\x32\xc2\x1a\x17\x2c\x42\x62\xa1\xc9\x3c\xdd\xe3\x55\x45\x85\x17\x7a\x24\xe1\x34\xa1\xc1\xb9\xdc\xa8\x6c\x1b\xff\x03\xc0\x6b\x04\x40\xa3\xae\xba\xf0\x9e\xe6\x6e\xbe\x2d\xcf\xf6\x88\xbb\x8d\x0e\xf9\x98\xe7\x76\x9b\x43\xdf\x63\x2d\x8c\x7e\xb4\x9b\x64\x36\x1f\xa8\x40\x26\x90\x2b\x25\xee\x03\xd8\x4c\x91\xa4\x3c\xa6\xb0\x94\xdf\xb3\x6d\xb5\x7d\xc0\x77\xef\x40\x6a\x73\x75\x29\xf3\xad\x88\x4d\x51\x03\x62\x5f\x1e\xb2\x24\x92\xdb\x03\xb5\xf6\x53\x7a\x07\x33\x0f\x9b\xe6\x29\x8a\x5d\x71\xa8\xe0\x4d\x0f\x5a\xd2\x08\x13\xee\xf6\x86\xf5\x8d\x1d\x0a\x68\xa1\xc8\xb8\xc7\x87\xcd\xbc\x54\xdf\x26\xe0\xb0\x06\x71\xbd\x32\x28\xa8\x2e\x36\x6b\x52\x24\xbe\x5f\x94\xd9\x81\xc4\x60\x21\x75\x26\xed\x0e\x5c\x84\x3b\x18\xfb\xdc\x00\x99\x10\xd2\x45\xf9\x01\x56\xce\x0b\x74\x3d\x2c\x7b\xf5\x3d\xee\xb2\x55\x36\xf9\x05\x25\xeb\x34\x03\xfb\xaa\xf6\xe2\x54\x8e\x98\x54\x2b\x6c\xd7\xc0\x4b\xd2\x93\x32\x62\xd0\xf1\xfc\x4c\x56\x34\x52\xac\xde\x86\x81\x08\xa4\xe9\x5a\x3c\x8d\x00\x8c\xef\xcb\x78\x85\x02\x7f\x81\xa0\x58\x74\xc8\x22\x33\xc5\x19\xfe\xda\xb0\x6f\xee\xdf\x7c\x69\x1e\xd9\x1e\x72\x81\x0d\xa1\xfb\x3d\x07\xc1\xf8\xa8\xec\x7a\x2b\xa9\xd6\x10\x50\x35\xdb\x16\x01\x7f\xea\xa6\xa6\x2d\x4d\x04\xfb\xa6\xf1\x26\xc3\xe8\x18\x9f\x3a\x4f\x42\xe3\xea\xe3\x09\xd8\xc6\x3b\x09\xb8\x8b\xcb\x0c\xf8\x2c\xea\xf1\xf7\x23\x76\x44\xf7\xd6\x83\x0e\x1a\x18\x4b\x85\xdb\x25\x4f\x59\x1d\x66\x2e\x71\xb4\xda\xd7\x77\x1f\xe5\x21\xb4\x07\x92\x97\xfb\x50\xdf\x94\xa5\x8a\x24\xb5\x9f\x56\xfc\x93\x9d\x24\xe0\x6f\xb7\x21\xf3\x6d\xa1\xbe\x84\xc6\x0e\x61\x08\x51\x08\x38\x66\xc2\xd4\x76\xd8\x80\x24\x87\x97\x7a\x30\xb9\x99\x7c\x62\xdb\xb7\x04\xf2\xca\x09\xa4\x04\xa2\x3f\x28\x73\xec\x50\xe4\xce\x30\xef\x78\xe7\x86\xe8\x54\xf9\x06\x0e\x38\x0f\xe6\x8d\xdf\x6c\xc4\x11\xd6\xec\x53\x64\x6a\xc4\x7c\x07\x4b\x7e\x14\x34\x9f\x01\xad\xb7\x4c\x5b\x86\x91\x89\x6c\x8b\x5c\xab\x8b\x82\x0d\xe7\x0e\x1a\x37\xf4\x81\xe5\x85\x61\x92\x2d\x23\x1e\x98\x04\x07\xa6\x22\xba\x2c\x69\x78\x8a\xd5\x7c\xcc\x95\xca\x77\xdc\xbc\x6c\x18\xeb\xbe\x01\x95\x80\x65\xe5\xbc\x4a\x76\x6b\x07\x21\xe7\xab\x4f\x85\xb1\x47\x5d\x14\x71\x71\x6f\x0b\xe9\x7c\x3f\x16\x58\x55\x72\x81\xeb\xea\x6c\x8d\x4b\x4b\x1b\x91\x79\x8f\x92\x8f\x1d\xa1\xac\xc2\x35\xed\x8e\x79\xbc\xb6\x63\x6e\xab\xe6\x1e\x75\x4e\xff\xf6\x4b\x87\x8c\x07\x33\xb3\x15\x78\xd7\xfd\x6c\x61\xa9\xec\xce\xa0\xa2\xb6\x87\x2b\x1c\x87\xe6\x57\x36\x22\x4c\xf5\x2e\x20\x83\x4a\x45\x16\x6b\xa6\xe7\x6e\xe0\xc2\x7f\xb4\x51\xc5\x6e\x66\xbe\x4f\x68\x87\x40\xaa\x94\x59\x5a\xf9\x12\xff\x52\xfe\x4c\xbd\xe8\x17\xf3\x69\x77\xc7\xe6\xe3\x9d\x9b\x1f\xb5\xef\x3c\x46\xc1\xd4\x7c\xa5\x4e\x99\xfd\xc4\x69\x7d\xee\xce\x2d\x5a\xb7\xdb\xad\xa9\xb0\x63\xb3\x48\xca\xf0\xa3\x97\x7b\x71\xc5\x53\xf7\xcd\xfe\x90\x47\xf7\x36\x61\xdb\x39\xb6\xae\xd1\x2e\x12\xde\xbe\x2f\x8d\x21\x47\x9c\xf9\xbc\x7d\x97\x63\x9a\x1b\xa2\xf8\x02\x7b\x2c\x2f\x96\xd2\xbf\xee\x41\xe7\x78\xdb\x9f\xa4\x52\xcb\x40\x46\x64\xc5\xa8\xce\xc0\x1a\x5c\xd2\x3e\x60\x5a\x7a\x30\xdf\xc9\xb1\xf5\xcf\x2f\xf6\xe9\x88\x3b\x85\xf9\x77\x5f\x37\x10\xb7\x68\xdc\x63\xd4\x00\x4b\x81\x32\x5f\xc7\x77\x24\x63\xfd\x56\xcd\x7b\xce\x94\x6b\x67\x49\xfd\xbe\x41\x33\x1d\xf7\xa6\xf4\x62\xb4\xc4\x0d\x95\xb2\x89\x0d\xbe\xf5\xfb\xbd\xbe\x7a\x2c\xad\x01\x15\xa6\x56\x59\xb7\xcf\xb7\xe2\xc5\xef\x64\x9a\xf9\x1c\xb6\x67\x11\x2f\x2f\x4b\x1d\x80\xd3\x3d\x23\x0b\xf3\x05\xcf\x16\xac\x26\x54\xbb\xb5\x2a\x7e\x33\xaa\x99\x4c\xa9\x38\xa8\x6f\x5a\xf2\xe1\x0d\x07\x0a\xdf\x72\xc2\x0f\xc8\x09\xc5\x12\xdd\x37\x00\x40\xdc\xfe\x7e\x72\x72\xc2\x40\x67\xf9\x2c\x8f\x3d\xf6\xde\x75\x67\x6a\xcd\xb9\x9e\xdf\xdd\xa8\xfd\xd3\xbe\xbf\x55\x30\x6a\x4e\x10\xf9\xb3\x05\xf9\xe1\x39\x7e\xc1\x2e\x88\x39\x0d\x4a\xa3\x04\x54\x67\x31\x4b\x79\x00\x18\xda\xee\xd3\xa6\x56\x5c\xe1\x14\x77\xa2\xdd\x9d\x76\xa8\x0c\xf6\xa7\x8e\xbd\xad\xc7\xce\x38\xbe\x8a\xd5\x34\xf6\x67\x31\xbc\xd5\x2c\x0a\xab\xef\xda\x23\x5a\x15\xef\x3e\xc1\x5e\xbf\xc4\x63\xe3\xf8\x6a\x46\xd6\x1f\x5e\xd9\xb1\xd0\xb7\x2b\x58\xa1\x29\x3c\x4a\x11\x7a\x45\x5c\x14\x47\xb2\x4c\xf2\x09\x30\x03\xae\x56\xd0\x76\xd8\xed\x64\xdf\xdf\x7a\x51\x10\x81\xf9\x70\xdc\x1f\x7d\xb9\x1a\xcc\xa7\xb3\xee\xa7\xc1\x15\x9a\xf2\x5f\xbc\xdc\x0a\x32\xa6\x31\x00\x00")
 
 func query_alphaGraphqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -172,7 +194,7 @@ func query_alphaGraphql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "query_alpha.graphql", size: 3650, mode: os.FileMode(436), modTime: time.Unix(1619013861, 0)}
+	info := bindataFileInfo{name: "query_alpha.graphql", size: 12710, mode: os.FileMode(436), modTime: time.Unix(1792401819, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -217,7 +239,27 @@ func search_transactionGraphql() (*asset, error) {
 	return a, nil
 }
 
-var _subscriptionGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x55\x4d\x6f\xdb\x46\x10\xbd\xe7\x57\xbc\xea\xe4\x04\x8a\x90\x7e\xa0\x07\x01\x39\xd8\xa8\x8b\x18\x48\x2c\xd4\x76\x9b\x2b\x47\xcb\xa1\xb8\xf0\x72\x56\xde\x0f\xb1\x4a\xd1\xff\x5e\xcc\x92\x92\x68\xb7\x6e\xd1\x53\x72\xc8\x49\x14\xb9\x33\xf3\xe6\xed\x9b\x37\x69\xbf\x65\xdc\xe6\x75\x34\xc1\x6e\x93\xf5\x82\x3f\x5e\x00\xc0\x6c\x36\x2b\xbf\xb7\x4c\xc1\xb4\x48\x2d\x63\xed\xbc\xb9\x37\x2d\x59\x41\xe3\x43\x4f\xa1\xd6\x5f\xa4\x40\x12\xc9\x94\xd8\x57\xfc\x3b\x9b\x5c\x1e\x53\x20\xc3\xf1\x15\xd6\x14\xb9\x86\x17\x54\x0f\x99\xc3\xbe\x5a\xbc\x28\x79\x3f\x9e\xdf\x5c\x2f\x41\xae\xa7\x7d\x84\xf1\x12\x6d\xcd\xa1\x94\xa9\xb2\xd4\xbe\x42\x63\xd9\xd5\x98\xd4\x8a\x05\x09\xc7\x39\xfa\xd6\x9a\x16\xd1\x6e\x84\x1c\x52\x4b\xa9\xc4\x75\x94\x4c\x6b\x65\x03\x76\xdc\xb1\xa4\x52\xa6\xa7\x58\x72\x90\x49\xb8\xb9\xfc\xb0\xfa\xed\xf2\x27\x34\xc1\x77\x25\x62\xe8\x65\xcd\x86\x72\x64\xf8\x66\xe8\x30\x22\xb0\x0f\x1b\x12\xfb\x89\xb4\x93\xc5\x23\x3e\x06\x14\x77\xa7\x9e\xe3\xcf\x03\xbe\xb3\xf2\xb9\x1c\xad\x1b\xcd\x37\x32\xf7\x8b\x76\x8d\xf7\x24\x9b\x4c\x1b\x46\x4c\xc1\xca\x66\x76\x3c\x5c\x48\x59\xe2\xb6\xbc\xfe\xe6\xc5\x29\xc9\x7b\xdf\x73\x18\x10\x41\x72\x87\xb5\xcf\x52\x53\xd8\xcf\x61\xc5\xb8\x1c\xed\x8e\xdd\x7e\x81\x73\x08\x6f\x28\xd9\x1d\x63\x47\x2e\x33\x3a\x26\x89\xa0\x31\x32\xb0\x1b\x3e\x26\x5f\x5a\x6e\x99\x6a\xf8\x00\x47\x31\xc1\x86\xc0\x3b\x0e\xd1\xae\xdd\x78\xbb\x38\xab\x79\xcb\x52\x2b\x8d\x7a\x65\xd3\x13\x2b\x71\xfb\xea\xe5\xe2\x04\xdd\xf9\xfe\x42\x83\xae\x73\xb7\xc4\x95\xa4\x1f\x7f\x98\xc0\x7f\x67\x37\xed\x17\x89\x1f\xe7\xa8\x24\x3b\x57\x3d\xaa\x27\x1e\xed\x80\xd8\xd9\xce\xa6\x38\xd7\x6a\x81\x1b\x1f\x78\xbc\x72\x4d\x69\x65\x84\xd1\xe4\x94\x43\x91\xcc\x51\x47\x13\x62\x34\xd3\xf3\xcc\xac\xb6\xf4\x90\x19\x35\x25\xc2\xd6\xb2\xe1\x41\xc2\x7b\x9f\x61\x48\xb0\xa5\x18\xb1\x26\x73\xaf\x2d\x1b\x2f\xc9\x4a\x66\xfd\x1a\x46\x20\xb0\x0d\x6c\x82\xf6\x85\xda\x46\xe3\x45\xd8\x24\xae\x17\xb8\xe1\x14\x2c\xef\x58\x3f\x1f\x45\x5e\x99\x1c\xa2\x0f\x93\x81\xd2\xb7\x81\xe3\xd6\x4b\xe4\x38\xf4\x60\x23\x0c\x39\xb7\xc0\x55\x82\x8d\x88\xd4\x14\xc6\x55\xc6\x7a\x3a\x52\xc7\x18\xf2\x68\x82\x8b\xd5\xdd\x3b\xd4\x36\xf0\x30\x00\x38\x3b\x8c\x28\x49\x5d\xa0\xeb\x9f\xa9\x52\x86\xd0\x83\xca\xa7\x22\x57\xb2\x4b\x09\xc9\xdd\x9a\x83\xa2\x09\x1c\xb3\x4b\x51\x27\x85\xa9\xe3\x21\xe3\x54\x76\x1a\x33\xd2\x8a\xb7\x78\x33\x49\xf7\xb1\x65\x35\x9e\xcc\x73\x78\x71\xfb\x31\xc5\xc0\xe6\x21\xad\x97\xc2\x38\xef\x07\xa6\xb5\xf6\x49\x25\xd6\xd9\xb4\x3f\x4a\x75\x81\x95\xaa\xa0\xb7\x91\xe7\x20\xe7\x7c\x8f\x86\x47\x93\x39\xa4\xcb\xdb\x47\xd2\x2c\x2a\x9c\x80\x7d\x2a\xc0\x25\x2e\xbc\x77\x4c\x82\xb7\x68\xc8\x45\x9e\xa0\x9f\xcd\xae\x1a\x88\x97\xd7\x9f\x38\x78\x1d\x93\xda\x1a\x4a\x1c\x8b\x34\x7a\x92\xa4\x95\x3a\x0a\xf7\xc3\x9d\x0c\xbd\xf5\xda\x72\x60\x1a\x50\x39\x1d\x95\xc1\xc5\x54\xe8\x7a\x98\x83\xde\x28\x1d\x6f\x1c\xbd\x4d\x2d\x08\x55\x31\xe8\x0a\xfc\x90\xd5\x45\xfd\x38\x15\x07\x77\xed\xad\x73\x58\xab\xf8\x25\x81\x12\xb4\x02\x2a\xcd\xff\xa1\x24\xbd\x92\xc4\x61\x47\xae\x3a\x96\xbb\xd3\xb9\xb0\x21\xa6\x63\xea\xe4\x35\xc3\x93\x02\x2a\x20\x3a\xa0\x7f\xdc\x23\x05\x86\xf8\x1e\xdb\xe0\x0d\xc7\xf8\xb4\xa1\x13\x55\x5a\x6a\x98\x5e\x5d\x40\xff\x88\xaa\xf4\x7c\x12\xd5\x21\xc5\x84\xec\x89\xa0\x9e\x86\x2f\xf1\xab\x95\xf4\xfd\x77\x27\x79\xbd\x5c\x8e\x7e\x3e\x71\xfe\xd1\xf8\x6f\x46\x62\x47\xf3\xfe\xf7\xc5\x79\x98\x8e\xbf\x6d\xce\xa7\x8b\xf3\xb9\xbd\x79\xbd\xba\xbb\x5c\x16\x02\x1e\xef\x49\xb5\xb0\xa4\x03\x5b\x46\xfc\x50\x26\x8e\x9e\xf1\x5f\x3b\xec\x62\x3c\xff\x99\x96\x98\xb6\x43\xeb\xc8\x3a\x9a\xbe\x01\x0d\x97\x3b\x57\xa3\x1f\x9f\xf5\xf5\x9b\xd1\xab\x0b\xa5\xbc\xb1\x22\xc5\xe6\xa7\x1e\xfc\x75\x1d\xfe\x4f\xfc\x73\xbc\xfe\x16\x6b\xd6\x03\xcf\x7a\xd8\xd7\x75\xf6\x05\xaf\xb3\x42\x76\x4b\x3b\x2e\x4c\x73\xfd\xf9\x17\xda\x73\x76\x79\xf0\x98\x89\x5f\xfe\xf9\x57\x00\x00\x00\xff\xff\x93\x1e\x2f\x48\x7e\x0c\x00\x00")
+var _statedbGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x58\x4b\x73\xe3\x36\x12\xbe\xeb\x57\xc0\xbe\x64\xb7\xca\xe3\xaa\x4d\xb6\xf6\xa0\x9b\x6c\x2b\x15\x25\x1e\xcb\x91\xe4\x4c\xed\xa6\x52\x43\x88\x84\x2d\xc4\x24\xc0\x00\xa0\x65\x65\x2a\xff\x3d\xdd\x78\x53\xa2\x3c\x4a\xb2\x99\xc3\x98\x14\x81\xaf\xbb\xbf\x7e\x02\xe7\xe7\xe7\xab\x0d\x23\xd7\x52\x08\x56\x1a\x2e\x05\x31\xbb\x96\x91\x47\xa9\x88\x81\xdf\x95\xdc\x6a\x22\x1f\x09\x25\xa5\x14\x46\xd1\xd2\x7c\xa1\x89\xa1\xeb\x9a\x9d\x9f\x9f\x8f\xec\xd2\x15\xbe\x2d\xe4\x36\x83\xf8\x34\x22\xf0\x0f\x56\x14\xeb\x5a\x96\xcf\x0b\xf6\x58\x10\xae\x2d\xa0\xfd\x81\x50\x43\xb6\x1b\x5e\x6e\x92\x8c\x2d\x53\xf0\xc4\x68\x75\x41\x68\x5d\x93\x96\x3e\x31\x2f\xf8\x97\x8e\xa9\x1d\xa1\xfe\x33\x6e\x35\x1b\x00\xd3\xb4\xf1\x68\xa8\x09\xca\x0b\xb2\xc6\xe4\xca\x3f\x8d\x82\x1e\x13\x52\x73\x6d\x10\x8f\x55\x08\x6c\xa4\x33\xc2\xca\x0e\xfb\xed\xa7\x31\xf9\x31\x18\x34\x85\xf7\xb3\x9f\xce\x22\xc8\x4c\x00\x2b\x0d\x75\x24\x49\x42\x79\x85\x6a\x72\x61\x7f\x09\x20\xa8\x38\x2e\x1c\x93\x7b\xff\x74\x36\xfa\x6d\x34\xb2\x3a\x68\x2e\x9e\x40\x66\x94\x0c\x06\xe9\x56\x0a\xcd\x2e\x0f\xc8\x44\xd9\x89\xc6\x79\x4b\x81\x05\x52\x76\x4a\x83\x5f\xc0\x0a\xcb\x00\x20\x5c\x80\x3c\xad\x09\x37\x64\x4d\x81\x56\xd0\xaa\x30\x1e\x41\x17\xf8\xfa\xc8\x8c\x67\x59\xb0\x57\x63\xb5\x0b\x9a\x3a\xb4\x31\x59\x1a\x05\x7a\x25\x33\x31\x1c\x82\x1a\x44\xae\x7f\x06\x9f\x5e\x86\x3d\x42\x56\x6c\x1c\xbf\x06\xcb\x16\x4c\x77\xb5\x71\xde\x3a\xb0\xb1\x96\xf2\xb9\x6b\x0f\x0c\xf4\x7b\xa2\x89\xd6\x65\xa4\x6b\x51\xe9\x14\x1a\xda\x50\xc3\xc8\x96\x6a\x1f\x1a\x85\xe8\xea\xba\x80\x05\x4c\x90\x82\x2b\xc5\x5e\x98\xd2\x1c\x10\xe7\xa2\xde\x15\x7e\x21\x50\xa5\x0d\xab\x82\xce\x5d\xbb\x92\x16\x7d\x20\x2e\x6e\x29\x44\x45\x8e\xe3\xe3\xf3\x59\xc8\xad\x20\xeb\x5d\xa6\x44\x45\xc1\x26\xaa\x99\x13\xee\xe3\x36\xaa\x16\x84\xd5\x00\x38\xcb\xf0\x8e\x09\x5e\xb9\xfd\x7d\x8b\x84\xb4\x98\xec\x15\x62\x55\xa7\x14\x0c\x06\x91\x56\xf1\x86\x42\x2e\x3c\xb3\x5d\x90\x07\xeb\x93\x3f\xf6\x03\x0d\xc1\x4e\xcb\xdd\xe4\x87\xfb\x24\xc3\x05\x9a\xd7\x93\x89\x12\x7c\x0f\xe9\x57\x96\x52\x55\x80\x6f\x73\xa8\xa7\x5e\x01\x7b\x56\x80\x5a\x04\xe5\xe0\xfd\x30\xbc\x26\x00\xd0\x09\x0c\xc5\x1d\xa2\x04\x2b\x17\x93\xf7\xa4\xd3\x80\x62\x49\x77\xd1\x9d\x72\x6a\xc7\x06\x02\xd5\x85\x8c\xe8\x9a\x35\x53\x79\x45\x71\x9b\xad\x6b\xd0\x1d\xa4\x91\x15\x7f\xe4\x29\x20\xac\x8b\xef\xba\x66\x4c\x1e\xb8\x30\xff\xf9\x77\x42\xfc\x86\xbd\xd2\x8a\x95\xc0\x40\x0d\x76\xb5\x90\x9e\x4c\x18\x97\xf0\x89\x0b\x20\x71\x0d\x49\x0f\x1c\x61\x48\x04\xd0\x0d\x7b\x3d\xd4\x70\x61\x57\xe3\x32\x02\xb0\x96\xbf\x4e\x5b\xee\x00\x2a\x73\xca\xe4\x6a\xe6\xea\x1a\xfc\x67\xb5\xeb\xc7\x05\xa4\x37\x70\x56\x57\x10\x20\xf0\x9d\x05\xac\x20\xfa\x67\x2d\xc5\x98\x7c\xbb\x9c\xdf\xf9\x00\x78\xab\xa0\xeb\x52\xb6\xec\xa4\x92\xbe\xc4\x95\x43\x45\xfd\x18\xef\x11\xfc\xff\x55\xcb\x07\x7d\x74\xb4\x94\x3b\xe1\x83\xc5\xdc\x9a\xf2\xf7\x96\x73\x2b\xfd\x58\x41\x8f\xf2\x3f\x57\xd2\x2d\xca\xb1\xa2\x6e\x51\xfe\x52\x59\xf7\xf0\x02\xe8\x7e\xe7\x13\xba\x5f\xd9\xc3\x26\x6f\xa2\x4b\x55\xf0\xdd\x56\x60\xd4\x52\xd2\x76\xeb\x9a\x97\xb6\x38\x70\xc8\x09\xc1\x7c\x5e\x70\x45\x5a\xa6\x1a\xae\x35\x70\xa7\xa3\xf1\xdf\xb1\x5d\xc4\x38\x21\x7c\x68\x58\x1b\x03\xe8\x84\x70\x08\x7b\x6c\xf2\x6c\xe8\x0b\xb3\x50\xa7\x2b\x8a\x38\x41\x30\xc4\x8b\x67\xe0\xa7\xc0\xc1\x7d\x5c\x0d\x71\x27\x9e\x5d\x1c\x8b\xb0\x23\x5a\x9a\x96\xdd\xda\x55\x7f\xa8\xaf\xfd\xed\x9d\xca\x69\x7e\xc0\xea\xa9\xcd\xea\x36\x18\x9e\x39\x09\x6a\x46\x46\x24\x5a\x17\xaa\x09\xac\x30\x39\xb9\xd9\x32\xe0\x17\xb1\x58\x95\xe8\x4a\x4c\x4f\xb2\x95\x56\x63\xa8\x96\x98\x97\xfb\xc0\xe4\x1f\x9a\x31\x52\x30\x09\x0b\xc7\x63\x5c\x48\x3b\xb3\x29\xfe\x19\x7d\xb1\x2f\xc2\x3b\x23\xc0\x0c\xb5\x24\x8b\x8b\x69\x01\x8d\xae\x69\xcd\x2e\x51\x97\xe9\x04\xf9\x99\xa9\x05\x85\xcd\x1b\x1a\x98\x09\x02\x52\x54\xe1\xe7\x3d\x71\x09\xef\x0e\xc4\xed\x67\x1c\x4c\x45\xb2\x53\x25\x7a\xac\xe1\x10\xd4\x54\x60\xc7\x80\xec\xee\x87\x1d\xa6\x0d\x25\x4f\xfc\x05\x94\x8c\x65\xd3\xda\xee\xd3\x21\xe0\x9c\x94\x76\x2a\x2e\x3e\x35\xef\xbc\x1a\xc7\x5a\x72\xbf\x27\x18\x8a\x94\x6d\x19\x7f\xda\x60\x29\x49\xed\xfe\x97\x4e\x42\x63\xec\x87\x95\xeb\xd8\xe5\x86\x8a\x27\x06\xed\x03\x9b\xa0\xf3\x45\x27\x6c\xa4\xc7\xd0\xb5\xfc\x5c\xed\xa9\xf7\x96\x1e\x91\xc6\x3f\x21\xcd\xee\x3d\x2a\x6c\xe9\x2c\xbc\xbe\x7f\xf0\x56\x5e\x90\x77\xff\x0a\x38\x56\xd1\x54\x66\xcb\xb6\xfb\x60\xd7\x8c\xc9\x6c\x08\xe4\x6e\xba\xfa\x3c\x88\x60\x66\x18\x24\xb1\xca\xb1\x26\x18\xa6\xdf\x40\x51\xb4\xf9\x1e\xd7\x0e\x81\xd8\x49\x2c\x60\x64\x1b\x1e\x90\x88\xc3\x22\x8c\xa6\x7b\x7e\xa1\x96\x38\x86\xe1\x01\xbb\xe9\x13\xd9\x72\x51\xe1\x04\x09\x70\x0d\x2f\x95\xd4\x30\xbd\x88\x4a\x67\x8c\x8c\xf7\xc3\xf6\x16\x35\x8d\xe8\xc8\xc9\x29\xe8\x3d\x65\x81\xa3\xcf\xc0\x5e\x6f\x28\x17\xef\xb6\xbc\x0a\x75\x13\x81\xd1\x12\x4c\x3a\x94\x19\x72\x50\xe1\xc4\xa5\x20\xd9\x2a\xf2\xa8\x64\x13\x27\x0c\x0c\x1e\x5e\x5a\x50\xa8\x6c\xd3\xfc\xd5\xa7\xf3\xc8\xf2\x65\x01\x2d\x98\x1b\x82\x42\xbe\x39\x73\xac\x88\x3d\x73\x2e\xc9\x04\x22\xf1\x85\xd6\x30\x59\x5b\xf9\xc1\x89\x79\xba\x62\x3d\x8a\x4e\xbd\x1c\x8d\x56\x31\xc8\x61\x3e\xa4\x3b\xc8\x66\x6e\x36\x84\x51\x88\x7f\x7f\xe8\x7e\x34\x5e\x5e\x16\xf6\x17\xe9\x54\xee\x44\x59\xd7\x43\x63\x32\x1c\x66\x32\x5d\x4b\x6c\xc7\x36\x89\x74\x8d\x21\x57\xef\x40\x28\xd0\x01\x23\x3f\x8c\xc9\x86\xb9\x53\xbd\x95\xe8\x54\x6c\x95\xac\xba\x12\x3a\x0a\xc1\x3a\x07\x58\x16\xe3\x72\x74\xac\x44\x59\xc2\x7c\x99\x42\xd9\x3e\x1c\xcf\x5c\xa1\x79\xa1\xbc\xc6\xd9\xa7\xf7\x6b\x43\x5f\xe3\xbb\x23\x3a\xf3\xa5\xea\x97\x50\x67\xdf\x0b\x57\xa6\x83\xa1\xde\xbb\x94\xbd\xb6\xe8\x93\x48\x69\x89\xdb\x91\x4f\x1c\xb0\x21\x3a\x9f\xdc\x81\x06\xd7\xc4\xe6\x13\x26\x71\x9e\xc6\x9b\x9e\xcb\xbd\x09\x06\x32\xaa\xbe\x4e\x59\x1e\x52\x25\x7e\xbb\x4b\xc9\x7b\xf0\x6d\x41\x9b\x2b\x0c\xe2\xfe\x27\xaf\x3c\x80\x5a\x49\x83\x1f\x01\x75\xef\x63\x6c\xaa\x4a\x1a\x59\xca\x1a\x66\x46\x6a\x3a\x70\x30\x1c\x6b\xde\x61\x6b\x7a\xa1\x68\x23\xd4\xe3\xec\x25\xa3\x23\x8d\x36\x1e\xe0\x6b\xbf\xdf\xd9\xe9\xd1\x6e\x38\x72\x75\xd8\x08\xae\x61\x9c\xc4\x7e\x1a\x0a\xee\xba\xe3\xb5\x01\x8e\xfd\x36\x70\x4b\xcd\x9f\xa1\x8b\xcf\xef\x6e\xff\xfb\xf1\x6a\x76\x7b\xfb\xf1\xeb\xd9\x62\xb9\xfa\x38\x79\x58\x7d\x33\x5f\xcc\xfe\x37\x5d\x14\x79\x3d\x46\xbf\xd0\x7d\x90\x98\xea\x59\x23\x7d\xab\x03\x04\x02\x70\xec\xea\x91\x90\x4b\x02\x0f\xe3\x77\x14\xd8\x5b\x13\xa7\x19\xc5\x26\xee\x37\x68\xe1\x07\x2d\xe1\x60\xc5\x4a\xbd\xce\x6e\xfe\xa8\x72\xc7\x14\xf3\x01\x9a\xfc\xb5\x63\xbd\x61\xe3\x0d\xa5\xe8\x5b\x1a\x55\x4c\x97\x8a\xb7\xf8\xf5\x98\x3f\xdd\xef\x71\xd4\x09\x6e\x74\x53\x77\xd3\x69\x7b\x2e\x4d\x8a\xad\x19\x9c\xad\x98\x3b\xd3\xc0\xf0\x1d\x94\xac\x58\xcb\xa0\x78\x88\x92\xb3\xa1\x59\x7b\xe2\x6b\x92\x2b\x93\xd9\xe4\xf5\xb9\x99\xfb\xda\x6d\xfb\x74\x64\x66\xb1\xf9\x95\x9b\x7d\x6c\x8c\xe9\x0f\x68\xfd\xdf\xe1\xe4\xa4\xa8\x1b\xe8\xee\xa7\x8b\xf7\xb3\xe5\x72\x36\xbf\xfb\x38\x87\xe7\xc9\x0a\x9e\xb2\x69\x12\xa6\x51\xa9\x38\x0c\x91\x91\x05\x16\x8b\x6d\xf2\xe7\xde\x70\x89\x7e\x2f\x61\xf4\xca\x42\x4d\xd6\x55\xc4\x82\x5e\x16\x1e\x07\x04\xb9\xca\x7e\xb2\x9c\x8a\xd5\xac\x37\x4e\x6c\x07\xe5\x80\x53\x18\xcc\x8b\x83\xe6\x7a\xae\x67\x77\xcb\xe9\x62\x65\x1f\x1f\xee\x6f\x26\xab\xa9\x7d\x5c\x4c\xdf\xcf\x7f\x98\xe2\x7e\x57\xf0\xa3\x9e\xbe\x44\x6e\x20\x72\x36\x60\x9d\xf3\xd0\x57\x5f\x9e\x85\xdb\x22\x0c\x0a\x38\x33\xba\xfa\x88\x71\x91\x79\x09\xbf\x65\xa7\x2c\x38\xb4\xd4\xfd\x75\x5b\x6a\xfb\xf0\x8f\x1f\xe0\x6f\xfa\x12\x94\x88\xb0\x5e\x09\x77\x40\xfc\x2e\xbf\xa1\xb2\x20\x59\x69\x46\xc5\xc2\xf6\x41\xc9\x01\x2a\x8b\x98\xbd\x75\x6f\x83\x26\x4d\x3d\x12\x9a\xb0\x64\x65\x9f\x97\x81\xdd\xfb\x97\x7c\xee\x86\xab\x8c\x37\x55\x94\x3c\xca\xba\x96\x5b\x3c\xaa\x1c\x5e\xec\xf4\xb2\x25\x04\xc1\x07\x7b\xa5\x6a\x54\xc7\x8a\x6c\x24\x20\xe8\x26\x77\x61\x05\x99\xec\xb3\xd3\x1d\x5b\x1b\x19\x06\xa2\xac\x85\xae\x61\x0e\x80\x26\x1e\xe6\x1c\xa9\x9e\xa8\xe0\xbf\x5a\xbd\x2e\x88\x3d\x68\x9a\x2c\x4c\x7b\x0a\xc0\x54\x21\xe1\xec\x29\x65\xcd\xa8\x48\xd9\x64\xff\xf6\x2f\x4b\xe0\xf4\x05\x01\xd4\x35\x2e\xb3\xdc\xdd\x88\xb3\x49\x17\x44\x77\xeb\x58\xcc\xdc\xf5\x93\x93\xe5\x2f\xa0\xa0\x97\x0b\xed\xcf\x91\x7a\x43\x7d\x76\xda\xfb\x27\x87\xde\x93\x3b\x78\x97\x32\x5c\x5f\xec\xaf\x07\x15\x06\xaf\x99\x6e\x42\xd1\xcc\x65\xbb\xba\xe9\x2f\x26\xc3\xcd\x62\x10\x3b\x54\xab\xd2\x9d\x71\xcf\xdb\x17\xc4\x1e\x86\x6f\xae\xe6\x6d\x11\xef\xf8\x1a\x60\x10\x9d\x06\x72\x0b\xf0\x1f\xde\x0c\x16\x76\x98\x29\x20\xd5\xed\x5b\x2c\xc8\xeb\x79\x3b\x26\xb8\x1b\xe3\xea\x77\xd8\x75\xd8\x66\x0c\x1a\x00\x00")
+
+func statedbGraphqlBytes() ([]byte, error) {
+	return bindataRead(
+		_statedbGraphql,
+		"statedb.graphql",
+	)
+}
+
+func statedbGraphql() (*asset, error) {
+	bytes, err := statedbGraphqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "statedb.graphql", size: 6668, mode: os.FileMode(436), modTime: time.Unix(1792401819, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _subscriptionGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x57\x4d\x73\xdb\x36\x10\xbd\xfb\x57\x6c\x75\xa9\x93\x71\x34\xe9\xc7\xf4\xa0\x99\x1e\xec\xd6\x19\x7b\xc6\x89\x5a\xdb\x6d\xae\x84\xc8\xa5\x88\x09\x04\x28\x00\x68\x86\xe9\xf4\xbf\x77\x77\x01\x8a\x94\x1b\xb9\x4d\xda\x4e\x32\x9d\xe8\x22\x09\x04\xf6\xe3\xed\xdb\xb7\x44\xec\xb7\x08\x37\xed\x2a\x94\x5e\x6f\xa3\x76\x16\x7e\x3b\x02\xfa\xcc\x66\x33\xf9\xbe\x41\xe5\xcb\x06\x62\x83\xb0\x32\xae\x7c\x55\x36\x4a\x5b\xa8\x9d\xef\x94\xaf\xf8\x1b\xa2\x57\x36\xa8\x52\xce\x3e\xc6\x37\x58\xb6\xf2\x93\x96\x4b\x0c\x8f\x61\xa5\x02\x56\x40\x0b\xc5\xeb\x16\x7d\x5f\xcc\x8f\xc4\xee\xcb\xd3\xeb\x17\x0b\x50\xa6\x53\x7d\x80\xd2\xd9\xa0\x2b\xf4\xe2\xa6\x68\x6d\xe5\x0a\xa8\x35\x9a\x0a\x26\xbe\x82\x44\x82\xe1\x04\xba\x46\x53\x48\x41\xaf\xad\x32\x74\x44\x45\x39\xb7\x51\xb1\x6c\xb4\x5d\x03\x1a\xdc\xa0\x8d\xe2\xa6\x53\x41\x6c\x50\x7c\x70\x7d\xfe\x7c\xf9\xeb\xf9\x8f\x50\x7b\xb7\x91\x13\x29\x97\x15\x96\xaa\x0d\x08\xae\x4e\x19\x06\xf0\xe8\xfc\x5a\x59\xfd\x56\x71\x26\xf3\x3d\x3c\x52\x14\xb7\x63\xce\xe1\x59\x8a\xef\x58\x1e\xcb\xd6\xaa\x66\x7b\x19\xb9\x9f\x39\x6b\xb8\x52\x76\xdd\xaa\x35\x42\x88\x9e\x62\x9c\xed\x36\x0b\x28\x0b\xb8\x91\xe5\x2f\x8e\x46\x23\x57\xae\x23\x40\x24\x22\xb0\xed\x06\x56\x8e\x70\x51\xbe\x3f\xa1\x7c\x4a\xd3\x06\x7d\x87\xa6\x9f\xc3\x29\x58\x5c\x53\x9c\x77\x08\x77\xca\xb4\x04\x03\x52\x68\xa0\xf2\x49\x8f\x26\x3d\x8c\x4e\x52\x6e\x50\x51\x31\x3c\x18\x15\x22\x68\xef\xf1\x0e\x7d\xd0\x2b\x93\xab\x0b\xc7\x15\x6e\xd1\x56\x0c\x23\x97\x6c\xba\x63\x69\x4d\x5f\x3c\x9a\x8f\xa1\x1b\xd7\x9d\xf1\xa1\x17\xed\x66\x01\x97\x36\x7e\xf7\xed\x24\xfc\x0b\xbd\x6e\x3e\xc9\xf8\xc9\x63\x61\x5b\x63\x8a\x3d\x7f\xd6\x41\x93\x22\x36\x7a\xa3\x23\x91\x8c\xbc\x79\x24\xee\x61\x2e\x39\x9b\xd4\x36\x87\x51\xb7\xb1\xf5\x42\x99\x1d\x8f\x26\xc0\xb0\xa5\xc3\xc8\x2c\xb7\x8a\x8a\x0e\x95\x8a\x0a\xb6\x1a\x4b\x4c\x14\xee\x5d\x0b\xa5\xb2\xb0\x55\x21\x50\xd3\x50\x2e\xe4\x8b\x1a\x23\x6a\x4b\xbb\xe9\xa9\xcf\x81\x80\xae\x41\x47\xe0\xbc\xa0\xd2\x81\xb6\x58\x2c\x23\x56\x73\xb8\x46\x62\x11\xad\xf3\xe3\x1d\xc9\x8b\xb2\xf5\xc1\xf9\x49\x43\xf1\xaa\xc7\xb0\x25\xee\x62\x48\x39\x68\xea\x41\x65\xcc\x1c\x2e\x09\xd5\x00\x41\xd5\x82\x38\xd3\x98\x77\x07\xb5\xa1\x2c\xc5\x0e\x1b\x38\x5b\xde\x5e\x90\x6b\x8f\xa9\x01\xe0\x78\x68\x51\x65\x2b\x09\x9d\xff\x4c\x99\x92\x8e\x0e\x2c\x9f\x92\x9c\xc1\x16\x17\x44\x91\x15\x25\x44\xd1\x50\x68\xad\x89\x81\x3b\x05\xc9\x6f\xb2\x38\xa5\x1d\x9f\xc9\xb0\xc2\xf7\xf0\x74\x62\xee\x65\x83\x2c\x3c\x2d\x9e\x50\xf5\x4d\x9f\x4d\x24\x34\x07\xb3\xce\x0a\xe2\xd8\x27\xa4\xd9\xf7\xc8\x12\x6d\x74\xec\x77\x54\x9d\xc3\x92\x59\xd0\xe9\x40\x06\x09\x1e\xd7\x41\x8d\x59\x64\x06\x73\xed\x76\x8f\x9a\xc2\xc2\x49\xb0\xf7\x09\xb8\x80\x33\xe7\x0c\x51\x8e\x22\xaf\x95\x09\x38\x89\x7e\x36\xbb\xac\x89\x88\xf6\xc9\x5b\xf4\x8e\xdb\xa4\xd2\xa5\x8a\x54\x22\xa6\x46\xa7\x6c\x64\x4f\x1b\xe5\x5f\xa5\x9a\xa4\xdc\x3a\x4e\x99\x7e\xa5\xa8\x0c\xb7\x4a\x52\x31\x26\x3a\x6f\x26\x50\x35\xf7\xd3\x50\x71\xe8\x74\x6c\xe8\x7f\x21\x02\x5d\x00\xbe\x6e\x59\x45\x5d\xee\x8a\x41\x5d\x3b\x6d\x0c\x49\x23\x71\x8e\xfc\x12\x3d\xd9\x03\x14\x6c\xff\xb9\x18\x25\xf8\xd1\x53\x07\x15\x3b\x77\xb7\xdc\x17\xda\x53\x5f\x0e\xa6\xc9\x28\x59\xb8\xe7\x80\x09\xa4\x86\xe8\xf7\x73\x54\xd4\x52\x96\x40\xde\x7a\x47\xa3\x23\xdc\x4f\x68\x84\x8a\x5d\xa5\xee\xe5\x01\xf4\xce\xa8\x24\xe7\x91\x54\x83\x89\x09\xd8\x13\x42\xdd\x3f\xbe\x80\x5f\xa8\xd5\xbf\xf9\x7a\xa4\xd7\xa3\x45\xd6\xf3\x89\xf2\x67\xe1\xbf\xce\xc0\x66\xf1\x7e\x78\x70\x0e\xdd\xf1\xa7\xc9\x79\x7f\x70\x1e\x9a\x9b\x2f\x96\xb7\xe7\x0b\x01\x60\x7f\x4e\xb2\x84\x45\x6e\x58\x69\xf1\xc1\x4d\xc8\x9a\xf1\x57\x33\xec\x2c\xef\xff\x48\x43\x8c\xd3\x51\x2b\xa2\x5a\x29\x9a\xaa\x52\x71\x4f\x58\xe8\xf3\x6f\x5e\x7e\x9a\xb5\x5a\x20\xc5\xb5\xb6\x56\x64\x7e\xaa\xc1\x9f\xc7\xe1\x7b\xc6\x7f\x02\x4f\xbe\x22\x30\x79\xc3\x41\x0d\xfb\x3c\xce\x3e\xe1\x71\x26\x60\x37\x8a\x50\x62\xa4\xc9\xfc\x47\x1f\x68\x87\xe4\x72\xd0\x98\x03\x7a\x79\x7a\xf5\xd3\xc5\x29\x43\xca\x29\xca\xfb\xbc\xab\x74\xcd\xd3\x41\x8a\x22\xb2\xc0\xfc\x21\x75\x8c\x5f\x52\xde\x8a\x59\x5e\x8b\x42\xac\xa9\x23\x2c\x10\x75\xb6\x9c\x56\x48\xd3\xbd\x51\x5b\xa2\x3e\xd3\x7e\x94\x87\xe4\xf1\x19\xa7\x9c\x56\x5b\xca\x86\xe6\x9b\x77\x5d\x48\x73\xb1\x10\xbb\xd7\xf4\xbf\xc8\xc3\x8c\x49\x10\xa2\xf2\x31\xf1\x2a\x4c\xaf\x4a\xf9\xe6\x61\xf1\x4d\xcc\x0d\xd7\xf2\xd4\x12\x2f\xc5\x44\x0f\xde\xef\xd2\xb3\x7f\xc1\x09\xe3\x0d\x87\x92\xb0\xa4\xbe\x0f\xdd\x69\xc4\xcd\x87\xdc\x6b\x24\xef\x1f\xc4\x41\x98\x4c\x01\x51\xe6\xb2\x24\xfe\x44\x86\x32\x05\x26\xb1\xe4\x52\x70\x7f\x91\xc8\x18\xd7\x63\x35\xe9\x90\xfc\xf4\x5d\x33\x81\x4d\xa6\xea\x59\xea\x07\x29\x58\x85\xb5\xb6\xb8\xeb\xe6\x49\x99\x4f\xcf\x2e\x47\xab\x72\xea\x90\x49\x29\xff\x30\x0d\x64\xe7\x78\x50\x9e\xfd\x0f\x2f\x59\x1f\xa4\xbd\x93\x97\xc7\xff\x50\x7a\xff\x86\x5a\x3e\x24\x6f\x89\xea\xff\xf4\x6d\x3d\x59\x64\x9c\x07\x7b\xff\x96\xba\xdd\x8e\xed\x42\x7c\xfa\xfd\x0f\x52\x7a\xcc\xe3\x46\x11\x00\x00")
 
 func subscriptionGraphqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -232,12 +274,12 @@ func subscriptionGraphql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "subscription.graphql", size: 3198, mode: os.FileMode(436), modTime: time.Unix(1587588369, 0)}
+	info := bindataFileInfo{name: "subscription.graphql", size: 4422, mode: os.FileMode(436), modTime: time.Unix(1792394894, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _tokenmetaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x54\x4f\x8f\xdb\xb6\x13\xbd\xeb\x53\x4c\xf4\x3b\xec\xaf\x40\x2b\xa3\xf9\xb3\x49\x05\xf4\xe0\x75\xdd\x62\x81\x66\x13\xac\xdd\x53\x51\x34\x34\x39\xb6\xa6\x2b\x91\x0a\x39\xda\x3f\x28\xf6\xbb\x17\x24\x45\xc9\x96\xbd\x68\x72\x68\xb2\x87\x85\x38\x9e\x79\x6f\xe6\xf1\x71\xf2\x3c\x5f\x57\x08\x0b\xa3\x35\x4a\x26\xa3\x81\x1f\x5a\x84\xad\xb1\x20\x60\x6d\x6e\x50\xe7\x79\x9e\x85\x58\x38\xed\x25\xfe\x9d\x01\x00\xe4\x79\xfe\x61\x53\x1b\x79\xf3\x01\xc8\x01\x57\x08\xe1\x04\x82\xe1\xae\x22\x59\x85\x10\xfb\x52\x50\x82\x85\x4f\xba\x15\x35\x29\x0f\xeb\xeb\x43\xf6\x35\x6e\x4b\xb8\xe8\xbf\xb2\x84\x3b\x87\x9a\x1c\x83\xd9\x02\xaa\x1d\x3a\x60\x13\x81\x5c\xaa\x0d\xe1\x12\x7e\x0f\x9d\x2d\xd5\x0e\xff\x78\x36\x14\x5f\xea\xad\xb1\x8d\x88\x23\x19\x10\xa4\xa0\x15\x3b\xd2\x21\x92\x00\x5a\xb1\x43\x9f\x58\xc2\xfb\xfe\x2b\x7b\xcc\xb2\x40\xed\x48\xef\xea\x7e\x68\xb0\xe8\x5a\xa3\x1d\x16\x87\x62\x78\xca\x51\x86\x15\x22\x54\xcc\xad\x2b\x67\x33\x65\xa4\x2b\xd4\xb6\x73\x58\x90\x99\xa1\x71\x64\x66\x6d\xb7\xa9\x49\x7e\x27\x5a\x72\x33\x8b\x5b\xb4\xa8\x25\xce\x1c\x0a\x2b\xab\x99\xec\xac\x33\x76\x98\x2c\x1e\x4b\x58\xb1\x25\xbd\x1b\xa7\xf2\x77\x15\x5b\x32\x9b\xbf\x50\x72\x91\x0a\xb4\x51\x58\xc6\x9f\x9e\x4d\x67\x08\xc2\x9e\x98\x21\x09\xbe\x3f\xc2\xc7\x0e\x35\x93\xa8\x41\x77\xcd\x06\xad\x17\x9f\x2b\x72\xfd\xa5\x7a\x2d\x2b\x04\x59\x09\xd2\x23\x75\xc8\x2c\xe1\x37\xd2\x7c\xfe\xb2\xef\x95\xd4\xd8\xfc\x29\x49\x0f\x85\x1c\x3b\x58\x18\xcd\x56\x48\x06\xae\x04\x83\xb4\x28\x18\xd5\x9e\x87\xa8\xc0\xa2\x84\x20\x68\xc1\x09\x28\x28\xd6\x17\x1e\x6b\xb6\x7a\x68\x36\xa6\x0e\x93\x04\x88\x1e\x63\xf9\x6e\x95\x6a\x5d\xc8\x38\xa1\x76\xc8\x6f\x2d\x4a\x72\xfb\xae\x49\x81\x38\xf3\x8b\xe7\xd3\x8a\xd4\x0b\x90\x73\x9d\x97\x26\xf4\x9b\xca\x53\x70\xca\x76\x35\x2a\x1e\x50\x2a\x53\x2b\x1c\x2d\xd1\x1f\x27\x3a\x27\xce\x33\x07\x8d\xb8\xa7\xa6\x6b\xc0\x75\x6d\x5b\x3f\xa4\xb2\x3e\xba\x0a\xc1\xff\xc7\x37\x51\xc2\x7c\xb5\x5a\xae\xff\xfc\xf9\xdd\xf5\xdb\xf9\x1a\x7e\x8c\xc7\x6f\x9e\x10\xe0\xcc\xbf\x3c\x16\xf5\x04\x38\xc4\x3e\x0f\x36\x1a\xe1\xe9\x7d\x33\x97\xd2\x74\x9a\xe1\x42\xd4\x42\x4b\x1c\x3c\xd2\xc7\xfb\xf0\x57\x5e\x41\xa2\x6f\x72\x13\xbb\x39\x5a\x46\x87\xcd\x7e\xd9\xad\x74\xcc\xfd\xe5\xd7\xd3\x44\x9f\xd3\x8b\xea\xb0\xd1\xe0\x8c\x53\x13\xfc\xa7\x7b\x21\xb9\xcd\x3f\x2c\xd2\xbb\x11\x2c\x95\xf6\x93\x7c\xdd\x8d\x32\x6f\x42\x93\x61\x0f\xa7\x61\x2b\xac\x15\x50\xdc\xc5\x7d\x93\x83\x97\xa3\x70\x9f\xf7\x22\x17\xf1\x76\xc1\xe2\xc7\x8e\xac\xd7\xd5\x04\xdd\x48\x77\x08\x48\x5c\xa1\xf5\x2f\xf4\x4e\x58\x05\xc6\xc2\x46\xc8\x1b\xff\xed\x60\x6b\x4d\x03\x62\x78\x23\xbd\x95\x51\x01\xd6\xd8\xa0\x66\x37\x38\x33\x39\x79\xbc\xd1\xe8\xa1\x34\xd8\x96\xac\xe3\x54\x96\x82\x1e\xf7\x5b\xe8\x1c\x02\xb1\xef\x29\x9a\x31\x4d\x6e\xda\xd6\x38\x62\x04\x45\x36\xee\x83\x41\x7d\x16\x96\x17\x13\x8f\x9e\xa4\xad\xc5\xbf\xb3\x26\x25\x86\x87\xae\xd5\x13\xd8\xa4\x15\x49\xc1\xe8\xe0\xae\xc2\xa0\x9a\xff\x87\x7e\xe1\x08\xd0\x78\xcf\xe1\x69\x0f\x0b\x5d\xb8\x2b\xbc\x67\xaf\x4c\x09\x17\xc6\xd4\x28\xf4\xa7\x41\xb5\x16\x6f\xc9\x74\x6e\x0a\xf7\xbe\x8f\x4f\x20\x1f\xb3\x0c\x75\xd7\x1c\x7a\x61\xb8\x87\x5b\x51\x77\x08\x77\xc4\x71\x4f\x0e\x56\x04\xa1\x55\xef\x63\xef\x6f\x38\xfb\xfe\xf9\x8b\x97\xc5\xab\xf3\xd7\x6f\xbc\xd1\xcf\x12\x6d\x00\x85\xf8\xf7\x3f\xf0\x39\xaf\x8a\xf3\xd7\x6f\x7e\xf0\x49\xc7\x14\xa6\xe3\x09\x8b\xb1\x7b\x24\x45\x64\xf1\x24\x03\xc1\xe5\xd5\x7a\xf9\xcb\xf2\x7a\x9f\xc0\xe3\x7f\x52\xfb\xda\x44\xba\x23\x86\xe2\x80\xe2\xa7\xe5\xe2\xf2\xed\xfc\xd7\xa3\x19\xb2\xc7\xec\x9f\x00\x00\x00\xff\xff\xa4\x1b\xf5\xed\x20\x0b\x00\x00")
+var _tokenmetaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x58\xdb\x6e\x1b\x37\x10\x7d\xd7\x57\x8c\xdd\x07\xb7\x80\x23\x35\xd7\xb6\x42\x1a\xc0\x76\x94\xda\x40\xe2\x18\x91\x5b\x14\x28\x82\x8a\xda\xa5\x2c\xd6\xbb\xe4\x86\xe4\x4a\x36\x8a\xfc\x7b\x87\xc3\xcb\x5e\x24\xa5\x76\x80\x26\xa8\x1f\x64\x2d\x97\x9c\xcb\x99\x99\x33\x43\xed\xef\xef\x5f\x2e\x39\x9c\x28\x29\x79\x66\x85\x92\x60\x6f\x2b\x0e\x0b\xa5\x81\xc1\xa5\xba\xe6\x72\x7f\x7f\x7f\x40\x6b\xf4\xd4\xda\xf8\xf7\x00\xf0\x0f\x5f\xcf\xe6\x85\xca\xae\x67\x20\x0c\x58\x94\x45\x4f\xc0\x2c\xac\x97\x22\x5b\xd2\x92\x75\x47\x21\x67\x96\xb9\x4d\x2b\x56\x88\xdc\x89\x75\xe7\x69\xf7\x3b\xbe\x18\xc3\x71\xf8\x36\x88\x72\x8f\xa0\x10\xc6\x82\x5a\x00\xcf\xaf\x38\x0a\x57\x5e\x90\x89\x67\x69\x79\x0c\x7f\x90\x65\x13\x7c\x78\xbf\x97\x0e\x9f\x49\xf4\xa1\x64\xde\x25\x05\x4c\xe4\x50\xb1\x2b\x21\x69\x25\x0a\xc0\x15\xee\x36\x8e\xe1\x22\x7c\x1b\x7c\x1c\x0c\x48\xb5\x11\xf2\xaa\x08\x4e\x83\xe6\xa6\x52\xd2\xf0\x61\x17\x0c\xa7\xb2\x81\x61\xca\x39\x2c\xad\xad\xcc\x78\x34\xca\x55\x66\x86\xf9\xa2\xc6\x23\x42\x8d\xb8\x32\xf8\x59\xd5\xf3\x42\x64\x0f\x58\x25\xcc\x48\xf3\x05\xd7\x5c\x66\x7c\x64\x38\xd3\xd9\x72\x94\xd5\xda\x28\x9d\x3c\xf3\x8f\x63\x98\x5a\x8d\x76\x34\x5e\xb9\x58\x79\x93\xd4\xfc\x2f\x8c\xc3\x30\x1e\x90\x2a\xe7\x63\xff\x6a\xaf\xef\x03\x01\xbb\xc5\x87\x08\x78\xdb\x85\x0f\x35\x97\x56\xb0\x02\x64\x5d\xce\xb9\x76\xe0\xdb\x25\xc6\xcc\x07\xd5\x61\x89\x16\x64\x4b\x26\x64\xa3\x9a\x76\x8e\xe1\x57\x21\xed\xb3\x27\xc1\x56\x91\x37\xc6\x6f\x83\xb4\x0b\x64\x63\x01\xe6\x97\xd5\x2c\xb3\xa8\x07\x33\x28\xd3\x9c\x59\x9e\xb7\x72\x48\x0c\xf9\x70\x0c\x04\xe8\xd0\x46\x41\x84\x58\x38\xb8\x89\xd9\xf4\xb6\x9c\xab\x82\x3c\x21\x11\x41\xc6\xe4\xed\x34\x9e\x35\xb4\x63\x0b\xda\xb4\xbf\xd2\x3c\x13\xa6\x9d\x35\x71\xc1\xfb\xfc\xf8\x51\xff\x44\xb4\x05\x93\xdd\xd4\x0e\x1a\xb2\x37\x1e\x8f\x8b\x7d\x6d\xe7\x0d\xe2\x24\x65\xa9\x8a\x9c\x37\x29\x11\x1e\x7b\x38\x47\x9d\x07\x06\x4a\x76\x23\xca\xba\x04\x53\x57\x55\x71\x1b\x8f\x85\xd5\x29\x2d\x7e\xeb\x6b\x62\x0c\x47\xd3\xe9\xe4\xf2\xcf\x57\x6f\xdf\xbd\x39\xba\x84\x9f\xfd\xe3\x77\x3b\x00\x38\x70\x95\x67\x31\x25\xba\x82\x69\xed\x7e\x62\x7d\x22\xec\xe6\x9b\xa3\x2c\x53\xb5\xb4\x70\xcc\x0a\x86\xb5\x91\x72\x24\xac\x87\xe5\xaf\x4c\x41\x2c\x18\x39\xf7\xd6\x6c\x90\x51\xd7\xd8\x2f\xcb\x4a\x9b\xba\xbf\x3c\x3d\xf5\xf0\xd9\x4e\x54\x5d\x43\x29\x33\xb6\x79\xf0\x9f\xf2\x42\xcc\x36\x57\x58\xf8\xa2\x11\x16\x8f\x06\x4f\xbe\x2e\xa3\x1c\x95\x64\x24\xf1\x70\x74\x76\xc9\x8b\x1c\x84\xe7\xe2\x60\x64\xca\x65\x0f\xdc\x7d\x0b\x9d\xfe\x5f\x68\xe1\xe2\xb5\x40\x9a\xe7\x50\x4b\xd1\xd3\x1a\x14\xa6\xdc\xf0\xcb\x87\x80\xac\x24\x56\x18\x8c\x85\x56\x25\x1e\x7d\x40\xdd\x01\x5e\x4e\x7e\x87\x4a\xa9\xc2\x1c\xc2\x4c\xd6\x45\x31\x23\x15\xeb\x25\x0a\x92\x8a\xde\x80\x56\xb5\xe5\xe8\x3b\x6a\x35\x8d\x9e\x8e\x49\xf4\x32\xf4\x35\x32\x2f\x59\xfc\x1b\x2b\x6a\x1e\x0d\x8c\xb9\xb6\xcb\x44\x6f\x81\xd7\xde\x38\x94\x31\x29\x15\x26\x6a\xb0\x21\xf1\xc1\xca\x89\x1e\xc3\xab\x42\x31\x1b\x2a\x2f\x41\xc3\xb6\x7a\xed\x95\x1a\xae\x57\xe8\x09\x01\xd8\x47\xa1\xdb\xef\xbc\xb8\xcd\xe4\xee\xe3\xdd\xd0\x17\x59\xe8\x98\x8b\xdf\x60\xb2\x18\x83\x9a\x77\xa6\x7c\x72\xff\xe4\x2e\x3d\xf1\x9e\xfa\x5a\x49\x9e\xf4\x4c\x77\x64\x7b\x8a\xd1\xf6\x84\x6a\x89\xde\x1a\xb7\x5e\x12\x50\x3c\x1a\xe1\x17\x0e\x56\x40\xff\x56\xd8\x15\x1d\x17\xa8\x60\x74\x52\x70\x08\xcc\xc0\xec\xb9\x51\xb5\xce\xf8\x8b\xd1\x73\x4a\x3a\x91\xbf\x98\x1d\x82\xb1\x4c\x5b\x57\xf4\x29\x7c\x5d\x04\x5d\x66\x22\x97\x07\x87\xde\xc7\xd6\x75\xe2\x69\x10\x0d\xfd\x50\x0b\xed\x95\x3a\x82\x11\x12\xfd\xe4\x02\xe5\x68\xd7\xca\xd6\x4c\xe7\x80\x1d\x6d\xce\xb2\x6b\xf7\xdd\x78\x35\x2c\x35\x93\xc0\xf9\x28\x80\x17\xbc\xc4\x89\xab\xc9\x8f\x48\xf9\x4d\x76\x78\xb2\x8d\xd0\x2d\x84\x46\x19\xe1\x58\x5c\x74\x72\x0f\x01\xe9\x1c\x10\x63\xb4\xc9\xb3\x76\x84\x55\x55\x15\x26\x09\x96\x5a\x8e\x36\x67\xed\x4e\x43\x30\x9c\xf4\xc8\x7c\xab\xda\x82\xfd\xbb\xd6\x88\x44\xea\x88\x32\xdf\x21\x5b\xc8\x5c\x64\xe8\xbf\x71\x25\x49\xa8\xb9\x0f\xca\x37\x06\x92\xdf\x58\xea\x81\x69\xf2\x61\xe6\x1c\xd7\x1c\x32\xd8\xa2\x31\x88\x9c\xc9\xbb\x89\xc2\xfc\x5a\x09\x55\x9b\xbe\xb8\x8b\xb0\xde\x13\x89\x41\xe6\x38\xcd\x76\x49\x33\xc5\x81\x68\x01\xd6\x18\xe5\x50\x21\x81\xb3\x81\xc9\x3c\x10\xbe\xab\x11\x38\x78\xf8\xe8\xf1\x93\xe1\xd3\x67\x3f\xfc\xe8\x8a\xe5\x20\xaa\x25\xa1\xe0\xff\xbe\x01\xb7\xe7\xe9\x10\xf7\xfc\xe4\x36\x6d\xaa\xc0\x04\xec\x69\xc1\x58\x34\x4a\x86\x5e\x8b\x53\x92\x14\x9c\x9d\x5f\x4e\x7e\x99\xbc\x6b\x2b\x70\xf2\xef\x64\xbe\xe3\x41\xb7\xba\xa1\x61\xd8\x51\xf1\x72\x72\x72\xf6\xe6\xe8\xf5\x86\x0f\xa1\x3c\x5e\x62\x42\x68\x31\xaf\x69\xbc\x09\x49\x12\xc6\xd6\x16\x77\xc2\xfc\x36\x12\x76\x97\x15\x4f\x69\x6b\x47\x48\x02\xff\x78\x73\xa2\x8b\x03\x18\xac\x5d\xbc\x71\x22\xf8\xc4\x40\xd7\xd0\xc6\xff\xfc\x82\xd1\x5c\x12\x42\xe3\x37\xbb\xe7\x97\x9d\x37\x86\x29\xe6\xb8\x93\x50\x14\x29\x3c\xfd\x69\x96\xa6\x7b\x3f\x7b\xdc\x77\x96\x38\x0d\x22\xfd\x74\xe5\xb2\x8b\xf9\x19\x86\xc6\x96\x8a\xeb\xd4\xae\xe7\x75\x76\xcd\x91\x42\x52\x24\xe7\xbc\x50\xeb\x16\xcd\xcd\xf1\x1c\x1e\xc7\xf0\xba\x04\x25\x81\x4d\x9b\xf6\xa7\xd3\xfd\xdf\xab\x3d\xa6\xc5\xbd\xd6\xc8\xfd\x9a\x69\x1c\xcc\x6d\xf4\xf4\xd0\xa5\x5f\xce\x4d\x86\xec\xe4\x50\x6b\x65\xa2\xf7\xbb\x3a\x8d\xb0\xf5\x46\xf9\xb6\xd0\x80\x60\x27\x0d\xf1\x79\xd6\x1c\x9f\x6d\x4a\xfc\x3c\x38\xa7\x4b\xe7\xbf\x17\x9e\x62\x32\xf3\x60\xa2\x2b\x6d\x95\xe8\x1b\xb7\x6b\x8e\x29\xf5\x3d\xe1\xfe\x70\xd3\x06\x92\x96\x7a\xa9\x2f\xdb\x18\xb0\xf5\x52\x99\xd6\x2c\x65\x88\x2c\xb0\x87\x30\xd0\x4c\x5e\x6d\x2d\x56\x0f\x77\x53\xa6\x67\x32\x2b\x6a\x83\x83\x11\x60\x20\x5d\xa4\x29\x80\x7d\xa4\xa8\x2f\xb9\x1f\x15\x42\xfc\xdd\x33\x55\x82\x9b\x11\x52\x0a\x96\x42\x06\xe4\x37\x7a\xff\xe4\x26\xaa\xc1\xfb\xe7\x67\xaa\x49\x53\xa1\xbb\x7b\xa6\x16\xe7\xf7\xb6\x2e\xcf\x5d\x13\x06\x77\x2a\xac\x4f\x58\x91\xee\x16\xf7\xca\x85\x6e\x9c\xb2\x78\x23\x88\x84\xaa\x56\xae\xed\x89\x72\x6b\x88\x4e\x91\x4f\x95\xbe\xed\x53\x69\x5d\xb9\x96\x1d\xd8\xd4\xed\x2e\xb9\x75\x0d\x53\x65\x7e\x24\x4b\xbf\xf3\xdc\x85\x54\xa7\xac\xac\x0a\xee\x6b\x8b\x35\xa5\x45\x8a\xfc\xcf\x43\x89\x09\xfd\xce\x6e\xd1\xfa\xe3\x61\xcc\xea\xdb\xef\x5f\x06\xf3\x49\x24\x72\x60\x03\x7d\x5a\xbd\x44\xff\xf1\xa6\x80\x9f\x7b\xdb\x83\xf4\x71\xf0\x0f\x95\xeb\xb8\xa1\xed\x14\x00\x00")
 
 func tokenmetaGraphqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -252,7 +294,7 @@ func tokenmetaGraphql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "tokenmeta.graphql", size: 2848, mode: os.FileMode(436), modTime: time.Unix(1614264387, 0)}
+	info := bindataFileInfo{name: "tokenmeta.graphql", size: 5357, mode: os.FileMode(436), modTime: time.Unix(1792395792, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -332,10 +374,12 @@ var _bindata = map[string]func() (*asset, error){
 	"accounthist.graphql": accounthistGraphql,
 	"block.graphql": blockGraphql,
 	"blockmeta.graphql": blockmetaGraphql,
+	"nftmeta.graphql": nftmetaGraphql,
 	"query.graphql": queryGraphql,
 	"query_alpha.graphql": query_alphaGraphql,
 	"schema.graphql": schemaGraphql,
 	"search_transaction.graphql": search_transactionGraphql,
+	"statedb.graphql": statedbGraphql,
 	"subscription.graphql": subscriptionGraphql,
 	"tokenmeta.graphql": tokenmetaGraphql,
 	"transactions.graphql": transactionsGraphql,
@@ -384,10 +428,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"accounthist.graphql": &bintree{accounthistGraphql, map[string]*bintree{}},
 	"block.graphql": &bintree{blockGraphql, map[string]*bintree{}},
 	"blockmeta.graphql": &bintree{blockmetaGraphql, map[string]*bintree{}},
+	"nftmeta.graphql": &bintree{nftmetaGraphql, map[string]*bintree{}},
 	"query.graphql": &bintree{queryGraphql, map[string]*bintree{}},
 	"query_alpha.graphql": &bintree{query_alphaGraphql, map[string]*bintree{}},
 	"schema.graphql": &bintree{schemaGraphql, map[string]*bintree{}},
 	"search_transaction.graphql": &bintree{search_transactionGraphql, map[string]*bintree{}},
+	"statedb.graphql": &bintree{statedbGraphql, map[string]*bintree{}},
 	"subscription.graphql": &bintree{subscriptionGraphql, map[string]*bintree{}},
 	"tokenmeta.graphql": &bintree{tokenmetaGraphql, map[string]*bintree{}},
 	"transactions.graphql": &bintree{transactionsGraphql, map[string]*bintree{}},
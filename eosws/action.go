@@ -17,6 +17,9 @@ package eosws
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/streamingfast/bstream"
 	"github.com/streamingfast/bstream/forkable"
@@ -24,12 +27,26 @@ import (
 	"github.com/dfuse-io/dfuse-eosio/eosws/mdl"
 	"github.com/dfuse-io/dfuse-eosio/eosws/metrics"
 	"github.com/dfuse-io/dfuse-eosio/eosws/wsmsg"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	_ "github.com/eoscanada/eos-go/forum"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/streamingfast/opaque"
 )
 
 func (ws *WSConn) onGetActionTraces(ctx context.Context, msg *wsmsg.GetActionTraces) {
+	var cursor *actionCursor
+	if msg.Data.Cursor != "" {
+		var err error
+		if cursor, err = actionCursorFromOpaque(msg.Data.Cursor); err != nil {
+			ws.EmitErrorReply(ctx, msg, AppInvalidCursorError(ctx, msg.Data.Cursor, err))
+			return
+		}
+
+		// Authorize the resumed stream like one starting at the cursor's block
+		msg.StartBlock = int64(cursor.startBlockNum())
+	}
+
 	authReq, ok := ws.AuthorizeRequest(ctx, msg)
 	if !ok {
 		return
@@ -46,119 +63,306 @@ func (ws *WSConn) onGetActionTraces(ctx context.Context, msg *wsmsg.GetActionTra
 		msg.Data.ActionNames = string(msg.Data.ActionName)
 	}
 
+	emitter, err := newActionTraceEmitter(ctx, ws, msg)
+	if err != nil {
+		ws.EmitErrorReply(ctx, msg, AppInvalidFilterError(ctx, msg.Data.Filter, err))
+		return
+	}
+
+	var handler bstream.Handler = bstream.HandlerFunc(func(block *bstream.Block, obj interface{}) error {
+		fObj := obj.(*forkable.ForkableObject)
+		return emitter.emitBlock(block.ToNative().(*pbcodec.Block), fObj.Step, fObj.Cursor(), nil)
+	})
+
+	steps := actionTraceSteps(msg)
+	if freq := msg.WithProgress; freq != 0 {
+		progHandler := NewProgressHandler(handler, ws, msg, ctx)
+		if msg.IrreversibleOnly {
+			progHandler.SetStepFilter(forkable.StepIrreversible)
+		}
+		handler = progHandler
+	}
+
+	var source bstream.Source
+	if cursor != nil {
+		// The forkable restores its state from the cursor and resumes after
+		// its block, the actions of that block not yet sent are emitted when
+		// it flows in, before the forkable gets it.
+		forkableHandler := forkable.New(handler, forkable.WithLogger(zlog), forkable.WithFilters(steps), forkable.FromCursor(cursor.forkCursor))
+		resumed := false
+		resumeHandler := bstream.HandlerFunc(func(block *bstream.Block, obj interface{}) error {
+			if !resumed && block.ID() == cursor.forkCursor.Block.ID() {
+				resumed = true
+				if err := emitter.emitBlock(block.ToNative().(*pbcodec.Block), cursor.forkCursor.Step, cursor.forkCursor, cursor); err != nil {
+					return err
+				}
+			}
+
+			return forkableHandler.ProcessBlock(block, obj)
+		})
+
+		source = ws.subscriptionHub.NewSourceFromBlockNumWithOpts(cursor.startBlockNum(), resumeHandler, bstream.JoiningSourceTargetBlockID(cursor.forkCursor.LIB.ID()), bstream.JoiningSourceRateLimit(300, ws.filesourceBlockRateLimit))
+	} else {
+		irrID, err := ws.irreversibleFinder.IrreversibleIDAtBlockNum(ctx, authReq.StartBlockNum)
+		if err != nil {
+			ws.EmitErrorReply(ctx, msg, derr.Wrap(err, "unable to retrieve irreversibility"))
+			return
+		}
+
+		irrRef := bstream.NewBlockRefFromID(irrID)
+		blocknumGate := bstream.NewBlockNumGate(uint64(authReq.StartBlockNum), bstream.GateInclusive, handler, bstream.GateOptionWithLogger(zlog))
+		forkableHandler := forkable.New(blocknumGate, forkable.WithLogger(zlog), forkable.WithFilters(steps), forkable.WithExclusiveLIB(irrRef))
+
+		source = ws.subscriptionHub.NewSourceFromBlockNumWithOpts(irrRef.Num(), forkableHandler, bstream.JoiningSourceTargetBlockID(irrRef.ID()), bstream.JoiningSourceRateLimit(300, ws.filesourceBlockRateLimit))
+	}
+
+	metrics.IncListeners("get_action_traces")
+	source.OnTerminating(func(_ error) {
+		metrics.CurrentListeners.Dec("get_action_traces")
+	})
+	err = ws.RegisterListener(ctx, msg.ReqID, func() error {
+		source.Shutdown(nil)
+		return nil
+	})
+	if err != nil {
+		source.Shutdown(nil) // important to ensure that OnRunFunc is run
+		ws.EmitErrorReply(ctx, msg, derr.Wrap(err, "unable to register listener to ws connection"))
+		return
+	}
+
+	ws.EmitReply(ctx, msg, wsmsg.NewListening(authReq.StartBlockNum))
+	go source.Run()
+}
+
+// actionTraceSteps returns the fork steps streamed to the client, only `new` ones
+// unless it opted in for `undo` and `redo` ones, with `data.with_undo` or by resuming
+// from a cursor.
+func actionTraceSteps(msg *wsmsg.GetActionTraces) forkable.StepType {
+	if msg.IrreversibleOnly {
+		return forkable.StepIrreversible
+	}
+
+	if msg.Data.WithUndo || msg.Data.Cursor != "" {
+		return forkable.StepNew | forkable.StepUndo | forkable.StepRedo
+	}
+
+	return forkable.StepNew
+}
+
+type actionTraceEmitter struct {
+	ctx     context.Context
+	emitter Emitter
+	msg     *wsmsg.GetActionTraces
+
+	targetAccounts  map[string]bool
+	targetReceivers map[string]bool
+	targetActions   map[string]bool
+	targetAuths     map[string]bool
+	targetDBTables  map[string]bool
+	targetDBScopes  map[string]bool
+	filter          *filtering.CELFilter
+}
+
+func newActionTraceEmitter(ctx context.Context, emitter Emitter, msg *wsmsg.GetActionTraces) (*actionTraceEmitter, error) {
 	// Support multiple things
 	targetAccounts := mapString(msg.Data.Accounts)
 	targetReceivers := targetAccounts
 	if msg.Data.Receivers != "" {
 		targetReceivers = mapString(msg.Data.Receivers)
 	}
-	targetActions := mapString(msg.Data.ActionNames)
 
-	handler := bstream.HandlerFunc(func(block *bstream.Block, _ interface{}) error {
-		blk := block.ToNative().(*pbcodec.Block)
+	filter, err := filtering.NewActionTraceFilter(msg.Data.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &actionTraceEmitter{
+		ctx:             ctx,
+		emitter:         emitter,
+		msg:             msg,
+		targetAccounts:  targetAccounts,
+		targetReceivers: targetReceivers,
+		targetActions:   mapString(msg.Data.ActionNames),
+		targetAuths:     mapString(msg.Data.Auths),
+		targetDBTables:  mapString(msg.Data.DBTables),
+		targetDBScopes:  mapString(msg.Data.DBScopes),
+		filter:          filter,
+	}, nil
+}
+
+// emitBlock emits the matching actions of `blk`, skipping the ones up to
+// the position of `after` when set.
+func (e *actionTraceEmitter) emitBlock(blk *pbcodec.Block, step forkable.StepType, forkCursor *forkable.Cursor, after *actionCursor) error {
+	msg := e.msg
+	for trxIdx, trx := range blk.TransactionTraces() {
+		if trx.Receipt == nil || trx.Receipt.Status != pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED {
+			// We do **not** stream transaction for that are not properly executed
+			continue
+		}
+
+		actionMatcher := blk.FilteringActionMatcher(trx)
+		memoizableTrx := &filtering.MemoizableTrxTrace{TrxTrace: trx}
 
-		for _, trx := range blk.TransactionTraces() {
-			if trx.Receipt == nil || trx.Receipt.Status != pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED {
-				// We do **not** stream transaction for that are not properly executed
+		allActions := trx.ActionTraces
+		for actIdx, act := range allActions {
+			if after != nil && !after.isBefore(trxIdx, actIdx) {
 				continue
 			}
 
-			actionMatcher := blk.FilteringActionMatcher(trx)
+			if !actionMatcher.Matched(act.ExecutionIndex) {
+				continue
+			}
 
-			allActions := trx.ActionTraces
-			for actIdx, act := range allActions {
-				if !actionMatcher.Matched(act.ExecutionIndex) {
-					continue
-				}
+			if !e.targetReceivers[string(act.Receiver)] {
+				continue
+			}
 
-				if !targetReceivers[string(act.Receiver)] {
-					continue
-				}
+			if !e.targetAccounts[string(act.Action.Account)] {
+				continue
+			}
 
-				if !targetAccounts[string(act.Action.Account)] {
-					continue
-				}
+			if msg.Data.ActionNames != "" && !e.targetActions[string(act.Action.Name)] {
+				continue
+			}
 
-				if msg.Data.ActionNames != "" && !targetActions[string(act.Action.Name)] {
-					continue
-				}
+			if !e.matchAuths(act) || !e.matchDBOps(trx, act) {
+				continue
+			}
 
-				rawTrace, err := mdl.ToV1ActionTraceRaw(act, allActions, msg.Data.WithInlineTraces)
-				if err != nil {
-					return err
-				}
+			if !e.filter.MatchActionTrace(act, memoizableTrx, step.String()) {
+				continue
+			}
 
-				out := wsmsg.NewActionTrace(trx.Id, actIdx /* , act.Depth() */, json.RawMessage(rawTrace))
-				out.Data.BlockNum = blk.Number
-				out.Data.BlockID = blk.Id
-				stamp, _ := ptypes.Timestamp(blk.Header.Timestamp)
-				out.Data.BlockTime = stamp
+			rawTrace, err := mdl.ToV1ActionTraceRaw(act, allActions, msg.Data.WithInlineTraces)
+			if err != nil {
+				return err
+			}
 
-				if msg.Data.WithRAMOps {
-					out.Data.RAMOps = mdl.ToV0RAMOps(trx.RAMOpsForAction(act.ExecutionIndex))
-				}
-				// TODO: we want that to reflect `eosws-go`'s `DTrxOp`
-				if msg.Data.WithDTrxOps {
-					out.Data.DTrxOps = mdl.ToV0DTrxOps(trx.DtrxOpsForAction(act.ExecutionIndex))
-				}
-				// TODO: we need to do JSON encoding with the ABI
-				// valid here (?)  TODO: we need to rewrite the
-				// `DBOps` because we don't want to send them in the
-				// `hlog.DBOp` format, but in the `eosws-go:v1.DBOp`
-				// format.
-				if msg.Data.WithDBOps {
-					out.Data.DBOps = mdl.ToV0DBOps(trx.DBOpsForAction(act.ExecutionIndex))
-				}
+			out := wsmsg.NewActionTrace(trx.Id, actIdx /* , act.Depth() */, json.RawMessage(rawTrace))
+			out.Data.BlockNum = blk.Number
+			out.Data.BlockID = blk.Id
+			stamp, _ := ptypes.Timestamp(blk.Header.Timestamp)
+			out.Data.BlockTime = stamp
+			out.Data.Step = step.String()
+			out.Data.Cursor = (&actionCursor{forkCursor: forkCursor, trxIndex: trxIdx, actionIndex: actIdx}).toOpaque()
 
-				if msg.Data.WithTableOps {
-					out.Data.TableOps = mdl.ToV0TableOps(trx.TableOpsForAction(act.ExecutionIndex))
-				}
+			if msg.Data.WithRAMOps {
+				out.Data.RAMOps = mdl.ToV0RAMOps(trx.RAMOpsForAction(act.ExecutionIndex))
+			}
+			// TODO: we want that to reflect `eosws-go`'s `DTrxOp`
+			if msg.Data.WithDTrxOps {
+				out.Data.DTrxOps = mdl.ToV0DTrxOps(trx.DtrxOpsForAction(act.ExecutionIndex))
+			}
+			// TODO: we need to do JSON encoding with the ABI
+			// valid here (?)  TODO: we need to rewrite the
+			// `DBOps` because we don't want to send them in the
+			// `hlog.DBOp` format, but in the `eosws-go:v1.DBOp`
+			// format.
+			if msg.Data.WithDBOps {
+				out.Data.DBOps = mdl.ToV0DBOps(trx.DBOpsForAction(act.ExecutionIndex))
+			}
 
-				metrics.DocumentResponseCounter.Inc()
-				ws.EmitReply(ctx, msg, out)
+			if msg.Data.WithTableOps {
+				out.Data.TableOps = mdl.ToV0TableOps(trx.TableOpsForAction(act.ExecutionIndex))
 			}
+
+			metrics.DocumentResponseCounter.Inc()
+			e.emitter.EmitReply(e.ctx, msg, out)
 		}
+	}
 
-		return nil
-	})
+	return nil
+}
 
-	irrID, err := ws.irreversibleFinder.IrreversibleIDAtBlockNum(ctx, authReq.StartBlockNum)
-	if err != nil {
-		ws.EmitErrorReply(ctx, msg, derr.Wrap(err, "unable to retrieve irreversibility"))
-		return
+func (e *actionTraceEmitter) matchAuths(act *pbcodec.ActionTrace) bool {
+	if len(e.targetAuths) == 0 {
+		return true
 	}
 
-	if freq := msg.WithProgress; freq != 0 {
-		progHandler := NewProgressHandler(handler, ws, msg, ctx)
-		if msg.IrreversibleOnly {
-			progHandler.SetStepFilter(forkable.StepIrreversible)
+	for _, auth := range act.Action.Authorization {
+		if e.targetAuths[auth.Actor] || e.targetAuths[auth.Authorization()] {
+			return true
 		}
-		handler = progHandler.ProcessBlock
 	}
+	return false
+}
 
-	if msg.IrreversibleOnly {
-		forkableHandler := forkable.New(handler, forkable.WithLogger(zlog), forkable.WithFilters(forkable.StepIrreversible))
-		handler = forkableHandler.ProcessBlock
+// matchDBOps returns whether one of the db ops of `act` is on one of the
+// targeted tables and scopes
+func (e *actionTraceEmitter) matchDBOps(trx *pbcodec.TransactionTrace, act *pbcodec.ActionTrace) bool {
+	if len(e.targetDBTables) == 0 && len(e.targetDBScopes) == 0 {
+		return true
 	}
 
-	blocknumGate := bstream.NewBlockNumGate(uint64(authReq.StartBlockNum), bstream.GateInclusive, handler, bstream.GateOptionWithLogger(zlog))
-	metrics.IncListeners("get_action_traces")
-	irrRef := bstream.NewBlockRefFromID(irrID)
-	source := ws.subscriptionHub.NewSourceFromBlockNumWithOpts(irrRef.Num(), blocknumGate, bstream.JoiningSourceTargetBlockID(irrRef.ID()), bstream.JoiningSourceRateLimit(300, ws.filesourceBlockRateLimit))
+	for _, op := range trx.DBOpsForAction(act.ExecutionIndex) {
+		if len(e.targetDBTables) != 0 && !e.targetDBTables[op.TableName] {
+			continue
+		}
+		if len(e.targetDBScopes) != 0 && !e.targetDBScopes[op.Scope] {
+			continue
+		}
+		return true
+	}
+	return false
+}
 
-	source.OnTerminating(func(_ error) {
-		metrics.CurrentListeners.Dec("get_action_traces")
-	})
-	err = ws.RegisterListener(ctx, msg.ReqID, func() error {
-		source.Shutdown(nil)
-		return nil
-	})
+// actionCursor points to an action of a block, at a given fork step. It
+// is the forkable cursor of the block the action belongs to, along with
+// the position of the action in the block.
+type actionCursor struct {
+	forkCursor  *forkable.Cursor
+	trxIndex    int
+	actionIndex int
+}
+
+func actionCursorFromOpaque(in string) (*actionCursor, error) {
+	payload, err := opaque.DecodeToString(in)
 	if err != nil {
-		source.Shutdown(nil) // important to ensure that OnRunFunc is run
-		ws.EmitErrorReply(ctx, msg, derr.Wrap(err, "unable to register listener to ws connection"))
-		return
+		return nil, fmt.Errorf("unable to decode: %w", err)
 	}
 
-	ws.EmitReply(ctx, msg, wsmsg.NewListening(authReq.StartBlockNum))
-	go source.Run()
+	parts := strings.SplitN(payload, ":", 4)
+	if len(parts) != 4 || parts[0] != "a1" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	trxIndex, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction index: %w", err)
+	}
+
+	actionIndex, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid action index: %w", err)
+	}
+
+	forkCursor, err := forkable.CursorFromString(parts[3])
+	if err != nil {
+		return nil, err
+	}
+	if forkCursor.IsEmpty() {
+		return nil, fmt.Errorf("invalid cursor: empty block reference")
+	}
+
+	return &actionCursor{forkCursor: forkCursor, trxIndex: trxIndex, actionIndex: actionIndex}, nil
+}
+
+func (c *actionCursor) toOpaque() string {
+	return opaque.EncodeString(fmt.Sprintf("a1:%d:%d:%s", c.trxIndex, c.actionIndex, c.forkCursor))
+}
+
+// startBlockNum is the block the source must start at so that both the
+// cursor's block and its LIB flow in
+func (c *actionCursor) startBlockNum() uint64 {
+	num := c.forkCursor.LIB.Num()
+	if blockNum := c.forkCursor.Block.Num(); blockNum < num {
+		num = blockNum
+	}
+	return num
+}
+
+// isBefore returns whether the cursor's action comes before the action
+// `actIdx` of transaction `trxIdx`
+func (c *actionCursor) isBefore(trxIdx, actIdx int) bool {
+	return c.trxIndex < trxIdx || (c.trxIndex == trxIdx && c.actionIndex < actIdx)
 }
@@ -16,6 +16,8 @@ package eosws
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -28,8 +30,10 @@ import (
 	"time"
 
 	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/forkable"
 	"github.com/streamingfast/bstream/hub"
 	"github.com/dfuse-io/dfuse-eosio/codec"
+	"github.com/dfuse-io/dfuse-eosio/eosws/wsmsg"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
 	"github.com/streamingfast/dstore"
@@ -37,6 +41,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/gorilla/websocket"
 	"github.com/streamingfast/dauth/authenticator"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/sjson"
 )
@@ -57,7 +62,8 @@ func TestOnGetActionsTraces(t *testing.T) {
 	actionTraceRespWithBlock := func(blockID string, reqID string, index int, trace string) string {
 		ref := bstream.NewBlockRefFromID(blockID)
 
-		out := fmt.Sprintf(`{"type":"action_trace","req_id":%q,"data":{"block_num":%d,"block_id":"%s","block_time":"0001-01-01T00:00:00Z","trx_id":"trx.1","idx":%d,"trace":%s}`, reqID, ref.Num(), blockID, index, trace)
+		cursor := testActionCursor(blockID, "00000001a", 0, index)
+		out := fmt.Sprintf(`{"type":"action_trace","req_id":%q,"data":{"block_num":%d,"block_id":"%s","block_time":"0001-01-01T00:00:00Z","trx_id":"trx.1","idx":%d,"step":"new","cursor":%q,"trace":%s}`, reqID, ref.Num(), blockID, index, cursor, trace)
 		out, _ = sjson.SetRaw(out, "data.trace.closest_unnotified_ancestor_action_ordinal", "0")
 		out, _ = sjson.SetRaw(out, "data.trace.console", `""`)
 		out, _ = sjson.SetRaw(out, "data.trace.block_num", `0`)
@@ -239,6 +245,178 @@ func TestOnGetActionsTraces(t *testing.T) {
 	}
 }
 
+func TestOnGetActionsTraces_Cursor(t *testing.T) {
+	statusExecuted := pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED
+
+	tests := []struct {
+		name            string
+		cursor          string
+		expectedActions []string
+		expectedError   string
+	}{
+		{
+			name:            "resumes after cursor action",
+			cursor:          testActionCursor("00000002a", "00000002a", 0, 1),
+			expectedActions: []string{"00000002a:2:new", "00000002a:3:new", "00000003a:0:new"},
+		},
+		{
+			name:            "resumes after cursor block",
+			cursor:          testActionCursor("00000002a", "00000002a", 0, 3),
+			expectedActions: []string{"00000003a:0:new"},
+		},
+		{
+			name:          "invalid cursor",
+			cursor:        "abc",
+			expectedError: "app_invalid_cursor_error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			archiveStore := dstore.NewMockStore(nil)
+			archiveStore.SetFile("0000000000", acceptedBlockWithActions(t, "00000002a", statusExecuted,
+				"eosioknights:eosioknights:transfer",
+				"eosioknights:eosioknights:transfer",
+				"eosioknights:eosioknights:transfer",
+				"eosioknights:eosioknights:transfer",
+			))
+			archiveStore.SetFile("0000000100", acceptedBlockWithActions(t, "00000003a", statusExecuted,
+				"eosioknights:eosioknights:transfer",
+			))
+
+			subscriptionHub := newTestSubscriptionHub(t, 0, archiveStore)
+			handler := NewWebsocketHandler(nil, nil, nil, subscriptionHub, pbstatedb.NewMockStateClient(), nil, nil, nil, NewTestIrreversibleFinder("00000001a", nil), 0, 12)
+
+			conn, closer := newTestConnection(t, handler, &testCredentials{startBlock: 2})
+			defer closer()
+
+			reqID := strconv.Itoa(rand.Int())
+			msg := fmt.Sprintf(`{"type":"get_action_traces","req_id":%q,"listen":true,"data":{"accounts":"eosioknights","cursor":%q}}`, reqID, test.cursor)
+			require.NoError(t, conn.WriteMessage(1, []byte(msg)))
+			go subscriptionHub.Launch()
+
+			if test.expectedError != "" {
+				require.Contains(t, nextMessage(t, reqID, conn, 5*time.Second), test.expectedError)
+				return
+			}
+
+			require.Contains(t, nextMessage(t, reqID, conn, 5*time.Second), `"type":"listening"`)
+
+			var actions []string
+			for range test.expectedActions {
+				out := &wsmsg.ActionTrace{}
+				require.NoError(t, json.Unmarshal([]byte(nextMessage(t, reqID, conn, 5*time.Second)), out))
+				actions = append(actions, fmt.Sprintf("%s:%d:%s", out.Data.BlockID, out.Data.ActionIndex, out.Data.Step))
+
+				cursor, err := actionCursorFromOpaque(out.Data.Cursor)
+				require.NoError(t, err)
+				assert.Equal(t, out.Data.BlockID, cursor.forkCursor.Block.ID())
+				assert.Equal(t, out.Data.ActionIndex, cursor.actionIndex)
+			}
+			assert.Equal(t, test.expectedActions, actions)
+		})
+	}
+}
+
+func TestActionTraceEmitter_Filters(t *testing.T) {
+	trx := &pbcodec.TransactionTrace{
+		Id:      "trx.1",
+		Receipt: &pbcodec.TransactionReceiptHeader{Status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED},
+		ActionTraces: []*pbcodec.ActionTrace{
+			{Receiver: "club", ExecutionIndex: 0, Action: &pbcodec.Action{Account: "club", Name: "join", Authorization: []*pbcodec.PermissionLevel{{Actor: "alice", Permission: "active"}}}},
+			{Receiver: "club", ExecutionIndex: 1, Action: &pbcodec.Action{Account: "club", Name: "join", Authorization: []*pbcodec.PermissionLevel{{Actor: "bob", Permission: "owner"}}}},
+			{Receiver: "club", ExecutionIndex: 2, Action: &pbcodec.Action{Account: "club", Name: "leave", Authorization: []*pbcodec.PermissionLevel{{Actor: "bob", Permission: "active"}}}},
+		},
+		DbOps: []*pbcodec.DBOp{
+			{ActionIndex: 0, Code: "club", TableName: "members", Scope: "club"},
+			{ActionIndex: 1, Code: "club", TableName: "members", Scope: "vip"},
+			{ActionIndex: 2, Code: "club", TableName: "stats", Scope: "club"},
+		},
+	}
+
+	stamp, _ := ptypes.TimestampProto(time.Time{})
+	blk := &pbcodec.Block{Id: "00000002a", Number: 2, Header: &pbcodec.BlockHeader{Timestamp: stamp}, UnfilteredTransactionTraces: []*pbcodec.TransactionTrace{trx}}
+	block := bstream.NewBlockRefFromID("00000002a")
+	forkCursor := &forkable.Cursor{Step: forkable.StepNew, Block: block, HeadBlock: block, LIB: block}
+
+	tests := []struct {
+		name            string
+		data            string
+		expectedIndexes []int
+		expectedError   string
+	}{
+		{"no filter", `{"accounts":"club"}`, []int{0, 1, 2}, ""},
+		{"auth actor", `{"accounts":"club","auth":"bob"}`, []int{1, 2}, ""},
+		{"auth permission", `{"accounts":"club","auth":"alice@owner|bob@owner"}`, []int{1}, ""},
+		{"db table", `{"accounts":"club","db_table":"members"}`, []int{0, 1}, ""},
+		{"db table and scope", `{"accounts":"club","db_table":"members","db_scope":"club"}`, []int{0}, ""},
+		{"db scope", `{"accounts":"club","db_scope":"club"}`, []int{0, 2}, ""},
+		{"filter", `{"accounts":"club","filter":"action == 'join' && !('alice' in auth)"}`, []int{1}, ""},
+		{"filter and auth", `{"accounts":"club","auth":"bob","filter":"action == 'leave'"}`, []int{2}, ""},
+		{"invalid filter", `{"accounts":"club","filter":"unknown == 1"}`, nil, "undeclared reference to 'unknown'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := &wsmsg.GetActionTraces{}
+			require.NoError(t, json.Unmarshal([]byte(test.data), &msg.Data))
+
+			emitter := &testEmitter{}
+			actionEmitter, err := newActionTraceEmitter(context.Background(), emitter, msg)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NoError(t, actionEmitter.emitBlock(blk, forkable.StepNew, forkCursor, nil))
+
+			var indexes []int
+			for _, out := range emitter.replies {
+				indexes = append(indexes, out.(*wsmsg.ActionTrace).Data.ActionIndex)
+			}
+			assert.Equal(t, test.expectedIndexes, indexes)
+		})
+	}
+}
+
+func TestActionTraceSteps(t *testing.T) {
+	msg := func(data string) *wsmsg.GetActionTraces {
+		out := &wsmsg.GetActionTraces{}
+		require.NoError(t, json.Unmarshal([]byte(data), out))
+		return out
+	}
+
+	assert.Equal(t, forkable.StepNew, actionTraceSteps(msg(`{"data":{"accounts":"eosio"}}`)))
+	assert.Equal(t, forkable.StepNew|forkable.StepUndo|forkable.StepRedo, actionTraceSteps(msg(`{"data":{"accounts":"eosio","with_undo":true}}`)))
+	assert.Equal(t, forkable.StepNew|forkable.StepUndo|forkable.StepRedo, actionTraceSteps(msg(`{"data":{"accounts":"eosio","cursor":"abc"}}`)))
+	assert.Equal(t, forkable.StepIrreversible, actionTraceSteps(msg(`{"irreversible_only":true,"data":{"accounts":"eosio","with_undo":true}}`)))
+}
+
+type testEmitter struct {
+	replies []wsmsg.OutgoingMessager
+}
+
+func (e *testEmitter) Emit(ctx context.Context, msg wsmsg.OutgoingMessager) {}
+
+func (e *testEmitter) EmitReply(ctx context.Context, originatingMsg wsmsg.IncomingMessager, msg wsmsg.OutgoingMessager) {
+	e.replies = append(e.replies, msg)
+}
+
+func (e *testEmitter) EmitErrorReply(ctx context.Context, msg wsmsg.IncomingMessager, err error) {}
+
+func (e *testEmitter) EmitError(ctx context.Context, reqID string, err error) {}
+
+func testActionCursor(blockID, libID string, trxIndex, actionIndex int) string {
+	block := bstream.NewBlockRefFromID(blockID)
+	return (&actionCursor{
+		forkCursor:  &forkable.Cursor{Step: forkable.StepNew, Block: block, HeadBlock: block, LIB: bstream.NewBlockRefFromID(libID)},
+		trxIndex:    trxIndex,
+		actionIndex: actionIndex,
+	}).toOpaque()
+}
+
 type archiveFiles struct {
 	name    string
 	content []byte
@@ -386,6 +386,8 @@ func (db *TRXDB) GetAccount(ctx context.Context, name string) (account *pbcodec.
 type MockDB struct {
 	trxdb.TimelineExplorer
 	trxdb.TransactionsReader
+	trxdb.DeferredTransactionsReader
+	trxdb.ProducersReader
 	path string
 }
 
@@ -144,6 +144,22 @@ func AppTableRowsCannotFetchInFutureError(ctx context.Context, blockNum uint32)
 	)
 }
 
+func AppInvalidCursorError(ctx context.Context, cursor string, cause error) *derr.ErrorResponse {
+	return derr.HTTPBadRequestError(ctx, cause, derr.C("app_invalid_cursor_error"),
+		"The provided cursor is not valid.",
+		"cursor", cursor,
+		"reason", cause.Error(),
+	)
+}
+
+func AppInvalidFilterError(ctx context.Context, filter string, cause error) *derr.ErrorResponse {
+	return derr.HTTPBadRequestError(ctx, cause, derr.C("app_invalid_filter_error"),
+		"The provided filter is not valid.",
+		"filter", filter,
+		"reason", cause.Error(),
+	)
+}
+
 func AppUnableToGetIrreversibleBlockIDError(ctx context.Context, identifier string) *derr.ErrorResponse {
 	return derr.HTTPInternalServerError(ctx, nil, derr.C("data_unable_to_get_irreversible_block_id_error"),
 		"Unable to get irreversible block ID.",
@@ -15,6 +15,7 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -30,6 +31,7 @@ import (
 	"github.com/streamingfast/bstream/forkable"
 	"github.com/streamingfast/bstream/hub"
 	"github.com/dfuse-io/dfuse-eosio/codec"
+	eosio_v2_0 "github.com/dfuse-io/dfuse-eosio/codec/eosio/v2.0"
 	"github.com/dfuse-io/dfuse-eosio/eosws"
 	"github.com/dfuse-io/dfuse-eosio/eosws/mdl"
 	"github.com/dfuse-io/dfuse-eosio/eosws/metrics"
@@ -87,6 +89,10 @@ type PushResponse struct {
 	BlockID       string          `json:"block_id"`
 	BlockNum      uint32          `json:"block_num"`
 	Processed     json.RawMessage `json:"processed"`
+
+	// DBOpsUnavailable is set on dry-run responses, the traces of nodeos
+	// `compute_transaction` carrying no db ops, only RAM deltas
+	DBOpsUnavailable bool `json:"db_ops_unavailable,omitempty"`
 }
 
 func NewTxPusher(API *eos.API, subscriptionHub *hub.SubscriptionHub, headInfoHub *eosws.HeadInfoHub, retries int, extraAPIs []*eos.API) *TxPusher {
@@ -125,6 +131,57 @@ func (t *TxPusher) tryPush(API *eos.API, ctx context.Context, tx *eos.PackedTran
 	return nil
 }
 
+var errDryRunUnsupported = errors.New("dry-run requires the managed nodeos to expose /v1/chain/compute_transaction (EOSIO >= 2.1)")
+
+type computeTransactionRequest struct {
+	Transaction *eos.PackedTransaction `json:"transaction"`
+}
+
+// computeTransaction runs the transaction through nodeos speculative `compute_transaction`
+// endpoint (EOSIO >= 2.1), the transaction is executed against the current head state then
+// discarded, it is never broadcasted nor included in a block. Returns `errDryRunUnsupported`
+// when nodeos does not know the endpoint.
+func computeTransaction(ctx context.Context, API *eos.API, tx *eos.PackedTransaction) (json.RawMessage, error) {
+	body, err := json.Marshal(&computeTransactionRequest{Transaction: tx})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", API.BaseURL+"/v1/chain/compute_transaction", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	for k, v := range API.Header {
+		req.Header[k] = append(req.Header[k], v...)
+	}
+
+	resp, err := API.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", req.URL.String(), err)
+	}
+	defer resp.Body.Close()
+
+	cnt, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errDryRunUnsupported
+	}
+
+	if resp.StatusCode > 299 {
+		var apiErr eos.APIError
+		if err := json.Unmarshal(cnt, &apiErr); err != nil {
+			return nil, fmt.Errorf("%s: status code=%d, body=%s", req.URL.String(), resp.StatusCode, string(cnt))
+		}
+		return nil, apiErr
+	}
+
+	return cnt, nil
+}
+
 func isExpiredError(err eos.APIError) bool {
 	return err.ErrorStruct.Code == 3040005
 }
@@ -194,6 +251,11 @@ func (t *TxPusher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	trxID := trxIDCheckSum.String()
 
+	if guarantee == "dry-run" {
+		t.serveDryRun(ctx, tx, trxID, w)
+		return
+	}
+
 	liveSourceFactory := bstream.SourceFactory(func(handler bstream.Handler) bstream.Source {
 		return t.subscriptionHub.NewSource(handler, 10) // does not need joining
 	})
@@ -221,7 +283,7 @@ func (t *TxPusher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		expirationDelay += 6 * time.Minute
 		trxTraceFoundChan, shutdownFunc = awaitTransactionIrreversible(ctx, trxID, liveSourceFactory)
 	default:
-		msg := "unknown value for X-Eos-Push-Guarantee. Please use 'irreversible', 'in-block', 'handoff:1', 'handoffs:2', 'handoffs:3'"
+		msg := "unknown value for X-Eos-Push-Guarantee. Please use 'irreversible', 'in-block', 'handoff:1', 'handoffs:2', 'handoffs:3', 'dry-run'"
 		checkHTTPError(fmt.Errorf(msg), msg, eoserr.ErrUnhandledException, w)
 		return
 	}
@@ -333,24 +395,9 @@ func (t *TxPusher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case trxTrace := <-trxTraceFoundChan:
 			blockID := trxTrace.ProducerBlockId
 
-			var processed json.RawMessage
-			if os.Getenv("EOSWS_PUSH_V1_OUTPUT") == "true" {
-				v1tr, err := mdl.ToV1TransactionTrace(trxTrace)
-				if checkHTTPError(err, "cannot marshal response", eoserr.ErrUnhandledException, w) {
-					return
-				}
-				out, err := json.Marshal(v1tr)
-				if checkHTTPError(err, "cannot marshal response", eoserr.ErrUnhandledException, w) {
-					return
-				}
-				processed = out
-			} else {
-				eosTrace := codec.TransactionTraceToEOS(trxTrace)
-				out, err := json.Marshal(eosTrace)
-				if checkHTTPError(err, "cannot marshal response", eoserr.ErrUnhandledException, w) {
-					return
-				}
-				processed = out
+			processed, err := pushOutput(trxTrace)
+			if checkHTTPError(err, "cannot marshal response", eoserr.ErrUnhandledException, w) {
+				return
 			}
 
 			resp := &PushResponse{
@@ -373,6 +420,100 @@ func (t *TxPusher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// serveDryRun executes the transaction speculatively on the managed nodeos and returns its
+// trace, in the same format as a normal push, without broadcasting it. The trace is the one
+// of the speculative block the transaction was executed in, as such, `block_id` is always
+// empty. Nodeos does not report db ops for speculative executions, the response is flagged
+// with `db_ops_unavailable` so clients can tell them apart from a transaction touching no
+// table.
+func (t *TxPusher) serveDryRun(ctx context.Context, tx *eos.PackedTransaction, trxID string, w http.ResponseWriter) {
+	metrics.PushTrxCount.Inc("dry-run")
+
+	timedoutContext, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	computeResp, err := computeTransaction(timedoutContext, t.API, tx)
+	if err != nil {
+		metrics.FailedPushTrxCount.Inc("dry-run")
+
+		if err == errDryRunUnsupported {
+			checkHTTPError(err, err.Error(), eoserr.ErrUnhandledException, w, zap.String("trx_id", trxID))
+			return
+		}
+
+		if apiErr, ok := err.(eos.APIError); ok {
+			zlog.Info("dry-run transaction API error", append(logFieldsFromAPIErr(apiErr), zap.String("trx_id", trxID))...)
+			if apiErrCnt, err := json.Marshal(apiErr); err == nil {
+				w.WriteHeader(apiErr.Code)
+				w.Write(apiErrCnt)
+				return
+			}
+		}
+
+		checkHTTPError(err, fmt.Sprintf("cannot dry-run transaction %q on Nodeos API.", trxID), eoserr.ErrUnhandledException, w)
+		return
+	}
+
+	trxTrace, err := computedTransactionTrace(computeResp)
+	if err != nil {
+		metrics.FailedPushTrxCount.Inc("dry-run")
+		checkHTTPError(err, fmt.Sprintf("invalid trace returned by Nodeos API for dry-run of transaction %q", trxID), eoserr.ErrUnhandledException, w)
+		return
+	}
+
+	processed, err := pushOutput(trxTrace)
+	if checkHTTPError(err, "cannot marshal response", eoserr.ErrUnhandledException, w) {
+		return
+	}
+
+	resp := &PushResponse{
+		TransactionID:    trxID,
+		BlockNum:         uint32(trxTrace.BlockNum),
+		Processed:        processed,
+		DBOpsUnavailable: true,
+	}
+
+	out, err := json.Marshal(resp)
+	if checkHTTPError(err, "cannot marshal response", eoserr.ErrUnhandledException, w) {
+		return
+	}
+
+	metrics.SucceededPushTrxCount.Inc("dry-run")
+	w.Header().Set("content-length", fmt.Sprintf("%d", len(out)))
+	w.Write(out)
+}
+
+// computedTransactionTrace turns the `processed` trace of a `compute_transaction` response,
+// which is in nodeos format, into the trace format produced by the deep-mind reader.
+func computedTransactionTrace(computeResp json.RawMessage) (*pbcodec.TransactionTrace, error) {
+	processed := gjson.GetBytes(computeResp, "processed")
+	if !processed.Exists() {
+		return nil, errors.New("no trace in response")
+	}
+
+	var eosTrace *eos.TransactionTrace
+	if err := json.Unmarshal([]byte(processed.Raw), &eosTrace); err != nil {
+		return nil, fmt.Errorf("unmarshal trace: %w", err)
+	}
+
+	return eosio_v2_0.TransactionTraceToDEOS(zlog, eosTrace), nil
+}
+
+// pushOutput renders the transaction trace as the `processed` field of the push response,
+// in the v1 format when `EOSWS_PUSH_V1_OUTPUT` is `true`, in nodeos format otherwise.
+func pushOutput(trxTrace *pbcodec.TransactionTrace) (json.RawMessage, error) {
+	if os.Getenv("EOSWS_PUSH_V1_OUTPUT") == "true" {
+		v1tr, err := mdl.ToV1TransactionTrace(trxTrace)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(v1tr)
+	}
+
+	return json.Marshal(codec.TransactionTraceToEOS(trxTrace))
+}
+
 func writeDetailedAPIError(err error, msg string, errorCode int, errorName, errorWhat, detailMessage string, w http.ResponseWriter) {
 	fields := []zap.Field{
 		zap.Error(err),
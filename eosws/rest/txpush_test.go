@@ -15,6 +15,11 @@
 package rest
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,9 +33,102 @@ import (
 	eos "github.com/eoscanada/eos-go"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
 )
 
+func TestTxPusher_DryRun(t *testing.T) {
+	cases := []struct {
+		name           string
+		nodeosStatus   int
+		nodeosResponse string
+		expectedStatus int
+		expectedFields map[string]string
+	}{
+		{
+			name:           "executed",
+			nodeosStatus:   200,
+			nodeosResponse: `{"transaction_id":"0a","processed":{"id":"0a","block_num":11,"block_time":"2020-09-13T12:26:40.000","producer_block_id":null,"elapsed":120,"net_usage":96,"scheduled":false,"action_traces":[{"action_ordinal":1,"creator_action_ordinal":0,"closest_unnotified_ancestor_action_ordinal":0,"receipt":{"receiver":"eosio","act_digest":"0b","global_sequence":42,"recv_sequence":3,"auth_sequence":[],"code_sequence":1,"abi_sequence":1},"receiver":"eosio","act":{"account":"eosio","name":"buyram","authorization":[],"data":""},"context_free":false,"elapsed":80,"console":"","trx_id":"0a","block_num":11,"block_time":"2020-09-13T12:26:40.000","producer_block_id":null,"account_ram_deltas":[{"account":"eosio","delta":100}],"except":null,"error_code":null}],"account_ram_delta":null,"except":null,"error_code":null}}`,
+			expectedStatus: 200,
+			expectedFields: map[string]string{
+				"block_id":            "",
+				"block_num":           "11",
+				"db_ops_unavailable":  "true",
+				"processed.id":        "0a",
+				"processed.block_num": "11",
+				"processed.elapsed":   "120",
+				"processed.action_traces.0.receipt.global_sequence":    "42",
+				"processed.action_traces.0.act.name":                   "buyram",
+				"processed.action_traces.0.account_ram_deltas.0.delta": "100",
+			},
+		},
+		{
+			name:           "no trace",
+			nodeosStatus:   200,
+			nodeosResponse: `{"transaction_id":"0a"}`,
+			expectedStatus: 500,
+		},
+		{
+			name:           "unsupported nodeos",
+			nodeosStatus:   404,
+			nodeosResponse: `{"code":404,"message":"Not Found","error":{"code":0,"name":"exception","what":"unspecified","details":[{"message":"Unknown Endpoint"}]}}`,
+			expectedStatus: 500,
+			expectedFields: map[string]string{
+				"message": "dry-run requires the managed nodeos to expose /v1/chain/compute_transaction (EOSIO >= 2.1)",
+			},
+		},
+		{
+			name:           "failed",
+			nodeosStatus:   500,
+			nodeosResponse: `{"code":500,"message":"Internal Service Error","error":{"code":3050003,"name":"eosio_assert_message_exception","what":"eosio_assert_message assertion failure","details":[]}}`,
+			expectedStatus: 500,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var computedPath string
+			nodeos := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				computedPath = r.URL.Path
+				w.WriteHeader(c.nodeosStatus)
+				w.Write([]byte(c.nodeosResponse))
+			}))
+			defer nodeos.Close()
+
+			tx := eos.NewSignedTransaction(&eos.Transaction{TransactionHeader: eos.TransactionHeader{Expiration: eos.JSONTime{Time: time.Unix(1600000000, 0)}}})
+			packed, err := tx.Pack(eos.CompressionNone)
+			require.NoError(t, err)
+
+			trxID, err := packed.ID()
+			require.NoError(t, err)
+
+			payload, err := json.Marshal(packed)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/v1/chain/push_transaction", strings.NewReader(string(payload)))
+			req.Header.Set("X-Eos-Push-Guarantee", "dry-run")
+			rec := httptest.NewRecorder()
+
+			pusher := NewTxPusher(eos.New(nodeos.URL), nil, nil, 0, nil)
+			pusher.ServeHTTP(rec, req)
+
+			require.Equal(t, "/v1/chain/compute_transaction", computedPath)
+			require.Equal(t, c.expectedStatus, rec.Code)
+
+			body, err := ioutil.ReadAll(rec.Body)
+			require.NoError(t, err)
+			if c.expectedStatus == 200 {
+				require.Equal(t, trxID.String(), gjson.GetBytes(body, "transaction_id").String())
+			}
+			if c.expectedFields != nil {
+				for path, expected := range c.expectedFields {
+					require.Equal(t, expected, gjson.GetBytes(body, path).String(), path)
+				}
+			}
+		})
+	}
+}
+
 type archiveFile struct {
 	name    string
 	content string
@@ -35,7 +35,6 @@ func init() {
 type GetActionTraces struct {
 	CommonIn
 
-	// TODO: Allow filtering by authorization accounts on DB ops
 	Data struct {
 		Receiver   eos.AccountName `json:"receiver"`    // deprecated (keep plural form)
 		Account    eos.AccountName `json:"account"`     // deprecated (keep plural form)
@@ -50,6 +49,25 @@ type GetActionTraces struct {
 		WithRAMOps       bool `json:"with_ramops"`
 		WithDTrxOps      bool `json:"with_dtrxops"`
 		WithTableOps     bool `json:"with_tableops"`
+
+		// Auths keeps the actions authorized by one of the `actor` or
+		// `actor@permission`, DBTables and DBScopes the ones with at least
+		// one db op on one of the tables and scopes
+		Auths    string `json:"auth"`
+		DBTables string `json:"db_table"`
+		DBScopes string `json:"db_scope"`
+
+		// Filter is a CEL expression every emitted action must match, see
+		// `filtering.ActionTraceDeclarations` for the available fields
+		Filter string `json:"filter,omitempty"`
+
+		// Cursor of the last `action_trace` received, the stream resumes
+		// right after it
+		Cursor string `json:"cursor,omitempty"`
+
+		// WithUndo streams the actions of `undo` and `redo` steps, on top of
+		// the `new` ones, it is implied when resuming from a cursor
+		WithUndo bool `json:"with_undo,omitempty"`
 	} `json:"data"`
 }
 
@@ -61,7 +79,10 @@ func (m *GetActionTraces) Validate(ctx context.Context) error {
 		return fmt.Errorf("'listen' required")
 	}
 	if m.Fetch {
-		return fmt.Errorf("'fetch' not supported")
+		return fmt.Errorf("'fetch' not supported, use 'start_block' or 'data.cursor' to stream past actions")
+	}
+	if m.Data.Cursor != "" && m.StartBlock != 0 {
+		return fmt.Errorf("only one of 'start_block' or 'data.cursor' can be set")
 	}
 
 	return nil
@@ -80,6 +101,11 @@ type ActionTrace struct {
 		//ActionDepth   int             `json:"depth"`
 		Trace json.RawMessage `json:"trace"`
 
+		// Step is `new`, `undo`, `redo` or `irreversible`, actions of an
+		// `undo` step belong to a block that was forked out
+		Step   string `json:"step"`
+		Cursor string `json:"cursor"`
+
 		DBOps    []*v0.DBOp    `json:"dbops,omitempty"`
 		RAMOps   []*v0.RAMOp   `json:"ramops,omitempty"`
 		DTrxOps  []*v0.DTrxOp  `json:"dtrxops,omitempty"`
@@ -219,6 +219,18 @@ func (f *CELFilter) match(activation interpreter.Activation) (matched bool) {
 	return bool(retval)
 }
 
+// NewActionTraceFilter compiles `code` against `ActionTraceDeclarations`
+// to match single action traces, an empty program matching everything.
+func NewActionTraceFilter(code string) (*CELFilter, error) {
+	return newCELFilter("action", code, []string{"", "true", "*"}, true)
+}
+
+// MatchActionTrace evaluates the filter against `actionTrace`, seen at
+// step `stepName` of its block.
+func (f *CELFilter) MatchActionTrace(actionTrace *pbcodec.ActionTrace, trxTrace *MemoizableTrxTrace, stepName string) bool {
+	return f.match(NewActionTraceActivation(actionTrace, trxTrace, stepName))
+}
+
 func NewActionTraceActivation(
 	actionTrace *pbcodec.ActionTrace,
 	trxTrace *MemoizableTrxTrace,
@@ -337,3 +337,22 @@ func multiAction(length int) (out []*pbcodec.ActionTrace) {
 	}
 	return
 }
+
+func TestActionTraceFilter(t *testing.T) {
+	trxTrace := &MemoizableTrxTrace{TrxTrace: &pbcodec.TransactionTrace{}}
+	actTrace := ct.ActionTrace(t, "eosio.token:eosio.token:transfer", ct.Authorizations("alice@active"))
+
+	noop, err := NewActionTraceFilter("")
+	require.NoError(t, err)
+	assert.True(t, noop.IsNoop())
+	assert.True(t, noop.MatchActionTrace(actTrace, trxTrace, "new"))
+
+	filter, err := NewActionTraceFilter(`"alice" in auth && step == "NEW"`)
+	require.NoError(t, err)
+	assert.True(t, filter.MatchActionTrace(actTrace, trxTrace, "new"))
+	assert.False(t, filter.MatchActionTrace(actTrace, trxTrace, "undo"))
+
+	_, err = NewActionTraceFilter(`receiver`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid return type")
+}
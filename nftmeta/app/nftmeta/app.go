@@ -0,0 +1,177 @@
+package nftmeta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta"
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dgrpc"
+	"github.com/streamingfast/dmetrics"
+	"github.com/streamingfast/dstore"
+	pbblockmeta "github.com/streamingfast/pbgo/dfuse/blockmeta/v1"
+	pbhealth "github.com/streamingfast/pbgo/grpc/health/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	GRPCListenAddr      string        // Address to listen for incoming gRPC requests
+	StateDBGRPCAddr     string        // StateDB gRPC URL, used to bootstrap the index
+	BlockStreamAddr     string        // gRPC URL to reach a stream of blocks
+	ABICodecAddr        string        // Abi Codec URL
+	BlocksStoreURL      string        // GS path to read blocks archives
+	Contracts           []string      // Indexed asset contracts, as `<kind>@<contract>`
+	CacheFile           string        // Path to GOB file containing the index. will try to Load and Save to that cache file
+	SaveEveryNBlock     uint32        // Save the index after N irreversible blocks processed
+	HistorySize         int           // Number of events kept for each asset, 0 for unbounded
+	ReadinessMaxLatency time.Duration // we advertise as not-ready if the last processed block is older than this
+}
+
+type Modules struct {
+	BlockFilter func(blk *bstream.Block) error
+	BlockMeta   pbblockmeta.BlockIDClient
+}
+
+type App struct {
+	*shutter.Shutter
+	config  *Config
+	modules *Modules
+
+	readinessProbe pbhealth.HealthClient
+}
+
+func New(config *Config, modules *Modules) *App {
+	return &App{
+		Shutter: shutter.New(),
+		config:  config,
+		modules: modules,
+	}
+}
+
+func (a *App) Run() error {
+	zlog.Info("running nftmeta", zap.Reflect("config", a.config))
+	dmetrics.Register(nftmeta.MetricsSet)
+
+	layouts, err := nftmeta.NewLayoutsFromSpecs(a.config.Contracts)
+	if err != nil {
+		return err
+	}
+	if len(layouts) == 0 {
+		return fmt.Errorf("at least one asset contract must be indexed")
+	}
+
+	var idx *index.Index
+	if a.config.CacheFile != "" {
+		if err := mkdirCacheFileParents(a.config.CacheFile); err != nil {
+			return err
+		}
+
+		zlog.Info("trying to load from index cache file", zap.String("filename", a.config.CacheFile))
+		idx, err = index.LoadFromFile(a.config.CacheFile, a.config.HistorySize)
+		if err != nil && !isNotExits(err) {
+			zlog.Warn("cannot load from cache file", zap.Error(err))
+		}
+	}
+
+	if idx == nil {
+		zlog.Info("nftmeta index was not setup generating it from statedb")
+		stateConn, err := dgrpc.NewInternalClient(a.config.StateDBGRPCAddr)
+		if err != nil {
+			return fmt.Errorf("cannot create statedb connection: %w", err)
+		}
+
+		idx = index.New(a.config.HistorySize, a.config.CacheFile)
+		if _, err := nftmeta.Bootstrap(context.Background(), pbstatedb.NewStateClient(stateConn), idx, layouts); err != nil {
+			return fmt.Errorf("bootstrap nftmeta index: %w", err)
+		}
+
+		if a.config.CacheFile != "" {
+			if err := idx.SaveToFile(); err != nil {
+				zlog.Error("cannot save index cache file", zap.Error(err), zap.String("filename", a.config.CacheFile))
+			}
+		}
+	}
+
+	zlog.Info("setting up blockstore")
+	blocksStore, err := dstore.NewDBinStore(a.config.BlocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up blocks store: %w", err)
+	}
+
+	startBlock := idx.AtBlockRef()
+	zlog.Info("resolved start block", zap.Uint64("start_block_num", startBlock.Num()), zap.String("start_block_id", startBlock.ID()))
+
+	zlog.Info("setting up abi client")
+	abiCodecConn, err := dgrpc.NewInternalClient(a.config.ABICodecAddr)
+	if err != nil {
+		return fmt.Errorf("failed getting abi codec grpc client: %w", err)
+	}
+
+	nmeta := nftmeta.NewNFTMeta(idx, layouts, pbabicodec.NewDecoderClient(abiCodecConn), a.config.SaveEveryNBlock, a.modules.BlockMeta)
+	nmeta.OnTerminated(a.Shutdown)
+	a.OnTerminating(nmeta.Shutdown)
+
+	nmeta.SetupPipeline(startBlock, a.modules.BlockFilter, a.config.BlockStreamAddr, blocksStore)
+
+	server := nftmeta.NewServer(idx, a.config.ReadinessMaxLatency)
+	server.OnTerminated(a.Shutdown)
+	a.OnTerminating(server.Shutdown)
+
+	go server.Serve(a.config.GRPCListenAddr)
+
+	gs, err := dgrpc.NewInternalClient(a.config.GRPCListenAddr)
+	if err != nil {
+		return fmt.Errorf("cannot create readiness probe: %w", err)
+	}
+	a.readinessProbe = pbhealth.NewHealthClient(gs)
+
+	go nmeta.Launch()
+	return nil
+}
+
+func (a *App) IsReady() bool {
+	if a.readinessProbe == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	resp, err := a.readinessProbe.Check(ctx, &pbhealth.HealthCheckRequest{})
+	if err != nil {
+		zlog.Info("nftmeta readiness probe error", zap.Error(err))
+		return false
+	}
+
+	return resp.Status == pbhealth.HealthCheckResponse_SERVING
+}
+
+func mkdirCacheFileParents(file string) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create parents %q for cache file %q: %w", dir, file, err)
+	}
+
+	return nil
+}
+
+func isNotExits(err error) bool {
+	for {
+		if os.IsNotExist(err) {
+			return true
+		}
+
+		err = errors.Unwrap(err)
+		if err == nil {
+			return false
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package nftmeta
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/bstream"
+	"go.uber.org/zap"
+)
+
+var bootstrapScopesBatchSize = 1000
+
+// Bootstrap fills the index with the assets found in the tables of each
+// layout at the last irreversible block known to statedb, which is
+// returned so the block stream can start right after it.
+func Bootstrap(ctx context.Context, stateClient pbstatedb.StateClient, idx *index.Index, layouts []Layout) (bstream.BlockRef, error) {
+	if len(layouts) == 0 {
+		return nil, fmt.Errorf("no asset contract to bootstrap")
+	}
+
+	ref, err := pbstatedb.ForEachTableRows(ctx, stateClient, &pbstatedb.StreamTableRowsRequest{
+		Contract:         layouts[0].Contract(),
+		Table:            layouts[0].Table(),
+		Scope:            layouts[0].Contract(),
+		KeyType:          "uint64",
+		IrreversibleOnly: true,
+	}, func(_ *pbstatedb.TableRowResponse) error { return nil })
+	if err != nil {
+		return nil, fmt.Errorf("resolving last irreversible block: %w", err)
+	}
+
+	startBlock := ref.UpToBlock
+	zlog.Info("bootstrapping nftmeta index from statedb", zap.Stringer("block", startBlock))
+
+	for _, layout := range layouts {
+		scopes, err := pbstatedb.FetchTableScopes(ctx, stateClient, startBlock.Num(), layout.Contract(), layout.Table())
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s owners: %w", layout.Contract(), err)
+		}
+
+		assetCount := 0
+		for start := 0; start < len(scopes); start += bootstrapScopesBatchSize {
+			end := start + bootstrapScopesBatchSize
+			if end > len(scopes) {
+				end = len(scopes)
+			}
+
+			_, err := pbstatedb.ForEachMultiScopesTableRows(ctx, stateClient, &pbstatedb.StreamMultiScopesTableRowsRequest{
+				BlockNum: startBlock.Num(),
+				Contract: layout.Contract(),
+				Table:    layout.Table(),
+				KeyType:  "uint64",
+				ToJson:   true,
+				Scopes:   scopes[start:end],
+			}, func(scope string, row *pbstatedb.TableRowResponse) error {
+				if row.Json == "" {
+					zlog.Warn("skipping asset row that could not be decoded", zap.String("contract", layout.Contract()), zap.String("scope", scope), zap.String("key", row.Key))
+					return nil
+				}
+
+				asset, err := layout.DecodeAsset(scope, []byte(row.Json))
+				if err != nil {
+					zlog.Warn("skipping invalid asset row", zap.String("contract", layout.Contract()), zap.String("scope", scope), zap.String("key", row.Key), zap.Error(err))
+					return nil
+				}
+
+				idx.SetAsset(asset)
+				assetCount++
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("reading %s assets: %w", layout.Contract(), err)
+			}
+		}
+
+		zlog.Info("bootstrapped asset contract",
+			zap.String("contract", layout.Contract()),
+			zap.String("kind", layout.Kind()),
+			zap.Int("owner_count", len(scopes)),
+			zap.Int("asset_count", assetCount),
+		)
+	}
+
+	idx.SetAtBlock(startBlock)
+	return startBlock, nil
+}
@@ -0,0 +1,107 @@
+package index
+
+import (
+	"fmt"
+	"time"
+)
+
+type AssetKey struct {
+	Contract string
+	ID       uint64
+}
+
+func (k AssetKey) String() string {
+	return fmt.Sprintf("%s:%d", k.Contract, k.ID)
+}
+
+type CollectionKey struct {
+	Contract   string
+	Collection string
+}
+
+func (k CollectionKey) String() string {
+	return fmt.Sprintf("%s:%s", k.Contract, k.Collection)
+}
+
+type Asset struct {
+	Contract   string
+	ID         uint64
+	Owner      string
+	Collection string
+	Schema     string
+
+	// TemplateID is negative when the asset is not bound to any template
+	TemplateID int64
+
+	// MintedAtBlockNum is 0 when the asset was minted before indexing started
+	MintedAtBlockNum  uint64
+	UpdatedAtBlockNum uint64
+}
+
+func (a *Asset) Key() AssetKey {
+	return AssetKey{Contract: a.Contract, ID: a.ID}
+}
+
+func (a *Asset) CollectionKey() CollectionKey {
+	return CollectionKey{Contract: a.Contract, Collection: a.Collection}
+}
+
+type EventType int32
+
+const (
+	MintEvent EventType = iota
+	TransferEvent
+	BurnEvent
+	UpdateEvent
+)
+
+func (t EventType) String() string {
+	switch t {
+	case MintEvent:
+		return "mint"
+	case TransferEvent:
+		return "transfer"
+	case BurnEvent:
+		return "burn"
+	case UpdateEvent:
+		return "update"
+	}
+	return fmt.Sprintf("unknown(%d)", int32(t))
+}
+
+type Event struct {
+	Type          EventType
+	From          string
+	To            string
+	BlockNum      uint64
+	BlockID       string
+	BlockTime     time.Time
+	TransactionID string
+
+	// Irreversible is flipped once the block of the event is applied to the index
+	Irreversible bool
+}
+
+// Mutation sets the new state of an asset, `Asset` is nil when the
+// asset was burned.
+type Mutation struct {
+	Key   AssetKey
+	Asset *Asset
+	Event *Event
+}
+
+// NewEvent derives the event turning `before` into `after`, both being
+// nil when the asset does not exist. It returns nil when neither exists.
+func NewEvent(before, after *Asset) *Event {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return &Event{Type: MintEvent, To: after.Owner}
+	case after == nil:
+		return &Event{Type: BurnEvent, From: before.Owner}
+	case before.Owner != after.Owner:
+		return &Event{Type: TransferEvent, From: before.Owner, To: after.Owner}
+	}
+	return &Event{Type: UpdateEvent, From: before.Owner, To: after.Owner}
+}
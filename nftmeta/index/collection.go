@@ -0,0 +1,125 @@
+package index
+
+type Collection struct {
+	Assets    uint64
+	Holders   map[string]uint64 // owner -> assets count
+	Schemas   map[string]uint64 // schema -> assets count
+	Templates map[int64]uint64  // template id -> assets count
+
+	Minted    uint64
+	Burned    uint64
+	Transfers uint64
+}
+
+type CollectionStats struct {
+	Assets    uint64
+	Holders   uint64
+	Schemas   uint64
+	Templates uint64
+	Minted    uint64
+	Burned    uint64
+	Transfers uint64
+}
+
+func newCollection() *Collection {
+	return &Collection{
+		Holders:   map[string]uint64{},
+		Schemas:   map[string]uint64{},
+		Templates: map[int64]uint64{},
+	}
+}
+
+func (c *Collection) Stats() *CollectionStats {
+	return &CollectionStats{
+		Assets:    c.Assets,
+		Holders:   uint64(len(c.Holders)),
+		Schemas:   uint64(len(c.Schemas)),
+		Templates: uint64(len(c.Templates)),
+		Minted:    c.Minted,
+		Burned:    c.Burned,
+		Transfers: c.Transfers,
+	}
+}
+
+func (c *Collection) clone() *Collection {
+	out := newCollection()
+	out.Assets = c.Assets
+	out.Minted = c.Minted
+	out.Burned = c.Burned
+	out.Transfers = c.Transfers
+	for k, v := range c.Holders {
+		out.Holders[k] = v
+	}
+	for k, v := range c.Schemas {
+		out.Schemas[k] = v
+	}
+	for k, v := range c.Templates {
+		out.Templates[k] = v
+	}
+	return out
+}
+
+func (c *Collection) add(a *Asset) {
+	c.Assets++
+	c.Holders[a.Owner]++
+	c.Schemas[a.Schema]++
+	if a.TemplateID >= 0 {
+		c.Templates[a.TemplateID]++
+	}
+}
+
+func (c *Collection) remove(a *Asset) {
+	if c.Assets > 0 {
+		c.Assets--
+	}
+	decrement(c.Holders, a.Owner)
+	decrement(c.Schemas, a.Schema)
+	if a.TemplateID >= 0 {
+		if c.Templates[a.TemplateID] <= 1 {
+			delete(c.Templates, a.TemplateID)
+		} else {
+			c.Templates[a.TemplateID]--
+		}
+	}
+}
+
+// applyMutation accounts for `m` in the collection identified by `key`,
+// `previous` being the state of the asset before the mutation.
+func (c *Collection) applyMutation(key CollectionKey, previous *Asset, m *Mutation) {
+	if previous != nil && previous.CollectionKey() == key {
+		c.remove(previous)
+	}
+
+	if m.Asset != nil && m.Asset.CollectionKey() == key {
+		c.add(m.Asset)
+	}
+
+	if m.Event == nil {
+		return
+	}
+
+	eventAsset := m.Asset
+	if eventAsset == nil {
+		eventAsset = previous
+	}
+	if eventAsset == nil || eventAsset.CollectionKey() != key {
+		return
+	}
+
+	switch m.Event.Type {
+	case MintEvent:
+		c.Minted++
+	case BurnEvent:
+		c.Burned++
+	case TransferEvent:
+		c.Transfers++
+	}
+}
+
+func decrement(counts map[string]uint64, key string) {
+	if counts[key] <= 1 {
+		delete(counts, key)
+		return
+	}
+	counts[key]--
+}
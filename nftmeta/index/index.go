@@ -0,0 +1,417 @@
+package index
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"go.uber.org/zap"
+)
+
+// Index holds the state of the tracked assets as of the last irreversible
+// block applied, with the mutations of the reversible blocks seen since
+// kept aside so queries can opt in to see the head of the chain.
+type Index struct {
+	Assets      map[AssetKey]*Asset
+	History     map[AssetKey][]*Event
+	Collections map[CollectionKey]*Collection
+
+	AtBlockNum    uint64
+	AtBlockID     string
+	HeadBlockTime time.Time
+
+	lock          sync.RWMutex
+	byOwner       map[string]map[AssetKey]bool
+	reversible    []*reversibleBlock
+	historySize   int
+	cacheFilePath string
+}
+
+type reversibleBlock struct {
+	ref       bstream.BlockRef
+	mutations []*Mutation
+}
+
+// New creates an empty index, `historySize` is the number of events kept
+// for each asset, 0 for unbounded.
+func New(historySize int, cacheFilePath string) *Index {
+	return &Index{
+		Assets:        map[AssetKey]*Asset{},
+		History:       map[AssetKey][]*Event{},
+		Collections:   map[CollectionKey]*Collection{},
+		byOwner:       map[string]map[AssetKey]bool{},
+		historySize:   historySize,
+		cacheFilePath: cacheFilePath,
+	}
+}
+
+func LoadFromFile(filename string, historySize int) (*Index, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file: %w", err)
+	}
+	defer f.Close()
+
+	i := &Index{}
+	if err := gob.NewDecoder(f).Decode(&i); err != nil {
+		return nil, fmt.Errorf("unable decode nftmeta index: %w", err)
+	}
+
+	if i.Assets == nil {
+		i.Assets = map[AssetKey]*Asset{}
+	}
+	if i.History == nil {
+		i.History = map[AssetKey][]*Event{}
+	}
+	if i.Collections == nil {
+		i.Collections = map[CollectionKey]*Collection{}
+	}
+
+	i.byOwner = map[string]map[AssetKey]bool{}
+	for _, asset := range i.Assets {
+		i.indexOwner(asset)
+	}
+
+	i.historySize = historySize
+	i.cacheFilePath = filename
+	return i, nil
+}
+
+func (i *Index) SaveToFile() error {
+	if i.cacheFilePath == "" {
+		return fmt.Errorf("cannot save index no filepath specified")
+	}
+
+	tempfile := fmt.Sprintf("%s.tmp", i.cacheFilePath)
+	zlog.Info("trying to save to nftmeta index file", zap.String("filename", i.cacheFilePath), zap.String("temp_filename", tempfile))
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	f, err := os.Create(tempfile)
+	if err != nil {
+		return err
+	}
+	err = gob.NewEncoder(f).Encode(i)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempfile, i.cacheFilePath)
+}
+
+// AtBlockRef returns the last irreversible block applied to the index
+func (i *Index) AtBlockRef() bstream.BlockRef {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return bstream.NewBlockRef(i.AtBlockID, i.AtBlockNum)
+}
+
+// HeadBlockRef returns the last reversible block pushed to the index, or
+// the last irreversible one when there is none.
+func (i *Index) HeadBlockRef() bstream.BlockRef {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if len(i.reversible) > 0 {
+		return i.reversible[len(i.reversible)-1].ref
+	}
+	return bstream.NewBlockRef(i.AtBlockID, i.AtBlockNum)
+}
+
+func (i *Index) SetHeadBlockTime(t time.Time) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.HeadBlockTime = t
+}
+
+func (i *Index) GetHeadBlockTime() time.Time {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return i.HeadBlockTime
+}
+
+// SetAsset stores an asset as is, without recording any event, it is
+// meant to bootstrap the index from the contracts tables.
+func (i *Index) SetAsset(asset *Asset) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.apply(&Mutation{Key: asset.Key(), Asset: asset})
+}
+
+// SetAtBlock sets the irreversible block the index is at, it is meant to
+// be called once bootstrapped.
+func (i *Index) SetAtBlock(ref bstream.BlockRef) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.AtBlockNum = ref.Num()
+	i.AtBlockID = ref.ID()
+}
+
+// PushReversible records the mutations of a reversible block, they are
+// only visible to head queries until the block becomes irreversible.
+func (i *Index) PushReversible(ref bstream.BlockRef, mutations []*Mutation) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.reversible = append(i.reversible, &reversibleBlock{ref: ref, mutations: mutations})
+}
+
+// PopReversible discards the mutations of a reversible block that was
+// forked out, it must be the last one pushed.
+func (i *Index) PopReversible(ref bstream.BlockRef) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if len(i.reversible) == 0 {
+		return fmt.Errorf("cannot undo block %s, no reversible block recorded", ref)
+	}
+
+	last := i.reversible[len(i.reversible)-1]
+	if last.ref.ID() != ref.ID() {
+		return fmt.Errorf("cannot undo block %s, last reversible block recorded is %s", ref, last.ref)
+	}
+
+	i.reversible = i.reversible[:len(i.reversible)-1]
+	return nil
+}
+
+// ReversibleMutations returns the mutations recorded for a reversible block
+func (i *Index) ReversibleMutations(ref bstream.BlockRef) ([]*Mutation, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	for _, blk := range i.reversible {
+		if blk.ref.ID() == ref.ID() {
+			return blk.mutations, true
+		}
+	}
+	return nil, false
+}
+
+// ApplyIrreversible applies the mutations of an irreversible block to the
+// index and forgets the reversible blocks up to it.
+func (i *Index) ApplyIrreversible(ref bstream.BlockRef, mutations []*Mutation) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for _, m := range mutations {
+		i.apply(m)
+	}
+
+	i.AtBlockNum = ref.Num()
+	i.AtBlockID = ref.ID()
+
+	kept := i.reversible[:0]
+	for _, blk := range i.reversible {
+		if blk.ref.Num() > ref.Num() {
+			kept = append(kept, blk)
+		}
+	}
+	i.reversible = kept
+}
+
+func (i *Index) apply(m *Mutation) {
+	previous := i.Assets[m.Key]
+
+	keys := map[CollectionKey]bool{}
+	if previous != nil {
+		keys[previous.CollectionKey()] = true
+		i.unindexOwner(previous)
+	}
+	if m.Asset != nil {
+		keys[m.Asset.CollectionKey()] = true
+	}
+
+	for key := range keys {
+		collection, found := i.Collections[key]
+		if !found {
+			collection = newCollection()
+			i.Collections[key] = collection
+		}
+		collection.applyMutation(key, previous, m)
+	}
+
+	if m.Asset == nil {
+		delete(i.Assets, m.Key)
+	} else {
+		i.Assets[m.Key] = m.Asset
+		i.indexOwner(m.Asset)
+	}
+
+	if m.Event != nil {
+		m.Event.Irreversible = true
+
+		events := append(i.History[m.Key], m.Event)
+		if i.historySize > 0 && len(events) > i.historySize {
+			events = events[len(events)-i.historySize:]
+		}
+		i.History[m.Key] = events
+	}
+}
+
+func (i *Index) indexOwner(asset *Asset) {
+	keys, found := i.byOwner[asset.Owner]
+	if !found {
+		keys = map[AssetKey]bool{}
+		i.byOwner[asset.Owner] = keys
+	}
+	keys[asset.Key()] = true
+}
+
+func (i *Index) unindexOwner(asset *Asset) {
+	keys := i.byOwner[asset.Owner]
+	delete(keys, asset.Key())
+	if len(keys) == 0 {
+		delete(i.byOwner, asset.Owner)
+	}
+}
+
+// headAssets returns the latest state of the assets mutated by reversible
+// blocks, burned assets being nil.
+func (i *Index) headAssets() map[AssetKey]*Asset {
+	out := map[AssetKey]*Asset{}
+	for _, blk := range i.reversible {
+		for _, m := range blk.mutations {
+			out[m.Key] = m.Asset
+		}
+	}
+	return out
+}
+
+func (i *Index) AssetCount() int {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return len(i.Assets)
+}
+
+// BlockRef returns the block the queries of the given mode are answered at
+func (i *Index) BlockRef(head bool) bstream.BlockRef {
+	if head {
+		return i.HeadBlockRef()
+	}
+	return i.AtBlockRef()
+}
+
+// Asset returns the asset, nil when it does not exist or was burned
+func (i *Index) Asset(key AssetKey, head bool) *Asset {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if head {
+		if asset, found := i.headAssets()[key]; found {
+			return asset
+		}
+	}
+	return i.Assets[key]
+}
+
+// AssetsByOwner returns the assets held by `owner`, sorted by contract
+// then asset id.
+func (i *Index) AssetsByOwner(owner string, head bool) (out []*Asset) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	assets := map[AssetKey]*Asset{}
+	for key := range i.byOwner[owner] {
+		assets[key] = i.Assets[key]
+	}
+
+	if head {
+		for key, asset := range i.headAssets() {
+			delete(assets, key)
+			if asset != nil && asset.Owner == owner {
+				assets[key] = asset
+			}
+		}
+	}
+
+	for _, asset := range assets {
+		out = append(out, asset)
+	}
+
+	sort.Slice(out, func(x, y int) bool {
+		if out[x].Contract != out[y].Contract {
+			return out[x].Contract < out[y].Contract
+		}
+		return out[x].ID < out[y].ID
+	})
+	return
+}
+
+// AssetHistory returns the events recorded for an asset, oldest first
+func (i *Index) AssetHistory(key AssetKey, head bool) (out []Event) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	for _, event := range i.History[key] {
+		out = append(out, *event)
+	}
+
+	if head {
+		for _, blk := range i.reversible {
+			for _, m := range blk.mutations {
+				if m.Key == key && m.Event != nil {
+					out = append(out, *m.Event)
+				}
+			}
+		}
+
+		if i.historySize > 0 && len(out) > i.historySize {
+			out = out[len(out)-i.historySize:]
+		}
+	}
+	return
+}
+
+// CollectionStats returns the statistics of a collection, nil when no
+// asset of the collection was ever seen.
+func (i *Index) CollectionStats(key CollectionKey, head bool) *CollectionStats {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	collection, found := i.Collections[key]
+	if !head {
+		if !found {
+			return nil
+		}
+		return collection.Stats()
+	}
+
+	if found {
+		collection = collection.clone()
+	} else {
+		collection = newCollection()
+	}
+
+	touched := false
+	latest := map[AssetKey]*Asset{}
+	for _, blk := range i.reversible {
+		for _, m := range blk.mutations {
+			previous, seen := latest[m.Key]
+			if !seen {
+				previous = i.Assets[m.Key]
+			}
+
+			if (previous != nil && previous.CollectionKey() == key) || (m.Asset != nil && m.Asset.CollectionKey() == key) {
+				touched = true
+			}
+
+			collection.applyMutation(key, previous, m)
+			latest[m.Key] = m.Asset
+		}
+	}
+
+	if !found && !touched {
+		return nil
+	}
+	return collection.Stats()
+}
@@ -0,0 +1,141 @@
+package index
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func asset(id uint64, owner, collection string, templateID int64) *Asset {
+	return &Asset{Contract: "atomicassets", ID: id, Owner: owner, Collection: collection, Schema: "cards", TemplateID: templateID}
+}
+
+func mutation(before, after *Asset, blockNum uint64) *Mutation {
+	event := NewEvent(before, after)
+	event.BlockNum = blockNum
+
+	key := before
+	if key == nil {
+		key = after
+	}
+	return &Mutation{Key: key.Key(), Asset: after, Event: event}
+}
+
+func newTestIndex() *Index {
+	idx := New(0, "")
+	idx.SetAsset(asset(1, "alice", "heroes", 10))
+	idx.SetAsset(asset(2, "alice", "heroes", 11))
+	idx.SetAsset(asset(3, "bob", "heroes", -1))
+	idx.SetAtBlock(bstream.NewBlockRef("00000064aa", 100))
+	return idx
+}
+
+func ids(assets []*Asset) (out []uint64) {
+	for _, a := range assets {
+		out = append(out, a.ID)
+	}
+	return
+}
+
+func TestIndex_Bootstrap(t *testing.T) {
+	idx := newTestIndex()
+
+	assert.Equal(t, []uint64{1, 2}, ids(idx.AssetsByOwner("alice", false)))
+	assert.Equal(t, &CollectionStats{Assets: 3, Holders: 2, Schemas: 1, Templates: 2}, idx.CollectionStats(CollectionKey{"atomicassets", "heroes"}, false))
+	assert.Nil(t, idx.CollectionStats(CollectionKey{"atomicassets", "villains"}, false))
+	assert.Empty(t, idx.AssetHistory(AssetKey{"atomicassets", 1}, false))
+}
+
+func TestIndex_HeadOverlay(t *testing.T) {
+	idx := newTestIndex()
+	heroes := CollectionKey{"atomicassets", "heroes"}
+
+	blk101 := bstream.NewBlockRef("00000065aa", 101)
+	idx.PushReversible(blk101, []*Mutation{
+		mutation(asset(1, "alice", "heroes", 10), asset(1, "bob", "heroes", 10), 101),
+		mutation(nil, asset(4, "carol", "heroes", 12), 101),
+	})
+
+	blk102 := bstream.NewBlockRef("00000066aa", 102)
+	idx.PushReversible(blk102, []*Mutation{
+		mutation(asset(2, "alice", "heroes", 11), nil, 102),
+	})
+
+	// Irreversible mode does not see reversible blocks
+	assert.Equal(t, []uint64{1, 2}, ids(idx.AssetsByOwner("alice", false)))
+	assert.Equal(t, uint64(100), idx.BlockRef(false).Num())
+
+	assert.Empty(t, ids(idx.AssetsByOwner("alice", true)))
+	assert.Equal(t, []uint64{1, 3}, ids(idx.AssetsByOwner("bob", true)))
+	assert.Equal(t, []uint64{4}, ids(idx.AssetsByOwner("carol", true)))
+	assert.Nil(t, idx.Asset(AssetKey{"atomicassets", 2}, true))
+	assert.Equal(t, uint64(102), idx.BlockRef(true).Num())
+	assert.Equal(t, &CollectionStats{Assets: 3, Holders: 2, Schemas: 1, Templates: 2, Minted: 1, Burned: 1, Transfers: 1}, idx.CollectionStats(heroes, true))
+
+	history := idx.AssetHistory(AssetKey{"atomicassets", 1}, true)
+	require.Len(t, history, 1)
+	assert.Equal(t, TransferEvent, history[0].Type)
+	assert.False(t, history[0].Irreversible)
+
+	// Block 102 is forked out
+	require.Error(t, idx.PopReversible(blk101))
+	require.NoError(t, idx.PopReversible(blk102))
+	assert.NotNil(t, idx.Asset(AssetKey{"atomicassets", 2}, true))
+
+	// Block 101 becomes irreversible
+	mutations, found := idx.ReversibleMutations(blk101)
+	require.True(t, found)
+	idx.ApplyIrreversible(blk101, mutations)
+
+	_, found = idx.ReversibleMutations(blk101)
+	assert.False(t, found)
+	assert.Equal(t, []uint64{2}, ids(idx.AssetsByOwner("alice", false)))
+	assert.Equal(t, []uint64{1, 3}, ids(idx.AssetsByOwner("bob", false)))
+	assert.Equal(t, &CollectionStats{Assets: 4, Holders: 3, Schemas: 1, Templates: 3, Minted: 1, Transfers: 1}, idx.CollectionStats(heroes, false))
+	assert.Equal(t, idx.CollectionStats(heroes, false), idx.CollectionStats(heroes, true))
+
+	history = idx.AssetHistory(AssetKey{"atomicassets", 1}, false)
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Irreversible)
+	assert.Equal(t, "alice", history[0].From)
+	assert.Equal(t, "bob", history[0].To)
+}
+
+func TestIndex_HistorySize(t *testing.T) {
+	idx := New(2, "")
+	idx.SetAsset(asset(1, "alice", "heroes", 10))
+
+	owners := []string{"alice", "bob", "carol", "dave"}
+	for i := 1; i < len(owners); i++ {
+		ref := bstream.NewBlockRef("", uint64(100+i))
+		idx.ApplyIrreversible(ref, []*Mutation{mutation(asset(1, owners[i-1], "heroes", 10), asset(1, owners[i], "heroes", 10), ref.Num())})
+	}
+
+	history := idx.AssetHistory(AssetKey{"atomicassets", 1}, false)
+	require.Len(t, history, 2)
+	assert.Equal(t, "carol", history[0].To)
+	assert.Equal(t, "dave", history[1].To)
+}
+
+func TestIndex_SaveAndLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "index.gob")
+
+	idx := newTestIndex()
+	idx.cacheFilePath = filename
+	idx.ApplyIrreversible(bstream.NewBlockRef("00000065aa", 101), []*Mutation{
+		mutation(asset(1, "alice", "heroes", 10), asset(1, "bob", "heroes", 10), 101),
+	})
+	require.NoError(t, idx.SaveToFile())
+
+	loaded, err := LoadFromFile(filename, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, "00000065aa", loaded.AtBlockRef().ID())
+	assert.Equal(t, []uint64{2}, ids(loaded.AssetsByOwner("alice", false)))
+	assert.Equal(t, []uint64{1, 3}, ids(loaded.AssetsByOwner("bob", false)))
+	assert.Len(t, loaded.AssetHistory(AssetKey{"atomicassets", 1}, false), 1)
+	assert.Equal(t, idx.CollectionStats(CollectionKey{"atomicassets", "heroes"}, false), loaded.CollectionStats(CollectionKey{"atomicassets", "heroes"}, false))
+}
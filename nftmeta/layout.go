@@ -0,0 +1,81 @@
+package nftmeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	"github.com/eoscanada/eos-go"
+)
+
+// Layout describes where a non-fungible asset contract stores its
+// assets, one asset per row of a single table.
+type Layout interface {
+	// Kind identifies the layout, like `atomicassets`
+	Kind() string
+
+	Contract() string
+	Table() string
+
+	// DecodeAsset turns the ABI decoded JSON of a row found in `scope`
+	// into an asset
+	DecodeAsset(scope string, row json.RawMessage) (*index.Asset, error)
+}
+
+type LayoutFactory func(contract string) Layout
+
+var layoutFactories = map[string]LayoutFactory{}
+
+// RegisterLayoutFactory makes a layout kind available to `NewLayoutFromSpec`
+func RegisterLayoutFactory(kind string, factory LayoutFactory) {
+	layoutFactories[kind] = factory
+}
+
+// LayoutKinds returns the registered layout kinds, sorted
+func LayoutKinds() (out []string) {
+	for kind := range layoutFactories {
+		out = append(out, kind)
+	}
+	sort.Strings(out)
+	return
+}
+
+// NewLayoutFromSpec creates a layout from a `<kind>@<contract>` spec, like `atomicassets@atomicassets`
+func NewLayoutFromSpec(spec string) (Layout, error) {
+	parts := strings.Split(spec, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid asset contract %q, expected <kind>@<contract>", spec)
+	}
+
+	factory, found := layoutFactories[parts[0]]
+	if !found {
+		return nil, fmt.Errorf("unknown asset contract kind %q, valid kinds are %s", parts[0], strings.Join(LayoutKinds(), ", "))
+	}
+
+	if _, err := eos.StringToName(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid asset contract %q: %w", parts[1], err)
+	}
+
+	return factory(parts[1]), nil
+}
+
+// NewLayoutsFromSpecs creates a layout for each spec, see `NewLayoutFromSpec`
+func NewLayoutsFromSpecs(specs []string) (out []Layout, err error) {
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		layout, err := NewLayoutFromSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[layout.Contract()] {
+			return nil, fmt.Errorf("asset contract %q declared more than once", layout.Contract())
+		}
+		seen[layout.Contract()] = true
+
+		out = append(out, layout)
+	}
+	return
+}
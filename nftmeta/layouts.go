@@ -0,0 +1,71 @@
+package nftmeta
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	"github.com/tidwall/gjson"
+)
+
+func init() {
+	RegisterLayoutFactory("atomicassets", func(contract string) Layout { return &AtomicAssetsLayout{contract: contract} })
+	RegisterLayoutFactory("simpleassets", func(contract string) Layout { return &SimpleAssetsLayout{contract: contract} })
+}
+
+// AtomicAssetsLayout reads the `assets` table of AtomicAssets contracts,
+// scoped by owner, where assets without template have a `template_id` of -1.
+type AtomicAssetsLayout struct {
+	contract string
+}
+
+func (l *AtomicAssetsLayout) Kind() string     { return "atomicassets" }
+func (l *AtomicAssetsLayout) Contract() string { return l.contract }
+func (l *AtomicAssetsLayout) Table() string    { return "assets" }
+
+func (l *AtomicAssetsLayout) DecodeAsset(scope string, row json.RawMessage) (*index.Asset, error) {
+	fields := gjson.ParseBytes(row)
+	for _, name := range []string{"asset_id", "collection_name", "schema_name", "template_id"} {
+		if !fields.Get(name).Exists() {
+			return nil, fmt.Errorf("row field %q not found", name)
+		}
+	}
+
+	return &index.Asset{
+		Contract:   l.contract,
+		ID:         fields.Get("asset_id").Uint(),
+		Owner:      scope,
+		Collection: fields.Get("collection_name").String(),
+		Schema:     fields.Get("schema_name").String(),
+		TemplateID: fields.Get("template_id").Int(),
+	}, nil
+}
+
+// SimpleAssetsLayout reads the `sassets` table of SimpleAssets contracts,
+// scoped by owner. Assets are grouped by `author` and `category`, which
+// are exposed as collection and schema, SimpleAssets having no templates.
+type SimpleAssetsLayout struct {
+	contract string
+}
+
+func (l *SimpleAssetsLayout) Kind() string     { return "simpleassets" }
+func (l *SimpleAssetsLayout) Contract() string { return l.contract }
+func (l *SimpleAssetsLayout) Table() string    { return "sassets" }
+
+func (l *SimpleAssetsLayout) DecodeAsset(scope string, row json.RawMessage) (*index.Asset, error) {
+	fields := gjson.ParseBytes(row)
+	for _, name := range []string{"id", "author", "category"} {
+		if !fields.Get(name).Exists() {
+			return nil, fmt.Errorf("row field %q not found", name)
+		}
+	}
+
+	return &index.Asset{
+		Contract:   l.contract,
+		ID:         fields.Get("id").Uint(),
+		Owner:      scope,
+		Collection: fields.Get("author").String(),
+		Schema:     fields.Get("category").String(),
+		TemplateID: -1,
+	}, nil
+}
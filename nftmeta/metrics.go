@@ -0,0 +1,11 @@
+package nftmeta
+
+import (
+	"github.com/streamingfast/dmetrics"
+)
+
+var MetricsSet = dmetrics.NewSet()
+
+var trackedAssetCount = MetricsSet.NewGauge("tracked_asset_count")
+var HeadBlockNum = MetricsSet.NewHeadBlockNumber("nftmeta")
+var HeadTimeDrift = MetricsSet.NewHeadTimeDrift("nftmeta")
@@ -0,0 +1,121 @@
+package nftmeta
+
+import (
+	"encoding/json"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"go.uber.org/zap"
+)
+
+// rowDecoder decodes the binary data of a table row to JSON
+type rowDecoder func(contract, table string, data []byte) (json.RawMessage, error)
+
+type assetChange struct {
+	before *index.Asset
+	after  *index.Asset
+}
+
+// blockMutations derives the mutations of the assets tracked by `layouts`,
+// keyed by contract, out of the database operations of the block. The
+// operations of a transaction are folded per asset, so an asset removed
+// from the owner's scope then inserted in another one is a transfer.
+// `current` returns the state of an asset prior to the block.
+func blockMutations(blk *pbcodec.Block, layouts map[string]Layout, decode rowDecoder, current func(key index.AssetKey) *index.Asset) (out []*index.Mutation) {
+	blockTime := blk.MustTime()
+	latest := map[index.AssetKey]*index.Asset{}
+
+	for _, trx := range blk.TransactionTraces() {
+		if trx.HasBeenReverted() {
+			continue
+		}
+
+		zlogger := zlog.With(zap.String("trx_id", trx.Id))
+		actionMatcher := blk.FilteringActionMatcher(trx)
+
+		var keys []index.AssetKey
+		changes := map[index.AssetKey]*assetChange{}
+		for _, dbop := range trx.DbOps {
+			layout := layouts[dbop.Code]
+			if layout == nil || dbop.TableName != layout.Table() || !actionMatcher.Matched(dbop.ActionIndex) {
+				continue
+			}
+
+			before, err := decodeAsset(layout, dbop.Scope, dbop.OldData, decode)
+			if err != nil {
+				zlogger.Warn("cannot decode asset old row", zap.String("contract", dbop.Code), zap.String("scope", dbop.Scope), zap.String("primary_key", dbop.PrimaryKey), zap.Error(err))
+				continue
+			}
+
+			after, err := decodeAsset(layout, dbop.Scope, dbop.NewData, decode)
+			if err != nil {
+				zlogger.Warn("cannot decode asset new row", zap.String("contract", dbop.Code), zap.String("scope", dbop.Scope), zap.String("primary_key", dbop.PrimaryKey), zap.Error(err))
+				continue
+			}
+
+			asset := after
+			if asset == nil {
+				asset = before
+			}
+			if asset == nil {
+				continue
+			}
+
+			key := asset.Key()
+			change, found := changes[key]
+			if !found {
+				change = &assetChange{before: before}
+				changes[key] = change
+				keys = append(keys, key)
+			}
+			change.after = after
+		}
+
+		for _, key := range keys {
+			change := changes[key]
+			event := index.NewEvent(change.before, change.after)
+			if event == nil {
+				continue
+			}
+
+			event.BlockNum = blk.Num()
+			event.BlockID = blk.ID()
+			event.BlockTime = blockTime
+			event.TransactionID = trx.Id
+
+			if change.after != nil {
+				change.after.UpdatedAtBlockNum = blk.Num()
+				if event.Type == index.MintEvent {
+					change.after.MintedAtBlockNum = blk.Num()
+				} else if previous := latestAsset(key, latest, current); previous != nil {
+					change.after.MintedAtBlockNum = previous.MintedAtBlockNum
+				}
+			}
+			latest[key] = change.after
+
+			out = append(out, &index.Mutation{Key: key, Asset: change.after, Event: event})
+		}
+	}
+
+	return
+}
+
+func latestAsset(key index.AssetKey, latest map[index.AssetKey]*index.Asset, current func(key index.AssetKey) *index.Asset) *index.Asset {
+	if asset, found := latest[key]; found {
+		return asset
+	}
+	return current(key)
+}
+
+func decodeAsset(layout Layout, scope string, data []byte, decode rowDecoder) (*index.Asset, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	row, err := decode(layout.Contract(), layout.Table(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	return layout.DecodeAsset(scope, row)
+}
@@ -0,0 +1,129 @@
+package nftmeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonRowDecoder treats the row data as being already JSON encoded
+func jsonRowDecoder(contract, table string, data []byte) (json.RawMessage, error) {
+	return json.RawMessage(data), nil
+}
+
+func atomicRow(id uint64, templateID int64) []byte {
+	return []byte(fmt.Sprintf(`{"asset_id":"%d","collection_name":"heroes","schema_name":"cards","template_id":%d}`, id, templateID))
+}
+
+func dbop(op pbcodec.DBOp_Operation, scope string, oldData, newData []byte) *pbcodec.DBOp {
+	return &pbcodec.DBOp{Operation: op, Code: "atomicassets", TableName: "assets", Scope: scope, OldData: oldData, NewData: newData}
+}
+
+func testBlock(t *testing.T, trxs ...*pbcodec.TransactionTrace) *pbcodec.Block {
+	timestamp, err := ptypes.TimestampProto(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	for _, trx := range trxs {
+		trx.Receipt = &pbcodec.TransactionReceiptHeader{Status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED}
+	}
+
+	return &pbcodec.Block{
+		Id:                          "0000000aaa",
+		Number:                      10,
+		Header:                      &pbcodec.BlockHeader{Timestamp: timestamp},
+		UnfilteredTransactionTraces: trxs,
+	}
+}
+
+func Test_blockMutations(t *testing.T) {
+	layouts := map[string]Layout{"atomicassets": &AtomicAssetsLayout{contract: "atomicassets"}}
+	current := func(key index.AssetKey) *index.Asset {
+		if key.ID == 1 {
+			return &index.Asset{Contract: "atomicassets", ID: 1, Owner: "alice", MintedAtBlockNum: 3}
+		}
+		return nil
+	}
+
+	blk := testBlock(t,
+		&pbcodec.TransactionTrace{Id: "trx1", DbOps: []*pbcodec.DBOp{
+			dbop(pbcodec.DBOp_OPERATION_REMOVE, "alice", atomicRow(1, 7), nil),
+			dbop(pbcodec.DBOp_OPERATION_INSERT, "bob", nil, atomicRow(1, 7)),
+			dbop(pbcodec.DBOp_OPERATION_INSERT, "carol", nil, atomicRow(2, -1)),
+			{Operation: pbcodec.DBOp_OPERATION_INSERT, Code: "atomicassets", TableName: "offers", Scope: "atomicassets", NewData: []byte(`{}`)},
+		}},
+		&pbcodec.TransactionTrace{Id: "trx2", DbOps: []*pbcodec.DBOp{
+			dbop(pbcodec.DBOp_OPERATION_UPDATE, "carol", atomicRow(2, -1), atomicRow(2, -1)),
+			dbop(pbcodec.DBOp_OPERATION_REMOVE, "bob", atomicRow(1, 7), nil),
+			dbop(pbcodec.DBOp_OPERATION_INSERT, "dave", nil, atomicRow(3, 8)),
+			dbop(pbcodec.DBOp_OPERATION_REMOVE, "dave", atomicRow(3, 8), nil),
+		}},
+	)
+
+	mutations := blockMutations(blk, layouts, jsonRowDecoder, current)
+	require.Len(t, mutations, 4)
+
+	type expected struct {
+		id       uint64
+		event    index.EventType
+		from, to string
+		trxID    string
+		minted   uint64
+	}
+
+	for i, exp := range []expected{
+		{1, index.TransferEvent, "alice", "bob", "trx1", 3},
+		{2, index.MintEvent, "", "carol", "trx1", 10},
+		{2, index.UpdateEvent, "carol", "carol", "trx2", 10},
+		{1, index.BurnEvent, "bob", "", "trx2", 0},
+	} {
+		m := mutations[i]
+		assert.Equal(t, exp.id, m.Key.ID, "mutation %d", i)
+		assert.Equal(t, exp.event, m.Event.Type, "mutation %d", i)
+		assert.Equal(t, exp.from, m.Event.From, "mutation %d", i)
+		assert.Equal(t, exp.to, m.Event.To, "mutation %d", i)
+		assert.Equal(t, exp.trxID, m.Event.TransactionID, "mutation %d", i)
+		assert.Equal(t, uint64(10), m.Event.BlockNum, "mutation %d", i)
+
+		if exp.event == index.BurnEvent {
+			assert.Nil(t, m.Asset, "mutation %d", i)
+			continue
+		}
+
+		require.NotNil(t, m.Asset, "mutation %d", i)
+		assert.Equal(t, exp.to, m.Asset.Owner, "mutation %d", i)
+		assert.Equal(t, exp.minted, m.Asset.MintedAtBlockNum, "mutation %d", i)
+		assert.Equal(t, uint64(10), m.Asset.UpdatedAtBlockNum, "mutation %d", i)
+	}
+}
+
+func TestNewLayoutsFromSpecs(t *testing.T) {
+	layouts, err := NewLayoutsFromSpecs([]string{"atomicassets@atomicassets", "simpleassets@simpleassets"})
+	require.NoError(t, err)
+	require.Len(t, layouts, 2)
+	assert.Equal(t, "assets", layouts[0].Table())
+	assert.Equal(t, "sassets", layouts[1].Table())
+
+	_, err = NewLayoutsFromSpecs([]string{"unknown@atomicassets"})
+	assert.Error(t, err)
+
+	_, err = NewLayoutsFromSpecs([]string{"atomicassets@atomicassets", "simpleassets@atomicassets"})
+	assert.Error(t, err)
+}
+
+func TestSimpleAssetsLayout_DecodeAsset(t *testing.T) {
+	layout := &SimpleAssetsLayout{contract: "simpleassets"}
+
+	asset, err := layout.DecodeAsset("alice", []byte(`{"id":"100000000000001","owner":"alice","author":"gamestudio","category":"weapons","idata":"{}","mdata":"{}"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &index.Asset{Contract: "simpleassets", ID: 100000000000001, Owner: "alice", Collection: "gamestudio", Schema: "weapons", TemplateID: -1}, asset)
+
+	_, err = layout.DecodeAsset("alice", []byte(`{"id":"1"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package nftmeta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/bstream"
+	pbblockmeta "github.com/streamingfast/pbgo/dfuse/blockmeta/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+)
+
+type NFTMeta struct {
+	*shutter.Shutter
+
+	source          bstream.Source
+	index           *index.Index
+	layouts         map[string]Layout
+	abiCodecCli     pbabicodec.DecoderClient
+	abisCache       map[string]*eos.ABI
+	saveEveryNBlock uint32
+	blockmeta       pbblockmeta.BlockIDClient
+}
+
+func NewNFTMeta(
+	idx *index.Index,
+	layouts []Layout,
+	abiCodecCli pbabicodec.DecoderClient,
+	saveEveryNBlock uint32,
+	blockmeta pbblockmeta.BlockIDClient,
+) *NFTMeta {
+	if blkTime := idx.GetHeadBlockTime(); !blkTime.IsZero() {
+		HeadTimeDrift.SetBlockTime(blkTime)
+	}
+
+	layoutsByContract := map[string]Layout{}
+	for _, layout := range layouts {
+		layoutsByContract[layout.Contract()] = layout
+	}
+
+	return &NFTMeta{
+		Shutter:         shutter.New(),
+		index:           idx,
+		layouts:         layoutsByContract,
+		abiCodecCli:     abiCodecCli,
+		abisCache:       map[string]*eos.ABI{},
+		saveEveryNBlock: saveEveryNBlock,
+		blockmeta:       blockmeta,
+	}
+}
+
+func (n *NFTMeta) Launch() error {
+	zlog.Info("launching pipeline")
+	go n.source.Run()
+
+	<-n.source.Terminated()
+	zlog.Info("source is done")
+
+	zlog.Info("export index")
+	if err := n.index.SaveToFile(); err != nil {
+		zlog.Error("error exporting index on shutdown", zap.Error(err))
+	}
+
+	if err := n.source.Err(); err != nil {
+		zlog.Error("source shutdown with error", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// decodeRow is a `rowDecoder` decoding rows with the ABI of the contract
+// at `blockNum`, ABIs are cached until the contract sets a new one.
+func (n *NFTMeta) decodeRow(blockNum uint32) rowDecoder {
+	return func(contract, table string, data []byte) (json.RawMessage, error) {
+		abi, err := n.getABI(contract, blockNum)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get ABI: %w", err)
+		}
+
+		return abi.DecodeTableRow(eos.TableName(table), data)
+	}
+}
+
+func (n *NFTMeta) getABI(contract string, blockNum uint32) (*eos.ABI, error) {
+	if abi, ok := n.abisCache[contract]; ok {
+		return abi, nil
+	}
+
+	zlog.Info("abi cache miss", zap.String("contract", contract), zap.Uint32("at_block_num", blockNum))
+	resp, err := n.abiCodecCli.GetAbi(context.Background(), &pbabicodec.GetAbiRequest{
+		Account:    contract,
+		AtBlockNum: blockNum,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get abi for contract %q: %w", contract, err)
+	}
+
+	var abi *eos.ABI
+	if err := json.Unmarshal([]byte(resp.JsonPayload), &abi); err != nil {
+		return nil, fmt.Errorf("unable to decode abi for contract %q: %w", contract, err)
+	}
+
+	n.abisCache[contract] = abi
+	return abi, nil
+}
@@ -0,0 +1,80 @@
+package nftmeta
+
+import (
+	"context"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/blockstream"
+	"github.com/streamingfast/bstream/forkable"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+func (n *NFTMeta) SetupPipeline(startBlock bstream.BlockRef, blockFilter func(blk *bstream.Block) error, blockstreamAddr string, blocksStore dstore.Store) {
+	var preprocessor bstream.PreprocessFunc
+	if blockFilter != nil {
+		preprocessor = bstream.PreprocessFunc(func(blk *bstream.Block) (interface{}, error) {
+			return nil, blockFilter(blk)
+		})
+	}
+
+	sf := bstream.SourceFromRefFactory(func(startBlockRef bstream.BlockRef, h bstream.Handler) bstream.Source {
+		if startBlockRef.ID() == "" {
+			startBlockRef = startBlock
+		}
+
+		archivedBlockSourceFactory := bstream.SourceFactory(func(subHandler bstream.Handler) bstream.Source {
+			return bstream.NewFileSource(blocksStore, startBlockRef.Num(), 2, preprocessor, subHandler)
+		})
+
+		zlog.Info("new live joining source", zap.Stringer("start_block", startBlockRef))
+		liveSourceFactory := bstream.SourceFactory(func(subHandler bstream.Handler) bstream.Source {
+			return blockstream.NewSource(
+				context.Background(),
+				blockstreamAddr,
+				200,
+				subHandler,
+			)
+		})
+
+		options := []bstream.JoiningSourceOption{bstream.JoiningSourceLogger(zlog)}
+		if startBlockRef.ID() != "" {
+			options = append(options, bstream.JoiningSourceTargetBlockID(startBlockRef.ID()))
+		}
+
+		return bstream.NewJoiningSource(
+			archivedBlockSourceFactory,
+			liveSourceFactory,
+			h,
+			options...)
+	})
+
+	// Reversible blocks feed the head mode of the index, see `ProcessBlock`
+	forkOptions := []forkable.Option{
+		forkable.WithLogger(zlog),
+		forkable.WithFilters(forkable.StepNew | forkable.StepRedo | forkable.StepUndo | forkable.StepIrreversible),
+	}
+	if startBlock.ID() != "" {
+		zlog.Info("setting exclusive LIB on forkable", zap.Stringer("start_block", startBlock))
+		forkOptions = append(forkOptions, forkable.WithExclusiveLIB(startBlock))
+	}
+	if n.blockmeta != nil {
+		zlog.Info("setting irreversibility checker on forkable")
+		forkOptions = append(forkOptions, forkable.WithIrreversibilityChecker(n.blockmeta, 2*time.Second))
+	}
+
+	forkableHandler := forkable.New(n, forkOptions...)
+
+	// EternalSource -> (headBlockHandler -> forkableHandler) wrapped in bstream.WithHeadMetrics handler
+	headBlockHandler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		n.index.SetHeadBlockTime(blk.Timestamp)
+		return forkableHandler.ProcessBlock(blk, obj)
+	})
+
+	n.source = bstream.NewEternalSource(sf, bstream.WithHeadMetrics(headBlockHandler, HeadBlockNum, HeadTimeDrift), bstream.EternalSourceWithLogger(zlog))
+
+	n.OnTerminating(func(e error) {
+		n.source.Shutdown(e)
+	})
+}
@@ -0,0 +1,78 @@
+package nftmeta
+
+import (
+	"fmt"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/forkable"
+	"go.uber.org/zap"
+)
+
+// ProcessBlock records the mutations of new blocks as reversible, drops
+// them when their block is forked out, and applies them to the index once
+// their block becomes irreversible.
+func (n *NFTMeta) ProcessBlock(block *bstream.Block, obj interface{}) error {
+	blk := block.ToNative().(*pbcodec.Block)
+	fObj := obj.(*forkable.ForkableObject)
+	ref := bstream.NewBlockRef(block.ID(), block.Num())
+
+	switch fObj.Step {
+	case forkable.StepNew, forkable.StepRedo:
+		n.forgetUpdatedABIs(blk)
+		n.index.PushReversible(ref, n.blockMutations(blk, true))
+
+	case forkable.StepUndo:
+		if err := n.index.PopReversible(ref); err != nil {
+			return fmt.Errorf("undo block %s: %w", ref, err)
+		}
+
+	case forkable.StepIrreversible:
+		if (blk.Number % 600) == 0 {
+			zlog.Info("process irreversible blk 1/600", zap.Stringer("block", block))
+		}
+
+		mutations, found := n.index.ReversibleMutations(ref)
+		if !found {
+			n.forgetUpdatedABIs(blk)
+			mutations = n.blockMutations(blk, false)
+		}
+
+		n.index.ApplyIrreversible(ref, mutations)
+		trackedAssetCount.SetUint64(uint64(n.index.AssetCount()))
+
+		if n.saveEveryNBlock != 0 && blk.Number%n.saveEveryNBlock == 0 {
+			if err := n.index.SaveToFile(); err != nil {
+				zlog.Error("error saving index", zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (n *NFTMeta) blockMutations(blk *pbcodec.Block, head bool) []*index.Mutation {
+	return blockMutations(blk, n.layouts, n.decodeRow(blk.Number), func(key index.AssetKey) *index.Asset {
+		return n.index.Asset(key, head)
+	})
+}
+
+// forgetUpdatedABIs evicts the cached ABI of the tracked contracts
+// setting a new one in the block
+func (n *NFTMeta) forgetUpdatedABIs(blk *pbcodec.Block) {
+	for _, trx := range blk.TransactionTraces() {
+		actionMatcher := blk.FilteringActionMatcher(trx)
+		for _, actTrace := range trx.ActionTraces {
+			if !actionMatcher.Matched(actTrace.ExecutionIndex) || actTrace.Receiver != "eosio" || actTrace.Action.Account != "eosio" || actTrace.Action.Name != "setabi" {
+				continue
+			}
+
+			account := actTrace.GetData("account").String()
+			if _, found := n.layouts[account]; found {
+				zlog.Info("tracked asset contract set a new abi", zap.String("contract", account), zap.Uint32("block_num", blk.Number))
+				delete(n.abisCache, account)
+			}
+		}
+	}
+}
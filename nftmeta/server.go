@@ -0,0 +1,237 @@
+package nftmeta
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/nftmeta/index"
+	pbnftmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/nftmeta/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/dgrpc"
+	pbhealth "github.com/streamingfast/pbgo/grpc/health/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type Server struct {
+	*shutter.Shutter
+
+	grpcServer          *grpc.Server
+	index               *index.Index
+	readinessMaxLatency time.Duration
+}
+
+func NewServer(idx *index.Index, readinessMaxLatency time.Duration) *Server {
+	s := &Server{
+		Shutter:             shutter.New(),
+		grpcServer:          dgrpc.NewServer(dgrpc.WithLogger(zlog)),
+		index:               idx,
+		readinessMaxLatency: readinessMaxLatency,
+	}
+
+	pbnftmeta.RegisterNFTMetaServer(s.grpcServer, s)
+	pbhealth.RegisterHealthServer(s.grpcServer, s)
+
+	return s
+}
+
+func (s *Server) Check(ctx context.Context, in *pbhealth.HealthCheckRequest) (*pbhealth.HealthCheckResponse, error) {
+	status := pbhealth.HealthCheckResponse_SERVING
+
+	if s.IsTerminating() {
+		status = pbhealth.HealthCheckResponse_NOT_SERVING
+	}
+	if s.readinessMaxLatency > 0 {
+		headBlkTime := s.index.GetHeadBlockTime()
+		if headBlkTime.IsZero() || time.Since(headBlkTime) > s.readinessMaxLatency {
+			status = pbhealth.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	return &pbhealth.HealthCheckResponse{
+		Status: status,
+	}, nil
+}
+
+func (s *Server) Serve(listenAddr string) {
+	zlog.Info("starting grpc server", zap.String("address", listenAddr))
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		s.Shutdown(fmt.Errorf("unable to listen on %q: %w", listenAddr, err))
+		return
+	}
+
+	err = s.grpcServer.Serve(listener)
+	if err == nil || err == grpc.ErrServerStopped {
+		zlog.Info("server shut down cleanly, nothing to do")
+		return
+	}
+
+	if err != nil {
+		s.Shutdown(err)
+	}
+}
+
+func (s *Server) Close() {
+	s.grpcServer.GracefulStop()
+}
+
+func (s *Server) GetAssetsByOwner(ctx context.Context, in *pbnftmeta.GetAssetsByOwnerRequest) (*pbnftmeta.AssetsResponse, error) {
+	zlog.Debug("get assets by owner",
+		zap.String("owner", in.Owner),
+		zap.Strings("filter_contracts", in.FilterContracts),
+		zap.Strings("filter_collections", in.FilterCollections),
+		zap.Uint32("limit", in.Limit),
+		zap.String("mode", in.Mode.String()),
+	)
+
+	if in.Owner == "" {
+		return nil, derr.Status(codes.InvalidArgument, "owner is required")
+	}
+
+	head := in.Mode == pbnftmeta.Mode_HEAD
+	blockRef := s.index.BlockRef(head)
+
+	out := &pbnftmeta.AssetsResponse{
+		Assets:     []*pbnftmeta.Asset{},
+		AtBlockNum: blockRef.Num(),
+		AtBlockId:  blockRef.ID(),
+	}
+
+	for _, asset := range s.index.AssetsByOwner(in.Owner, head) {
+		if !stringInFilter(asset.Contract, in.FilterContracts) || !stringInFilter(asset.Collection, in.FilterCollections) {
+			continue
+		}
+
+		out.Assets = append(out.Assets, assetToProto(asset))
+		if in.Limit != 0 && uint32(len(out.Assets)) >= in.Limit {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func (s *Server) GetAssetHistory(ctx context.Context, in *pbnftmeta.GetAssetHistoryRequest) (*pbnftmeta.AssetHistoryResponse, error) {
+	zlog.Debug("get asset history",
+		zap.String("contract", in.Contract),
+		zap.Uint64("asset_id", in.AssetId),
+		zap.String("mode", in.Mode.String()),
+	)
+
+	if in.Contract == "" {
+		return nil, derr.Status(codes.InvalidArgument, "contract is required")
+	}
+
+	head := in.Mode == pbnftmeta.Mode_HEAD
+	key := index.AssetKey{Contract: in.Contract, ID: in.AssetId}
+	blockRef := s.index.BlockRef(head)
+
+	events := s.index.AssetHistory(key, head)
+	asset := s.index.Asset(key, head)
+	if asset == nil && len(events) == 0 {
+		return nil, derr.Statusf(codes.NotFound, "asset %s not found", key)
+	}
+
+	out := &pbnftmeta.AssetHistoryResponse{
+		Events:     []*pbnftmeta.AssetEvent{},
+		AtBlockNum: blockRef.Num(),
+		AtBlockId:  blockRef.ID(),
+	}
+	if asset != nil {
+		out.Asset = assetToProto(asset)
+	}
+	for _, event := range events {
+		out.Events = append(out.Events, eventToProto(&event))
+	}
+
+	return out, nil
+}
+
+func (s *Server) GetCollectionStats(ctx context.Context, in *pbnftmeta.GetCollectionStatsRequest) (*pbnftmeta.CollectionStatsResponse, error) {
+	zlog.Debug("get collection stats",
+		zap.String("contract", in.Contract),
+		zap.String("collection", in.Collection),
+		zap.String("mode", in.Mode.String()),
+	)
+
+	if in.Contract == "" || in.Collection == "" {
+		return nil, derr.Status(codes.InvalidArgument, "contract and collection are required")
+	}
+
+	head := in.Mode == pbnftmeta.Mode_HEAD
+	key := index.CollectionKey{Contract: in.Contract, Collection: in.Collection}
+	blockRef := s.index.BlockRef(head)
+
+	stats := s.index.CollectionStats(key, head)
+	if stats == nil {
+		return nil, derr.Statusf(codes.NotFound, "collection %s not found", key)
+	}
+
+	return &pbnftmeta.CollectionStatsResponse{
+		Contract:   in.Contract,
+		Collection: in.Collection,
+		Assets:     stats.Assets,
+		Holders:    stats.Holders,
+		Schemas:    stats.Schemas,
+		Templates:  stats.Templates,
+		Minted:     stats.Minted,
+		Burned:     stats.Burned,
+		Transfers:  stats.Transfers,
+		AtBlockNum: blockRef.Num(),
+		AtBlockId:  blockRef.ID(),
+	}, nil
+}
+
+func assetToProto(asset *index.Asset) *pbnftmeta.Asset {
+	return &pbnftmeta.Asset{
+		Contract:          asset.Contract,
+		AssetId:           asset.ID,
+		Owner:             asset.Owner,
+		Collection:        asset.Collection,
+		Schema:            asset.Schema,
+		TemplateId:        asset.TemplateID,
+		MintedAtBlockNum:  asset.MintedAtBlockNum,
+		UpdatedAtBlockNum: asset.UpdatedAtBlockNum,
+	}
+}
+
+func eventToProto(event *index.Event) *pbnftmeta.AssetEvent {
+	return &pbnftmeta.AssetEvent{
+		Type:          pbnftmeta.AssetEvent_Type(event.Type),
+		From:          event.From,
+		To:            event.To,
+		BlockNum:      event.BlockNum,
+		BlockId:       event.BlockID,
+		BlockTime:     mustProtoTimestamp(event.BlockTime),
+		TransactionId: event.TransactionID,
+		Irreversible:  event.Irreversible,
+	}
+}
+
+func mustProtoTimestamp(in time.Time) *timestamp.Timestamp {
+	out, err := ptypes.TimestampProto(in)
+	if err != nil {
+		panic(fmt.Sprintf("invalid timestamp conversion %q: %s", in, err))
+	}
+	return out
+}
+
+func stringInFilter(str string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, f := range filter {
+		if f == str {
+			return true
+		}
+	}
+	return false
+}
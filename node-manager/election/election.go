@@ -0,0 +1,66 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Elector elects a single leader among the candidates campaigning on the
+// same election.
+type Elector interface {
+	// Campaign blocks until `candidate` is elected leader, or until the
+	// context is done.
+	Campaign(ctx context.Context, candidate string) error
+
+	// Resign gives up the leadership acquired by the last successful
+	// `Campaign`, letting another candidate be elected.
+	Resign(ctx context.Context) error
+
+	// Leader returns the current leader, empty when there is none.
+	Leader(ctx context.Context) (string, error)
+
+	// Lost returns a channel closed when the leadership acquired by the
+	// last successful `Campaign` is lost without having resigned, like
+	// when the elector cannot reach its backing store anymore.
+	Lost() <-chan struct{}
+
+	Close() error
+}
+
+// New creates an elector from a DSN:
+//
+//	etcd://<etcd-host>:<etcd-port>/<etcd-namespace>[?ttl=<seconds>]
+//	local://<name>
+//
+// The `local` scheme elects among the electors of the current process
+// sharing the same name, it is meant for development and tests.
+func New(dsn string) (Elector, error) {
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid election dsn %q: %w", dsn, err)
+	}
+
+	switch dsnURL.Scheme {
+	case "etcd":
+		return newEtcdElector(dsnURL)
+	case "local":
+		return NewLocalElector(dsnURL.Host), nil
+	}
+
+	return nil, fmt.Errorf("cannot resolve election scheme %q", dsnURL.Scheme)
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/dmesh/client/etcd"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.etcd.io/etcd/client/v3/namespace"
+	"go.uber.org/zap"
+)
+
+const electionPrefix = "/election/producer"
+
+// DefaultEtcdSessionTTL is the time, in seconds, after which the leadership
+// of an elector that cannot reach etcd anymore expires.
+var DefaultEtcdSessionTTL = 10
+
+type etcdElector struct {
+	client *clientv3.Client
+	ttl    int
+
+	lock     sync.Mutex
+	session  *concurrency.Session
+	election *concurrency.Election
+}
+
+func newEtcdElector(dsnURL *url.URL) (*etcdElector, error) {
+	addr, nspace, err := etcd.ParseDSNURL(dsnURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dsn: %w", err)
+	}
+
+	ttl := DefaultEtcdSessionTTL
+	if rawTTL := dsnURL.Query().Get("ttl"); rawTTL != "" {
+		ttl, err = strconv.Atoi(rawTTL)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid dsn ttl %q, expected a positive number of seconds", rawTTL)
+		}
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{addr},
+		DialTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create etcd client: %w", err)
+	}
+
+	zlog.Info("setting up etcd elector", zap.String("etcd_addr", addr), zap.String("etcd_namespace", nspace), zap.Int("ttl", ttl))
+	client.KV = namespace.NewKV(client.KV, nspace)
+	client.Watcher = namespace.NewWatcher(client.Watcher, nspace)
+	client.Lease = namespace.NewLease(client.Lease, nspace)
+
+	return &etcdElector{client: client, ttl: ttl}, nil
+}
+
+// currentElection returns the election bound to a live session, creating a
+// new session when the previous one expired.
+func (e *etcdElector) currentElection() (*concurrency.Election, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.session != nil {
+		select {
+		case <-e.session.Done():
+			e.session = nil
+		default:
+			return e.election, nil
+		}
+	}
+
+	session, err := concurrency.NewSession(e.client, concurrency.WithTTL(e.ttl))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create etcd session: %w", err)
+	}
+
+	e.session = session
+	e.election = concurrency.NewElection(session, electionPrefix)
+	return e.election, nil
+}
+
+func (e *etcdElector) Campaign(ctx context.Context, candidate string) error {
+	election, err := e.currentElection()
+	if err != nil {
+		return err
+	}
+
+	return election.Campaign(ctx, candidate)
+}
+
+func (e *etcdElector) Resign(ctx context.Context) error {
+	e.lock.Lock()
+	election := e.election
+	e.lock.Unlock()
+
+	if election == nil {
+		return nil
+	}
+	return election.Resign(ctx)
+}
+
+func (e *etcdElector) Leader(ctx context.Context) (string, error) {
+	election, err := e.currentElection()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := election.Leader(ctx)
+	if err != nil {
+		if errors.Is(err, concurrency.ErrElectionNoLeader) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return string(resp.Kvs[0].Value), nil
+}
+
+func (e *etcdElector) Lost() <-chan struct{} {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.session == nil {
+		lost := make(chan struct{})
+		close(lost)
+		return lost
+	}
+	return e.session.Done()
+}
+
+func (e *etcdElector) Close() error {
+	e.lock.Lock()
+	session := e.session
+	e.session = nil
+	e.lock.Unlock()
+
+	if session != nil {
+		session.Close()
+	}
+	return e.client.Close()
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election
+
+import (
+	"context"
+	"sync"
+)
+
+var localElectionsLock sync.Mutex
+var localElections = map[string]*localElection{}
+
+type localElection struct {
+	lock     sync.Mutex
+	leader   *LocalElector
+	released chan struct{}
+}
+
+// LocalElector elects among the electors of the current process created
+// with the same name.
+type LocalElector struct {
+	election  *localElection
+	candidate string
+	lost      chan struct{}
+}
+
+func NewLocalElector(name string) *LocalElector {
+	localElectionsLock.Lock()
+	defer localElectionsLock.Unlock()
+
+	election, found := localElections[name]
+	if !found {
+		election = &localElection{released: make(chan struct{})}
+		localElections[name] = election
+	}
+
+	return &LocalElector{election: election, lost: make(chan struct{})}
+}
+
+func (e *LocalElector) Campaign(ctx context.Context, candidate string) error {
+	for {
+		e.election.lock.Lock()
+		if e.election.leader == nil {
+			e.election.leader = e
+			e.candidate = candidate
+			e.lost = make(chan struct{})
+			e.election.lock.Unlock()
+			return nil
+		}
+		released := e.election.released
+		e.election.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+func (e *LocalElector) Resign(ctx context.Context) error {
+	e.election.lock.Lock()
+	defer e.election.lock.Unlock()
+
+	if e.election.leader == e {
+		e.release()
+	}
+	return nil
+}
+
+// Revoke takes the leadership away from the elector as if it had lost its
+// session, it is meant to simulate failures in tests.
+func (e *LocalElector) Revoke() {
+	e.election.lock.Lock()
+	defer e.election.lock.Unlock()
+
+	if e.election.leader == e {
+		close(e.lost)
+		e.release()
+	}
+}
+
+func (e *LocalElector) release() {
+	e.election.leader = nil
+	close(e.election.released)
+	e.election.released = make(chan struct{})
+}
+
+func (e *LocalElector) Leader(ctx context.Context) (string, error) {
+	e.election.lock.Lock()
+	defer e.election.lock.Unlock()
+
+	if e.election.leader == nil {
+		return "", nil
+	}
+	return e.election.leader.candidate, nil
+}
+
+func (e *LocalElector) Lost() <-chan struct{} {
+	e.election.lock.Lock()
+	defer e.election.lock.Unlock()
+
+	return e.lost
+}
+
+func (e *LocalElector) Close() error {
+	return e.Resign(context.Background())
+}
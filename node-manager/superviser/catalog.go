@@ -0,0 +1,321 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package superviser
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/dstore"
+)
+
+const snapshotSuffix = "-snapshot.bin"
+const snapshotMetadataSuffix = ".json"
+
+var snapshotNameRegex = regexp.MustCompile(`^(\d{10})-([0-9a-f]{64})-snapshot\.bin$`)
+
+// SnapshotMetadata is the sidecar stored next to each snapshot, as
+// `<snapshot name>.json`, once the snapshot was uploaded and validated.
+type SnapshotMetadata struct {
+	Name          string    `json:"name"`
+	BlockNum      uint32    `json:"block_num"`
+	BlockID       string    `json:"block_id"`
+	ChainID       string    `json:"chain_id"`
+	NodeosVersion string    `json:"nodeos_version"`
+	Size          int64     `json:"size"`
+	SHA256        string    `json:"sha256"`
+	TakenAt       time.Time `json:"taken_at"`
+}
+
+// SnapshotEntry is a snapshot found in the store, `Metadata` is nil
+// when the snapshot has no sidecar, either because it was taken before
+// the catalog existed or because its upload never completed.
+type SnapshotEntry struct {
+	Name     string            `json:"name"`
+	BlockNum uint32            `json:"block_num"`
+	BlockID  string            `json:"block_id"`
+	Verified bool              `json:"verified"`
+	Metadata *SnapshotMetadata `json:"metadata,omitempty"`
+}
+
+// SnapshotRetention defines how many snapshots are kept per time bucket,
+// the most recent snapshot of each of the last N hours, days and weeks
+// being kept. Zero disables a tier.
+type SnapshotRetention struct {
+	Hourly int
+	Daily  int
+	Weekly int
+}
+
+func (r SnapshotRetention) enabled() bool {
+	return r.Hourly > 0 || r.Daily > 0 || r.Weekly > 0
+}
+
+func snapshotMetadataName(snapshotName string) string {
+	return snapshotName + snapshotMetadataSuffix
+}
+
+// parseSnapshotName extracts the block num and block id out of a snapshot
+// file name, as generated by `TakeSnapshot`.
+func parseSnapshotName(name string) (blockNum uint32, blockID string, ok bool) {
+	groups := snapshotNameRegex.FindStringSubmatch(name)
+	if groups == nil {
+		return 0, "", false
+	}
+
+	num, err := strconv.ParseUint(groups[1], 10, 32)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return uint32(num), groups[2], true
+}
+
+// ListSnapshots returns the snapshots of the store sorted by block num,
+// each one paired with its metadata sidecar when present.
+func ListSnapshots(ctx context.Context, snapshotStore dstore.Store) ([]*SnapshotEntry, error) {
+	var names []string
+	sidecars := map[string]bool{}
+	err := snapshotStore.Walk(ctx, "", "", func(filename string) error {
+		switch {
+		case strings.HasSuffix(filename, snapshotSuffix):
+			names = append(names, filename)
+		case strings.HasSuffix(filename, snapshotSuffix+snapshotMetadataSuffix):
+			sidecars[strings.TrimSuffix(filename, snapshotMetadataSuffix)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to walk snapshot store: %w", err)
+	}
+
+	var out []*SnapshotEntry
+	for _, name := range names {
+		blockNum, blockID, ok := parseSnapshotName(name)
+		if !ok {
+			continue
+		}
+
+		entry := &SnapshotEntry{Name: name, BlockNum: blockNum, BlockID: blockID}
+		if sidecars[name] {
+			metadata, err := readSnapshotMetadata(ctx, snapshotStore, name)
+			if err != nil {
+				return nil, err
+			}
+
+			entry.Metadata = metadata
+			entry.Verified = metadata.Name == name && metadata.BlockNum == blockNum && metadata.Size > 0 && metadata.SHA256 != ""
+		}
+
+		out = append(out, entry)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].BlockNum < out[j].BlockNum })
+	return out, nil
+}
+
+func readSnapshotMetadata(ctx context.Context, snapshotStore dstore.Store, snapshotName string) (*SnapshotMetadata, error) {
+	reader, err := snapshotStore.OpenObject(ctx, snapshotMetadataName(snapshotName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open snapshot metadata of %q: %w", snapshotName, err)
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot metadata of %q: %w", snapshotName, err)
+	}
+
+	metadata := &SnapshotMetadata{}
+	if err := json.Unmarshal(content, metadata); err != nil {
+		return nil, fmt.Errorf("unable to decode snapshot metadata of %q: %w", snapshotName, err)
+	}
+
+	return metadata, nil
+}
+
+func writeSnapshotMetadata(ctx context.Context, snapshotStore dstore.Store, metadata *SnapshotMetadata) error {
+	content, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("unable to encode snapshot metadata: %w", err)
+	}
+
+	return snapshotStore.WriteObject(ctx, snapshotMetadataName(metadata.Name), bytes.NewReader(content))
+}
+
+// checksumReader computes the size and SHA-256 of everything read through it
+type checksumReader struct {
+	io.Reader
+	size   int64
+	digest hash.Hash
+}
+
+func newChecksumReader(reader io.Reader) *checksumReader {
+	r := &checksumReader{digest: sha256.New()}
+	r.Reader = io.TeeReader(reader, r.digest)
+	return r
+}
+
+func (r *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = r.Reader.Read(p)
+	r.size += int64(n)
+	return
+}
+
+func (r *checksumReader) SHA256() string {
+	return hex.EncodeToString(r.digest.Sum(nil))
+}
+
+// validateSnapshot reads back a snapshot from the store and rejects it
+// when its size or checksum does not match the expected ones, which is
+// the case of a truncated upload.
+func validateSnapshot(ctx context.Context, snapshotStore dstore.Store, name string, size int64, checksum string) error {
+	reader, err := snapshotStore.OpenObject(ctx, name)
+	if err != nil {
+		return fmt.Errorf("unable to open snapshot %q: %w", name, err)
+	}
+	defer reader.Close()
+
+	checksummed := newChecksumReader(reader)
+	if _, err := io.Copy(ioutil.Discard, checksummed); err != nil {
+		return fmt.Errorf("unable to read snapshot %q: %w", name, err)
+	}
+
+	return checkSnapshotChecksum(name, checksummed, size, checksum)
+}
+
+func checkSnapshotChecksum(name string, checksummed *checksumReader, size int64, checksum string) error {
+	if checksummed.size != size {
+		return fmt.Errorf("snapshot %q is truncated, expected %d bytes, got %d", name, size, checksummed.size)
+	}
+
+	if actual := checksummed.SHA256(); actual != checksum {
+		return fmt.Errorf("snapshot %q is corrupted, expected sha256 %s, got %s", name, checksum, actual)
+	}
+
+	return nil
+}
+
+// closestVerifiedSnapshots returns the verified snapshots at or below
+// `blockNum`, closest first. A `blockNum` of 0 means no upper bound. When
+// `chainID` is non-empty, snapshots of other chains are excluded.
+func closestVerifiedSnapshots(entries []*SnapshotEntry, blockNum uint32, chainID string) (out []*SnapshotEntry) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !entry.Verified {
+			continue
+		}
+
+		if blockNum != 0 && entry.BlockNum > blockNum {
+			continue
+		}
+
+		if chainID != "" && entry.Metadata.ChainID != "" && entry.Metadata.ChainID != chainID {
+			continue
+		}
+
+		out = append(out, entry)
+	}
+
+	return
+}
+
+// snapshotsToDelete returns the verified snapshots not retained by either
+// the `keep` most recent verified ones or the time based `retention` tiers,
+// the tiers bucket being derived from the time they were taken at.
+//
+// Unverified snapshots are never returned: they are either legacy ones
+// taken before the catalog existed, still usable by an explicit or
+// `latest` restore, or ones another instance sharing the store is still
+// uploading or validating. Truncated uploads are deleted by `TakeSnapshot`
+// itself. `entries` must be sorted by block num.
+func snapshotsToDelete(entries []*SnapshotEntry, keep int, retention SnapshotRetention) (out []*SnapshotEntry) {
+	kept := map[string]bool{}
+	for i := len(entries) - 1; i >= 0 && len(kept) < keep; i-- {
+		if entries[i].Verified {
+			kept[entries[i].Name] = true
+		}
+	}
+
+	retainTier := func(count int, bucket func(t time.Time) string) {
+		if count <= 0 {
+			return
+		}
+
+		seen := map[string]bool{}
+		for i := len(entries) - 1; i >= 0 && len(seen) < count; i-- {
+			entry := entries[i]
+			if !entry.Verified || entry.Metadata.TakenAt.IsZero() {
+				continue
+			}
+
+			key := bucket(entry.Metadata.TakenAt.UTC())
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			kept[entry.Name] = true
+		}
+	}
+
+	retainTier(retention.Hourly, func(t time.Time) string { return t.Format("2006-01-02T15") })
+	retainTier(retention.Daily, func(t time.Time) string { return t.Format("2006-01-02") })
+	retainTier(retention.Weekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+
+	for _, entry := range entries {
+		if entry.Verified && !kept[entry.Name] {
+			out = append(out, entry)
+		}
+	}
+
+	return
+}
+
+// parseSnapshotRestoreTarget interprets the snapshot name given to
+// `RestoreSnapshot`. It returns `restoreClosest` true with an upper bound
+// block num (0 for none) for `latest` and `below:<block num>`, otherwise
+// the name is an explicit snapshot name.
+func parseSnapshotRestoreTarget(snapshotName string) (blockNum uint32, restoreClosest bool, err error) {
+	if snapshotName == "latest" {
+		return 0, true, nil
+	}
+
+	if strings.HasPrefix(snapshotName, "below:") {
+		num, err := strconv.ParseUint(strings.TrimPrefix(snapshotName, "below:"), 10, 32)
+		if err != nil || num == 0 {
+			return 0, false, fmt.Errorf("invalid snapshot name %q, expected 'below:<block num>'", snapshotName)
+		}
+
+		return uint32(num), true, nil
+	}
+
+	return 0, false, nil
+}
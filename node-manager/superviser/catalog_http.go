@@ -0,0 +1,82 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package superviser
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+// SnapshotCatalogHTTPOption returns a function registering the snapshot
+// catalog routes on a manager API router:
+//
+//	GET /v1/snapshots                   all snapshots, most recent first
+//	GET /v1/snapshots?verified=true     only verified snapshots
+//	GET /v1/snapshots?below=<block num> verified snapshots at or below that block, closest first
+func SnapshotCatalogHTTPOption(snapshotStore dstore.Store, logger *zap.Logger) func(r *mux.Router) {
+	return func(r *mux.Router) {
+		r.HandleFunc("/v1/snapshots", func(w http.ResponseWriter, r *http.Request) {
+			listSnapshotsHandler(snapshotStore, logger, w, r)
+		}).Methods("GET")
+	}
+}
+
+func listSnapshotsHandler(snapshotStore dstore.Store, logger *zap.Logger, w http.ResponseWriter, r *http.Request) {
+	var belowBlockNum uint32
+	if below := r.FormValue("below"); below != "" {
+		num, err := strconv.ParseUint(below, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid 'below' parameter, expected a block num", http.StatusBadRequest)
+			return
+		}
+		belowBlockNum = uint32(num)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1*time.Minute)
+	defer cancel()
+
+	entries, err := ListSnapshots(ctx, snapshotStore)
+	if err != nil {
+		logger.Warn("unable to list snapshots", zap.Error(err))
+		http.Error(w, "unable to list snapshots", http.StatusInternalServerError)
+		return
+	}
+
+	out := []*SnapshotEntry{}
+	switch {
+	case belowBlockNum != 0:
+		out = append(out, closestVerifiedSnapshots(entries, belowBlockNum, "")...)
+	default:
+		verifiedOnly := r.FormValue("verified") == "true"
+		for i := len(entries) - 1; i >= 0; i-- {
+			if verifiedOnly && !entries[i].Verified {
+				continue
+			}
+			out = append(out, entries[i])
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		logger.Warn("unable to write snapshots response", zap.Error(err))
+	}
+}
@@ -0,0 +1,158 @@
+package superviser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSnapshotName(blockNum uint32) string {
+	return fmt.Sprintf("%010d-%s-snapshot.bin", blockNum, strings.Repeat("a", 64))
+}
+
+func testSnapshotEntry(blockNum uint32, takenAt string) *SnapshotEntry {
+	entry := &SnapshotEntry{Name: testSnapshotName(blockNum), BlockNum: blockNum}
+	if takenAt != "" {
+		t, err := time.Parse(time.RFC3339, takenAt)
+		if err != nil {
+			panic(err)
+		}
+
+		entry.Verified = true
+		entry.Metadata = &SnapshotMetadata{Name: entry.Name, BlockNum: blockNum, ChainID: "cc", TakenAt: t}
+	}
+	return entry
+}
+
+func entryNames(entries []*SnapshotEntry) (out []uint32) {
+	for _, entry := range entries {
+		out = append(out, entry.BlockNum)
+	}
+	return
+}
+
+func TestListSnapshots(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	ctx := context.Background()
+
+	content := []byte("snapshot content")
+	checksummed := newChecksumReader(strings.NewReader(string(content)))
+	_, err := checksummed.Read(make([]byte, 64))
+	require.NoError(t, err)
+
+	store.SetFile(testSnapshotName(200), content)
+	store.SetFile(testSnapshotName(100), []byte("legacy"))
+	store.SetFile("unrelated.txt", []byte("other"))
+	require.NoError(t, writeSnapshotMetadata(ctx, store, &SnapshotMetadata{
+		Name:     testSnapshotName(200),
+		BlockNum: 200,
+		Size:     checksummed.size,
+		SHA256:   checksummed.SHA256(),
+	}))
+
+	entries, err := ListSnapshots(ctx, store)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, uint32(100), entries[0].BlockNum)
+	assert.False(t, entries[0].Verified)
+	assert.Nil(t, entries[0].Metadata)
+
+	assert.Equal(t, uint32(200), entries[1].BlockNum)
+	assert.True(t, entries[1].Verified)
+	assert.Equal(t, strings.Repeat("a", 64), entries[1].BlockID)
+
+	assert.NoError(t, validateSnapshot(ctx, store, testSnapshotName(200), checksummed.size, checksummed.SHA256()))
+
+	store.SetFile(testSnapshotName(200), content[:4])
+	assert.Error(t, validateSnapshot(ctx, store, testSnapshotName(200), checksummed.size, checksummed.SHA256()))
+}
+
+func TestClosestVerifiedSnapshots(t *testing.T) {
+	entries := []*SnapshotEntry{
+		testSnapshotEntry(100, "2021-01-01T00:00:00Z"),
+		testSnapshotEntry(200, ""),
+		testSnapshotEntry(300, "2021-01-01T02:00:00Z"),
+		testSnapshotEntry(400, "2021-01-01T03:00:00Z"),
+	}
+	entries[3].Metadata.ChainID = "other"
+
+	tests := []struct {
+		name     string
+		blockNum uint32
+		chainID  string
+		expected []uint32
+	}{
+		{"no bound", 0, "", []uint32{400, 300, 100}},
+		{"exact block", 300, "", []uint32{300, 100}},
+		{"skips unverified", 299, "", []uint32{100}},
+		{"nothing below", 99, "", nil},
+		{"filters chain", 0, "cc", []uint32{300, 100}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, entryNames(closestVerifiedSnapshots(entries, test.blockNum, test.chainID)))
+		})
+	}
+}
+
+func TestSnapshotsToDelete(t *testing.T) {
+	entries := []*SnapshotEntry{
+		testSnapshotEntry(1, "2020-12-30T10:00:00Z"), // previous ISO week
+		testSnapshotEntry(2, "2021-01-10T09:00:00Z"),
+		testSnapshotEntry(3, "2021-01-10T10:15:00Z"),
+		testSnapshotEntry(4, "2021-01-11T10:30:00Z"),
+		testSnapshotEntry(5, ""),
+		testSnapshotEntry(6, "2021-01-11T11:00:00Z"),
+		testSnapshotEntry(7, "2021-01-11T11:30:00Z"),
+	}
+
+	tests := []struct {
+		name      string
+		keep      int
+		retention SnapshotRetention
+		expected  []uint32
+	}{
+		{"keep only", 2, SnapshotRetention{}, []uint32{1, 2, 3, 4}},
+		{"keep counts verified only", 3, SnapshotRetention{}, []uint32{1, 2, 3}},
+		{"hourly", 0, SnapshotRetention{Hourly: 2}, []uint32{1, 2, 3, 6}},
+		{"daily", 0, SnapshotRetention{Daily: 2}, []uint32{1, 2, 4, 6}},
+		{"weekly", 0, SnapshotRetention{Weekly: 3}, []uint32{2, 4, 6}},
+		{"combined", 1, SnapshotRetention{Hourly: 1, Daily: 3}, []uint32{2, 4, 6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, entryNames(snapshotsToDelete(entries, test.keep, test.retention)))
+		})
+	}
+
+	legacyEntries := []*SnapshotEntry{testSnapshotEntry(1, ""), testSnapshotEntry(2, ""), testSnapshotEntry(3, "2021-01-11T11:30:00Z")}
+	assert.Nil(t, snapshotsToDelete(legacyEntries, 1, SnapshotRetention{}), "unverified snapshots are never deleted")
+}
+
+func TestParseSnapshotRestoreTarget(t *testing.T) {
+	blockNum, closest, err := parseSnapshotRestoreTarget("latest")
+	require.NoError(t, err)
+	assert.True(t, closest)
+	assert.Equal(t, uint32(0), blockNum)
+
+	blockNum, closest, err = parseSnapshotRestoreTarget("below:1234")
+	require.NoError(t, err)
+	assert.True(t, closest)
+	assert.Equal(t, uint32(1234), blockNum)
+
+	_, closest, err = parseSnapshotRestoreTarget(testSnapshotName(10))
+	require.NoError(t, err)
+	assert.False(t, closest)
+
+	_, _, err = parseSnapshotRestoreTarget("below:abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,306 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package superviser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/node-manager/election"
+	"go.uber.org/zap"
+)
+
+// ProducerFailoverOptions configures the election of the active producer
+// among several node managers, replacing the static `ProducerHostname`.
+// Exactly one node manager, the elected one, resumes production, the
+// others staying paused until the leader steps down or loses leadership.
+type ProducerFailoverOptions struct {
+	// Elector is the election shared by all the node managers of the producer
+	Elector election.Elector
+
+	// CheckInterval is the delay between two health checks (2s if zero)
+	CheckInterval time.Duration
+
+	// MaxHeadBlockLag makes the leader step down, and keeps candidates from
+	// campaigning or taking over, when the head block time of their node is
+	// older than this, 0 to disable.
+	MaxHeadBlockLag time.Duration
+
+	// MaxProductionGap makes the leader step down when its node did not
+	// produce any block for that long, 0 to disable. It must exceed the
+	// interval between two production rounds of the producer.
+	MaxProductionGap time.Duration
+
+	// TakeoverDelay is waited after being elected, before resuming
+	// production, it must exceed the time a former leader takes to notice
+	// it lost leadership (the election TTL).
+	TakeoverDelay time.Duration
+
+	// HandoffTimeout bounds the wait for the end of the production round
+	// when stepping down from a node that still runs (2m if zero).
+	HandoffTimeout time.Duration
+
+	// RecampaignDelay is waited after stepping down before campaigning
+	// again, giving other candidates a chance to be elected (30s if zero).
+	RecampaignDelay time.Duration
+}
+
+// producerNode is the part of the superviser driven by the failover
+type producerNode interface {
+	IsRunning() bool
+	ResumeProduction() error
+	PauseProduction() error
+	WaitUntilEndOfNextProductionRound(timeout time.Duration) error
+	HeadBlockTime() time.Time
+	LastProducedTime() time.Time
+	SetActiveProducer(active bool)
+}
+
+type producerFailover struct {
+	node        producerNode
+	candidate   string
+	options     *ProducerFailoverOptions
+	terminating <-chan struct{}
+	logger      *zap.Logger
+}
+
+func newProducerFailover(node producerNode, candidate string, options *ProducerFailoverOptions, terminating <-chan struct{}, logger *zap.Logger) *producerFailover {
+	if options.CheckInterval == 0 {
+		options.CheckInterval = 2 * time.Second
+	}
+	if options.HandoffTimeout == 0 {
+		options.HandoffTimeout = 2 * time.Minute
+	}
+	if options.RecampaignDelay == 0 {
+		options.RecampaignDelay = 30 * time.Second
+	}
+
+	return &producerFailover{
+		node:        node,
+		candidate:   candidate,
+		options:     options,
+		terminating: terminating,
+		logger:      logger.With(zap.String("candidate", candidate)),
+	}
+}
+
+// run campaigns for the active producer role whenever the node is healthy
+// and leads until it steps down, until terminating.
+//
+// This should be performed through a go routine.
+func (f *producerFailover) run() {
+	defer f.options.Elector.Close()
+
+	for {
+		if !f.sleep(0) {
+			return
+		}
+
+		if reason := f.unhealthyReason(false, time.Time{}); reason != "" {
+			f.logger.Debug("not campaigning for producer role, node is unhealthy", zap.String("reason", reason))
+			if !f.sleep(f.options.CheckInterval) {
+				return
+			}
+			continue
+		}
+
+		f.logger.Info("campaigning for producer role")
+		if err := f.campaign(); err != nil {
+			if err != context.Canceled {
+				f.logger.Warn("producer role campaign failed", zap.Error(err))
+			}
+			if !f.sleep(f.options.CheckInterval) {
+				return
+			}
+			continue
+		}
+
+		if !f.lead() {
+			return
+		}
+
+		if !f.sleep(f.options.RecampaignDelay) {
+			return
+		}
+	}
+}
+
+// campaign blocks until elected, aborting when the node becomes unhealthy
+func (f *producerFailover) campaign() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(f.options.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-f.terminating:
+				cancel()
+				return
+			case <-ticker.C:
+				if reason := f.unhealthyReason(false, time.Time{}); reason != "" {
+					f.logger.Info("aborting producer role campaign, node became unhealthy", zap.String("reason", reason))
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return f.options.Elector.Campaign(ctx, f.candidate)
+}
+
+// lead holds the producer role until stepping down, it returns false when
+// terminating.
+func (f *producerFailover) lead() bool {
+	lost := f.options.Elector.Lost()
+	f.logger.Info("elected for producer role, waiting before taking over", zap.Duration("takeover_delay", f.options.TakeoverDelay))
+
+	select {
+	case <-lost:
+		f.logger.Warn("lost producer role before taking over")
+		return true
+	case <-f.terminating:
+		f.resign()
+		return false
+	case <-time.After(f.options.TakeoverDelay):
+	}
+
+	// The node may have become unhealthy while waiting, it must not take over in this case
+	if reason := f.unhealthyReason(false, time.Time{}); reason != "" {
+		f.logger.Warn("node became unhealthy before taking over, stepping down", zap.String("reason", reason))
+		f.stepDown(false)
+		return true
+	}
+
+	f.node.SetActiveProducer(true)
+	if err := f.node.ResumeProduction(); err != nil {
+		f.logger.Error("unable to resume production, stepping down", zap.Error(err))
+		f.stepDown(false)
+		return true
+	}
+
+	leadingSince := time.Now()
+	f.logger.Info("took over producer role")
+
+	ticker := time.NewTicker(f.options.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lost:
+			// Someone else may be elected already, there is no time for a graceful handoff
+			f.logger.Error("lost producer role, pausing production immediately")
+			f.pause()
+			return true
+
+		case <-f.terminating:
+			f.logger.Info("terminating, handing off producer role")
+			f.stepDown(f.node.IsRunning())
+			return false
+
+		case <-ticker.C:
+			if reason := f.unhealthyReason(true, leadingSince); reason != "" {
+				f.logger.Warn("node is unhealthy, handing off producer role", zap.String("reason", reason))
+				f.stepDown(f.node.IsRunning())
+				return true
+			}
+		}
+	}
+}
+
+// stepDown pauses production, at the end of the production round when
+// `graceful`, then resigns so another candidate is elected.
+func (f *producerFailover) stepDown(graceful bool) {
+	if graceful {
+		f.logger.Info("waiting for end of production round before handing off")
+		if err := f.node.WaitUntilEndOfNextProductionRound(f.options.HandoffTimeout); err != nil {
+			f.logger.Warn("production round did not end in time, pausing anyway", zap.Error(err))
+		}
+	}
+
+	f.pause()
+	f.resign()
+}
+
+func (f *producerFailover) pause() {
+	f.node.SetActiveProducer(false)
+	if f.node.IsRunning() {
+		if err := f.node.PauseProduction(); err != nil {
+			f.logger.Error("unable to pause production", zap.Error(err))
+		}
+	}
+}
+
+func (f *producerFailover) resign() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := f.options.Elector.Resign(ctx); err != nil {
+		f.logger.Warn("unable to resign from producer role", zap.Error(err))
+	}
+}
+
+// unhealthyReason returns why the node should not hold the producer role,
+// empty when healthy.
+func (f *producerFailover) unhealthyReason(leading bool, leadingSince time.Time) string {
+	if !f.node.IsRunning() {
+		return "nodeos is not running"
+	}
+
+	headBlockTime := f.node.HeadBlockTime()
+	if headBlockTime.IsZero() {
+		return "head block is not known yet"
+	}
+
+	// A lagging candidate would produce on a stale fork, it is unhealthy as much as a lagging leader
+	if f.options.MaxHeadBlockLag > 0 {
+		if lag := time.Since(headBlockTime); lag > f.options.MaxHeadBlockLag {
+			return fmt.Sprintf("head block is %s behind", lag.Round(time.Second))
+		}
+	}
+
+	if !leading {
+		return ""
+	}
+
+	if f.options.MaxProductionGap > 0 && time.Since(leadingSince) > f.options.MaxProductionGap {
+		lastProduced := f.node.LastProducedTime()
+		if lastProduced.Before(leadingSince) {
+			lastProduced = leadingSince
+		}
+
+		if gap := time.Since(lastProduced); gap > f.options.MaxProductionGap {
+			return fmt.Sprintf("no block produced for %s", gap.Round(time.Second))
+		}
+	}
+
+	return ""
+}
+
+// sleep waits for `delay`, returning false when terminating
+func (f *producerFailover) sleep(delay time.Duration) bool {
+	select {
+	case <-f.terminating:
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
@@ -0,0 +1,192 @@
+package superviser
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/node-manager/election"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeProducerNode struct {
+	lock      sync.Mutex
+	running   bool
+	producing bool
+	active    bool
+	roundEnds int
+	headLag   time.Duration
+}
+
+func (n *fakeProducerNode) IsRunning() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.running
+}
+
+func (n *fakeProducerNode) setRunning(running bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.running = running
+}
+
+func (n *fakeProducerNode) ResumeProduction() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.producing = true
+	return nil
+}
+
+func (n *fakeProducerNode) PauseProduction() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.producing = false
+	return nil
+}
+
+func (n *fakeProducerNode) WaitUntilEndOfNextProductionRound(timeout time.Duration) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.roundEnds++
+	return nil
+}
+
+func (n *fakeProducerNode) HeadBlockTime() time.Time {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return time.Now().Add(-n.headLag)
+}
+
+func (n *fakeProducerNode) setHeadLag(lag time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.headLag = lag
+}
+
+func (n *fakeProducerNode) LastProducedTime() time.Time { return time.Now() }
+
+func (n *fakeProducerNode) SetActiveProducer(active bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.active = active
+}
+
+func (n *fakeProducerNode) isProducing() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.producing && n.active
+}
+
+func newTestFailover(name, candidate string) (*fakeProducerNode, *election.LocalElector, chan struct{}) {
+	return newTestFailoverOnNode(name, candidate, &fakeProducerNode{running: true})
+}
+
+func newTestFailoverOnNode(name, candidate string, node *fakeProducerNode) (*fakeProducerNode, *election.LocalElector, chan struct{}) {
+	elector := election.NewLocalElector(name)
+	terminating := make(chan struct{})
+
+	failover := newProducerFailover(node, candidate, &ProducerFailoverOptions{
+		Elector:         elector,
+		CheckInterval:   5 * time.Millisecond,
+		TakeoverDelay:   10 * time.Millisecond,
+		RecampaignDelay: 50 * time.Millisecond,
+		MaxHeadBlockLag: time.Minute,
+	}, terminating, zap.NewNop())
+
+	go failover.run()
+	return node, elector, terminating
+}
+
+func producingCount(nodes ...*fakeProducerNode) (count int) {
+	for _, node := range nodes {
+		if node.isProducing() {
+			count++
+		}
+	}
+	return
+}
+
+func TestProducerFailover(t *testing.T) {
+	node1, elector1, terminating1 := newTestFailover(t.Name(), "bp1")
+	defer close(terminating1)
+
+	require.Eventually(t, func() bool { return node1.isProducing() }, time.Second, time.Millisecond)
+
+	node2, _, terminating2 := newTestFailover(t.Name(), "bp2")
+	defer close(terminating2)
+
+	// The standby never produces while the leader is healthy
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, producingCount(node1, node2))
+	assert.True(t, node1.isProducing())
+
+	// The leader's nodeos dies, the standby takes over
+	node1.setRunning(false)
+	require.Eventually(t, func() bool { return node2.isProducing() }, time.Second, time.Millisecond)
+	assert.False(t, node1.isProducing())
+
+	leader, err := elector1.Leader(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "bp2", leader)
+}
+
+func TestProducerFailover_LaggingCandidate(t *testing.T) {
+	node1, _, terminating1 := newTestFailover(t.Name(), "bp1")
+	defer close(terminating1)
+
+	require.Eventually(t, func() bool { return node1.isProducing() }, time.Second, time.Millisecond)
+
+	node2, elector2, terminating2 := newTestFailoverOnNode(t.Name(), "bp2", &fakeProducerNode{running: true, headLag: time.Hour})
+	defer close(terminating2)
+
+	// The leader dies, the lagging standby must not take over
+	node1.setRunning(false)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, producingCount(node1, node2))
+
+	leader, err := elector2.Leader(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, "bp2", leader)
+
+	// Once caught up, it does
+	node2.setHeadLag(0)
+	require.Eventually(t, func() bool { return node2.isProducing() }, time.Second, time.Millisecond)
+}
+
+func TestProducerFailover_LostLeadership(t *testing.T) {
+	node1, elector1, terminating1 := newTestFailover(t.Name(), "bp1")
+	defer close(terminating1)
+
+	require.Eventually(t, func() bool { return node1.isProducing() }, time.Second, time.Millisecond)
+
+	node2, _, terminating2 := newTestFailover(t.Name(), "bp2")
+	defer close(terminating2)
+
+	elector1.Revoke()
+	require.Eventually(t, func() bool { return !node1.isProducing() }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return node2.isProducing() }, time.Second, time.Millisecond)
+
+	// Leadership was lost, the former leader must not have waited for a graceful handoff
+	node1.lock.Lock()
+	defer node1.lock.Unlock()
+	assert.Equal(t, 0, node1.roundEnds)
+}
+
+func TestProducerFailover_GracefulHandoffOnTerminate(t *testing.T) {
+	node1, _, terminating1 := newTestFailover(t.Name(), "bp1")
+	require.Eventually(t, func() bool { return node1.isProducing() }, time.Second, time.Millisecond)
+
+	node2, _, terminating2 := newTestFailover(t.Name(), "bp2")
+	defer close(terminating2)
+
+	close(terminating1)
+	require.Eventually(t, func() bool { return node2.isProducing() }, time.Second, time.Millisecond)
+	assert.False(t, node1.isProducing())
+
+	node1.lock.Lock()
+	defer node1.lock.Unlock()
+	assert.Equal(t, 1, node1.roundEnds)
+}
@@ -32,6 +32,10 @@ func (s *NodeosSuperviser) Monitor() {
 
 	getInfoFailureCount := 0
 
+	if s.failover != nil {
+		go s.failover.run()
+	}
+
 	for {
 		time.Sleep(5 * time.Second)
 		if !s.IsRunning() {
@@ -54,6 +58,9 @@ func (s *NodeosSuperviser) Monitor() {
 		s.lastBlockSeen = uint32(chainInfo.HeadBlockNum)
 
 		lastHeadBlockTime = chainInfo.HeadBlockTime.Time
+		s.productionStateLock.Lock()
+		s.lastHeadBlockTime = lastHeadBlockTime
+		s.productionStateLock.Unlock()
 
 		if s.headBlockUpdateFunc != nil {
 			s.headBlockUpdateFunc(uint64(chainInfo.HeadBlockNum), chainInfo.HeadBlockID.String(), chainInfo.HeadBlockTime.Time)
@@ -32,7 +32,9 @@ func (s *NodeosSuperviser) ResumeProduction() error {
 		return err
 	}
 
+	s.productionStateLock.Lock()
 	s.producerHostname = s.options.Hostname
+	s.productionStateLock.Unlock()
 
 	return nil
 }
@@ -60,7 +62,37 @@ func (s *NodeosSuperviser) IsProducing() (bool, error) {
 	return !isPaused, nil
 }
 
+// SetActiveProducer marks this node as the active producer, or not, it is
+// driven by the producer failover when enabled.
+func (s *NodeosSuperviser) SetActiveProducer(active bool) {
+	s.productionStateLock.Lock()
+	defer s.productionStateLock.Unlock()
+
+	if active {
+		s.producerHostname = s.options.Hostname
+	} else {
+		s.producerHostname = ""
+	}
+}
+
+func (s *NodeosSuperviser) HeadBlockTime() time.Time {
+	s.productionStateLock.Lock()
+	defer s.productionStateLock.Unlock()
+
+	return s.lastHeadBlockTime
+}
+
+func (s *NodeosSuperviser) LastProducedTime() time.Time {
+	s.productionStateLock.Lock()
+	defer s.productionStateLock.Unlock()
+
+	return s.productionStateLastProduced
+}
+
 func (s *NodeosSuperviser) IsActiveProducer() bool {
+	s.productionStateLock.Lock()
+	defer s.productionStateLock.Unlock()
+
 	return s.forceProduction || (s.options.Hostname != "" && s.producerHostname == s.options.Hostname)
 }
 
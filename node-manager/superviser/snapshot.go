@@ -16,6 +16,7 @@ package superviser
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -39,7 +40,8 @@ func (s *NodeosSuperviser) TakeSnapshot(snapshotStore dstore.Store, numberOfSnap
 		return fmt.Errorf("api call failed: %s", err)
 	}
 
-	filename := fmt.Sprintf("%010d-%s-snapshot.bin", eos.BlockNum(snapshot.HeadBlockID), snapshot.HeadBlockID)
+	blockNum := eos.BlockNum(snapshot.HeadBlockID)
+	filename := fmt.Sprintf("%010d-%s-snapshot.bin", blockNum, snapshot.HeadBlockID)
 
 	s.Logger.Info("saving state snapshot", zap.String("destination", filename))
 	fileReader, err := os.Open(snapshot.SnapshotName)
@@ -48,18 +50,49 @@ func (s *NodeosSuperviser) TakeSnapshot(snapshotStore dstore.Store, numberOfSnap
 	}
 	defer fileReader.Close()
 
+	stat, err := fileReader.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat snapshot file: %s", err)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
 
-	err = snapshotStore.WriteObject(ctx, filename, fileReader)
+	checksummed := newChecksumReader(fileReader)
+	err = snapshotStore.WriteObject(ctx, filename, checksummed)
 	if err != nil {
 		return fmt.Errorf("cannot write snapshot to store: %s", err)
 	}
 
+	checksum := checksummed.SHA256()
+	if checksummed.size != stat.Size() {
+		return s.rejectSnapshot(snapshotStore, filename, fmt.Errorf("snapshot %q was truncated while uploading, expected %d bytes, uploaded %d", filename, stat.Size(), checksummed.size))
+	}
+
+	s.Logger.Info("validating uploaded state snapshot", zap.String("snapshot_name", filename), zap.Int64("size", checksummed.size), zap.String("sha256", checksum))
+	if err := validateSnapshot(ctx, snapshotStore, filename, checksummed.size, checksum); err != nil {
+		return s.rejectSnapshot(snapshotStore, filename, err)
+	}
+
+	chainID, nodeosVersion := s.chainIdentity()
+	err = writeSnapshotMetadata(ctx, snapshotStore, &SnapshotMetadata{
+		Name:          filename,
+		BlockNum:      blockNum,
+		BlockID:       snapshot.HeadBlockID,
+		ChainID:       chainID,
+		NodeosVersion: nodeosVersion,
+		Size:          checksummed.size,
+		SHA256:        checksum,
+		TakenAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot write snapshot metadata to store: %s", err)
+	}
+
 	metrics.NodeosSuccessfulSnapshots.Inc()
 
-	if numberOfSnapshotsToKeep > 0 {
-		err := cleanupSnapshots(snapshotStore, numberOfSnapshotsToKeep)
+	if numberOfSnapshotsToKeep > 0 || s.options.SnapshotRetention.enabled() {
+		err := cleanupSnapshots(snapshotStore, numberOfSnapshotsToKeep, s.options.SnapshotRetention, s.Logger)
 		if err != nil {
 			s.Logger.Warn("cannot cleanup snapshots", zap.Error(err))
 		}
@@ -68,51 +101,59 @@ func (s *NodeosSuperviser) TakeSnapshot(snapshotStore dstore.Store, numberOfSnap
 	return os.Remove(snapshot.SnapshotName)
 }
 
-func cleanupSnapshots(snapshotStore dstore.Store, keep int) error {
+// rejectSnapshot deletes a snapshot that failed validation from the
+// store so it can never be picked up by a restore.
+func (s *NodeosSuperviser) rejectSnapshot(snapshotStore dstore.Store, snapshotName string, cause error) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 
-	var snapshots []string
-	err := snapshotStore.Walk(ctx, "", "", func(filename string) (err error) {
-		snapshots = append(snapshots, filename)
-		return nil
-	})
-	if err != nil {
-		return err
+	if err := snapshotStore.DeleteObject(ctx, snapshotName); err != nil {
+		s.Logger.Warn("cannot delete rejected snapshot", zap.String("snapshot_name", snapshotName), zap.Error(err))
 	}
 
-	if len(snapshots) <= keep {
-		return nil
-	}
+	return fmt.Errorf("snapshot rejected: %w", cause)
+}
 
-	for _, s := range snapshots[:len(snapshots)-keep] {
-		err := snapshotStore.DeleteObject(ctx, s)
-		if err != nil {
-			return err
-		}
+// chainIdentity returns the chain id and the version string of the managed
+// `nodeos`, asking the node directly as monitoring might not be enabled.
+func (s *NodeosSuperviser) chainIdentity() (chainID string, nodeosVersion string) {
+	info, err := s.api.GetInfo(context.Background())
+	if err != nil {
+		s.Logger.Warn("cannot get chain info for snapshot metadata, using last values seen", zap.Error(err))
+		return hex.EncodeToString(s.chainID), s.serverVersionString
 	}
-	return nil
 
+	return hex.EncodeToString(info.ChainID), info.ServerVersionString
 }
 
-func findLatestSnapshotName(snapshotStore dstore.Store) (string, error) {
+func cleanupSnapshots(snapshotStore dstore.Store, keep int, retention SnapshotRetention, logger *zap.Logger) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 
-	latestSnapshot := ""
-	err := snapshotStore.Walk(ctx, "", "", func(filename string) (err error) {
-		latestSnapshot = filename
-		return nil
-	})
-
+	entries, err := ListSnapshots(ctx, snapshotStore)
 	if err != nil {
-		return "", fmt.Errorf("unable to find latest snapshot: %s", err)
+		return err
+	}
+
+	for _, entry := range snapshotsToDelete(entries, keep, retention) {
+		logger.Info("deleting snapshot not retained anymore", zap.String("snapshot_name", entry.Name), zap.Bool("verified", entry.Verified))
+		err := snapshotStore.DeleteObject(ctx, entry.Name)
+		if err != nil {
+			return err
+		}
+
+		if entry.Metadata != nil {
+			err := snapshotStore.DeleteObject(ctx, snapshotMetadataName(entry.Name))
+			if err != nil {
+				return err
+			}
+		}
 	}
-	return latestSnapshot, nil
+	return nil
 
 }
 
-func (s *NodeosSuperviser) downloadSnapshotFile(snapshotName string, snapshotStore dstore.Store) (string, error) {
+func (s *NodeosSuperviser) downloadSnapshotFile(snapshotName string, metadata *SnapshotMetadata, snapshotStore dstore.Store) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
 
@@ -130,41 +171,57 @@ func (s *NodeosSuperviser) downloadSnapshotFile(snapshotName string, snapshotSto
 	}
 	defer w.Close()
 
-	_, err = io.Copy(w, reader)
+	checksummed := newChecksumReader(reader)
+	_, err = io.Copy(w, checksummed)
 	if err != nil {
 		return "", err
 	}
+
+	if metadata != nil {
+		if err := checkSnapshotChecksum(snapshotName, checksummed, metadata.Size, metadata.SHA256); err != nil {
+			os.Remove(snapshotPath)
+			return "", err
+		}
+	}
+
 	return snapshotPath, nil
 
 }
 
+// RestoreSnapshot restores the node from a snapshot of the store. The
+// `snapshotName` is either an explicit snapshot name, `latest` for the
+// most recent verified snapshot or `below:<block num>` for the verified
+// snapshot closest to, but not above, that block. A verified snapshot is
+// one with a metadata sidecar, its checksum being checked once downloaded.
 func (s *NodeosSuperviser) RestoreSnapshot(snapshotName string, snapshotStore dstore.Store) error {
 	if snapshotStore == nil {
 		return fmt.Errorf("trying to get snapshot store, but instance is nil, have you provided --snapshot-store-url flag?")
 	}
 
-	if snapshotName == "latest" {
-		var err error
-		snapshotName, err = findLatestSnapshotName(snapshotStore)
-		if err != nil {
-			return err
-		}
+	belowBlockNum, restoreClosest, err := parseSnapshotRestoreTarget(snapshotName)
+	if err != nil {
+		return err
 	}
 
-	if snapshotName == "" {
+	var snapshotPath string
+	if restoreClosest {
+		snapshotPath, err = s.downloadClosestSnapshot(snapshotStore, belowBlockNum, snapshotName == "latest")
+	} else {
+		snapshotPath, err = s.downloadNamedSnapshot(snapshotStore, snapshotName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if snapshotPath == "" {
 		s.Logger.Warn("cannot find latest snapshot, will replay from blocks.log")
 		s.snapshotRestoreFilename = ""
 	} else {
-		s.Logger.Info("getting snapshot from store", zap.String("snapshot_name", snapshotName))
-		snapshotPath, err := s.downloadSnapshotFile(snapshotName, snapshotStore)
-		if err != nil {
-			return err
-		}
 		s.snapshotRestoreFilename = snapshotPath
 		s.snapshotRestoreOnNextStart = true
 	}
 
-	err := s.removeState()
+	err = s.removeState()
 	if err != nil {
 		return err
 	}
@@ -179,3 +236,69 @@ func (s *NodeosSuperviser) RestoreSnapshot(snapshotName string, snapshotStore ds
 
 	return nil
 }
+
+func (s *NodeosSuperviser) downloadNamedSnapshot(snapshotStore dstore.Store, snapshotName string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	var metadata *SnapshotMetadata
+	if exists, err := snapshotStore.FileExists(ctx, snapshotMetadataName(snapshotName)); err == nil && exists {
+		metadata, err = readSnapshotMetadata(ctx, snapshotStore, snapshotName)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		s.Logger.Warn("snapshot has no metadata, it will not be verified", zap.String("snapshot_name", snapshotName))
+	}
+
+	s.Logger.Info("getting snapshot from store", zap.String("snapshot_name", snapshotName))
+	return s.downloadSnapshotFile(snapshotName, metadata, snapshotStore)
+}
+
+// downloadClosestSnapshot downloads the verified snapshot closest to
+// `belowBlockNum` (0 for no bound), moving on to the next candidate when
+// one fails its checksum validation. When `allowUnverified` is set and no
+// verified snapshot exists, the most recent unverified one is used as a
+// last resort, retaining the behavior of stores predating the catalog.
+func (s *NodeosSuperviser) downloadClosestSnapshot(snapshotStore dstore.Store, belowBlockNum uint32, allowUnverified bool) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	entries, err := ListSnapshots(ctx, snapshotStore)
+	if err != nil {
+		return "", fmt.Errorf("unable to list snapshots: %w", err)
+	}
+
+	var chainID string
+	if len(s.chainID) != 0 {
+		chainID = hex.EncodeToString(s.chainID)
+	}
+
+	candidates := closestVerifiedSnapshots(entries, belowBlockNum, chainID)
+	for _, candidate := range candidates {
+		s.Logger.Info("getting verified snapshot from store", zap.String("snapshot_name", candidate.Name), zap.Uint32("block_num", candidate.BlockNum))
+		snapshotPath, err := s.downloadSnapshotFile(candidate.Name, candidate.Metadata, snapshotStore)
+		if err != nil {
+			s.Logger.Warn("snapshot failed validation, trying next one", zap.String("snapshot_name", candidate.Name), zap.Error(err))
+			continue
+		}
+
+		return snapshotPath, nil
+	}
+
+	if allowUnverified && len(entries) > 0 && len(candidates) == 0 {
+		latest := entries[len(entries)-1]
+		s.Logger.Warn("no verified snapshot found, using latest unverified one", zap.String("snapshot_name", latest.Name))
+		return s.downloadSnapshotFile(latest.Name, nil, snapshotStore)
+	}
+
+	if belowBlockNum != 0 {
+		return "", fmt.Errorf("no valid verified snapshot found at or below block #%d", belowBlockNum)
+	}
+
+	if len(candidates) > 0 {
+		return "", fmt.Errorf("none of the %d verified snapshots passed validation", len(candidates))
+	}
+
+	return "", nil
+}
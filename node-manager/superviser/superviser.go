@@ -43,8 +43,9 @@ type NodeosSuperviser struct {
 	options      *SuperviserOptions
 	snapshotsDir string
 
-	chainID       eos.SHA256Bytes
-	lastBlockSeen uint32
+	chainID           eos.SHA256Bytes
+	lastBlockSeen     uint32
+	lastHeadBlockTime time.Time
 
 	producerHostname    string
 	serverVersion       string
@@ -59,6 +60,7 @@ type NodeosSuperviser struct {
 	snapshotRestoreFilename    string
 
 	headBlockUpdateFunc nodeManager.HeadBlockUpdater
+	failover            *producerFailover
 
 	logger *zap.Logger
 }
@@ -120,6 +122,15 @@ type SuperviserOptions struct {
 	// Redirects all output to zlog instance configured for this process
 	// instead of the standard console output
 	LogToZap bool
+
+	// ProducerFailover, when set, elects the active producer among several
+	// node managers instead of relying on `ProducerHostname`. It requires
+	// supervisor monitoring to be enabled on the operator.
+	ProducerFailover *ProducerFailoverOptions
+
+	// SnapshotRetention defines the time based tiers of snapshots kept
+	// after a successful snapshot, in addition to the most recent ones.
+	SnapshotRetention SnapshotRetention
 }
 
 func NewSuperviser(debugDeepMind bool, headBlockUpdateFunc nodeManager.HeadBlockUpdater, options *SuperviserOptions, logger *zap.Logger) (*NodeosSuperviser, error) {
@@ -139,6 +150,13 @@ func NewSuperviser(debugDeepMind bool, headBlockUpdateFunc nodeManager.HeadBlock
 		logger:              logger,
 	}
 
+	if options.ProducerFailover != nil {
+		// The elected producer is the only one ever active, whatever the static configuration says
+		s.forceProduction = false
+		s.producerHostname = ""
+		s.failover = newProducerFailover(s, options.Hostname, options.ProducerFailover, s.Terminating(), logger)
+	}
+
 	s.RegisterLogPlugin(logplugin.LogPluginFunc(s.analyzeLogLineForStateChange))
 
 	if options.LogToZap {
@@ -272,11 +290,16 @@ func (s *NodeosSuperviser) getArguments() []string {
 }
 
 func (s *NodeosSuperviser) maybeReloadProducerHostnameFromConfigFile() {
-	if !s.options.ProducerHostnameFromViper {
+	if !s.options.ProducerHostnameFromViper || s.failover != nil {
 		return
 	}
 
 	_ = viper.ReadInConfig() // viper.WatchConfig broken on symlinks...
-	s.producerHostname = viper.GetString("producer_hostname")
-	s.Logger.Info("reloaded config", zap.String("hostname", s.options.Hostname), zap.String("producing_hostname", s.producerHostname))
+	producerHostname := viper.GetString("producer_hostname")
+
+	s.productionStateLock.Lock()
+	s.producerHostname = producerHostname
+	s.productionStateLock.Unlock()
+
+	s.Logger.Info("reloaded config", zap.String("hostname", s.options.Hostname), zap.String("producing_hostname", producerHostname))
 }
@@ -0,0 +1,111 @@
+package parquetsink
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/parquetsink"
+	"github.com/streamingfast/dgrpc"
+	"github.com/streamingfast/dmetrics"
+	"github.com/streamingfast/dstore"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type Config struct {
+	FirehoseAddr      string // gRPC address of the firehose
+	FirehoseInsecure  bool   // skip the verification of the firehose TLS certificate
+	FirehosePlaintext bool   // reach the firehose without TLS
+	StoreURL          string // dstore URL where Parquet files and the checkpoint are written
+	StartBlockNum     int64  // block to start at when the store holds no checkpoint
+	StopBlockNum      uint64 // last block to write, 0 to follow the chain forever
+	IncludeFilterExpr string // CEL filter expression of the included actions
+	ExcludeFilterExpr string // CEL filter expression of the excluded actions
+	Details           string // `full` or `light` blocks, light blocks having no db or RAM ops
+	SegmentSize       uint64 // number of blocks written per file
+}
+
+type App struct {
+	*shutter.Shutter
+	config *Config
+}
+
+func New(config *Config) *App {
+	return &App{
+		Shutter: shutter.New(),
+		config:  config,
+	}
+}
+
+func (a *App) Run() error {
+	zlog.Info("running firehose parquet sink", zap.Reflect("config", a.config))
+	dmetrics.Register(parquetsink.MetricsSet)
+
+	details, err := parseDetails(a.config.Details)
+	if err != nil {
+		return err
+	}
+
+	if a.config.SegmentSize == 0 {
+		return fmt.Errorf("segment size must be greater than 0")
+	}
+
+	store, err := dstore.NewStore(a.config.StoreURL, "", "", true)
+	if err != nil {
+		return fmt.Errorf("unable to create store %q: %w", a.config.StoreURL, err)
+	}
+
+	conn, err := a.dialFirehose()
+	if err != nil {
+		return fmt.Errorf("unable to create firehose client to %q: %w", a.config.FirehoseAddr, err)
+	}
+
+	sink := parquetsink.NewSink(pbbstream.NewBlockStreamV2Client(conn), store, &pbbstream.BlocksRequestV2{
+		StartBlockNum:     a.config.StartBlockNum,
+		StopBlockNum:      a.config.StopBlockNum,
+		IncludeFilterExpr: a.config.IncludeFilterExpr,
+		ExcludeFilterExpr: a.config.ExcludeFilterExpr,
+		Details:           details,
+	}, a.config.SegmentSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.OnTerminating(func(_ error) {
+		cancel()
+		sink.Shutdown(nil)
+	})
+
+	go func() {
+		err := sink.Run(ctx)
+		conn.Close()
+		a.Shutdown(err)
+	}()
+
+	return nil
+}
+
+func (a *App) dialFirehose() (*grpc.ClientConn, error) {
+	if a.config.FirehosePlaintext {
+		return dgrpc.NewInternalClient(a.config.FirehoseAddr)
+	}
+
+	if a.config.FirehoseInsecure {
+		return dgrpc.NewExternalClient(a.config.FirehoseAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	}
+
+	return dgrpc.NewExternalClient(a.config.FirehoseAddr)
+}
+
+func parseDetails(in string) (pbbstream.BlockDetails, error) {
+	switch strings.ToLower(in) {
+	case "full":
+		return pbbstream.BlockDetails_BLOCK_DETAILS_FULL, nil
+	case "light":
+		return pbbstream.BlockDetails_BLOCK_DETAILS_LIGHT, nil
+	}
+	return 0, fmt.Errorf("invalid details %q, expecting 'full' or 'light'", in)
+}
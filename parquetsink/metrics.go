@@ -0,0 +1,11 @@
+package parquetsink
+
+import (
+	"github.com/streamingfast/dmetrics"
+)
+
+var MetricsSet = dmetrics.NewSet()
+
+var writtenSegmentCount = MetricsSet.NewCounter("written_segment_count")
+var HeadBlockNum = MetricsSet.NewHeadBlockNumber("firehose-parquet-sink")
+var HeadTimeDrift = MetricsSet.NewHeadTimeDrift("firehose-parquet-sink")
@@ -0,0 +1,212 @@
+package parquetsink
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type columnKind int
+
+const (
+	kindInt64 columnKind = iota
+	kindTimestamp
+	kindBool
+	kindString
+	kindBytes
+)
+
+func (k columnKind) String() string {
+	switch k {
+	case kindInt64:
+		return "int64"
+	case kindTimestamp:
+		return "timestamp"
+	case kindBool:
+		return "bool"
+	case kindString:
+		return "string"
+	case kindBytes:
+		return "bytes"
+	}
+	return "unknown"
+}
+
+// parquetType returns the type of the column in the `parquet` struct tags
+// of the writer, a logical type standing for its physical one
+func (k columnKind) parquetType() string {
+	switch k {
+	case kindTimestamp:
+		return "TIMESTAMP_MILLIS"
+	case kindBool:
+		return "BOOLEAN"
+	case kindString:
+		return "UTF8"
+	case kindBytes:
+		return "BYTE_ARRAY"
+	}
+	return "INT64"
+}
+
+func (k columnKind) goType() reflect.Type {
+	switch k {
+	case kindBool:
+		return reflect.TypeOf(false)
+	case kindString, kindBytes:
+		return reflect.TypeOf("")
+	}
+	return reflect.TypeOf(int64(0))
+}
+
+type column struct {
+	Name     string
+	Kind     columnKind
+	Optional bool
+}
+
+// table buffers the rows of one of the exported tables and writes them as
+// a Parquet file, compressed with Snappy. Nested types are not supported.
+type table struct {
+	name     string
+	columns  []column
+	rowType  reflect.Type
+	rows     [][]interface{}
+	rowCount int
+}
+
+func newTable(name string, columns ...column) *table {
+	t := &table{name: name, columns: columns, rowType: rowType(columns)}
+	t.reset()
+	return t
+}
+
+// rowType returns the struct the writer derives the schema of the file from,
+// optional columns being pointer fields
+func rowType(columns []column) reflect.Type {
+	fields := make([]reflect.StructField, len(columns))
+	for i, col := range columns {
+		repetition := "REQUIRED"
+		fieldType := col.Kind.goType()
+		if col.Optional {
+			repetition = "OPTIONAL"
+			fieldType = reflect.PtrTo(fieldType)
+		}
+
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Column%d", i),
+			Type: fieldType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`parquet:"name=%s, type=%s, repetitiontype=%s"`, col.Name, col.Kind.parquetType(), repetition)),
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+func (t *table) reset() {
+	t.rows = nil
+	t.rowCount = 0
+}
+
+// append adds a row, `values` being in the order of the columns. A `nil`
+// or empty bytes value is a null in optional columns.
+func (t *table) append(values ...interface{}) {
+	if len(values) != len(t.columns) {
+		panic(fmt.Errorf("table %s: got %d values for %d columns", t.name, len(values), len(t.columns)))
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		col := t.columns[i]
+		if col.Optional {
+			if data, ok := value.([]byte); value == nil || (ok && len(data) == 0) {
+				continue
+			}
+		}
+
+		switch col.Kind {
+		case kindInt64:
+			row[i] = toInt64(value)
+		case kindTimestamp:
+			row[i] = value.(time.Time).UnixNano() / int64(time.Millisecond)
+		case kindBool:
+			row[i] = value.(bool)
+		case kindString:
+			row[i] = value.(string)
+		case kindBytes:
+			row[i] = string(value.([]byte))
+		}
+	}
+
+	t.rows = append(t.rows, row)
+	t.rowCount++
+}
+
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	}
+	panic(fmt.Errorf("unsupported int64 value of type %T", value))
+}
+
+// encode returns the Parquet file of the buffered rows, split in row groups
+// and pages of about `rowGroupSize` and `pageSize` bytes
+func (t *table) encode(rowGroupSize, pageSize int64, metadata map[string]string) ([]byte, error) {
+	out := bytes.NewBuffer(nil)
+	pw, err := writer.NewParquetWriterFromWriter(out, reflect.New(t.rowType).Interface(), 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create writer: %w", err)
+	}
+	pw.RowGroupSize = rowGroupSize
+	pw.PageSize = pageSize
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	for _, row := range t.rows {
+		if err := pw.Write(t.toStruct(row)); err != nil {
+			return nil, fmt.Errorf("unable to write row: %w", err)
+		}
+	}
+
+	createdBy := "dfuse-eosio parquetsink schema v" + strconv.Itoa(SchemaVersion)
+	pw.Footer.CreatedBy = &createdBy
+	for _, key := range sortedKeys(metadata) {
+		value := metadata[key]
+		pw.Footer.KeyValueMetadata = append(pw.Footer.KeyValueMetadata, &parquet.KeyValue{Key: key, Value: &value})
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		return nil, fmt.Errorf("unable to write footer: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func (t *table) toStruct(row []interface{}) interface{} {
+	out := reflect.New(t.rowType).Elem()
+	for i, value := range row {
+		if value == nil {
+			continue
+		}
+
+		field := out.Field(i)
+		if t.columns[i].Optional {
+			ptr := reflect.New(field.Type().Elem())
+			ptr.Elem().Set(reflect.ValueOf(value))
+			field.Set(ptr)
+			continue
+		}
+		field.Set(reflect.ValueOf(value))
+	}
+	return out.Interface()
+}
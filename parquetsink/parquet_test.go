@@ -0,0 +1,126 @@
+package parquetsink
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func testTable() *table {
+	return newTable("test",
+		column{Name: "num", Kind: kindInt64},
+		column{Name: "time", Kind: kindTimestamp},
+		column{Name: "flag", Kind: kindBool},
+		column{Name: "name", Kind: kindString},
+		column{Name: "data", Kind: kindBytes, Optional: true},
+		column{Name: "note", Kind: kindString, Optional: true},
+	)
+}
+
+func TestTable_Encode(t *testing.T) {
+	tbl := testTable()
+
+	blockTime := time.Date(2020, 1, 2, 3, 4, 5, 500000000, time.UTC)
+	tbl.append(uint32(1), blockTime, true, "alice", []byte{0x01}, nil)
+	tbl.append(int64(-2), blockTime, false, "bob", nil, "hello")
+	tbl.append(uint64(math.MaxInt64), blockTime, true, "", []byte{}, nil)
+
+	data, err := tbl.encode(1, 1, map[string]string{"key": "value"})
+	require.NoError(t, err)
+
+	file, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+
+	// The column reader renames the schema to its Go names, the footer is
+	// read on its own to check the written one
+	footerReader := &reader.ParquetReader{PFile: file}
+	require.NoError(t, footerReader.ReadFooter())
+
+	footer := footerReader.Footer
+	assert.Equal(t, int64(3), footer.NumRows)
+	assert.Equal(t, "dfuse-eosio parquetsink schema v1", footer.GetCreatedBy())
+	assert.True(t, len(footer.RowGroups) > 1, "expected several row groups, got %d", len(footer.RowGroups))
+
+	require.Len(t, footer.KeyValueMetadata, 1)
+	assert.Equal(t, "key", footer.KeyValueMetadata[0].Key)
+	assert.Equal(t, "value", footer.KeyValueMetadata[0].GetValue())
+
+	require.Len(t, footer.Schema, 7)
+	var names []string
+	for _, element := range footer.Schema[1:] {
+		names = append(names, element.Name)
+	}
+	assert.Equal(t, []string{"num", "time", "flag", "name", "data", "note"}, names)
+	assert.Equal(t, parquet.Type_INT64, footer.Schema[2].GetType())
+	assert.Equal(t, parquet.ConvertedType_TIMESTAMP_MILLIS, footer.Schema[2].GetConvertedType())
+	assert.Equal(t, parquet.Type_BYTE_ARRAY, footer.Schema[4].GetType())
+	assert.Equal(t, parquet.ConvertedType_UTF8, footer.Schema[4].GetConvertedType())
+	assert.Equal(t, parquet.FieldRepetitionType_REQUIRED, footer.Schema[4].GetRepetitionType())
+	assert.Equal(t, parquet.FieldRepetitionType_OPTIONAL, footer.Schema[5].GetRepetitionType())
+	for _, rowGroup := range footer.RowGroups {
+		for _, chunk := range rowGroup.Columns {
+			assert.Equal(t, parquet.CompressionCodec_SNAPPY, chunk.MetaData.Codec)
+		}
+	}
+
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	millis := blockTime.UnixNano() / int64(time.Millisecond)
+	assert.Equal(t, []interface{}{int64(1), int64(-2), int64(math.MaxInt64)}, readTestColumn(t, pr, 0))
+	assert.Equal(t, []interface{}{millis, millis, millis}, readTestColumn(t, pr, 1))
+	assert.Equal(t, []interface{}{true, false, true}, readTestColumn(t, pr, 2))
+	assert.Equal(t, []interface{}{"alice", "bob", ""}, readTestColumn(t, pr, 3))
+	assert.Equal(t, []interface{}{"\x01", nil, nil}, readTestColumn(t, pr, 4))
+	assert.Equal(t, []interface{}{nil, "hello", nil}, readTestColumn(t, pr, 5))
+}
+
+func TestTable_Encode_SingleRowGroup(t *testing.T) {
+	tbl := testTable()
+
+	blockTime := time.Date(2020, 1, 2, 3, 4, 5, 500000000, time.UTC)
+	for i := 0; i < 5; i++ {
+		tbl.append(uint64(i), blockTime.Add(time.Duration(i)*time.Second), i%2 == 0, fmt.Sprintf("name%d", i), nil, nil)
+	}
+	tbl.append(int64(-1), blockTime, true, "", []byte{0x01, 0x02}, "hello")
+
+	data, err := tbl.encode(rowGroupSize, pageSize, nil)
+	require.NoError(t, err)
+
+	file, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(6), pr.GetNumRows())
+	assert.Len(t, pr.Footer.RowGroups, 1)
+	assert.Empty(t, pr.Footer.KeyValueMetadata)
+
+	millis := func(i int) int64 {
+		return blockTime.Add(time.Duration(i)*time.Second).UnixNano() / int64(time.Millisecond)
+	}
+
+	assert.Equal(t, []interface{}{int64(0), int64(1), int64(2), int64(3), int64(4), int64(-1)}, readTestColumn(t, pr, 0))
+	assert.Equal(t, []interface{}{millis(0), millis(1), millis(2), millis(3), millis(4), millis(0)}, readTestColumn(t, pr, 1))
+	assert.Equal(t, []interface{}{true, false, true, false, true, true}, readTestColumn(t, pr, 2))
+	assert.Equal(t, []interface{}{"name0", "name1", "name2", "name3", "name4", ""}, readTestColumn(t, pr, 3))
+	assert.Equal(t, []interface{}{nil, nil, nil, nil, nil, "\x01\x02"}, readTestColumn(t, pr, 4))
+	assert.Equal(t, []interface{}{nil, nil, nil, nil, nil, "hello"}, readTestColumn(t, pr, 5))
+}
+
+func readTestColumn(t *testing.T, pr *reader.ParquetReader, index int64) []interface{} {
+	t.Helper()
+
+	values, _, _, err := pr.ReadColumnByIndex(index, pr.GetNumRows())
+	require.NoError(t, err)
+	return values
+}
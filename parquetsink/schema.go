@@ -0,0 +1,217 @@
+package parquetsink
+
+import (
+	"fmt"
+	"strings"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// SchemaVersion is the version of the published schema, written in every
+// file and checkpoint. Columns are only ever added at the end of a table
+// within a version, any other change bumps it.
+const SchemaVersion = 1
+
+// tables are the buffers of the exported tables, one Parquet file being
+// written per table and segment of blocks
+type tables struct {
+	blocks       *table
+	transactions *table
+	actions      *table
+	dbOps        *table
+	ramOps       *table
+}
+
+func newTables() *tables {
+	return &tables{
+		blocks: newTable("blocks",
+			column{Name: "block_num", Kind: kindInt64},
+			column{Name: "block_id", Kind: kindString},
+			column{Name: "previous_id", Kind: kindString},
+			column{Name: "block_time", Kind: kindTimestamp},
+			column{Name: "producer", Kind: kindString},
+			column{Name: "lib_num", Kind: kindInt64},
+			column{Name: "transaction_count", Kind: kindInt64},
+		),
+		transactions: newTable("transactions",
+			column{Name: "block_num", Kind: kindInt64},
+			column{Name: "block_time", Kind: kindTimestamp},
+			column{Name: "trx_id", Kind: kindString},
+			column{Name: "trx_index", Kind: kindInt64},
+			column{Name: "status", Kind: kindString},
+			column{Name: "scheduled", Kind: kindBool},
+			column{Name: "cpu_usage_us", Kind: kindInt64},
+			column{Name: "net_usage_words", Kind: kindInt64},
+			column{Name: "elapsed_us", Kind: kindInt64},
+			column{Name: "action_count", Kind: kindInt64},
+		),
+		actions: newTable("actions",
+			column{Name: "block_num", Kind: kindInt64},
+			column{Name: "block_time", Kind: kindTimestamp},
+			column{Name: "trx_id", Kind: kindString},
+			column{Name: "execution_index", Kind: kindInt64},
+			column{Name: "action_ordinal", Kind: kindInt64},
+			column{Name: "creator_action_ordinal", Kind: kindInt64},
+			column{Name: "global_sequence", Kind: kindInt64, Optional: true},
+			column{Name: "receiver", Kind: kindString},
+			column{Name: "account", Kind: kindString},
+			column{Name: "name", Kind: kindString},
+			column{Name: "authorization", Kind: kindString},
+			column{Name: "json_data", Kind: kindString, Optional: true},
+			column{Name: "raw_data", Kind: kindBytes, Optional: true},
+			column{Name: "context_free", Kind: kindBool},
+		),
+		dbOps: newTable("db_ops",
+			column{Name: "block_num", Kind: kindInt64},
+			column{Name: "block_time", Kind: kindTimestamp},
+			column{Name: "trx_id", Kind: kindString},
+			column{Name: "action_index", Kind: kindInt64},
+			column{Name: "operation", Kind: kindString},
+			column{Name: "code", Kind: kindString},
+			column{Name: "scope", Kind: kindString},
+			column{Name: "table_name", Kind: kindString},
+			column{Name: "primary_key", Kind: kindString},
+			column{Name: "old_payer", Kind: kindString, Optional: true},
+			column{Name: "new_payer", Kind: kindString, Optional: true},
+			column{Name: "old_data", Kind: kindBytes, Optional: true},
+			column{Name: "new_data", Kind: kindBytes, Optional: true},
+		),
+		ramOps: newTable("ram_ops",
+			column{Name: "block_num", Kind: kindInt64},
+			column{Name: "block_time", Kind: kindTimestamp},
+			column{Name: "trx_id", Kind: kindString},
+			column{Name: "action_index", Kind: kindInt64},
+			column{Name: "operation", Kind: kindString},
+			column{Name: "action", Kind: kindString},
+			column{Name: "namespace", Kind: kindString},
+			column{Name: "payer", Kind: kindString},
+			column{Name: "delta", Kind: kindInt64},
+			column{Name: "usage", Kind: kindInt64},
+			column{Name: "unique_key", Kind: kindString},
+		),
+	}
+}
+
+func (t *tables) all() []*table {
+	return []*table{t.blocks, t.transactions, t.actions, t.dbOps, t.ramOps}
+}
+
+func (t *tables) reset() {
+	for _, tbl := range t.all() {
+		tbl.reset()
+	}
+}
+
+// addBlock appends the rows of `blk`. When the block was filtered, only the
+// matching actions and their db and RAM ops are kept.
+func (t *tables) addBlock(blk *pbcodec.Block) error {
+	blockTime, err := ptypes.Timestamp(blk.Header.Timestamp)
+	if err != nil {
+		return fmt.Errorf("invalid block time: %w", err)
+	}
+
+	traces := blk.TransactionTraces()
+	t.blocks.append(blk.Number, blk.Id, blk.Header.Previous, blockTime, blk.Header.Producer, blk.DposIrreversibleBlocknum, len(traces))
+
+	for _, trx := range traces {
+		var status string
+		var cpuUsage, netUsage uint32
+		if trx.Receipt != nil {
+			status = enumName(trx.Receipt.Status.String(), "TRANSACTIONSTATUS_")
+			cpuUsage, netUsage = trx.Receipt.CpuUsageMicroSeconds, trx.Receipt.NetUsageWords
+		}
+
+		t.transactions.append(blk.Number, blockTime, trx.Id, trx.Index, status, trx.Scheduled, cpuUsage, netUsage, trx.Elapsed, len(trx.ActionTraces))
+
+		matcher := blk.FilteringActionMatcher(trx)
+		for _, act := range trx.ActionTraces {
+			if !matcher.Matched(act.ExecutionIndex) {
+				continue
+			}
+
+			var globalSequence interface{}
+			if act.Receipt != nil {
+				globalSequence = act.Receipt.GlobalSequence
+			}
+
+			var authorization []string
+			var jsonData interface{}
+			var rawData []byte
+			if act.Action != nil {
+				for _, auth := range act.Action.Authorization {
+					authorization = append(authorization, auth.Authorization())
+				}
+				if act.Action.JsonData != "" {
+					jsonData = act.Action.JsonData
+				}
+				rawData = act.Action.RawData
+			}
+
+			t.actions.append(blk.Number, blockTime, trx.Id, act.ExecutionIndex, act.ActionOrdinal, act.CreatorActionOrdinal, globalSequence,
+				act.Receiver, act.Account(), act.Name(), strings.Join(authorization, ","), jsonData, rawData, act.ContextFree)
+		}
+
+		for _, op := range trx.DbOps {
+			if !matcher.Matched(op.ActionIndex) {
+				continue
+			}
+
+			t.dbOps.append(blk.Number, blockTime, trx.Id, op.ActionIndex, enumName(op.Operation.String(), "OPERATION_"),
+				op.Code, op.Scope, op.TableName, op.PrimaryKey, optionalString(op.OldPayer), optionalString(op.NewPayer), op.OldData, op.NewData)
+		}
+
+		for _, op := range trx.RamOps {
+			if !matcher.Matched(op.ActionIndex) {
+				continue
+			}
+
+			t.ramOps.append(blk.Number, blockTime, trx.Id, op.ActionIndex, enumName(op.Operation.String(), "OPERATION_"),
+				enumName(op.Action.String(), "ACTION_"), enumName(op.Namespace.String(), "NAMESPACE_"), op.Payer, op.Delta, op.Usage, op.UniqueKey)
+		}
+	}
+
+	return nil
+}
+
+// enumName turns a protobuf enum name like `OPERATION_INSERT` into `insert`
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+func optionalString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// Schema describes the published schema, written as `schema.json` at the
+// root of the destination store
+type Schema struct {
+	Version int            `json:"version"`
+	Tables  []*SchemaTable `json:"tables"`
+}
+
+type SchemaTable struct {
+	Name    string          `json:"name"`
+	Columns []*SchemaColumn `json:"columns"`
+}
+
+type SchemaColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+func PublishedSchema() *Schema {
+	schema := &Schema{Version: SchemaVersion}
+	for _, tbl := range newTables().all() {
+		schemaTable := &SchemaTable{Name: tbl.name}
+		for _, col := range tbl.columns {
+			schemaTable.Columns = append(schemaTable.Columns, &SchemaColumn{Name: col.Name, Type: col.Kind.String(), Optional: col.Optional})
+		}
+		schema.Tables = append(schema.Tables, schemaTable)
+	}
+	return schema
+}
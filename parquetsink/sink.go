@@ -0,0 +1,317 @@
+package parquetsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/streamingfast/dstore"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+)
+
+const (
+	checkpointFilename = "checkpoint.json"
+	schemaFilename     = "schema.json"
+
+	rowGroupSize = 128 * 1024 * 1024
+	pageSize     = 1024 * 1024
+)
+
+// Checkpoint is the position of the sink, written once all the files of a
+// segment are in the store. The sink resumes from its cursor, rewriting the
+// files of a segment that was not completely written.
+type Checkpoint struct {
+	SchemaVersion int    `json:"schema_version"`
+	Cursor        string `json:"cursor"`
+	BlockNum      uint64 `json:"block_num"`
+	BlockID       string `json:"block_id"`
+}
+
+// segment is the range of blocks buffered before being written, all the
+// segments but the first one being aligned on the segment size
+type segment struct {
+	startBlockNum uint64
+	endBlockNum   uint64 // exclusive
+	lastBlock     *pbcodec.Block
+	lastCursor    string
+}
+
+// Sink writes the irreversible blocks of a firehose stream as Parquet files,
+// one file per table and segment of blocks under `<table>/<first>-<last>.parquet`.
+// Reversible blocks are never requested, so nothing written is ever undone.
+type Sink struct {
+	*shutter.Shutter
+
+	client      pbbstream.BlockStreamV2Client
+	store       dstore.Store
+	request     *pbbstream.BlocksRequestV2
+	segmentSize uint64
+	retryDelay  time.Duration
+
+	tables       *tables
+	segment      *segment
+	checkpoint   *Checkpoint
+	lastBlockNum uint64
+}
+
+// NewSink creates a sink streaming with `request`, its start block, stop
+// block, filters and details being honored. Its start is ignored when the
+// store already holds a checkpoint.
+func NewSink(client pbbstream.BlockStreamV2Client, store dstore.Store, request *pbbstream.BlocksRequestV2, segmentSize uint64) *Sink {
+	return &Sink{
+		Shutter:     shutter.New(),
+		client:      client,
+		store:       store,
+		request:     request,
+		segmentSize: segmentSize,
+		retryDelay:  5 * time.Second,
+		tables:      newTables(),
+	}
+}
+
+func (s *Sink) Run(ctx context.Context) error {
+	checkpoint, err := s.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint != nil {
+		if checkpoint.SchemaVersion != SchemaVersion {
+			return fmt.Errorf("store holds files of schema version %d, cannot write version %d files in it", checkpoint.SchemaVersion, SchemaVersion)
+		}
+
+		zlog.Info("resuming from checkpoint", zap.Uint64("block_num", checkpoint.BlockNum), zap.String("block_id", checkpoint.BlockID))
+		s.checkpoint = checkpoint
+		s.lastBlockNum = checkpoint.BlockNum
+	}
+
+	if s.stopBlockReached() {
+		zlog.Info("stop block already reached by checkpoint, nothing to do")
+		return nil
+	}
+
+	if err := s.writeJSON(ctx, schemaFilename, PublishedSchema()); err != nil {
+		return fmt.Errorf("unable to write schema: %w", err)
+	}
+
+	for {
+		err := s.stream(ctx)
+		if err == nil {
+			zlog.Info("stop block reached, all segments written")
+			return nil
+		}
+
+		if ctx.Err() != nil || s.IsTerminating() {
+			return nil
+		}
+
+		zlog.Warn("blocks stream failed, resuming from last checkpoint", zap.Error(err), zap.Duration("retry_delay", s.retryDelay))
+		s.tables.reset()
+		s.segment = nil
+		s.lastBlockNum = 0
+		if s.checkpoint != nil {
+			s.lastBlockNum = s.checkpoint.BlockNum
+		}
+
+		select {
+		case <-time.After(s.retryDelay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// stream consumes the blocks stream up to the stop block, returning an
+// error when it stops before
+func (s *Sink) stream(ctx context.Context) error {
+	request := &pbbstream.BlocksRequestV2{
+		StartBlockNum:     s.request.StartBlockNum,
+		StopBlockNum:      s.request.StopBlockNum,
+		ForkSteps:         []pbbstream.ForkStep{pbbstream.ForkStep_STEP_IRREVERSIBLE},
+		IncludeFilterExpr: s.request.IncludeFilterExpr,
+		ExcludeFilterExpr: s.request.ExcludeFilterExpr,
+		Details:           s.request.Details,
+	}
+	if s.checkpoint != nil {
+		request.StartCursor = s.checkpoint.Cursor
+	}
+
+	stream, err := s.client.Blocks(ctx, request)
+	if err != nil {
+		return fmt.Errorf("unable to start blocks stream: %w", err)
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			if !s.stopBlockReached() {
+				return fmt.Errorf("blocks stream ended before the stop block")
+			}
+			return s.flush(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		if response.Step != pbbstream.ForkStep_STEP_IRREVERSIBLE {
+			zlog.Debug("skipping reversible step", zap.Stringer("step", response.Step))
+			continue
+		}
+
+		blk := &pbcodec.Block{}
+		if err := ptypes.UnmarshalAny(response.Block, blk); err != nil {
+			return fmt.Errorf("unable to decode block: %w", err)
+		}
+
+		if err := s.processBlock(ctx, blk, response.Cursor); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Sink) processBlock(ctx context.Context, blk *pbcodec.Block, cursor string) error {
+	blockNum := uint64(blk.Number)
+	if s.segment != nil && blockNum >= s.segment.endBlockNum {
+		if err := s.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	if s.segment == nil {
+		s.segment = &segment{
+			startBlockNum: blockNum,
+			endBlockNum:   (blockNum/s.segmentSize + 1) * s.segmentSize,
+		}
+	}
+
+	if err := s.tables.addBlock(blk); err != nil {
+		return fmt.Errorf("unable to process block #%d (%s): %w", blk.Number, blk.Id, err)
+	}
+	s.segment.lastBlock = blk
+	s.segment.lastCursor = cursor
+	s.lastBlockNum = blockNum
+
+	HeadBlockNum.SetUint64(blockNum)
+	if blockTime, err := ptypes.Timestamp(blk.Header.Timestamp); err == nil {
+		HeadTimeDrift.SetBlockTime(blockTime)
+	}
+
+	if blockNum+1 >= s.segment.endBlockNum {
+		return s.flush(ctx)
+	}
+	return nil
+}
+
+// flush writes the files of the current segment then the checkpoint
+// pointing to its last block
+func (s *Sink) flush(ctx context.Context) error {
+	seg := s.segment
+	if seg == nil {
+		return nil
+	}
+
+	lastBlockNum := uint64(seg.lastBlock.Number)
+	metadata := map[string]string{
+		"dfuse.schema_version":  fmt.Sprintf("%d", SchemaVersion),
+		"dfuse.start_block_num": fmt.Sprintf("%d", seg.startBlockNum),
+		"dfuse.stop_block_num":  fmt.Sprintf("%d", lastBlockNum),
+	}
+
+	for _, tbl := range s.tables.all() {
+		if tbl.rowCount == 0 {
+			continue
+		}
+
+		filename := segmentFilename(tbl.name, seg.startBlockNum, lastBlockNum)
+		file, err := tbl.encode(rowGroupSize, pageSize, metadata)
+		if err != nil {
+			return fmt.Errorf("unable to encode %q: %w", filename, err)
+		}
+
+		if err := s.store.WriteObject(ctx, filename, bytes.NewReader(file)); err != nil {
+			return fmt.Errorf("unable to write %q: %w", filename, err)
+		}
+	}
+
+	checkpoint := &Checkpoint{
+		SchemaVersion: SchemaVersion,
+		Cursor:        seg.lastCursor,
+		BlockNum:      lastBlockNum,
+		BlockID:       seg.lastBlock.Id,
+	}
+	if err := s.writeJSON(ctx, checkpointFilename, checkpoint); err != nil {
+		return fmt.Errorf("unable to write checkpoint: %w", err)
+	}
+
+	zlog.Info("segment written",
+		zap.Uint64("start_block_num", seg.startBlockNum),
+		zap.Uint64("last_block_num", lastBlockNum),
+		zap.Int("action_count", s.tables.actions.rowCount),
+	)
+	writtenSegmentCount.Inc()
+
+	s.checkpoint = checkpoint
+	s.tables.reset()
+	s.segment = nil
+	return nil
+}
+
+func (s *Sink) stopBlockReached() bool {
+	return s.request.StopBlockNum != 0 && s.lastBlockNum >= s.request.StopBlockNum
+}
+
+func segmentFilename(tableName string, startBlockNum, lastBlockNum uint64) string {
+	return fmt.Sprintf("%s/%010d-%010d.parquet", tableName, startBlockNum, lastBlockNum)
+}
+
+func (s *Sink) loadCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	exists, err := s.store.FileExists(ctx, checkpointFilename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check checkpoint: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	reader, err := s.store.OpenObject(ctx, checkpointFilename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open checkpoint: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read checkpoint: %w", err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("unable to decode checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+func (s *Sink) writeJSON(ctx context.Context, filename string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.store.WriteObject(ctx, filename, bytes.NewReader(data))
+}
+
+func sortedKeys(in map[string]string) (out []string) {
+	for key := range in {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return
+}
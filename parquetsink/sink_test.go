@@ -0,0 +1,190 @@
+package parquetsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/streamingfast/dstore"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestSink_Run(t *testing.T) {
+	client := &testBlocksClient{t: t, streams: []*testBlocksStream{
+		testStream(t, 5, 14, fmt.Errorf("connection reset")),
+		testStream(t, 10, 23, nil),
+	}}
+	client.streams[1].responses = append([]*pbbstream.BlockResponseV2{testUndoResponse(t, 11)}, client.streams[1].responses...)
+
+	store := dstore.NewMockStore(nil)
+	store.SetOverwrite(true)
+
+	sink := NewSink(client, store, &pbbstream.BlocksRequestV2{StartBlockNum: 5, StopBlockNum: 23, IncludeFilterExpr: "true"}, 10)
+	sink.retryDelay = 0
+	require.NoError(t, sink.Run(context.Background()))
+
+	require.Len(t, client.requests, 2)
+	assert.Equal(t, "", client.requests[0].StartCursor)
+	assert.Equal(t, "cursor-9", client.requests[1].StartCursor)
+	for _, request := range client.requests {
+		assert.Equal(t, []pbbstream.ForkStep{pbbstream.ForkStep_STEP_IRREVERSIBLE}, request.ForkSteps)
+		assert.Equal(t, "true", request.IncludeFilterExpr)
+	}
+
+	for _, filename := range []string{
+		"blocks/0000000005-0000000009.parquet",
+		"blocks/0000000010-0000000019.parquet",
+		"blocks/0000000020-0000000023.parquet",
+		"actions/0000000010-0000000019.parquet",
+		"db_ops/0000000020-0000000023.parquet",
+		"schema.json",
+	} {
+		exists, err := store.FileExists(context.Background(), filename)
+		require.NoError(t, err)
+		assert.True(t, exists, filename)
+	}
+
+	exists, err := store.FileExists(context.Background(), "ram_ops/0000000010-0000000019.parquet")
+	require.NoError(t, err)
+	assert.False(t, exists, "empty tables are not written")
+
+	checkpoint, err := sink.loadCheckpoint(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{SchemaVersion: SchemaVersion, Cursor: "cursor-23", BlockNum: 23, BlockID: "00000023a"}, checkpoint)
+
+	// Resuming once the stop block is reached does not stream anything
+	require.NoError(t, NewSink(client, store, &pbbstream.BlocksRequestV2{StartBlockNum: 5, StopBlockNum: 23}, 10).Run(context.Background()))
+	assert.Len(t, client.requests, 2)
+}
+
+func TestSink_SchemaVersionMismatch(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	data, err := json.Marshal(&Checkpoint{SchemaVersion: SchemaVersion + 1, Cursor: "cursor-9", BlockNum: 9})
+	require.NoError(t, err)
+	store.SetFile(checkpointFilename, data)
+
+	err = NewSink(&testBlocksClient{t: t}, store, &pbbstream.BlocksRequestV2{}, 10).Run(context.Background())
+	assert.EqualError(t, err, "store holds files of schema version 2, cannot write version 1 files in it")
+}
+
+func TestTables_AddBlock(t *testing.T) {
+	blk := testBlock(t, 10)
+	blk.FilteringApplied = true
+	blk.FilteredTransactionTraces, blk.UnfilteredTransactionTraces = blk.UnfilteredTransactionTraces, nil
+	trx := blk.FilteredTransactionTraces[0]
+	trx.ActionTraces[0].FilteringMatched = true
+	trx.ActionTraces = append(trx.ActionTraces, &pbcodec.ActionTrace{Receiver: "other", ExecutionIndex: 1, Action: &pbcodec.Action{Account: "other", Name: "skip"}})
+	trx.DbOps = append(trx.DbOps, &pbcodec.DBOp{ActionIndex: 1, Code: "other"})
+
+	tables := newTables()
+	require.NoError(t, tables.addBlock(blk))
+
+	assert.Equal(t, 1, tables.blocks.rowCount)
+	assert.Equal(t, 1, tables.transactions.rowCount)
+	assert.Equal(t, 1, tables.actions.rowCount)
+	assert.Equal(t, 1, tables.dbOps.rowCount)
+	assert.Equal(t, 0, tables.ramOps.rowCount)
+
+	assert.Equal(t, "executed", tables.transactions.rows[0][4])
+	assert.Equal(t, "alice@active", tables.actions.rows[0][10])
+	assert.Equal(t, "insert", tables.dbOps.rows[0][4])
+	assert.Nil(t, tables.dbOps.rows[0][9])
+	assert.NotNil(t, tables.dbOps.rows[0][10])
+}
+
+func testBlock(t *testing.T, num uint32) *pbcodec.Block {
+	stamp, err := ptypes.TimestampProto(time.Date(2020, 1, 1, 0, 0, int(num), 0, time.UTC))
+	require.NoError(t, err)
+
+	return &pbcodec.Block{
+		Id:     fmt.Sprintf("%08da", num),
+		Number: num,
+		Header: &pbcodec.BlockHeader{Timestamp: stamp, Previous: fmt.Sprintf("%08da", num-1), Producer: "eosio"},
+		UnfilteredTransactionTraces: []*pbcodec.TransactionTrace{{
+			Id:      fmt.Sprintf("trx-%d", num),
+			Receipt: &pbcodec.TransactionReceiptHeader{Status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED},
+			ActionTraces: []*pbcodec.ActionTrace{{
+				Receiver: "eosio.token",
+				Receipt:  &pbcodec.ActionReceipt{GlobalSequence: uint64(num)},
+				Action: &pbcodec.Action{
+					Account:       "eosio.token",
+					Name:          "transfer",
+					Authorization: []*pbcodec.PermissionLevel{{Actor: "alice", Permission: "active"}},
+					JsonData:      `{"from":"alice"}`,
+				},
+			}},
+			DbOps: []*pbcodec.DBOp{{
+				Operation: pbcodec.DBOp_OPERATION_INSERT,
+				Code:      "eosio.token",
+				Scope:     "alice",
+				TableName: "accounts",
+				NewPayer:  "alice",
+				NewData:   []byte{0x01},
+			}},
+		}},
+	}
+}
+
+func testResponse(t *testing.T, num uint32, step pbbstream.ForkStep) *pbbstream.BlockResponseV2 {
+	payload, err := ptypes.MarshalAny(testBlock(t, num))
+	require.NoError(t, err)
+
+	return &pbbstream.BlockResponseV2{Block: payload, Step: step, Cursor: fmt.Sprintf("cursor-%d", num)}
+}
+
+func testUndoResponse(t *testing.T, num uint32) *pbbstream.BlockResponseV2 {
+	return testResponse(t, num, pbbstream.ForkStep_STEP_UNDO)
+}
+
+// testStream streams the irreversible blocks `from` to `to` inclusively
+// then fails with `err`, ending normally when nil
+func testStream(t *testing.T, from, to uint32, err error) *testBlocksStream {
+	stream := &testBlocksStream{err: err}
+	for num := from; num <= to; num++ {
+		stream.responses = append(stream.responses, testResponse(t, num, pbbstream.ForkStep_STEP_IRREVERSIBLE))
+	}
+	return stream
+}
+
+type testBlocksClient struct {
+	t        *testing.T
+	streams  []*testBlocksStream
+	requests []*pbbstream.BlocksRequestV2
+}
+
+func (c *testBlocksClient) Blocks(ctx context.Context, in *pbbstream.BlocksRequestV2, opts ...grpc.CallOption) (pbbstream.BlockStreamV2_BlocksClient, error) {
+	require.NotEmpty(c.t, c.streams, "unexpected blocks request")
+
+	c.requests = append(c.requests, in)
+	stream := c.streams[0]
+	c.streams = c.streams[1:]
+	return stream, nil
+}
+
+type testBlocksStream struct {
+	grpc.ClientStream
+
+	responses []*pbbstream.BlockResponseV2
+	err       error
+}
+
+func (s *testBlocksStream) Recv() (*pbbstream.BlockResponseV2, error) {
+	if len(s.responses) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
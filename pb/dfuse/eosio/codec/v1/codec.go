@@ -90,6 +90,26 @@ func (b *Block) ImplicitTransactionOps() []*TrxOp {
 	return b.UnfilteredImplicitTransactionOps
 }
 
+// ActiveScheduleProducers returns the account names of the producers found in the
+// active schedule of the block, in schedule order, regardless of the schedule version
+// (`ActiveScheduleV2` takes precedence over `ActiveScheduleV1` when both are set).
+func (b *Block) ActiveScheduleProducers() (out []string) {
+	if b.ActiveScheduleV2 != nil {
+		for _, producer := range b.ActiveScheduleV2.Producers {
+			out = append(out, producer.AccountName)
+		}
+		return
+	}
+
+	if b.ActiveScheduleV1 != nil {
+		for _, producer := range b.ActiveScheduleV1.Producers {
+			out = append(out, producer.AccountName)
+		}
+	}
+
+	return
+}
+
 func (b *Block) CanceledDTrxIDs() (out []string) {
 	seen := make(map[string]bool)
 	for _, trx := range b.TransactionTraces() {
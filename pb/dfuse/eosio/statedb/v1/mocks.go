@@ -76,6 +76,13 @@ func (m *MockStateClient) StreamTableRows(ctx context.Context, in *StreamTableRo
 		return m.tableRowsStream, nil
 	}
 
+	if in.AfterKey != "" {
+		// Mock rows are expected to be ordered by key
+		for m.streamTableRows.at < len(m.streamTableRows.Rows) && m.streamTableRows.Rows[m.streamTableRows.at].Key <= in.AfterKey {
+			m.streamTableRows.at++
+		}
+	}
+
 	return m.streamTableRows, nil
 }
 
@@ -553,6 +553,7 @@ type StreamTableRowsRequest struct {
 	Contract             string   `protobuf:"bytes,6,opt,name=contract,proto3" json:"contract,omitempty"`
 	Table                string   `protobuf:"bytes,7,opt,name=table,proto3" json:"table,omitempty"`
 	Scope                string   `protobuf:"bytes,8,opt,name=scope,proto3" json:"scope,omitempty"`
+	AfterKey             string   `protobuf:"bytes,9,opt,name=after_key,json=afterKey,proto3" json:"after_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -639,6 +640,13 @@ func (m *StreamTableRowsRequest) GetScope() string {
 	return ""
 }
 
+func (m *StreamTableRowsRequest) GetAfterKey() string {
+	if m != nil {
+		return m.AfterKey
+	}
+	return ""
+}
+
 type TableRowResponse struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -714,6 +722,7 @@ type StreamTableScopesRequest struct {
 	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
 	Contract             string   `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
 	Table                string   `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	AfterKey             string   `protobuf:"bytes,4,opt,name=after_key,json=afterKey,proto3" json:"after_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -765,6 +774,13 @@ func (m *StreamTableScopesRequest) GetTable() string {
 	return ""
 }
 
+func (m *StreamTableScopesRequest) GetAfterKey() string {
+	if m != nil {
+		return m.AfterKey
+	}
+	return ""
+}
+
 type TableScopeResponse struct {
 	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
 	Scope                string   `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
@@ -1121,71 +1137,72 @@ func init() {
 }
 
 var fileDescriptor_7eba888d47f0653d = []byte{
-	// 1020 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0x5f, 0x6f, 0xdc, 0x44,
-	0x10, 0x97, 0xef, 0x2e, 0xf7, 0x67, 0x2e, 0xa4, 0xe9, 0x42, 0xaf, 0x8e, 0xa1, 0x90, 0x5a, 0x01,
-	0x4e, 0xad, 0x7a, 0x7f, 0xc2, 0x13, 0xa5, 0x2f, 0x49, 0x85, 0x4e, 0x6d, 0xa0, 0x80, 0x13, 0x09,
-	0x89, 0x17, 0x6b, 0x7d, 0xd9, 0x50, 0x73, 0xb6, 0xd7, 0x78, 0xd7, 0x39, 0x59, 0xbc, 0xc2, 0x03,
-	0x8f, 0x20, 0xbe, 0x00, 0x9f, 0x83, 0x4f, 0xc3, 0xb7, 0xe0, 0x11, 0x79, 0xbd, 0xf6, 0xd9, 0x77,
-	0x67, 0xc7, 0x11, 0x2f, 0x95, 0x78, 0xdb, 0x99, 0xdd, 0xf9, 0xcd, 0xcc, 0x6f, 0xc7, 0xb3, 0x63,
-	0x38, 0xba, 0xbc, 0x0a, 0x19, 0x19, 0x13, 0xca, 0x6c, 0x3a, 0x66, 0x1c, 0x73, 0x72, 0x69, 0x8d,
-	0xaf, 0xa7, 0xe9, 0x72, 0xe4, 0x07, 0x94, 0x53, 0x34, 0x10, 0xa7, 0x46, 0xe2, 0xd4, 0x28, 0xdd,
-	0xba, 0x9e, 0x6a, 0xef, 0x27, 0xd6, 0x16, 0xe3, 0x01, 0xc1, 0x6e, 0x6c, 0x27, 0x97, 0x89, 0x9d,
-	0x8e, 0xe1, 0xad, 0x19, 0xe1, 0x27, 0xa7, 0x2f, 0x0c, 0xf2, 0x63, 0x48, 0x18, 0x47, 0x1a, 0x74,
-	0xe7, 0xd4, 0xe3, 0x01, 0x9e, 0x73, 0x55, 0x39, 0x54, 0x86, 0x3d, 0x23, 0x93, 0xd1, 0xbb, 0xd0,
-	0xb3, 0x1c, 0x3a, 0x5f, 0x98, 0x5e, 0xe8, 0xaa, 0x8d, 0x43, 0x65, 0xd8, 0x32, 0xba, 0x42, 0xf1,
-	0x2a, 0x74, 0xd1, 0x7d, 0xe8, 0x70, 0x6a, 0xfe, 0xc0, 0xa8, 0xa7, 0x36, 0x0f, 0x95, 0x61, 0xd7,
-	0x68, 0x73, 0xfa, 0x92, 0x51, 0x4f, 0xc7, 0xb0, 0x97, 0xba, 0x60, 0x3e, 0xf5, 0x18, 0x29, 0xe2,
-	0x28, 0x9b, 0x38, 0x01, 0x5e, 0x9a, 0xd8, 0xb2, 0x85, 0x8b, 0x5d, 0xa3, 0x1d, 0xe0, 0xe5, 0x89,
-	0x65, 0xa3, 0x03, 0xe8, 0xc6, 0xe8, 0x62, 0xa7, 0x29, 0x22, 0xeb, 0xc4, 0xf2, 0x89, 0x65, 0xeb,
-	0xe7, 0x70, 0x6f, 0x46, 0xf8, 0x19, 0x89, 0x4e, 0xe6, 0x73, 0x1a, 0x7a, 0x9c, 0xa5, 0xd9, 0x3c,
-	0x00, 0xf0, 0x43, 0xcb, 0xb1, 0xe7, 0xe6, 0x82, 0x44, 0x32, 0x9f, 0x5e, 0xa2, 0x39, 0x23, 0x51,
-	0x65, 0x42, 0xfa, 0x37, 0x30, 0x58, 0x07, 0xad, 0x13, 0xbf, 0x06, 0x5d, 0x2c, 0x0d, 0xd4, 0xc6,
-	0x61, 0x33, 0x26, 0x30, 0x95, 0x75, 0x03, 0x0e, 0x66, 0x84, 0x7f, 0x4d, 0x02, 0xd7, 0x66, 0xcc,
-	0xa6, 0xde, 0x17, 0xb6, 0xb7, 0xc8, 0x62, 0xad, 0x44, 0x55, 0xa1, 0x23, 0x51, 0x44, 0x9c, 0x3d,
-	0x23, 0x15, 0xf5, 0x7f, 0x14, 0xd0, 0xb6, 0x81, 0xca, 0x58, 0x9f, 0x42, 0x3f, 0xf4, 0x4d, 0x4e,
-	0x4d, 0x01, 0x25, 0x70, 0xfb, 0xc7, 0xda, 0x28, 0x29, 0x97, 0xb4, 0x16, 0xae, 0xa7, 0xa3, 0xd3,
-	0x78, 0xdb, 0x20, 0x57, 0x46, 0x2f, 0xf4, 0x2f, 0xa8, 0x90, 0x90, 0x01, 0xf7, 0x1d, 0xcc, 0xb8,
-	0x69, 0x07, 0x01, 0xb9, 0x26, 0x01, 0xb3, 0x2d, 0x87, 0x48, 0x9c, 0xc6, 0x8d, 0x38, 0xf7, 0x62,
-	0xd3, 0x17, 0x39, 0xcb, 0x04, 0xf3, 0x25, 0xf4, 0xfd, 0x2c, 0x54, 0xa6, 0x36, 0x0f, 0x9b, 0xc3,
-	0xfe, 0xf1, 0x70, 0xb4, 0xbd, 0x7c, 0x47, 0x71, 0x2e, 0xe4, 0x72, 0x95, 0x9b, 0x91, 0x37, 0xd6,
-	0x29, 0xec, 0xaf, 0x1f, 0xa8, 0xac, 0xdf, 0x01, 0xb4, 0xf1, 0x9c, 0xdb, 0xd4, 0x93, 0x1c, 0x4a,
-	0x09, 0x7d, 0x0c, 0x77, 0x56, 0xb0, 0xa6, 0x87, 0x5d, 0x22, 0x0b, 0x6c, 0x6f, 0xa5, 0x7e, 0x85,
-	0x5d, 0xa2, 0xff, 0xd9, 0x00, 0x34, 0x23, 0xfc, 0x02, 0x5b, 0x0e, 0x31, 0xe8, 0xb2, 0xd6, 0xcd,
-	0x1d, 0x40, 0x77, 0x41, 0x22, 0x93, 0x47, 0x3e, 0x49, 0xaf, 0x6e, 0x41, 0xa2, 0x8b, 0xc8, 0x27,
-	0xa5, 0x9f, 0x0c, 0x3a, 0x82, 0xbd, 0xa5, 0xcd, 0x5f, 0x9b, 0x2b, 0xd4, 0x96, 0xd8, 0xdf, 0x8d,
-	0xb5, 0xa7, 0x29, 0xf2, 0x63, 0xb8, 0x5b, 0xb8, 0x19, 0xea, 0x39, 0x91, 0xba, 0x23, 0x0e, 0xee,
-	0xe7, 0x37, 0xbe, 0xf2, 0x9c, 0xa8, 0xc0, 0x4b, 0x7b, 0x8d, 0x97, 0x77, 0x60, 0x87, 0xc7, 0x29,
-	0xa9, 0x1d, 0xb1, 0x91, 0x08, 0xb1, 0x96, 0xcd, 0xa9, 0x4f, 0xd4, 0x6e, 0xa2, 0x15, 0x02, 0xfa,
-	0x00, 0xfa, 0x7e, 0x60, 0xbb, 0x38, 0x88, 0xc4, 0x27, 0xd5, 0x13, 0x7b, 0x20, 0x55, 0x67, 0x24,
-	0xd2, 0xff, 0x56, 0xe0, 0xed, 0x02, 0x47, 0x6f, 0x68, 0x21, 0x3e, 0x85, 0x66, 0x40, 0x97, 0x82,
-	0xf8, 0x8a, 0x02, 0x5c, 0x4f, 0xc3, 0x88, 0x8d, 0xf4, 0x9f, 0x1b, 0x30, 0x38, 0x17, 0x9e, 0xd2,
-	0x7d, 0xf6, 0x3f, 0xac, 0x05, 0xfd, 0x17, 0x05, 0xf6, 0x37, 0xee, 0x79, 0x1f, 0x9a, 0xab, 0x5e,
-	0x1b, 0x2f, 0x11, 0x82, 0xd6, 0x25, 0xe6, 0x58, 0xb6, 0x73, 0xb1, 0x8e, 0x75, 0x59, 0xae, 0x3d,
-	0x43, 0xac, 0x63, 0x27, 0x3e, 0x8e, 0x48, 0x20, 0x12, 0xec, 0x19, 0x89, 0x80, 0x1e, 0xc2, 0x6e,
-	0x96, 0xba, 0x45, 0x02, 0x91, 0x54, 0xcb, 0xe8, 0xa7, 0x9c, 0x5a, 0x24, 0xd0, 0x6d, 0x50, 0x73,
-	0xb7, 0x71, 0x1e, 0xc7, 0x56, 0xef, 0x3e, 0xf2, 0x44, 0x34, 0xca, 0x88, 0x68, 0xe6, 0x88, 0xd0,
-	0x67, 0x80, 0x56, 0x4e, 0xea, 0x3d, 0x08, 0x19, 0x77, 0x8d, 0x3c, 0x77, 0xbf, 0x35, 0xe0, 0x61,
-	0x12, 0xf4, 0x97, 0xa1, 0xc3, 0xed, 0x24, 0xe8, 0xdb, 0x55, 0xd3, 0xad, 0xa3, 0x2f, 0xd4, 0x5f,
-	0xab, 0xb4, 0xfe, 0x76, 0x6e, 0xa8, 0xbf, 0x76, 0xdd, 0xfa, 0xeb, 0x94, 0xd4, 0xdf, 0x00, 0xda,
-	0x82, 0x04, 0xa6, 0x76, 0xc5, 0x03, 0x29, 0x25, 0xfd, 0x8f, 0x06, 0x1c, 0xe5, 0x38, 0x79, 0x2e,
-	0x93, 0xb9, 0x25, 0x2d, 0x5b, 0xf9, 0x7e, 0xb3, 0x09, 0x79, 0x0f, 0x7a, 0xe9, 0xcd, 0xa5, 0x9c,
-	0xac, 0x14, 0xba, 0x03, 0x83, 0x8c, 0x81, 0x62, 0xdd, 0x65, 0xa9, 0x2a, 0xf9, 0x54, 0x9f, 0x41,
-	0x2b, 0xa0, 0x4b, 0xa6, 0xb6, 0xaa, 0xdf, 0xd6, 0x8d, 0xd6, 0x26, 0xac, 0xf4, 0x10, 0x0e, 0x32,
-	0x6f, 0xe9, 0x0d, 0x64, 0x0e, 0xab, 0x5e, 0xd7, 0xff, 0xe4, 0xf6, 0xf8, 0xaf, 0x0e, 0xec, 0x9c,
-	0xc7, 0xa7, 0xd0, 0xb7, 0xd0, 0x4e, 0xe6, 0x45, 0xf4, 0x61, 0x19, 0x46, 0x61, 0x64, 0xd5, 0x3e,
-	0xba, 0xe9, 0x98, 0x0c, 0x9e, 0x8a, 0x41, 0x34, 0x37, 0xd0, 0xa1, 0x27, 0x15, 0x96, 0x9b, 0xd3,
-	0xa4, 0x36, 0xaa, 0x7b, 0x5c, 0x3a, 0xfc, 0x49, 0x4c, 0x0b, 0x6b, 0x93, 0x19, 0x9a, 0x56, 0xa0,
-	0x6c, 0x1f, 0x0d, 0xb5, 0xe3, 0xdb, 0x98, 0x48, 0xe7, 0x57, 0xd0, 0xcf, 0x3d, 0xc3, 0xe8, 0x51,
-	0x05, 0xc4, 0xda, 0x3c, 0xa3, 0x3d, 0xae, 0x75, 0x56, 0xfa, 0x71, 0xe1, 0xce, 0xda, 0x53, 0x88,
-	0x4a, 0x79, 0xda, 0xfe, 0x66, 0x6a, 0xb5, 0x6b, 0x65, 0xa2, 0x20, 0x06, 0x77, 0x37, 0x7a, 0x3d,
-	0x9a, 0xd4, 0x70, 0x58, 0x78, 0x16, 0xb4, 0x47, 0x95, 0x2e, 0x0b, 0x5f, 0xd9, 0x44, 0x41, 0xbf,
-	0x2a, 0xa0, 0x95, 0x37, 0x6b, 0xf4, 0x69, 0xb5, 0xfb, 0x8a, 0x06, 0x5f, 0x5e, 0x52, 0xdb, 0xbf,
-	0xf8, 0x89, 0x82, 0x7e, 0x57, 0xe0, 0x41, 0x65, 0x93, 0x44, 0xcf, 0x6a, 0x84, 0x53, 0xda, 0x5b,
-	0xb5, 0xe9, 0x8d, 0x11, 0xad, 0x77, 0x85, 0x89, 0x72, 0xfa, 0xf9, 0x77, 0xcf, 0xbf, 0xb7, 0xf9,
-	0xeb, 0xd0, 0x1a, 0xcd, 0xa9, 0x3b, 0x16, 0x00, 0x4f, 0x6c, 0x2a, 0x17, 0xc9, 0xaf, 0xab, 0x6f,
-	0x8d, 0xb7, 0xff, 0xc9, 0x7e, 0xe6, 0x5b, 0x52, 0xb0, 0xda, 0xe2, 0xa7, 0xf4, 0x93, 0x7f, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0xcc, 0x45, 0x01, 0x9c, 0xf4, 0x0e, 0x00, 0x00,
+	// 1034 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0x97, 0x93, 0x6c, 0x12, 0xbf, 0x2c, 0xdb, 0xed, 0x40, 0x53, 0xaf, 0x4b, 0x21, 0xb5, 0x16,
+	0x88, 0x5a, 0x35, 0x7f, 0x96, 0x13, 0xa5, 0x97, 0xdd, 0x0a, 0x45, 0xed, 0x42, 0x01, 0xef, 0x4a,
+	0x48, 0x5c, 0xac, 0x71, 0x76, 0x96, 0x9a, 0x24, 0x1e, 0xe3, 0x19, 0x6f, 0x64, 0x71, 0x06, 0x89,
+	0x23, 0x88, 0x2f, 0xc0, 0xe7, 0xe0, 0xd3, 0xf0, 0x2d, 0x38, 0x22, 0x8f, 0xc7, 0x8e, 0x9d, 0xc4,
+	0x5e, 0xaf, 0xb8, 0xac, 0xd4, 0xdb, 0xbc, 0x37, 0xf3, 0x7e, 0xef, 0xbd, 0xdf, 0xfc, 0x3c, 0x33,
+	0x86, 0xc3, 0x8b, 0xcb, 0x80, 0x91, 0x21, 0xa1, 0xcc, 0xa1, 0x43, 0xc6, 0x31, 0x27, 0x17, 0xf6,
+	0xf0, 0x6a, 0x9c, 0x0c, 0x07, 0x9e, 0x4f, 0x39, 0x45, 0x5d, 0xb1, 0x6a, 0x20, 0x56, 0x0d, 0x92,
+	0xa9, 0xab, 0xb1, 0xfe, 0x41, 0x1c, 0x6d, 0x33, 0xee, 0x13, 0xbc, 0x88, 0xe2, 0xe4, 0x30, 0x8e,
+	0x33, 0x30, 0xbc, 0x33, 0x21, 0xfc, 0xf8, 0xe4, 0xa5, 0x49, 0x7e, 0x0a, 0x08, 0xe3, 0x48, 0x87,
+	0xf6, 0x94, 0xba, 0xdc, 0xc7, 0x53, 0xae, 0x29, 0x3d, 0xa5, 0xaf, 0x9a, 0xa9, 0x8d, 0x1e, 0x80,
+	0x6a, 0xcf, 0xe9, 0x74, 0x66, 0xb9, 0xc1, 0x42, 0xab, 0xf5, 0x94, 0x7e, 0xc3, 0x6c, 0x0b, 0xc7,
+	0xeb, 0x60, 0x81, 0xee, 0x43, 0x8b, 0x53, 0xeb, 0x47, 0x46, 0x5d, 0xad, 0xde, 0x53, 0xfa, 0x6d,
+	0xb3, 0xc9, 0xe9, 0x2b, 0x46, 0x5d, 0x03, 0xc3, 0x5e, 0x92, 0x82, 0x79, 0xd4, 0x65, 0x24, 0x8f,
+	0xa3, 0x6c, 0xe2, 0xf8, 0x78, 0x69, 0x61, 0xdb, 0x11, 0x29, 0x76, 0xcd, 0xa6, 0x8f, 0x97, 0xc7,
+	0xb6, 0x83, 0x0e, 0xa0, 0x1d, 0xa1, 0x8b, 0x99, 0xba, 0xa8, 0xac, 0x15, 0xd9, 0xc7, 0xb6, 0x63,
+	0x9c, 0xc1, 0xbd, 0x09, 0xe1, 0xa7, 0x24, 0x3c, 0x9e, 0x4e, 0x69, 0xe0, 0x72, 0x96, 0x74, 0xf3,
+	0x10, 0xc0, 0x0b, 0xec, 0xb9, 0x33, 0xb5, 0x66, 0x24, 0x94, 0xfd, 0xa8, 0xb1, 0xe7, 0x94, 0x84,
+	0xa5, 0x0d, 0x19, 0xdf, 0x42, 0x77, 0x1d, 0xb4, 0x4a, 0xfd, 0x3a, 0xb4, 0xb1, 0x0c, 0xd0, 0x6a,
+	0xbd, 0x7a, 0x44, 0x60, 0x62, 0x1b, 0x26, 0x1c, 0x4c, 0x08, 0xff, 0x86, 0xf8, 0x0b, 0x87, 0x31,
+	0x87, 0xba, 0x5f, 0x3a, 0xee, 0x2c, 0xad, 0xb5, 0x14, 0x55, 0x83, 0x96, 0x44, 0x11, 0x75, 0xaa,
+	0x66, 0x62, 0x1a, 0xff, 0x2a, 0xa0, 0x6f, 0x03, 0x95, 0xb5, 0x3e, 0x83, 0x4e, 0xe0, 0x59, 0x9c,
+	0x5a, 0x02, 0x4a, 0xe0, 0x76, 0x8e, 0xf4, 0x41, 0x2c, 0x97, 0x44, 0x0b, 0x57, 0xe3, 0xc1, 0x49,
+	0x34, 0x6d, 0x92, 0x4b, 0x53, 0x0d, 0xbc, 0x73, 0x2a, 0x2c, 0x64, 0xc2, 0xfd, 0x39, 0x66, 0xdc,
+	0x72, 0x7c, 0x9f, 0x5c, 0x11, 0x9f, 0x39, 0xf6, 0x9c, 0x48, 0x9c, 0xda, 0xb5, 0x38, 0xf7, 0xa2,
+	0xd0, 0x97, 0x99, 0xc8, 0x18, 0xf3, 0x15, 0x74, 0xbc, 0xb4, 0x54, 0xa6, 0xd5, 0x7b, 0xf5, 0x7e,
+	0xe7, 0xa8, 0x3f, 0xd8, 0x2e, 0xdf, 0x41, 0xd4, 0x0b, 0xb9, 0x58, 0xf5, 0x66, 0x66, 0x83, 0x0d,
+	0x0a, 0xfb, 0xeb, 0x0b, 0x4a, 0xf5, 0xdb, 0x85, 0x26, 0x9e, 0x72, 0x87, 0xba, 0x92, 0x43, 0x69,
+	0xa1, 0x4f, 0xe0, 0xce, 0x0a, 0xd6, 0x72, 0xf1, 0x82, 0x48, 0x81, 0xed, 0xad, 0xdc, 0xaf, 0xf1,
+	0x82, 0x18, 0x7f, 0xd5, 0x00, 0x4d, 0x08, 0x3f, 0xc7, 0xf6, 0x9c, 0x98, 0x74, 0x59, 0x69, 0xe7,
+	0x0e, 0xa0, 0x3d, 0x23, 0xa1, 0xc5, 0x43, 0x8f, 0x24, 0x5b, 0x37, 0x23, 0xe1, 0x79, 0xe8, 0x91,
+	0xc2, 0x4f, 0x06, 0x1d, 0xc2, 0xde, 0xd2, 0xe1, 0x6f, 0xac, 0x15, 0x6a, 0x43, 0xcc, 0xef, 0x46,
+	0xde, 0x93, 0x04, 0xf9, 0x09, 0xdc, 0xcd, 0xed, 0x0c, 0x75, 0xe7, 0xa1, 0xb6, 0x23, 0x16, 0xee,
+	0x67, 0x27, 0xbe, 0x76, 0xe7, 0x61, 0x8e, 0x97, 0xe6, 0x1a, 0x2f, 0xef, 0xc1, 0x0e, 0x8f, 0x5a,
+	0xd2, 0x5a, 0x62, 0x22, 0x36, 0x22, 0x2f, 0x9b, 0x52, 0x8f, 0x68, 0xed, 0xd8, 0x2b, 0x0c, 0xf4,
+	0x21, 0x74, 0x3c, 0xdf, 0x59, 0x60, 0x3f, 0x14, 0x9f, 0x94, 0x2a, 0xe6, 0x40, 0xba, 0x4e, 0x49,
+	0x68, 0xfc, 0xa3, 0xc0, 0xbb, 0x39, 0x8e, 0x6e, 0xa9, 0x10, 0x9f, 0x41, 0xdd, 0xa7, 0x4b, 0x41,
+	0x7c, 0x89, 0x00, 0xd7, 0xdb, 0x30, 0xa3, 0xa0, 0x48, 0x07, 0xdd, 0x33, 0x91, 0x29, 0x99, 0x67,
+	0x6f, 0xa3, 0x16, 0x1e, 0x80, 0x8a, 0x2f, 0x39, 0xf1, 0x33, 0x4a, 0x68, 0x0b, 0x47, 0xa4, 0x83,
+	0x5f, 0x14, 0xd8, 0xdf, 0x10, 0xc1, 0x3e, 0xd4, 0x57, 0x07, 0x71, 0x34, 0x44, 0x08, 0x1a, 0x17,
+	0x98, 0x63, 0x79, 0xd6, 0x8b, 0x71, 0xe4, 0x4b, 0x89, 0x50, 0x4d, 0x31, 0x8e, 0x2a, 0xf0, 0x70,
+	0x48, 0x7c, 0xd1, 0xbd, 0x6a, 0xc6, 0x06, 0x7a, 0x04, 0xbb, 0x29, 0x2f, 0x36, 0xf1, 0x45, 0xc7,
+	0x0d, 0xb3, 0x93, 0x10, 0x6e, 0x13, 0xdf, 0xf8, 0x55, 0x01, 0x2d, 0xb3, 0x57, 0x67, 0x51, 0xe5,
+	0xd5, 0x76, 0x2b, 0x4b, 0x53, 0xad, 0x88, 0xa6, 0x7a, 0x96, 0xa6, 0x1c, 0x21, 0x8d, 0x35, 0x42,
+	0x26, 0x80, 0x56, 0x15, 0x54, 0xbb, 0x4b, 0x52, 0xda, 0x6b, 0x19, 0xda, 0x8d, 0xdf, 0x6b, 0xf0,
+	0x28, 0xee, 0xe8, 0xab, 0x60, 0xce, 0x9d, 0xb8, 0xa3, 0x9b, 0x09, 0xf1, 0xe6, 0xad, 0x65, 0xa5,
+	0xdb, 0x28, 0x94, 0xee, 0xce, 0x35, 0xd2, 0x6d, 0x56, 0x95, 0x6e, 0xab, 0x40, 0xba, 0x5d, 0x68,
+	0x0a, 0x12, 0x98, 0xd6, 0x16, 0x77, 0xab, 0xb4, 0x8c, 0x3f, 0x6b, 0x70, 0x98, 0xe1, 0xe4, 0x85,
+	0x6c, 0xe6, 0x86, 0xb4, 0x6c, 0xe5, 0xfb, 0x76, 0x13, 0xf2, 0x3e, 0xa8, 0xc9, 0xce, 0x25, 0x9c,
+	0xac, 0x1c, 0xc6, 0x1c, 0xba, 0x29, 0x03, 0x79, 0xdd, 0xa5, 0xad, 0x2a, 0xd9, 0x56, 0x9f, 0x43,
+	0xc3, 0xa7, 0x4b, 0xa6, 0x35, 0xca, 0xaf, 0xe5, 0x8d, 0x53, 0x51, 0x44, 0x19, 0x01, 0x1c, 0xa4,
+	0xd9, 0x92, 0x1d, 0x48, 0x13, 0x96, 0x5d, 0xcc, 0xff, 0x2b, 0xed, 0xd1, 0xdf, 0x2d, 0xd8, 0x39,
+	0x8b, 0x56, 0xa1, 0xef, 0xa0, 0x19, 0x3f, 0x35, 0xd1, 0x47, 0x45, 0x18, 0xb9, 0xd7, 0xae, 0xfe,
+	0xf1, 0x75, 0xcb, 0x64, 0xf1, 0x14, 0xf6, 0xf2, 0x6f, 0x41, 0xf4, 0xb4, 0x24, 0x72, 0xf3, 0x21,
+	0xaa, 0x0f, 0xaa, 0x2e, 0x97, 0x09, 0x7f, 0x16, 0x0f, 0x8d, 0xb5, 0x47, 0x1d, 0x1a, 0x97, 0xa0,
+	0x6c, 0x7f, 0x55, 0xea, 0x47, 0x37, 0x09, 0x91, 0xc9, 0x2f, 0xa1, 0x93, 0xb9, 0xc1, 0xd1, 0xe3,
+	0x12, 0x88, 0xb5, 0xa7, 0x90, 0xfe, 0xa4, 0xd2, 0x5a, 0x99, 0x67, 0x01, 0x77, 0xd6, 0x6e, 0x51,
+	0x54, 0xc8, 0xd3, 0xf6, 0xeb, 0x56, 0xaf, 0xac, 0x95, 0x91, 0x82, 0x18, 0xdc, 0xdd, 0xb8, 0x08,
+	0xd0, 0xa8, 0x42, 0xc2, 0xdc, 0x9d, 0xa1, 0x3f, 0x2e, 0x4d, 0x99, 0xfb, 0xca, 0x46, 0x0a, 0xfa,
+	0x4d, 0x01, 0xbd, 0xf8, 0xb0, 0x46, 0x9f, 0x95, 0xa7, 0x2f, 0x39, 0xe0, 0x8b, 0x25, 0xb5, 0xfd,
+	0x8b, 0x1f, 0x29, 0xe8, 0x0f, 0x05, 0x1e, 0x96, 0x1e, 0x92, 0xe8, 0x79, 0x85, 0x72, 0x0a, 0xcf,
+	0x56, 0x7d, 0x7c, 0x6d, 0x45, 0xeb, 0xa7, 0xc2, 0x48, 0x39, 0xf9, 0xe2, 0xfb, 0x17, 0x3f, 0x38,
+	0xfc, 0x4d, 0x60, 0x0f, 0xa6, 0x74, 0x31, 0x14, 0x00, 0x4f, 0x1d, 0x2a, 0x07, 0xf1, 0x5f, 0xaf,
+	0x67, 0x0f, 0xb7, 0xff, 0x04, 0x7f, 0xee, 0xd9, 0xd2, 0xb0, 0x9b, 0xe2, 0x7f, 0xf6, 0xd3, 0xff,
+	0x06, 0x00, 0xe4, 0xe8, 0xd6, 0x2f, 0x2f, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
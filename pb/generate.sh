@@ -30,12 +30,14 @@ function main() {
 
   generate "dfuse/eosio/abicodec/v1/abicodec.proto"
   generate "dfuse/eosio/codec/v1/codec.proto"
-  generate "dfuse/eosio/statedb/v1/" "statedb.proto" "tablet.proto" "singlet.proto"
+  generate "dfuse/eosio/statedb/v1/" "statedb.proto" "tablet.proto" "singlet.proto" "resources.proto" "features.proto" "permissions.proto"
   generate "dfuse/eosio/trxdb/v1/trxdb.proto"
   generate "dfuse/eosio/funnel/v1/funnel.proto"
   generate "dfuse/eosio/search/v1/search.proto"
-  generate "dfuse/eosio/tokenmeta/v1/tokenmeta.proto"
+  generate "dfuse/eosio/tokenmeta/v1/" "tokenmeta.proto" "prices.proto" "holders.proto"
   generate "dfuse/eosio/accounthist/v1/accounthist.proto"
+  generate "dfuse/eosio/nftmeta/v1/nftmeta.proto"
+  generate "dfuse/eosio/dbopfeed/v1/dbopfeed.proto"
 
   echo "generate.sh - `date` - `whoami`" > $ROOT/pb/last_generate.txt
   echo "streamingfast/proto revision: `GIT_DIR=$PROTO/.git git rev-parse HEAD`" >> $ROOT/pb/last_generate.txt
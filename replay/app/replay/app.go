@@ -0,0 +1,181 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/replay"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/blockstream"
+	"github.com/streamingfast/dgrpc"
+	nodeManager "github.com/streamingfast/node-manager"
+	"github.com/streamingfast/node-manager/mindreader"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	LogPath                      string        // Recorded deep-mind log to replay, optionally gzip or zstd compressed
+	Speed                        float64       // Replay speed relative to the chain production rate, 0 for as fast as possible
+	EOFGracePeriod               time.Duration // Time given to downstream apps to process the last blocks once the log is exhausted
+	GRPCAddr                     string
+	ArchiveStoreURL              string
+	MergeArchiveStoreURL         string
+	OneblockSuffix               string
+	BatchMode                    bool
+	MergeThresholdBlockAge       time.Duration
+	MindReadBlocksChanCapacity   int
+	FailOnNonContinuousBlocks    bool
+	StartBlockNum                uint64
+	StopBlockNum                 uint64
+	WorkingDir                   string
+	WaitUploadCompleteOnShutdown time.Duration
+}
+
+type Modules struct {
+	ConsoleReaderFactory       mindreader.ConsolerReaderFactory
+	ConsoleReaderTransformer   mindreader.ConsoleReaderBlockTransformer
+	MetricsAndReadinessManager *nodeManager.MetricsAndReadinessManager
+	Tracker                    *bstream.Tracker
+}
+
+// App feeds a recorded deep-mind log through the mindreader plugin, as if
+// it was read from a running node, and shuts down cleanly once the whole
+// log was processed.
+type App struct {
+	*shutter.Shutter
+	config    *Config
+	modules   *Modules
+	readyFunc func()
+	stopFeed  context.CancelFunc
+	logger    *zap.Logger
+}
+
+func New(config *Config, modules *Modules, logger *zap.Logger) *App {
+	return &App{
+		Shutter:   shutter.New(),
+		config:    config,
+		modules:   modules,
+		readyFunc: func() {},
+		logger:    logger,
+	}
+}
+
+func (a *App) Run() error {
+	a.logger.Info("launching mindreader replay", zap.Reflect("config", a.config))
+
+	log, err := replay.OpenLog(a.config.LogPath)
+	if err != nil {
+		return err
+	}
+
+	var plugin *mindreader.MindReaderPlugin
+	plugin, err = mindreader.NewMindReaderPlugin(
+		a.config.ArchiveStoreURL,
+		a.config.MergeArchiveStoreURL,
+		a.config.BatchMode,
+		a.config.MergeThresholdBlockAge,
+		a.config.WorkingDir,
+		a.modules.ConsoleReaderFactory,
+		a.modules.ConsoleReaderTransformer,
+		a.modules.Tracker,
+		a.config.StartBlockNum,
+		a.config.StopBlockNum,
+		a.config.MindReadBlocksChanCapacity,
+		a.modules.MetricsAndReadinessManager.UpdateHeadBlock,
+		func(err error) { a.onPluginShutdown(plugin, err) },
+		a.config.FailOnNonContinuousBlocks,
+		a.config.WaitUploadCompleteOnShutdown,
+		a.config.OneblockSuffix,
+		a.logger,
+	)
+	if err != nil {
+		log.Close()
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopFeed = cancel
+	a.OnTerminating(func(err error) {
+		cancel()
+		plugin.Shutdown(err)
+	})
+
+	gs := dgrpc.NewServer(dgrpc.WithLogger(a.logger))
+	blockServer := blockstream.NewServer(gs)
+	if err := mindreader.RunGRPCServer(gs, a.config.GRPCAddr, a.logger); err != nil {
+		log.Close()
+		return err
+	}
+
+	go plugin.Launch(blockServer)
+	go a.modules.MetricsAndReadinessManager.Launch()
+
+	go func() {
+		defer log.Close()
+
+		a.logger.Info("replaying deep-mind log", zap.String("path", a.config.LogPath), zap.Float64("speed", a.config.Speed))
+		err := replay.Feed(ctx, log, a.config.Speed, plugin.LogLine)
+		switch {
+		case err == context.Canceled:
+			a.logger.Info("stopped replaying deep-mind log")
+			plugin.Close(nil)
+		case err != nil:
+			a.logger.Error("replay of deep-mind log failed", zap.Error(err))
+			plugin.Close(fmt.Errorf("replay of %q failed: %w", a.config.LogPath, err))
+		default:
+			a.logger.Info("done replaying deep-mind log")
+			plugin.Close(nil)
+		}
+	}()
+
+	return nil
+}
+
+// onPluginShutdown is the mindreader plugin shutdown function, called at
+// the end of the log or once the stop block is reached. On a clean end,
+// it waits for the plugin to flush its blocks and gives the downstream
+// apps the grace period to consume them before shutting down, the
+// launcher then stopping all the other apps.
+func (a *App) onPluginShutdown(plugin *mindreader.MindReaderPlugin, err error) {
+	if err != nil || a.IsTerminating() {
+		a.Shutdown(err)
+		return
+	}
+
+	// The plugin terminates only once the feed closed its pipe
+	a.stopFeed()
+	<-plugin.Terminated()
+	if a.config.EOFGracePeriod > 0 {
+		a.logger.Info("replay completed, waiting for downstream apps to catch up", zap.Duration("grace_period", a.config.EOFGracePeriod))
+		select {
+		case <-time.After(a.config.EOFGracePeriod):
+		case <-a.Terminating():
+		}
+	}
+
+	a.Shutdown(nil)
+}
+
+func (a *App) OnReady(f func()) {
+	a.readyFunc = f
+}
+
+func (a *App) IsReady() bool {
+	return true
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// OpenLog opens a recorded deep-mind log for reading. Gzip and zstd
+// compressed logs are decompressed transparently, the compression being
+// detected from the content of the file rather than from its extension.
+func OpenLog(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open deep-mind log: %w", err)
+	}
+
+	buffered := bufio.NewReader(file)
+	magic, err := buffered.Peek(4)
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, fmt.Errorf("unable to read deep-mind log %q: %w", path, err)
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to read gzip deep-mind log %q: %w", path, err)
+		}
+
+		return &readCloser{Reader: reader, closers: []io.Closer{reader, file}}, nil
+
+	case bytes.HasPrefix(magic, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to read zstd deep-mind log %q: %w", path, err)
+		}
+
+		return &readCloser{Reader: decoder, closers: []io.Closer{decoder.IOReadCloser(), file}}, nil
+	}
+
+	return &readCloser{Reader: buffered, closers: []io.Closer{file}}, nil
+}
+
+// CreateLog creates a deep-mind log for writing, compressed with gzip
+// when `path` ends with `.gz` and with zstd when it ends with `.zst` or
+// `.zstd`.
+func CreateLog(path string) (io.WriteCloser, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create deep-mind log: %w", err)
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		writer := gzip.NewWriter(file)
+		return &writeCloser{Writer: writer, closers: []io.Closer{writer, file}}, nil
+
+	case strings.HasSuffix(path, ".zst") || strings.HasSuffix(path, ".zstd"):
+		encoder, err := zstd.NewWriter(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to create zstd deep-mind log %q: %w", path, err)
+		}
+
+		return &writeCloser{Writer: encoder, closers: []io.Closer{encoder, file}}, nil
+	}
+
+	writer := bufio.NewWriter(file)
+	return &writeCloser{Writer: writer, closers: []io.Closer{flushCloser{writer}, file}}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *readCloser) Close() error {
+	return closeAll(r.closers)
+}
+
+type writeCloser struct {
+	io.Writer
+	closers []io.Closer
+}
+
+func (w *writeCloser) Close() error {
+	return closeAll(w.closers)
+}
+
+type flushCloser struct {
+	writer *bufio.Writer
+}
+
+func (c flushCloser) Close() error {
+	return c.writer.Flush()
+}
+
+// closeAll closes every closer in order, the first error being returned
+func closeAll(closers []io.Closer) (err error) {
+	for _, closer := range closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return
+}
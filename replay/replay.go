@@ -0,0 +1,187 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BlockInterval is the block production interval of an EOSIO chain, a
+// replay at speed 1 feeds one accepted block per interval.
+const BlockInterval = 500 * time.Millisecond
+
+const deepMindPrefix = "DMLOG "
+const acceptedBlockPrefix = deepMindPrefix + "ACCEPTED_BLOCK "
+
+// Pacer throttles accepted blocks to `speed` times the chain production
+// rate, a speed of 0 (or less) meaning no throttling at all. Pacing is
+// computed from the replay start so a slow consumer does not accumulate
+// drift, the pacer catching up without waiting instead.
+type Pacer struct {
+	speed  float64
+	start  time.Time
+	blocks int64
+}
+
+func NewPacer(speed float64) *Pacer {
+	return &Pacer{speed: speed}
+}
+
+// Wait blocks until the next accepted block is due
+func (p *Pacer) Wait(ctx context.Context) error {
+	if p.speed <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if p.start.IsZero() {
+		p.start = now
+	}
+
+	delay := p.delay(now)
+	p.blocks++
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// delay returns how long to wait at `now` before the next block is due
+func (p *Pacer) delay(now time.Time) time.Duration {
+	due := p.start.Add(time.Duration(float64(p.blocks) * float64(BlockInterval) / p.speed))
+	return due.Sub(now)
+}
+
+// Feed reads a deep-mind log line by line, handing each line, without its
+// line terminator, to `lineFunc`. Accepted blocks are paced at `speed`
+// times the chain production rate, 0 for as fast as possible. It returns
+// nil once the end of the log is reached.
+func Feed(ctx context.Context, reader io.Reader, speed float64, lineFunc func(line string)) error {
+	pacer := NewPacer(speed)
+	buffered := bufio.NewReaderSize(reader, 1024*1024)
+
+	for {
+		line, err := buffered.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("unable to read deep-mind log: %w", err)
+		}
+
+		if len(line) > 0 {
+			line = strings.TrimRight(line, "\r\n")
+			if strings.HasPrefix(line, acceptedBlockPrefix) {
+				if waitErr := pacer.Wait(ctx); waitErr != nil {
+					return waitErr
+				}
+			}
+
+			lineFunc(line)
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// RecordOptions controls which lines of a node output are recorded
+type RecordOptions struct {
+	// AllLines keeps every line instead of only the deep-mind ones
+	AllLines bool
+
+	// StopBlockNum stops recording after the accepted block with that
+	// number, 0 to record until the end of the input.
+	StopBlockNum uint64
+}
+
+// RecordStats summarizes what was recorded
+type RecordStats struct {
+	Lines        uint64
+	Blocks       uint64
+	LastBlockNum uint64
+}
+
+// Record copies the deep-mind lines of a node output, `nodeos` being
+// started with `--deep-mind`, to `writer`. It returns once the input is
+// exhausted or once the stop block was recorded.
+func Record(reader io.Reader, writer io.Writer, options RecordOptions) (stats RecordStats, err error) {
+	buffered := bufio.NewReaderSize(reader, 1024*1024)
+
+	for {
+		line, readErr := buffered.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return stats, fmt.Errorf("unable to read node output: %w", readErr)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" && (options.AllLines || strings.HasPrefix(line, deepMindPrefix)) {
+			if _, err := io.WriteString(writer, line+"\n"); err != nil {
+				return stats, fmt.Errorf("unable to write deep-mind log: %w", err)
+			}
+			stats.Lines++
+
+			if blockNum, ok := acceptedBlockNum(line); ok {
+				stats.Blocks++
+				stats.LastBlockNum = blockNum
+
+				if options.StopBlockNum != 0 && blockNum >= options.StopBlockNum {
+					zlog.Info("stop block reached, ending recording", zap.Uint64("block_num", blockNum))
+					return stats, nil
+				}
+			}
+		}
+
+		if readErr == io.EOF {
+			return stats, nil
+		}
+	}
+}
+
+// acceptedBlockNum extracts the block number of an `ACCEPTED_BLOCK` line,
+// formatted as `DMLOG ACCEPTED_BLOCK ${block_num} ${block_state_hex}`.
+func acceptedBlockNum(line string) (uint64, bool) {
+	if !strings.HasPrefix(line, acceptedBlockPrefix) {
+		return 0, false
+	}
+
+	rest := line[len(acceptedBlockPrefix):]
+	if i := strings.IndexByte(rest, ' '); i >= 0 {
+		rest = rest[:i]
+	}
+
+	blockNum, err := strconv.ParseUint(rest, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return blockNum, true
+}
@@ -0,0 +1,96 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNodeOutput = `info  2021-01-01T00:00:00.000 nodeos    main.cpp:100     main  ] nodeos started
+DMLOG TRX_OP CREATE onblock aa
+DMLOG ACCEPTED_BLOCK 10 00
+info  2021-01-01T00:00:00.500 nodeos    producer_plugin.cpp:100 on_incoming_block ] Received block
+DMLOG TRX_OP CREATE onblock bb
+DMLOG ACCEPTED_BLOCK 11 01
+DMLOG ACCEPTED_BLOCK 12 02
+`
+
+func TestRecord(t *testing.T) {
+	out := &bytes.Buffer{}
+	stats, err := Record(strings.NewReader(testNodeOutput), out, RecordOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, RecordStats{Lines: 5, Blocks: 3, LastBlockNum: 12}, stats)
+	assert.NotContains(t, out.String(), "nodeos started")
+
+	out.Reset()
+	stats, err = Record(strings.NewReader(testNodeOutput), out, RecordOptions{AllLines: true, StopBlockNum: 11})
+	require.NoError(t, err)
+
+	assert.Equal(t, RecordStats{Lines: 6, Blocks: 2, LastBlockNum: 11}, stats)
+	assert.True(t, strings.HasSuffix(out.String(), "DMLOG ACCEPTED_BLOCK 11 01\n"))
+}
+
+func TestLogRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replay")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"deep-mind.log", "deep-mind.log.gz", "deep-mind.log.zst"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+
+			writer, err := CreateLog(path)
+			require.NoError(t, err)
+			_, err = Record(strings.NewReader(testNodeOutput), writer, RecordOptions{})
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			reader, err := OpenLog(path)
+			require.NoError(t, err)
+			defer reader.Close()
+
+			var lines []string
+			require.NoError(t, Feed(context.Background(), reader, 0, func(line string) {
+				lines = append(lines, line)
+			}))
+
+			assert.Equal(t, []string{
+				"DMLOG TRX_OP CREATE onblock aa",
+				"DMLOG ACCEPTED_BLOCK 10 00",
+				"DMLOG TRX_OP CREATE onblock bb",
+				"DMLOG ACCEPTED_BLOCK 11 01",
+				"DMLOG ACCEPTED_BLOCK 12 02",
+			}, lines)
+		})
+	}
+}
+
+func TestPacerDelay(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	pacer := &Pacer{speed: 2, start: start, blocks: 4}
+	assert.Equal(t, time.Second, pacer.delay(start))
+	assert.Equal(t, 250*time.Millisecond, pacer.delay(start.Add(750*time.Millisecond)))
+
+	// A slow consumer is not delayed further
+	assert.True(t, pacer.delay(start.Add(3*time.Second)) < 0)
+}
+
+func TestFeed_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var lines []string
+	err := Feed(ctx, strings.NewReader(testNodeOutput), 1, func(line string) { lines = append(lines, line) })
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, lines, 1)
+}
@@ -0,0 +1,211 @@
+package searchclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/client"
+	pbsearch "github.com/streamingfast/pbgo/dfuse/search/v1"
+	"go.uber.org/zap"
+)
+
+type resumeOptions struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+type ResumeOption func(*resumeOptions)
+
+// WithMaxRetries sets how many times in a row the stream is reconnected
+// before the error is returned to the caller, the count being reset on
+// each received match. Defaults to 5.
+func WithMaxRetries(maxRetries int) ResumeOption {
+	return func(o *resumeOptions) {
+		o.maxRetries = maxRetries
+	}
+}
+
+// WithBackoff sets the delay before the first reconnection, doubled on
+// each consecutive one up to `max`. Defaults to 500ms up to 30s.
+func WithBackoff(initial, max time.Duration) ResumeOption {
+	return func(o *resumeOptions) {
+		o.initialBackoff = initial
+		o.maxBackoff = max
+	}
+}
+
+// StreamMatchesWithResume is like `StreamMatches` but survives transient
+// failures of the search router or of trxdb: the stream is re-issued from
+// the cursor of the last received match, after a backoff, and the matches
+// replayed around this cursor are skipped.
+//
+// Errors that cannot go away by retrying (invalid query, permission denied,
+// ...) are returned right away, as well as the last error once the retries
+// are exhausted.
+func (e *EOSClient) StreamMatchesWithResume(callerCtx context.Context, req *pbsearch.RouterRequest, opts ...ResumeOption) (EOSStreamMatchesClient, error) {
+	options := &resumeOptions{
+		maxRetries:     5,
+		initialBackoff: 500 * time.Millisecond,
+		maxBackoff:     30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &resumingStreamMatches{
+		client:  e,
+		ctx:     callerCtx,
+		req:     req,
+		options: options,
+		cursor:  req.Cursor,
+		seen:    map[matchKey]bool{},
+	}, nil
+}
+
+type matchKey struct {
+	cursor      string
+	trxIDPrefix string
+	undo        bool
+}
+
+type resumingStreamMatches struct {
+	client  *EOSClient
+	ctx     context.Context
+	req     *pbsearch.RouterRequest
+	options *resumeOptions
+
+	stream       EOSStreamMatchesClient
+	cancelStream context.CancelFunc
+	attempt      int
+
+	cursor       string
+	trxCount     int64
+	seenBlockNum uint64
+	seen         map[matchKey]bool
+}
+
+func (s *resumingStreamMatches) Recv() (*EOSSearchMatch, error) {
+	for {
+		if s.req.Limit > 0 && s.trxCount >= s.req.Limit {
+			s.closeStream()
+			return nil, io.EOF
+		}
+
+		if s.stream == nil {
+			if err := s.openStream(); err != nil {
+				return nil, err
+			}
+		}
+
+		match, err := s.stream.Recv()
+		if err == nil {
+			if s.isReplayed(match) {
+				zlog.Debug("skipping match replayed after reconnection", zap.Uint64("block_num", match.BlockNum), zap.String("trx_id_prefix", match.TrxIdPrefix))
+				continue
+			}
+
+			s.attempt = 0
+			return match, nil
+		}
+
+		s.closeStream()
+		if err == io.EOF || s.ctx.Err() != nil {
+			return nil, err
+		}
+		if !client.IsRetryableError(err) || s.attempt >= s.options.maxRetries {
+			return nil, err
+		}
+
+		s.attempt++
+		backoff := s.backoff()
+		zlog.Info("search stream failed, reconnecting from last cursor",
+			zap.Error(err),
+			zap.Int("attempt", s.attempt),
+			zap.Duration("backoff", backoff),
+			zap.String("cursor", s.cursor),
+		)
+
+		select {
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (s *resumingStreamMatches) openStream() error {
+	req := &pbsearch.RouterRequest{
+		Query:               s.req.Query,
+		LowBlockNum:         s.req.LowBlockNum,
+		HighBlockNum:        s.req.HighBlockNum,
+		LowBlockUnbounded:   s.req.LowBlockUnbounded,
+		HighBlockUnbounded:  s.req.HighBlockUnbounded,
+		Descending:          s.req.Descending,
+		Cursor:              s.cursor,
+		Limit:               s.req.Limit,
+		WithReversible:      s.req.WithReversible,
+		Mode:                s.req.Mode,
+		UseLegacyBoundaries: s.req.UseLegacyBoundaries,
+		StartBlock:          s.req.StartBlock,
+		BlockCount:          s.req.BlockCount,
+		LiveMarkerInterval:  s.req.LiveMarkerInterval,
+	}
+	if req.Limit > 0 {
+		req.Limit -= s.trxCount
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	stream, err := s.client.StreamMatches(ctx, req)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("unable to stream matches: %w", err)
+	}
+
+	s.stream = stream
+	s.cancelStream = cancel
+	return nil
+}
+
+func (s *resumingStreamMatches) closeStream() {
+	if s.cancelStream != nil {
+		s.cancelStream()
+	}
+	s.stream = nil
+	s.cancelStream = nil
+}
+
+// isReplayed records `match` as received and returns whether it already
+// was. Resuming from a cursor restarts at most at the block of this cursor,
+// so only the matches of the last received block are remembered.
+func (s *resumingStreamMatches) isReplayed(match *EOSSearchMatch) bool {
+	key := matchKey{cursor: match.Cursor, trxIDPrefix: match.TrxIdPrefix, undo: match.Undo}
+	if match.BlockNum != s.seenBlockNum {
+		s.seenBlockNum = match.BlockNum
+		s.seen = map[matchKey]bool{}
+	} else if s.seen[key] {
+		return true
+	}
+
+	s.seen[key] = true
+	if match.Cursor != "" {
+		s.cursor = match.Cursor
+	}
+	if match.TrxIdPrefix != "" {
+		s.trxCount++
+	}
+	return false
+}
+
+func (s *resumingStreamMatches) backoff() time.Duration {
+	backoff := s.options.initialBackoff
+	for i := 1; i < s.attempt && backoff < s.options.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.options.maxBackoff {
+		backoff = s.options.maxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,190 @@
+package searchclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbsearcheos "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/search/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/golang/protobuf/ptypes"
+	pbsearch "github.com/streamingfast/pbgo/dfuse/search/v1"
+	searchclient "github.com/streamingfast/search-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamMatchesWithResume(t *testing.T) {
+	release := make(chan struct{})
+	router := &testRouterClient{t: t, streams: []*testMatchesStream{
+		{
+			matches: []*pbsearch.SearchMatch{testMatch(t, 1, "a", true), testMatch(t, 2, "b", true)},
+			release: release,
+			err:     status.Error(codes.Unavailable, "router restarting"),
+		},
+		{
+			// The router replays the match of the cursor block
+			matches: []*pbsearch.SearchMatch{testMatch(t, 2, "b", true), testMatch(t, 2, "c", true), testMatch(t, 3, "d", true)},
+		},
+	}}
+
+	stream := testResumingStream(t, router, &pbsearch.RouterRequest{Query: "receiver:eosio", Limit: 10})
+
+	assert.Equal(t, "a", testRecv(t, stream).TrxIdPrefix)
+	assert.Equal(t, "b", testRecv(t, stream).TrxIdPrefix)
+	close(release)
+	assert.Equal(t, "c", testRecv(t, stream).TrxIdPrefix)
+	assert.Equal(t, "d", testRecv(t, stream).TrxIdPrefix)
+
+	_, err := stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	require.Len(t, router.requests, 2)
+	assert.Equal(t, "", router.requests[0].Cursor)
+	assert.Equal(t, "cursor-2-b", router.requests[1].Cursor)
+	assert.Equal(t, "receiver:eosio", router.requests[1].Query)
+	assert.Equal(t, int64(8), router.requests[1].Limit)
+}
+
+func TestStreamMatchesWithResume_TrxDBError(t *testing.T) {
+	router := &testRouterClient{t: t, streams: []*testMatchesStream{
+		{matches: []*pbsearch.SearchMatch{testMatch(t, 1, "a", false)}},
+		{matches: []*pbsearch.SearchMatch{testMatch(t, 1, "a", true)}},
+	}}
+
+	stream := testResumingStream(t, router, &pbsearch.RouterRequest{Cursor: "start"})
+
+	assert.Equal(t, "a", testRecv(t, stream).TrxIdPrefix)
+	require.Len(t, router.requests, 2)
+	assert.Equal(t, "start", router.requests[1].Cursor)
+}
+
+func TestStreamMatchesWithResume_NotRetryable(t *testing.T) {
+	router := &testRouterClient{t: t, streams: []*testMatchesStream{
+		{err: status.Error(codes.InvalidArgument, "invalid query")},
+	}}
+
+	stream := testResumingStream(t, router, &pbsearch.RouterRequest{})
+
+	_, err := stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Len(t, router.requests, 1)
+}
+
+func TestStreamMatchesWithResume_RetriesExhausted(t *testing.T) {
+	router := &testRouterClient{t: t, streams: []*testMatchesStream{
+		{err: status.Error(codes.Unavailable, "unavailable")},
+		{err: status.Error(codes.Unavailable, "unavailable")},
+		{err: status.Error(codes.Unavailable, "unavailable")},
+	}}
+
+	stream := testResumingStream(t, router, &pbsearch.RouterRequest{}, WithMaxRetries(2))
+
+	_, err := stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Len(t, router.requests, 3)
+}
+
+func TestResumingStreamMatches_Backoff(t *testing.T) {
+	s := &resumingStreamMatches{options: &resumeOptions{initialBackoff: 1, maxBackoff: 5}}
+
+	var backoffs []int
+	for s.attempt = 1; s.attempt <= 5; s.attempt++ {
+		backoffs = append(backoffs, int(s.backoff()))
+	}
+	assert.Equal(t, []int{1, 2, 4, 5, 5}, backoffs)
+}
+
+func testResumingStream(t *testing.T, router *testRouterClient, req *pbsearch.RouterRequest, opts ...ResumeOption) EOSStreamMatchesClient {
+	client := &EOSClient{CommonClient: &searchclient.CommonClient{Client: router}, dbReader: &testFailingDBReader{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stream, err := client.StreamMatchesWithResume(ctx, req, append([]ResumeOption{WithBackoff(0, 0)}, opts...)...)
+	require.NoError(t, err)
+	return stream
+}
+
+func testRecv(t *testing.T, stream EOSStreamMatchesClient) *EOSSearchMatch {
+	match, err := stream.Recv()
+	require.NoError(t, err)
+	return match
+}
+
+// testMatch returns a match of block `blockNum`, carrying its trace when
+// `reversible`, fetched from trxdb otherwise
+func testMatch(t *testing.T, blockNum uint64, trxIDPrefix string, reversible bool) *pbsearch.SearchMatch {
+	eosMatch := &pbsearcheos.Match{}
+	if reversible {
+		eosMatch.Block = &pbsearcheos.BlockTrxPayload{
+			BlockID: fmt.Sprintf("%08da", blockNum),
+			Trace:   &pbcodec.TransactionTrace{Id: trxIDPrefix},
+		}
+	}
+
+	chainSpecific, err := ptypes.MarshalAny(eosMatch)
+	require.NoError(t, err)
+
+	return &pbsearch.SearchMatch{
+		TrxIdPrefix:   trxIDPrefix,
+		BlockNum:      blockNum,
+		Cursor:        fmt.Sprintf("cursor-%d-%s", blockNum, trxIDPrefix),
+		ChainSpecific: chainSpecific,
+	}
+}
+
+type testRouterClient struct {
+	pbsearch.RouterClient
+
+	t        *testing.T
+	streams  []*testMatchesStream
+	requests []*pbsearch.RouterRequest
+}
+
+func (c *testRouterClient) StreamMatches(ctx context.Context, in *pbsearch.RouterRequest, opts ...grpc.CallOption) (pbsearch.Router_StreamMatchesClient, error) {
+	require.NotEmpty(c.t, c.streams, "unexpected search request")
+
+	c.requests = append(c.requests, in)
+	stream := c.streams[0]
+	c.streams = c.streams[1:]
+	return stream, nil
+}
+
+type testMatchesStream struct {
+	grpc.ClientStream
+
+	matches []*pbsearch.SearchMatch
+	// release, when set, is waited for before returning `err`
+	release chan struct{}
+	err     error
+}
+
+func (s *testMatchesStream) Recv() (*pbsearch.SearchMatch, error) {
+	if len(s.matches) == 0 {
+		if s.err != nil {
+			if s.release != nil {
+				<-s.release
+			}
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+
+	match := s.matches[0]
+	s.matches = s.matches[1:]
+	return match, nil
+}
+
+type testFailingDBReader struct {
+	trxdb.DBReader
+}
+
+func (r *testFailingDBReader) GetTransactionTracesBatch(ctx context.Context, idPrefixes []string) ([][]*pbcodec.TransactionEvent, error) {
+	return nil, fmt.Errorf("kv store unavailable")
+}
@@ -0,0 +1,96 @@
+package sqlsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/eoscanada/eos-go"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// abiDecoder decodes db op rows with the ABI of their contract, fetched from
+// the ABI codec. ABIs are cached until the contract sets a new one, contracts
+// without ABI being cached as such.
+type abiDecoder struct {
+	client pbabicodec.DecoderClient
+	abis   map[string]*eos.ABI
+}
+
+func newABIDecoder(client pbabicodec.DecoderClient) *abiDecoder {
+	return &abiDecoder{
+		client: client,
+		abis:   map[string]*eos.ABI{},
+	}
+}
+
+// decodeRow returns the JSON of a row of `contract`, nil when it cannot be
+// decoded
+func (d *abiDecoder) decodeRow(ctx context.Context, contract, table string, data []byte, blockNum uint32) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	abi, err := d.getABI(ctx, contract, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if abi == nil {
+		return nil, nil
+	}
+
+	out, err := abi.DecodeTableRow(eos.TableName(table), data)
+	if err != nil {
+		zlog.Debug("unable to decode row", zap.String("contract", contract), zap.String("table", table), zap.Error(err))
+		return nil, nil
+	}
+	return string(out), nil
+}
+
+func (d *abiDecoder) getABI(ctx context.Context, contract string, blockNum uint32) (*eos.ABI, error) {
+	if abi, ok := d.abis[contract]; ok {
+		return abi, nil
+	}
+
+	zlog.Debug("abi cache miss", zap.String("contract", contract), zap.Uint32("at_block_num", blockNum))
+	resp, err := d.client.GetAbi(ctx, &pbabicodec.GetAbiRequest{
+		Account:    contract,
+		AtBlockNum: blockNum,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, fmt.Errorf("unable to get abi for contract %q: %w", contract, err)
+	}
+
+	var abi *eos.ABI
+	if err == nil && resp.JsonPayload != "" {
+		if err := json.Unmarshal([]byte(resp.JsonPayload), &abi); err != nil {
+			zlog.Info("unable to decode abi, rows of contract will not be decoded", zap.String("contract", contract), zap.Error(err))
+			abi = nil
+		}
+	}
+
+	d.abis[contract] = abi
+	return abi, nil
+}
+
+// invalidateSetABIs drops the cached ABIs of the contracts setting a new one
+// in `blk`
+func (d *abiDecoder) invalidateSetABIs(blk *pbcodec.Block) {
+	for _, trx := range blk.TransactionTraces() {
+		for _, act := range trx.ActionTraces {
+			if act.Receiver == "eosio" && act.Account() == "eosio" && act.Name() == "setabi" {
+				delete(d.abis, act.GetData("account").String())
+			}
+		}
+	}
+}
+
+// reset drops all the cached ABIs, the ones set in undone blocks being
+// possibly cached
+func (d *abiDecoder) reset() {
+	d.abis = map[string]*eos.ABI{}
+}
@@ -0,0 +1,123 @@
+package sqlsink
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	"github.com/dfuse-io/dfuse-eosio/sqlsink"
+	"github.com/streamingfast/dgrpc"
+	"github.com/streamingfast/dmetrics"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type Config struct {
+	FirehoseAddr      string // gRPC address of the firehose
+	FirehoseInsecure  bool   // skip the verification of the firehose TLS certificate
+	FirehosePlaintext bool   // reach the firehose without TLS
+	DSN               string // database DSN, its scheme selecting the driver
+	ABICodecAddr      string // gRPC address of the ABI codec decoding db op rows, empty to leave them undecoded
+	StartBlockNum     int64  // block to start at when the database holds no cursor
+	StopBlockNum      uint64 // last block to write, 0 to follow the chain forever
+	IncludeFilterExpr string // CEL filter expression of the included actions
+	ExcludeFilterExpr string // CEL filter expression of the excluded actions
+	Details           string // `full` or `light` blocks, light blocks having no db ops
+	IrreversibleOnly  bool   // only write irreversible blocks
+}
+
+type App struct {
+	*shutter.Shutter
+	config *Config
+}
+
+func New(config *Config) *App {
+	return &App{
+		Shutter: shutter.New(),
+		config:  config,
+	}
+}
+
+func (a *App) Run() error {
+	zlog.Info("running firehose sql sink", zap.Reflect("config", a.config))
+	dmetrics.Register(sqlsink.MetricsSet)
+
+	details, err := parseDetails(a.config.Details)
+	if err != nil {
+		return err
+	}
+
+	driver, err := sqlsink.NewDriver(a.config.DSN)
+	if err != nil {
+		return fmt.Errorf("unable to open database: %w", err)
+	}
+
+	conn, err := a.dialFirehose()
+	if err != nil {
+		driver.Close()
+		return fmt.Errorf("unable to create firehose client to %q: %w", a.config.FirehoseAddr, err)
+	}
+
+	var abiCodecClient pbabicodec.DecoderClient
+	if a.config.ABICodecAddr != "" {
+		abiCodecConn, err := dgrpc.NewInternalClient(a.config.ABICodecAddr)
+		if err != nil {
+			driver.Close()
+			conn.Close()
+			return fmt.Errorf("unable to create abicodec client to %q: %w", a.config.ABICodecAddr, err)
+		}
+		abiCodecClient = pbabicodec.NewDecoderClient(abiCodecConn)
+	}
+
+	sink := sqlsink.NewSink(pbbstream.NewBlockStreamV2Client(conn), driver, abiCodecClient, &pbbstream.BlocksRequestV2{
+		StartBlockNum:     a.config.StartBlockNum,
+		StopBlockNum:      a.config.StopBlockNum,
+		IncludeFilterExpr: a.config.IncludeFilterExpr,
+		ExcludeFilterExpr: a.config.ExcludeFilterExpr,
+		Details:           details,
+	}, a.config.IrreversibleOnly)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.OnTerminating(func(_ error) {
+		cancel()
+		sink.Shutdown(nil)
+	})
+
+	go func() {
+		err := sink.Run(ctx)
+		conn.Close()
+		if err := driver.Close(); err != nil {
+			zlog.Warn("unable to close database", zap.Error(err))
+		}
+		a.Shutdown(err)
+	}()
+
+	return nil
+}
+
+func (a *App) dialFirehose() (*grpc.ClientConn, error) {
+	if a.config.FirehosePlaintext {
+		return dgrpc.NewInternalClient(a.config.FirehoseAddr)
+	}
+
+	if a.config.FirehoseInsecure {
+		return dgrpc.NewExternalClient(a.config.FirehoseAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	}
+
+	return dgrpc.NewExternalClient(a.config.FirehoseAddr)
+}
+
+func parseDetails(in string) (pbbstream.BlockDetails, error) {
+	switch strings.ToLower(in) {
+	case "full":
+		return pbbstream.BlockDetails_BLOCK_DETAILS_FULL, nil
+	case "light":
+		return pbbstream.BlockDetails_BLOCK_DETAILS_LIGHT, nil
+	}
+	return 0, fmt.Errorf("invalid details %q, expecting 'full' or 'light'", in)
+}
@@ -0,0 +1,77 @@
+package sqlsink
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ColumnKind is the portable type of a column, each driver mapping it to
+// its native type
+type ColumnKind int
+
+const (
+	KindInt64 ColumnKind = iota
+	KindBool
+	KindText
+	KindBytes
+	KindTimestamp
+	KindJSON
+)
+
+// Driver is a SQL database the sink writes to. Statements are shared by all
+// drivers, which must support `CREATE TABLE IF NOT EXISTS`,
+// `CREATE INDEX IF NOT EXISTS` and `INSERT ... ON CONFLICT ... DO UPDATE`.
+type Driver interface {
+	DB() *sql.DB
+
+	// ColumnType returns the native type of the columns of `kind`
+	ColumnType(kind ColumnKind) string
+
+	// Placeholder returns the placeholder of the `index`-th (1-based)
+	// argument of a statement
+	Placeholder(index int) string
+
+	Close() error
+}
+
+type DriverFactory func(dsn string) (Driver, error)
+
+var registry = make(map[string]DriverFactory)
+
+// RegisterDriver registers the driver handling the DSNs of `schemeName`
+func RegisterDriver(schemeName string, factory DriverFactory) {
+	schemeName = strings.ToLower(schemeName)
+
+	if _, ok := registry[schemeName]; ok {
+		panic(fmt.Errorf("%s is already registered", schemeName))
+	}
+
+	registry[schemeName] = factory
+}
+
+// NewDriver opens the database of `dsn` with the driver registered for its
+// scheme, like `sqlite3:///data/sink.db` or `postgres://user@host/db`
+func NewDriver(dsn string) (Driver, error) {
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dsn: %w", err)
+	}
+
+	factory, ok := registry[strings.ToLower(dsnURL.Scheme)]
+	if !ok {
+		return nil, fmt.Errorf("no driver registered for scheme %q, registered: %s", dsnURL.Scheme, strings.Join(registeredSchemes(), ", "))
+	}
+
+	return factory(dsn)
+}
+
+func registeredSchemes() (out []string) {
+	for scheme := range registry {
+		out = append(out, scheme)
+	}
+	sort.Strings(out)
+	return
+}
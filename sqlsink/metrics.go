@@ -0,0 +1,11 @@
+package sqlsink
+
+import (
+	"github.com/streamingfast/dmetrics"
+)
+
+var MetricsSet = dmetrics.NewSet()
+
+var undoneBlockCount = MetricsSet.NewCounter("undone_block_count")
+var HeadBlockNum = MetricsSet.NewHeadBlockNumber("firehose-sql-sink")
+var HeadTimeDrift = MetricsSet.NewHeadTimeDrift("firehose-sql-sink")
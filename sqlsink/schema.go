@@ -0,0 +1,176 @@
+package sqlsink
+
+import (
+	"fmt"
+	"strings"
+)
+
+type column struct {
+	name     string
+	kind     ColumnKind
+	nullable bool
+}
+
+// table is a table written by the sink. Every row holds the id of the block
+// it was written for, undoing a block deleting all the rows with its id.
+type table struct {
+	name       string
+	columns    []column
+	primaryKey []string
+	indexes    [][]string
+}
+
+var cursorTable = &table{
+	name: "sink_cursor",
+	columns: []column{
+		{name: "id", kind: KindInt64},
+		{name: "cursor", kind: KindText},
+		{name: "block_num", kind: KindInt64},
+		{name: "block_id", kind: KindText},
+	},
+	primaryKey: []string{"id"},
+}
+
+var blocksTable = &table{
+	name: "blocks",
+	columns: []column{
+		{name: "block_id", kind: KindText},
+		{name: "block_num", kind: KindInt64},
+		{name: "previous_id", kind: KindText},
+		{name: "block_time", kind: KindTimestamp},
+		{name: "producer", kind: KindText},
+		{name: "irreversible", kind: KindBool},
+	},
+	primaryKey: []string{"block_id"},
+	indexes:    [][]string{{"block_num"}},
+}
+
+var transactionsTable = &table{
+	name: "transactions",
+	columns: []column{
+		{name: "block_id", kind: KindText},
+		{name: "trx_id", kind: KindText},
+		{name: "block_num", kind: KindInt64},
+		{name: "trx_index", kind: KindInt64},
+		{name: "status", kind: KindText},
+		{name: "cpu_usage_us", kind: KindInt64},
+		{name: "net_usage_words", kind: KindInt64},
+		{name: "elapsed_us", kind: KindInt64},
+	},
+	primaryKey: []string{"block_id", "trx_id"},
+	indexes:    [][]string{{"trx_id"}, {"block_num"}},
+}
+
+var actionsTable = &table{
+	name: "actions",
+	columns: []column{
+		{name: "block_id", kind: KindText},
+		{name: "trx_id", kind: KindText},
+		{name: "execution_index", kind: KindInt64},
+		{name: "block_num", kind: KindInt64},
+		{name: "action_ordinal", kind: KindInt64},
+		{name: "creator_action_ordinal", kind: KindInt64},
+		{name: "global_sequence", kind: KindInt64, nullable: true},
+		{name: "receiver", kind: KindText},
+		{name: "account", kind: KindText},
+		{name: "name", kind: KindText},
+		{name: "authorization", kind: KindText},
+		{name: "json_data", kind: KindJSON, nullable: true},
+		{name: "raw_data", kind: KindBytes, nullable: true},
+	},
+	primaryKey: []string{"block_id", "trx_id", "execution_index"},
+	indexes:    [][]string{{"block_num"}, {"receiver", "account", "name"}},
+}
+
+var dbOpsTable = &table{
+	name: "db_ops",
+	columns: []column{
+		{name: "block_id", kind: KindText},
+		{name: "trx_id", kind: KindText},
+		{name: "op_index", kind: KindInt64},
+		{name: "block_num", kind: KindInt64},
+		{name: "action_index", kind: KindInt64},
+		{name: "operation", kind: KindText},
+		{name: "code", kind: KindText},
+		{name: "scope", kind: KindText},
+		{name: "table_name", kind: KindText},
+		{name: "primary_key", kind: KindText},
+		{name: "old_payer", kind: KindText, nullable: true},
+		{name: "new_payer", kind: KindText, nullable: true},
+		{name: "old_data", kind: KindBytes, nullable: true},
+		{name: "new_data", kind: KindBytes, nullable: true},
+		{name: "old_json", kind: KindJSON, nullable: true},
+		{name: "new_json", kind: KindJSON, nullable: true},
+	},
+	primaryKey: []string{"block_id", "trx_id", "op_index"},
+	indexes:    [][]string{{"block_num"}, {"code", "table_name", "scope", "primary_key"}},
+}
+
+// dataTables are the tables holding block data, in the order rows are
+// inserted, deletions happening in reverse order
+var dataTables = []*table{blocksTable, transactionsTable, actionsTable, dbOpsTable}
+
+// createStatements returns the statements creating the tables and indexes
+// of the sink when they do not exist
+func createStatements(driver Driver) (out []string) {
+	for _, tbl := range append([]*table{cursorTable}, dataTables...) {
+		var definitions []string
+		for _, col := range tbl.columns {
+			definition := fmt.Sprintf("%s %s", quote(col.name), driver.ColumnType(col.kind))
+			if !col.nullable {
+				definition += " NOT NULL"
+			}
+			definitions = append(definitions, definition)
+		}
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", quoteAll(tbl.primaryKey)))
+
+		out = append(out, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", quote(tbl.name), strings.Join(definitions, ", ")))
+
+		for _, index := range tbl.indexes {
+			name := fmt.Sprintf("%s_%s_idx", tbl.name, strings.Join(index, "_"))
+			out = append(out, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", quote(name), quote(tbl.name), quoteAll(index)))
+		}
+	}
+	return out
+}
+
+// upsertStatement inserts a row of `tbl`, replacing the one with the same
+// primary key
+func upsertStatement(driver Driver, tbl *table) string {
+	names := make([]string, len(tbl.columns))
+	placeholders := make([]string, len(tbl.columns))
+	var updates []string
+	for i, col := range tbl.columns {
+		names[i] = quote(col.name)
+		placeholders[i] = driver.Placeholder(i + 1)
+		if !tbl.isPrimaryKey(col.name) {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", quote(col.name), quote(col.name)))
+		}
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		quote(tbl.name), strings.Join(names, ", "), strings.Join(placeholders, ", "), quoteAll(tbl.primaryKey), strings.Join(updates, ", "))
+}
+
+func (t *table) isPrimaryKey(name string) bool {
+	for _, key := range t.primaryKey {
+		if key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// quote quotes identifiers, some columns like `authorization` being
+// reserved words
+func quote(identifier string) string {
+	return `"` + identifier + `"`
+}
+
+func quoteAll(identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = quote(identifier)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,232 @@
+package sqlsink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/streamingfast/shutter"
+	"go.uber.org/zap"
+)
+
+// Sink writes the blocks of a firehose stream as rows of a SQL database.
+// Every step is applied in its own database transaction along with the
+// cursor pointing to it, so the database is always consistent with the
+// cursor it resumes from.
+//
+// New blocks are written as reversible, undone blocks have all their rows
+// deleted and irreversible blocks are flagged as such. When only irreversible
+// blocks are requested, nothing written is ever undone.
+type Sink struct {
+	*shutter.Shutter
+
+	client           pbbstream.BlockStreamV2Client
+	driver           Driver
+	writer           *writer
+	request          *pbbstream.BlocksRequestV2
+	irreversibleOnly bool
+	retryDelay       time.Duration
+
+	checkpoint *Checkpoint
+}
+
+// NewSink creates a sink streaming with `request`, its start block, stop
+// block, filters and details being honored. Its start is ignored when the
+// database already holds a cursor. Rows of db ops are decoded with the ABIs
+// of `abiCodecClient` when not nil.
+func NewSink(client pbbstream.BlockStreamV2Client, driver Driver, abiCodecClient pbabicodec.DecoderClient, request *pbbstream.BlocksRequestV2, irreversibleOnly bool) *Sink {
+	var decoder *abiDecoder
+	if abiCodecClient != nil {
+		decoder = newABIDecoder(abiCodecClient)
+	}
+
+	return &Sink{
+		Shutter:          shutter.New(),
+		client:           client,
+		driver:           driver,
+		writer:           newWriter(driver, decoder),
+		request:          request,
+		irreversibleOnly: irreversibleOnly,
+		retryDelay:       5 * time.Second,
+	}
+}
+
+func (s *Sink) Run(ctx context.Context) error {
+	if err := s.writer.createSchema(ctx); err != nil {
+		return fmt.Errorf("unable to create schema: %w", err)
+	}
+
+	checkpoint, err := s.writer.loadCheckpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to load cursor: %w", err)
+	}
+
+	if checkpoint != nil {
+		zlog.Info("resuming from cursor", zap.Uint64("block_num", checkpoint.BlockNum), zap.String("block_id", checkpoint.BlockID))
+		s.checkpoint = checkpoint
+	}
+
+	for {
+		err := s.stream(ctx)
+		if err == nil {
+			zlog.Info("stop block reached")
+			return nil
+		}
+
+		if ctx.Err() != nil || s.IsTerminating() {
+			return nil
+		}
+
+		zlog.Warn("blocks stream failed, resuming from last cursor", zap.Error(err), zap.Duration("retry_delay", s.retryDelay))
+		if s.writer.decoder != nil {
+			s.writer.decoder.reset()
+		}
+
+		select {
+		case <-time.After(s.retryDelay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// stream consumes the blocks stream up to the stop block, returning an
+// error when it stops before
+func (s *Sink) stream(ctx context.Context) error {
+	forkSteps := []pbbstream.ForkStep{pbbstream.ForkStep_STEP_NEW, pbbstream.ForkStep_STEP_UNDO, pbbstream.ForkStep_STEP_IRREVERSIBLE}
+	if s.irreversibleOnly {
+		forkSteps = []pbbstream.ForkStep{pbbstream.ForkStep_STEP_IRREVERSIBLE}
+	}
+
+	request := &pbbstream.BlocksRequestV2{
+		StartBlockNum:     s.request.StartBlockNum,
+		StopBlockNum:      s.request.StopBlockNum,
+		ForkSteps:         forkSteps,
+		IncludeFilterExpr: s.request.IncludeFilterExpr,
+		ExcludeFilterExpr: s.request.ExcludeFilterExpr,
+		Details:           s.request.Details,
+	}
+	if s.checkpoint != nil {
+		request.StartCursor = s.checkpoint.Cursor
+	}
+
+	stream, err := s.client.Blocks(ctx, request)
+	if err != nil {
+		return fmt.Errorf("unable to start blocks stream: %w", err)
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			if s.request.StopBlockNum == 0 {
+				return fmt.Errorf("blocks stream ended without a stop block")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		blk := &pbcodec.Block{}
+		if err := ptypes.UnmarshalAny(response.Block, blk); err != nil {
+			return fmt.Errorf("unable to decode block: %w", err)
+		}
+
+		if err := s.processStep(ctx, blk, response.Step, response.Cursor); err != nil {
+			return fmt.Errorf("unable to process step %s of block #%d (%s): %w", response.Step, blk.Number, blk.Id, err)
+		}
+	}
+}
+
+func (s *Sink) processStep(ctx context.Context, blk *pbcodec.Block, step pbbstream.ForkStep, cursor string) error {
+	checkpoint := &Checkpoint{Cursor: cursor, BlockNum: uint64(blk.Number), BlockID: blk.Id}
+
+	var rows *blockRows
+	var err error
+	switch {
+	case step == pbbstream.ForkStep_STEP_NEW, step == pbbstream.ForkStep_STEP_IRREVERSIBLE && s.irreversibleOnly:
+		if rows, err = s.writer.blockRows(ctx, blk, step == pbbstream.ForkStep_STEP_IRREVERSIBLE); err != nil {
+			return err
+		}
+	case step == pbbstream.ForkStep_STEP_UNDO, step == pbbstream.ForkStep_STEP_IRREVERSIBLE:
+	default:
+		zlog.Debug("skipping unsupported step", zap.Stringer("step", step))
+		return nil
+	}
+
+	err = s.inTransaction(ctx, func(tx *sql.Tx) error {
+		switch {
+		case rows != nil:
+			if err := s.writer.writeBlock(ctx, tx, rows); err != nil {
+				return err
+			}
+
+		case step == pbbstream.ForkStep_STEP_UNDO:
+			if err := s.writer.undoBlock(ctx, tx, blk.Id); err != nil {
+				return err
+			}
+
+		case step == pbbstream.ForkStep_STEP_IRREVERSIBLE:
+			marked, err := s.writer.markIrreversible(ctx, tx, blk.Id)
+			if err != nil {
+				return err
+			}
+
+			// Blocks before the start cursor were never written as new
+			if !marked {
+				rows, err := s.writer.blockRows(ctx, blk, true)
+				if err != nil {
+					return err
+				}
+				if err := s.writer.writeBlock(ctx, tx, rows); err != nil {
+					return err
+				}
+			}
+		}
+
+		return s.writer.saveCheckpoint(ctx, tx, checkpoint)
+	})
+	if err != nil {
+		return err
+	}
+
+	if step == pbbstream.ForkStep_STEP_UNDO {
+		zlog.Info("block undone", zap.Uint32("block_num", blk.Number), zap.String("block_id", blk.Id))
+		undoneBlockCount.Inc()
+		if s.writer.decoder != nil {
+			s.writer.decoder.reset()
+		}
+	}
+
+	s.checkpoint = checkpoint
+	HeadBlockNum.SetUint64(uint64(blk.Number))
+	if blockTime, err := ptypes.Timestamp(blk.Header.Timestamp); err == nil {
+		HeadTimeDrift.SetBlockTime(blockTime)
+	}
+	return nil
+}
+
+func (s *Sink) inTransaction(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := s.driver.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+
+	if err := f(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			zlog.Warn("unable to rollback transaction", zap.Error(rollbackErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package sqlsink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	pbabicodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/abicodec/v1"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	_ "modernc.org/sqlite"
+)
+
+func TestSink_Run(t *testing.T) {
+	driver := newTestDriver(t)
+	client := &testBlocksClient{t: t, streams: []*testBlocksStream{
+		{
+			responses: []*pbbstream.BlockResponseV2{
+				testResponse(t, 1, "a", pbbstream.ForkStep_STEP_NEW),
+				testResponse(t, 2, "a", pbbstream.ForkStep_STEP_NEW),
+				testResponse(t, 1, "a", pbbstream.ForkStep_STEP_IRREVERSIBLE),
+				testResponse(t, 2, "a", pbbstream.ForkStep_STEP_UNDO),
+			},
+			err: fmt.Errorf("connection reset"),
+		},
+		{
+			responses: []*pbbstream.BlockResponseV2{
+				testResponse(t, 2, "b", pbbstream.ForkStep_STEP_NEW),
+				testResponse(t, 3, "b", pbbstream.ForkStep_STEP_NEW),
+			},
+		},
+	}}
+
+	sink := NewSink(client, driver, nil, &pbbstream.BlocksRequestV2{StartBlockNum: 1, StopBlockNum: 3, IncludeFilterExpr: "true"}, false)
+	sink.retryDelay = 0
+	require.NoError(t, sink.Run(context.Background()))
+
+	require.Len(t, client.requests, 2)
+	assert.Equal(t, "", client.requests[0].StartCursor)
+	assert.Equal(t, "cursor-2a-undo", client.requests[1].StartCursor)
+	assert.Equal(t, []pbbstream.ForkStep{pbbstream.ForkStep_STEP_NEW, pbbstream.ForkStep_STEP_UNDO, pbbstream.ForkStep_STEP_IRREVERSIBLE}, client.requests[0].ForkSteps)
+	assert.Equal(t, "true", client.requests[0].IncludeFilterExpr)
+
+	assert.Equal(t, []string{"00000001a:true", "00000002b:false", "00000003b:false"},
+		testQuery(t, driver, `SELECT block_id || ':' || CASE WHEN irreversible THEN 'true' ELSE 'false' END FROM blocks ORDER BY block_num`))
+	assert.Equal(t, []string{"00000001a", "00000002b", "00000003b"}, testQuery(t, driver, `SELECT block_id FROM transactions ORDER BY block_num`))
+	assert.Equal(t, []string{"alice@active:{\"from\":\"alice\"}"}, testQuery(t, driver, `SELECT "authorization" || ':' || json_data FROM actions WHERE block_num = 2`))
+	assert.Equal(t, []string{"insert:accounts:alice"}, testQuery(t, driver, `SELECT operation || ':' || table_name || ':' || new_payer FROM db_ops WHERE block_num = 3 AND old_payer IS NULL`))
+
+	checkpoint, err := sink.writer.loadCheckpoint(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Cursor: "cursor-3b-new", BlockNum: 3, BlockID: "00000003b"}, checkpoint)
+}
+
+func TestSink_RunIrreversibleOnly(t *testing.T) {
+	driver := newTestDriver(t)
+	client := &testBlocksClient{t: t, streams: []*testBlocksStream{{
+		responses: []*pbbstream.BlockResponseV2{
+			testResponse(t, 1, "a", pbbstream.ForkStep_STEP_IRREVERSIBLE),
+			testResponse(t, 2, "a", pbbstream.ForkStep_STEP_IRREVERSIBLE),
+		},
+	}}}
+
+	abiCodec := &testABICodecClient{abis: map[string]string{"eosio.token": `{"tables":[{"name":"accounts","type":"account"}],"structs":[{"name":"account","fields":[{"name":"balance","type":"uint8"}]}]}`}}
+	sink := NewSink(client, driver, abiCodec, &pbbstream.BlocksRequestV2{StopBlockNum: 2}, true)
+	require.NoError(t, sink.Run(context.Background()))
+
+	assert.Equal(t, []pbbstream.ForkStep{pbbstream.ForkStep_STEP_IRREVERSIBLE}, client.requests[0].ForkSteps)
+	assert.Equal(t, []string{"00000001a", "00000002a"}, testQuery(t, driver, `SELECT block_id FROM blocks WHERE irreversible ORDER BY block_num`))
+	assert.Equal(t, []string{`{"balance":1}`, `{"balance":1}`}, testQuery(t, driver, `SELECT new_json FROM db_ops ORDER BY block_num`))
+	assert.Equal(t, 1, abiCodec.calls, "ABIs are cached")
+
+	// Resuming starts from the cursor saved with the last block
+	client.streams = []*testBlocksStream{{}}
+	require.NoError(t, NewSink(client, driver, nil, &pbbstream.BlocksRequestV2{StopBlockNum: 2}, true).Run(context.Background()))
+	assert.Equal(t, "cursor-2a-irreversible", client.requests[1].StartCursor)
+}
+
+func TestSink_FailedStepIsRolledBack(t *testing.T) {
+	driver := newTestDriver(t)
+	abiCodec := &testABICodecClient{err: status.Error(codes.Unavailable, "unavailable")}
+	sink := NewSink(&testBlocksClient{t: t}, driver, abiCodec, &pbbstream.BlocksRequestV2{}, false)
+	require.NoError(t, sink.writer.createSchema(context.Background()))
+
+	// Rows of a block written when first seen as irreversible are decoded in the transaction
+	err := sink.processStep(context.Background(), testBlock(t, 1, "a"), pbbstream.ForkStep_STEP_IRREVERSIBLE, "cursor")
+	require.Error(t, err)
+
+	assert.Empty(t, testQuery(t, driver, `SELECT block_id FROM blocks`))
+	checkpoint, err := sink.writer.loadCheckpoint(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestUpsertStatement(t *testing.T) {
+	assert.Equal(t,
+		`INSERT INTO "sink_cursor" ("id", "cursor", "block_num", "block_id") VALUES ($1, $2, $3, $4) ON CONFLICT ("id") DO UPDATE SET "cursor" = excluded."cursor", "block_num" = excluded."block_num", "block_id" = excluded."block_id"`,
+		upsertStatement(&testPostgresDriver{}, cursorTable),
+	)
+}
+
+type testPostgresDriver struct {
+	Driver
+}
+
+func (d *testPostgresDriver) Placeholder(index int) string {
+	return fmt.Sprintf("$%d", index)
+}
+
+// testDriver is an in-memory SQLite database, the `sqlite` package
+// importing this one
+type testDriver struct {
+	db *sql.DB
+}
+
+func newTestDriver(t *testing.T) *testDriver {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// Each connection has its own in-memory database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return &testDriver{db: db}
+}
+
+func (d *testDriver) DB() *sql.DB                  { return d.db }
+func (d *testDriver) Placeholder(index int) string { return "?" }
+func (d *testDriver) Close() error                 { return d.db.Close() }
+
+func (d *testDriver) ColumnType(kind ColumnKind) string {
+	switch kind {
+	case KindInt64:
+		return "INTEGER"
+	case KindBool:
+		return "BOOLEAN"
+	case KindBytes:
+		return "BLOB"
+	}
+	return "TEXT"
+}
+
+func testQuery(t *testing.T, driver Driver, query string) (out []string) {
+	rows, err := driver.DB().Query(query)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	for rows.Next() {
+		var value string
+		require.NoError(t, rows.Scan(&value))
+		out = append(out, value)
+	}
+	require.NoError(t, rows.Err())
+	return out
+}
+
+func testBlock(t *testing.T, num uint32, fork string) *pbcodec.Block {
+	stamp, err := ptypes.TimestampProto(time.Date(2020, 1, 1, 0, 0, int(num), 0, time.UTC))
+	require.NoError(t, err)
+
+	return &pbcodec.Block{
+		Id:     fmt.Sprintf("%08d%s", num, fork),
+		Number: num,
+		Header: &pbcodec.BlockHeader{Timestamp: stamp, Previous: fmt.Sprintf("%08d%s", num-1, fork), Producer: "eosio"},
+		UnfilteredTransactionTraces: []*pbcodec.TransactionTrace{{
+			Id:      fmt.Sprintf("trx-%d", num),
+			Receipt: &pbcodec.TransactionReceiptHeader{Status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED},
+			ActionTraces: []*pbcodec.ActionTrace{{
+				Receiver: "eosio.token",
+				Receipt:  &pbcodec.ActionReceipt{GlobalSequence: uint64(num)},
+				Action: &pbcodec.Action{
+					Account:       "eosio.token",
+					Name:          "transfer",
+					Authorization: []*pbcodec.PermissionLevel{{Actor: "alice", Permission: "active"}},
+					JsonData:      `{"from":"alice"}`,
+				},
+			}},
+			DbOps: []*pbcodec.DBOp{{
+				Operation: pbcodec.DBOp_OPERATION_INSERT,
+				Code:      "eosio.token",
+				Scope:     "alice",
+				TableName: "accounts",
+				NewPayer:  "alice",
+				NewData:   []byte{0x01},
+			}},
+		}},
+	}
+}
+
+func testResponse(t *testing.T, num uint32, fork string, step pbbstream.ForkStep) *pbbstream.BlockResponseV2 {
+	payload, err := ptypes.MarshalAny(testBlock(t, num, fork))
+	require.NoError(t, err)
+
+	return &pbbstream.BlockResponseV2{Block: payload, Step: step, Cursor: fmt.Sprintf("cursor-%d%s-%s", num, fork, stepName(step))}
+}
+
+func stepName(step pbbstream.ForkStep) string {
+	return enumName(step.String(), "STEP_")
+}
+
+type testBlocksClient struct {
+	t        *testing.T
+	streams  []*testBlocksStream
+	requests []*pbbstream.BlocksRequestV2
+}
+
+func (c *testBlocksClient) Blocks(ctx context.Context, in *pbbstream.BlocksRequestV2, opts ...grpc.CallOption) (pbbstream.BlockStreamV2_BlocksClient, error) {
+	require.NotEmpty(c.t, c.streams, "unexpected blocks request")
+
+	c.requests = append(c.requests, in)
+	stream := c.streams[0]
+	c.streams = c.streams[1:]
+	return stream, nil
+}
+
+type testBlocksStream struct {
+	grpc.ClientStream
+
+	responses []*pbbstream.BlockResponseV2
+	err       error
+}
+
+func (s *testBlocksStream) Recv() (*pbbstream.BlockResponseV2, error) {
+	if len(s.responses) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
+
+type testABICodecClient struct {
+	pbabicodec.DecoderClient
+
+	abis  map[string]string
+	err   error
+	calls int
+}
+
+func (c *testABICodecClient) GetAbi(ctx context.Context, in *pbabicodec.GetAbiRequest, opts ...grpc.CallOption) (*pbabicodec.Response, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	abi, ok := c.abis[in.Account]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ABI found for account: %s", in.Account)
+	}
+	return &pbabicodec.Response{JsonPayload: abi}, nil
+}
@@ -0,0 +1,95 @@
+// Package sqlite registers the SQLite driver of the SQL sink, handling DSNs
+// like `sqlite3:///data/sink.db` (absolute path) or `sqlite3://./sink.db`
+// (relative path). Query parameters are passed to the pure Go `modernc.org/sqlite`
+// driver, which does not require cgo, pragmas being set with `_pragma=<name>(<value>)`.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/sqlsink"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	sqlsink.RegisterDriver("sqlite3", New)
+	sqlsink.RegisterDriver("sqlite", New)
+}
+
+type Driver struct {
+	db *sql.DB
+}
+
+func New(dsn string) (sqlsink.Driver, error) {
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dsn: %w", err)
+	}
+
+	path := dsnURL.Host + dsnURL.Path
+	if path == "" {
+		return nil, fmt.Errorf("invalid dsn %q, expecting a database path like sqlite3:///data/sink.db", dsn)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("unable to create database directory: %w", err)
+	}
+
+	query := dsnURL.Query()
+	if !hasPragma(query, "journal_mode") {
+		query.Add("_pragma", "journal_mode(WAL)")
+	}
+	if !hasPragma(query, "busy_timeout") {
+		query.Add("_pragma", "busy_timeout(5000)")
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite serializes writes, a single connection avoids `database is locked` errors
+	db.SetMaxOpenConns(1)
+
+	return &Driver{db: db}, nil
+}
+
+func hasPragma(query url.Values, name string) bool {
+	for _, pragma := range query["_pragma"] {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(pragma)), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Driver) DB() *sql.DB {
+	return d.db
+}
+
+func (d *Driver) ColumnType(kind sqlsink.ColumnKind) string {
+	switch kind {
+	case sqlsink.KindInt64:
+		return "INTEGER"
+	case sqlsink.KindBool:
+		return "BOOLEAN"
+	case sqlsink.KindBytes:
+		return "BLOB"
+	case sqlsink.KindTimestamp:
+		return "TIMESTAMP"
+	}
+	return "TEXT"
+}
+
+func (d *Driver) Placeholder(index int) string {
+	return "?"
+}
+
+func (d *Driver) Close() error {
+	return d.db.Close()
+}
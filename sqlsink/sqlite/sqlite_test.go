@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/sqlsink"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDriver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlsink")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	driver, err := sqlsink.NewDriver("sqlite3://" + filepath.Join(dir, "data", "sink.db"))
+	require.NoError(t, err)
+	defer driver.Close()
+
+	_, err = driver.DB().Exec("CREATE TABLE test (id INTEGER)")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "data", "sink.db"))
+
+	var journalMode string
+	require.NoError(t, driver.DB().QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+}
+
+func TestNewDriver_InvalidDSN(t *testing.T) {
+	_, err := sqlsink.NewDriver("sqlite3://")
+	assert.EqualError(t, err, `invalid dsn "sqlite3://", expecting a database path like sqlite3:///data/sink.db`)
+
+	_, err = sqlsink.NewDriver("mysql://localhost/db")
+	assert.EqualError(t, err, `no driver registered for scheme "mysql", registered: sqlite, sqlite3`)
+}
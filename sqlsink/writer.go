@@ -0,0 +1,212 @@
+package sqlsink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Checkpoint is the position of the sink, written in the same database
+// transaction as the rows of the block it points to
+type Checkpoint struct {
+	Cursor   string
+	BlockNum uint64
+	BlockID  string
+}
+
+// blockRows are the rows of a block, decoded before the database transaction
+// is opened
+type blockRows struct {
+	blockID string
+	rows    map[*table][][]interface{}
+}
+
+// writer holds the statements of a driver, all of them being executed in a
+// transaction given by the caller
+type writer struct {
+	driver  Driver
+	decoder *abiDecoder // nil when db op rows are not decoded
+	upserts map[*table]string
+}
+
+func newWriter(driver Driver, decoder *abiDecoder) *writer {
+	upserts := map[*table]string{}
+	for _, tbl := range append([]*table{cursorTable}, dataTables...) {
+		upserts[tbl] = upsertStatement(driver, tbl)
+	}
+
+	return &writer{
+		driver:  driver,
+		decoder: decoder,
+		upserts: upserts,
+	}
+}
+
+func (w *writer) createSchema(ctx context.Context) error {
+	for _, statement := range createStatements(w.driver) {
+		if _, err := w.driver.DB().ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("unable to execute %q: %w", statement, err)
+		}
+	}
+	return nil
+}
+
+func (w *writer) loadCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	query := fmt.Sprintf("SELECT %s, %s, %s FROM %s WHERE %s = %s",
+		quote("cursor"), quote("block_num"), quote("block_id"), quote(cursorTable.name), quote("id"), w.driver.Placeholder(1))
+
+	checkpoint := &Checkpoint{}
+	err := w.driver.DB().QueryRowContext(ctx, query, 1).Scan(&checkpoint.Cursor, &checkpoint.BlockNum, &checkpoint.BlockID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+func (w *writer) saveCheckpoint(ctx context.Context, tx *sql.Tx, checkpoint *Checkpoint) error {
+	_, err := tx.ExecContext(ctx, w.upserts[cursorTable], 1, checkpoint.Cursor, checkpoint.BlockNum, checkpoint.BlockID)
+	return err
+}
+
+// blockRows builds the rows of `blk`. When the block was filtered, only the
+// matching actions and their db ops are kept.
+func (w *writer) blockRows(ctx context.Context, blk *pbcodec.Block, irreversible bool) (*blockRows, error) {
+	blockTime, err := ptypes.Timestamp(blk.Header.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block time: %w", err)
+	}
+
+	if w.decoder != nil {
+		w.decoder.invalidateSetABIs(blk)
+	}
+
+	out := &blockRows{blockID: blk.Id, rows: map[*table][][]interface{}{}}
+	add := func(tbl *table, values ...interface{}) {
+		out.rows[tbl] = append(out.rows[tbl], values)
+	}
+
+	add(blocksTable, blk.Id, blk.Number, blk.Header.Previous, blockTime.UTC(), blk.Header.Producer, irreversible)
+
+	for _, trx := range blk.TransactionTraces() {
+		var status string
+		var cpuUsage, netUsage uint32
+		if trx.Receipt != nil {
+			status = enumName(trx.Receipt.Status.String(), "TRANSACTIONSTATUS_")
+			cpuUsage, netUsage = trx.Receipt.CpuUsageMicroSeconds, trx.Receipt.NetUsageWords
+		}
+		add(transactionsTable, blk.Id, trx.Id, blk.Number, trx.Index, status, cpuUsage, netUsage, trx.Elapsed)
+
+		matcher := blk.FilteringActionMatcher(trx)
+		for _, act := range trx.ActionTraces {
+			if !matcher.Matched(act.ExecutionIndex) {
+				continue
+			}
+
+			var globalSequence interface{}
+			if act.Receipt != nil {
+				globalSequence = act.Receipt.GlobalSequence
+			}
+
+			var authorization []string
+			var jsonData, rawData interface{}
+			if act.Action != nil {
+				for _, auth := range act.Action.Authorization {
+					authorization = append(authorization, auth.Authorization())
+				}
+				jsonData = optionalString(act.Action.JsonData)
+				rawData = optionalBytes(act.Action.RawData)
+			}
+
+			add(actionsTable, blk.Id, trx.Id, act.ExecutionIndex, blk.Number, act.ActionOrdinal, act.CreatorActionOrdinal, globalSequence,
+				act.Receiver, act.Account(), act.Name(), strings.Join(authorization, ","), jsonData, rawData)
+		}
+
+		for i, op := range trx.DbOps {
+			if !matcher.Matched(op.ActionIndex) {
+				continue
+			}
+
+			var oldJSON, newJSON interface{}
+			if w.decoder != nil {
+				if oldJSON, err = w.decoder.decodeRow(ctx, op.Code, op.TableName, op.OldData, blk.Number); err != nil {
+					return nil, err
+				}
+				if newJSON, err = w.decoder.decodeRow(ctx, op.Code, op.TableName, op.NewData, blk.Number); err != nil {
+					return nil, err
+				}
+			}
+
+			add(dbOpsTable, blk.Id, trx.Id, i, blk.Number, op.ActionIndex, enumName(op.Operation.String(), "OPERATION_"), op.Code, op.Scope, op.TableName, op.PrimaryKey,
+				optionalString(op.OldPayer), optionalString(op.NewPayer), optionalBytes(op.OldData), optionalBytes(op.NewData), oldJSON, newJSON)
+		}
+	}
+
+	return out, nil
+}
+
+func (w *writer) writeBlock(ctx context.Context, tx *sql.Tx, rows *blockRows) error {
+	for _, tbl := range dataTables {
+		for _, values := range rows.rows[tbl] {
+			if _, err := tx.ExecContext(ctx, w.upserts[tbl], values...); err != nil {
+				return fmt.Errorf("unable to write %s row: %w", tbl.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// markIrreversible flags the block as irreversible, returning false when
+// it was never written
+func (w *writer) markIrreversible(ctx context.Context, tx *sql.Tx, blockID string) (bool, error) {
+	statement := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+		quote(blocksTable.name), quote("irreversible"), w.driver.Placeholder(1), quote("block_id"), w.driver.Placeholder(2))
+
+	result, err := tx.ExecContext(ctx, statement, true, blockID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected != 0, nil
+}
+
+// undoBlock deletes all the rows written for the block
+func (w *writer) undoBlock(ctx context.Context, tx *sql.Tx, blockID string) error {
+	for i := len(dataTables) - 1; i >= 0; i-- {
+		tbl := dataTables[i]
+		statement := fmt.Sprintf("DELETE FROM %s WHERE %s = %s", quote(tbl.name), quote("block_id"), w.driver.Placeholder(1))
+		if _, err := tx.ExecContext(ctx, statement, blockID); err != nil {
+			return fmt.Errorf("unable to delete %s rows: %w", tbl.name, err)
+		}
+	}
+	return nil
+}
+
+// enumName turns a protobuf enum name like `OPERATION_INSERT` into `insert`
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+func optionalString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func optionalBytes(value []byte) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
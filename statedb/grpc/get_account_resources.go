@@ -0,0 +1,192 @@
+package grpc
+
+import (
+	"context"
+	"regexp"
+
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/dfuse-io/dfuse-eosio/statedb"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/fluxdb"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+var accountNameRegex = regexp.MustCompile(`^[a-z1-5.]{1,13}$`)
+
+func (s *Server) GetAccountResources(ctx context.Context, request *pbstatedb.GetAccountResourcesRequest) (*pbstatedb.AccountResourcesResponse, error) {
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("get account resources",
+		zap.String("account", request.Account),
+		zap.Uint64("block_num", request.BlockNum),
+	)
+
+	if !accountNameRegex.MatchString(request.Account) {
+		return nil, derr.Statusf(codes.InvalidArgument, "invalid account %q", request.Account)
+	}
+
+	actualBlockNum, _, _, speculativeWrites, err := s.prepareRead(ctx, request.BlockNum, false)
+	if err != nil {
+		return nil, derr.Statusf(codes.Internal, "unable to prepare read: %s", err)
+	}
+
+	return s.readAccountResources(ctx, request.Account, actualBlockNum, speculativeWrites)
+}
+
+func (s *Server) StreamAccountResourcesHistory(request *pbstatedb.StreamAccountResourcesHistoryRequest, stream pbstatedb.Resources_StreamAccountResourcesHistoryServer) error {
+	ctx := stream.Context()
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("stream account resources history",
+		zap.String("account", request.Account),
+		zap.Uint64("low_block_num", request.LowBlockNum),
+		zap.Uint64("high_block_num", request.HighBlockNum),
+		zap.Uint32("limit", request.Limit),
+	)
+
+	if !accountNameRegex.MatchString(request.Account) {
+		return derr.Statusf(codes.InvalidArgument, "invalid account %q", request.Account)
+	}
+
+	highBlockNum, _, _, speculativeWrites, err := s.prepareRead(ctx, request.HighBlockNum, false)
+	if err != nil {
+		return derr.Statusf(codes.Internal, "unable to prepare read: %s", err)
+	}
+
+	if request.LowBlockNum > highBlockNum {
+		return derr.Statusf(codes.InvalidArgument, "low block num %d is higher than high block num %d", request.LowBlockNum, highBlockNum)
+	}
+
+	limitsSinglet := statedb.NewAccountLimitsSinglet(request.Account)
+	usageSinglet := statedb.NewAccountUsageSinglet(request.Account)
+
+	// Each singlet entry is the value since its height, the history is walked backward
+	// by reading each singlet right below the height of its last read entry
+	limitsEntry, err := s.readSingletEntryAt(ctx, limitsSinglet, highBlockNum, speculativeWrites)
+	if err != nil {
+		return err
+	}
+
+	usageEntry, err := s.readSingletEntryAt(ctx, usageSinglet, highBlockNum, speculativeWrites)
+	if err != nil {
+		return err
+	}
+
+	sentCount := uint32(0)
+	for limitsEntry != nil || usageEntry != nil {
+		height := maxEntryHeight(limitsEntry, usageEntry)
+		if height < request.LowBlockNum {
+			break
+		}
+
+		response, err := s.readAccountResources(ctx, request.Account, height, statedb.SpeculativeWritesUpTo(speculativeWrites, height))
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+
+		sentCount++
+		if (request.Limit > 0 && sentCount >= request.Limit) || height == 0 {
+			break
+		}
+
+		if limitsEntry != nil && limitsEntry.Height() == height {
+			if limitsEntry, err = s.readSingletEntryAt(ctx, limitsSinglet, height-1, statedb.SpeculativeWritesUpTo(speculativeWrites, height-1)); err != nil {
+				return err
+			}
+		}
+
+		if usageEntry != nil && usageEntry.Height() == height {
+			if usageEntry, err = s.readSingletEntryAt(ctx, usageSinglet, height-1, statedb.SpeculativeWritesUpTo(speculativeWrites, height-1)); err != nil {
+				return err
+			}
+		}
+	}
+
+	zlogger.Debug("account resources history completed", zap.Uint32("sent_count", sentCount))
+	return nil
+}
+
+func (s *Server) readAccountResources(ctx context.Context, account string, blockNum uint64, speculativeWrites []*fluxdb.WriteRequest) (*pbstatedb.AccountResourcesResponse, error) {
+	response := &pbstatedb.AccountResourcesResponse{
+		BlockNum: blockNum,
+		Account:  account,
+	}
+
+	entry, err := s.readSingletEntryAt(ctx, statedb.NewAccountLimitsSinglet(account), blockNum, speculativeWrites)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil {
+		response.LimitsBlockNum = entry.Height()
+		if response.Limits, err = entry.(*statedb.AccountLimitsEntry).Limits(); err != nil {
+			return nil, derr.Statusf(codes.Internal, "unable to decode account limits: %s", err)
+		}
+	}
+
+	if entry, err = s.readSingletEntryAt(ctx, statedb.NewAccountUsageSinglet(account), blockNum, speculativeWrites); err != nil {
+		return nil, err
+	}
+
+	if entry != nil {
+		response.UsageBlockNum = entry.Height()
+		if response.Usage, err = entry.(*statedb.AccountUsageEntry).Usage(); err != nil {
+			return nil, derr.Statusf(codes.Internal, "unable to decode account usage: %s", err)
+		}
+	}
+
+	if entry, err = s.readSingletEntryAt(ctx, statedb.ResourceStateSinglet{}, blockNum, speculativeWrites); err != nil {
+		return nil, err
+	}
+
+	if entry != nil {
+		if response.State, err = entry.(*statedb.ResourceStateEntry).State(); err != nil {
+			return nil, derr.Statusf(codes.Internal, "unable to decode resource limits state: %s", err)
+		}
+	}
+
+	if entry, err = s.readSingletEntryAt(ctx, statedb.ResourceConfigSinglet{}, blockNum, speculativeWrites); err != nil {
+		return nil, err
+	}
+
+	if entry != nil {
+		if response.Config, err = entry.(*statedb.ResourceConfigEntry).Config(); err != nil {
+			return nil, derr.Statusf(codes.Internal, "unable to decode resource limits config: %s", err)
+		}
+	}
+
+	// A block is produced on each slot unless the producer missed it, the blocks
+	// since the last usage update are as close as we can get to the elapsed slots
+	var elapsedSlots uint64
+	if response.Usage != nil {
+		elapsedSlots = blockNum - response.UsageBlockNum
+	}
+
+	response.CpuLimit, response.NetLimit = statedb.ComputeAccountResourceLimits(response.Limits, response.Usage, response.State, response.Config, elapsedSlots)
+	return response, nil
+}
+
+func (s *Server) readSingletEntryAt(ctx context.Context, singlet fluxdb.Singlet, blockNum uint64, speculativeWrites []*fluxdb.WriteRequest) (fluxdb.SingletEntry, error) {
+	entry, err := s.db.ReadSingletEntryAt(ctx, singlet, blockNum, speculativeWrites)
+	if err != nil {
+		return nil, derr.Statusf(codes.Internal, "unable to read singlet %s at %d: %s", singlet, blockNum, err)
+	}
+
+	return entry, nil
+}
+
+func maxEntryHeight(left, right fluxdb.SingletEntry) uint64 {
+	if left == nil {
+		return right.Height()
+	}
+
+	if right == nil || left.Height() > right.Height() {
+		return left.Height()
+	}
+
+	return right.Height()
+}
@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/dfuse-io/dfuse-eosio/statedb"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/forkable"
+	"github.com/streamingfast/fluxdb"
+	fluxdbKV "github.com/streamingfast/fluxdb/store/kv"
+	_ "github.com/streamingfast/kvdb/store/badger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAccountResources(t *testing.T) {
+	server := newTestServer(t, resourcesBlocks(t)...)
+
+	response, err := server.GetAccountResources(context.Background(), &pbstatedb.GetAccountResourcesRequest{Account: "alice", BlockNum: 4})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(4), response.BlockNum)
+	assert.Equal(t, uint64(2), response.LimitsBlockNum)
+	assert.Equal(t, int64(5), response.Limits.CpuWeight)
+	assert.Equal(t, uint64(3), response.UsageBlockNum)
+	assert.Equal(t, uint64(1000000), response.Usage.CpuUsage.ValueEx)
+	assert.Equal(t, uint64(100), response.State.TotalCpuWeight)
+	assert.Equal(t, uint32(172800), response.Config.AccountCpuUsageAverageWindow)
+	assert.Equal(t, &pbstatedb.AccountResourceLimit{Used: 172799, Available: 1727827201, Max: 1728000000}, response.CpuLimit)
+
+	// Blocks #6 and #7 are not irreversible yet, they are read from the speculative writes
+	response, err = server.GetAccountResources(context.Background(), &pbstatedb.GetAccountResourcesRequest{Account: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), response.BlockNum)
+	assert.Equal(t, uint64(6), response.UsageBlockNum)
+	assert.Equal(t, uint64(5), response.LimitsBlockNum)
+
+	response, err = server.GetAccountResources(context.Background(), &pbstatedb.GetAccountResourcesRequest{Account: "bob"})
+	require.NoError(t, err)
+	assert.Nil(t, response.Limits)
+	assert.Nil(t, response.CpuLimit)
+
+	_, err = server.GetAccountResources(context.Background(), &pbstatedb.GetAccountResourcesRequest{Account: "Invalid!"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStreamAccountResourcesHistory(t *testing.T) {
+	server := newTestServer(t, resourcesBlocks(t)...)
+
+	tests := []struct {
+		name              string
+		request           *pbstatedb.StreamAccountResourcesHistoryRequest
+		expectedBlockNums []uint64
+	}{
+		{"whole history", &pbstatedb.StreamAccountResourcesHistoryRequest{Account: "alice"}, []uint64{6, 5, 3, 2}},
+		{"bounded", &pbstatedb.StreamAccountResourcesHistoryRequest{Account: "alice", LowBlockNum: 3, HighBlockNum: 4}, []uint64{3}},
+		{"limited", &pbstatedb.StreamAccountResourcesHistoryRequest{Account: "alice", Limit: 2}, []uint64{6, 5}},
+		{"unknown account", &pbstatedb.StreamAccountResourcesHistoryRequest{Account: "bob"}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := &testResourcesHistoryStream{ctx: context.Background()}
+			require.NoError(t, server.StreamAccountResourcesHistory(test.request, stream))
+
+			var blockNums []uint64
+			for _, response := range stream.responses {
+				blockNums = append(blockNums, response.BlockNum)
+			}
+			assert.Equal(t, test.expectedBlockNums, blockNums)
+		})
+	}
+}
+
+func resourcesBlocks(t *testing.T) []*pbcodec.Block {
+	return []*pbcodec.Block{
+		ct.Block(t, "00000002aa",
+			ct.TrxTrace(t, accountLimitsOp("alice", 5), accountUsageOp("alice", 0)),
+			stateOp(100),
+			&pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_INSERT, Kind: &pbcodec.RlimitOp_Config{
+				Config: &pbcodec.RlimitConfig{AccountCpuUsageAverageWindow: 172800, AccountNetUsageAverageWindow: 172800},
+			}},
+		),
+		ct.Block(t, "00000003aa", ct.TrxTrace(t, accountUsageOp("alice", 1000000)), stateOp(100)),
+		ct.Block(t, "00000004aa", stateOp(100)),
+		ct.Block(t, "00000005aa", ct.TrxTrace(t, accountLimitsOp("alice", 10), accountUsageOp("alice", 2000000)), stateOp(105)),
+		ct.Block(t, "00000006aa", ct.TrxTrace(t, accountUsageOp("alice", 3000000)), stateOp(105)),
+		ct.Block(t, "00000007aa"),
+	}
+}
+
+func accountLimitsOp(account string, weight int64) *pbcodec.RlimitOp {
+	return &pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_AccountLimits{
+		AccountLimits: &pbcodec.RlimitAccountLimits{Owner: account, CpuWeight: weight, NetWeight: weight, RamBytes: 8000},
+	}}
+}
+
+func accountUsageOp(account string, cpuValueEx uint64) *pbcodec.RlimitOp {
+	return &pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_AccountUsage{
+		AccountUsage: &pbcodec.RlimitAccountUsage{Owner: account, CpuUsage: &pbcodec.UsageAccumulator{ValueEx: cpuValueEx}},
+	}}
+}
+
+func stateOp(totalWeight uint64) *pbcodec.RlimitOp {
+	return &pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_State{
+		State: &pbcodec.RlimitState{TotalCpuWeight: totalWeight, TotalNetWeight: totalWeight, VirtualCpuLimit: 200000, VirtualNetLimit: 1048576},
+	}}
+}
+
+// newTestServer feeds `blocks` to a statedb backed by a temporary badger store,
+// the last block is only there to make the previous one irreversible
+func newTestServer(t *testing.T, blocks ...*pbcodec.Block) *Server {
+	tmp, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+
+	kvStore, err := fluxdbKV.NewStore(fmt.Sprintf("badger://%s/test.db?createTables=true", tmp))
+	require.NoError(t, err)
+
+	mapper := &statedb.BlockMapper{}
+	db := fluxdb.New(kvStore, nil, mapper, false)
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll(tmp)
+	})
+
+	handler := fluxdb.NewHandler(db)
+	handler.EnableWrites()
+	handler.EnableWriteOnEachIrreversibleStep()
+	handler.InitializeStartBlockID()
+
+	db.HeadBlock = handler.HeadBlock
+	db.SpeculativeWritesFetcher = handler.FetchSpeculativeWrites
+
+	preprocessor := fluxdb.NewPreprocessBlock(mapper)
+	source := bstream.NewMockSource(ct.ToBstreamBlocks(t, blocks), bstream.NewPreprocessor(preprocessor, forkable.New(handler)))
+	source.Run()
+	require.NoError(t, source.Err())
+
+	return New(":0", db)
+}
+
+type testResourcesHistoryStream struct {
+	grpc.ServerStream
+
+	ctx       context.Context
+	responses []*pbstatedb.AccountResourcesResponse
+}
+
+func (s *testResourcesHistoryStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testResourcesHistoryStream) Send(response *pbstatedb.AccountResourcesResponse) error {
+	s.responses = append(s.responses, response)
+	return nil
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/dfuse-io/dfuse-eosio/statedb"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/logging"
+	pbbstream "github.com/streamingfast/pbgo/dfuse/bstream/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *Server) GetProtocolFeatures(ctx context.Context, request *pbstatedb.GetProtocolFeaturesRequest) (*pbstatedb.GetProtocolFeaturesResponse, error) {
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("get protocol features", zap.Uint64("block_num", request.BlockNum))
+
+	actualBlockNum, lastWrittenBlock, upToBlock, speculativeWrites, err := s.prepareRead(ctx, request.BlockNum, false)
+	if err != nil {
+		return nil, derr.Statusf(codes.Internal, "unable to prepare read: %s", err)
+	}
+
+	tabletRows, err := s.db.ReadTabletAt(ctx, actualBlockNum, statedb.ProtocolFeatureTablet{}, speculativeWrites)
+	if err != nil {
+		return nil, derr.Statusf(codes.Internal, "unable to read tablet at %d: %s", actualBlockNum, err)
+	}
+
+	features, err := statedb.ProtocolFeaturesFromRows(tabletRows)
+	if err != nil {
+		return nil, derr.Statusf(codes.Internal, "unable to read protocol features at %d: %s", actualBlockNum, err)
+	}
+
+	return &pbstatedb.GetProtocolFeaturesResponse{
+		UpToBlock:             &pbbstream.BlockRef{Num: upToBlock.Num(), Id: upToBlock.ID()},
+		LastIrreversibleBlock: &pbbstream.BlockRef{Num: lastWrittenBlock.Num(), Id: lastWrittenBlock.ID()},
+		Features:              features,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	preactivateFeatureDigest = "0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd"
+	onlyBillFeatureDigest    = "8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405"
+)
+
+func TestGetProtocolFeatures(t *testing.T) {
+	server := newTestServer(t,
+		ct.Block(t, "00000002aa", ct.TrxTrace(t, ct.TrxID("a1"), featureOp("ACTIVATE", preactivateFeatureDigest, "PREACTIVATE_FEATURE"))),
+		ct.Block(t, "00000003aa", ct.TrxTrace(t, ct.TrxID("a2"), featureOp("PRE_ACTIVATE", onlyBillFeatureDigest, "ONLY_BILL_FIRST_AUTHORIZER"))),
+		ct.Block(t, "00000004aa", ct.TrxTrace(t, ct.TrxID("a3"), featureOp("ACTIVATE", onlyBillFeatureDigest, "ONLY_BILL_FIRST_AUTHORIZER"))),
+		ct.Block(t, "00000005aa"),
+	)
+
+	response, err := server.GetProtocolFeatures(context.Background(), &pbstatedb.GetProtocolFeaturesRequest{BlockNum: 3})
+	require.NoError(t, err)
+	require.Len(t, response.Features, 2)
+
+	assert.Equal(t, "PREACTIVATE_FEATURE", response.Features[0].Name)
+	assert.Equal(t, uint64(2), response.Features[0].ActivationBlockNum)
+	assert.Equal(t, "a1", response.Features[0].ActivationTrxId)
+
+	// Only pre-activated at block #3
+	assert.Equal(t, onlyBillFeatureDigest, response.Features[1].FeatureDigest)
+	assert.Equal(t, uint64(3), response.Features[1].PreActivationBlockNum)
+	assert.Equal(t, "a2", response.Features[1].PreActivationTrxId)
+	assert.Equal(t, uint64(0), response.Features[1].ActivationBlockNum)
+
+	// Blocks #4 and #5 are not irreversible yet, they are read from the speculative writes
+	response, err = server.GetProtocolFeatures(context.Background(), &pbstatedb.GetProtocolFeaturesRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Features, 2)
+	assert.Equal(t, uint64(5), response.UpToBlock.Num)
+	assert.Equal(t, "ONLY_BILL_FIRST_AUTHORIZER", response.Features[1].Name)
+	assert.Equal(t, uint64(3), response.Features[1].PreActivationBlockNum)
+	assert.Equal(t, uint64(4), response.Features[1].ActivationBlockNum)
+	assert.Equal(t, "a3", response.Features[1].ActivationTrxId)
+}
+
+func featureOp(kind, digest, codename string) *pbcodec.FeatureOp {
+	return &pbcodec.FeatureOp{Kind: kind, FeatureDigest: digest, Feature: &pbcodec.Feature{
+		FeatureDigest: digest,
+		Specification: []*pbcodec.Specification{{Name: "builtin_feature_codename", Value: codename}},
+	}}
+}
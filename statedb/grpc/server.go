@@ -29,6 +29,9 @@ func (s *Server) Serve() {
 	zlog.Info("listening & serving GRPC content", zap.String("grpc_listen_addr", s.grpcAddr))
 	grpcServer := dgrpc.NewServer(dgrpc.WithLogger(zlog))
 	pbstatedb.RegisterStateServer(grpcServer, s)
+	pbstatedb.RegisterResourcesServer(grpcServer, s)
+	pbstatedb.RegisterProtocolFeaturesServer(grpcServer, s)
+	pbstatedb.RegisterPermissionsServer(grpcServer, s)
 
 	lis, err := net.Listen("tcp", s.grpcAddr)
 	if err != nil {
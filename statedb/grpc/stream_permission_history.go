@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/dfuse-io/dfuse-eosio/statedb"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) StreamPermissionHistory(request *pbstatedb.StreamPermissionHistoryRequest, stream pbstatedb.Permissions_StreamPermissionHistoryServer) error {
+	ctx := stream.Context()
+	zlogger := logging.Logger(ctx, zlog)
+	zlogger.Debug("stream permission history",
+		zap.String("account", request.Account),
+		zap.String("permission", request.Permission),
+		zap.Uint64("low_block_num", request.LowBlockNum),
+		zap.Uint64("high_block_num", request.HighBlockNum),
+		zap.Uint32("limit", request.Limit),
+	)
+
+	if !accountNameRegex.MatchString(request.Account) {
+		return derr.Statusf(codes.InvalidArgument, "invalid account %q", request.Account)
+	}
+
+	if request.Permission != "" && !accountNameRegex.MatchString(request.Permission) {
+		return derr.Statusf(codes.InvalidArgument, "invalid permission %q", request.Permission)
+	}
+
+	highBlockNum, _, _, speculativeWrites, err := s.prepareRead(ctx, request.HighBlockNum, false)
+	if err != nil {
+		return derr.Statusf(codes.Internal, "unable to prepare read: %s", err)
+	}
+
+	if request.LowBlockNum > highBlockNum {
+		return derr.Statusf(codes.InvalidArgument, "low block num %d is higher than high block num %d", request.LowBlockNum, highBlockNum)
+	}
+
+	sentCount := 0
+	err = statedb.ReadPermissionHistory(ctx, s.db, request.Account, request.Permission, request.LowBlockNum, highBlockNum, request.Limit, speculativeWrites, func(row *statedb.PermissionHistoryRow) error {
+		change, err := row.Change()
+		if err != nil {
+			return derr.Statusf(codes.Internal, "unable to decode permission history row %q: %s", row, err)
+		}
+
+		sentCount++
+		return stream.Send(&pbstatedb.PermissionChange{
+			BlockNum:   row.Height(),
+			TrxId:      change.TrxId,
+			Account:    request.Account,
+			Permission: row.Permission(),
+			Operation:  change.Operation,
+			OldPerm:    change.OldPerm,
+			NewPerm:    change.NewPerm,
+		})
+	})
+	if err != nil {
+		if _, isStatus := status.FromError(err); isStatus {
+			return err
+		}
+
+		return derr.Statusf(codes.Internal, "unable to read permission history: %s", err)
+	}
+
+	zlogger.Debug("permission history completed", zap.Int("sent_count", sentCount))
+	return nil
+}
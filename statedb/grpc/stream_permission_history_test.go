@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamPermissionHistory(t *testing.T) {
+	server := newTestServer(t,
+		ct.Block(t, "00000002aa",
+			ct.TrxTrace(t, ct.TrxID("a1"), ct.PermOp(t, "INS", ct.NewPerm(ct.Permission(t, "alice@owner", ct.PublicKey("PUB1"))))),
+			ct.TrxTrace(t, ct.TrxID("a2"), ct.PermOp(t, "INS", ct.NewPerm(ct.Permission(t, "alice@active", ct.PublicKey("PUB1"))))),
+		),
+		ct.Block(t, "00000003aa",
+			ct.TrxTrace(t, ct.TrxID("a3"), updatePermOp(t, "alice@active", "PUB1", "PUB2")),
+		),
+		ct.Block(t, "00000004aa"),
+		ct.Block(t, "00000005aa",
+			ct.TrxTrace(t, ct.TrxID("a4"),
+				updatePermOp(t, "alice@owner", "PUB1", "PUB3"),
+				ct.PermOp(t, "REM", ct.OldPerm(ct.Permission(t, "alice@active", ct.PublicKey("PUB2")))),
+			),
+		),
+		ct.Block(t, "00000006aa",
+			ct.TrxTrace(t, ct.TrxID("a5"), updatePermOp(t, "alice@owner", "PUB3", "PUB4")),
+		),
+		ct.Block(t, "00000007aa"),
+	)
+
+	tests := []struct {
+		name            string
+		request         *pbstatedb.StreamPermissionHistoryRequest
+		expectedChanges []string
+	}{
+		{
+			"whole history",
+			&pbstatedb.StreamPermissionHistoryRequest{Account: "alice"},
+			[]string{"6:owner:OPERATION_UPDATE:a5", "5:active:OPERATION_REMOVE:a4", "5:owner:OPERATION_UPDATE:a4", "3:active:OPERATION_UPDATE:a3", "2:active:OPERATION_INSERT:a2", "2:owner:OPERATION_INSERT:a1"},
+		},
+		{
+			"single permission",
+			&pbstatedb.StreamPermissionHistoryRequest{Account: "alice", Permission: "owner"},
+			[]string{"6:owner:OPERATION_UPDATE:a5", "5:owner:OPERATION_UPDATE:a4", "2:owner:OPERATION_INSERT:a1"},
+		},
+		{
+			"bounded",
+			&pbstatedb.StreamPermissionHistoryRequest{Account: "alice", LowBlockNum: 3, HighBlockNum: 5},
+			[]string{"5:active:OPERATION_REMOVE:a4", "5:owner:OPERATION_UPDATE:a4", "3:active:OPERATION_UPDATE:a3"},
+		},
+		{
+			"limited",
+			&pbstatedb.StreamPermissionHistoryRequest{Account: "alice", Limit: 2},
+			[]string{"6:owner:OPERATION_UPDATE:a5", "5:active:OPERATION_REMOVE:a4"},
+		},
+		{
+			"unknown account",
+			&pbstatedb.StreamPermissionHistoryRequest{Account: "bob"},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := &testPermissionHistoryStream{ctx: context.Background()}
+			require.NoError(t, server.StreamPermissionHistory(test.request, stream))
+
+			var changes []string
+			for _, change := range stream.changes {
+				changes = append(changes, fmt.Sprintf("%d:%s:%s:%s", change.BlockNum, change.Permission, change.Operation, change.TrxId))
+			}
+			assert.Equal(t, test.expectedChanges, changes)
+		})
+	}
+
+	stream := &testPermissionHistoryStream{ctx: context.Background()}
+	require.NoError(t, server.StreamPermissionHistory(&pbstatedb.StreamPermissionHistoryRequest{Account: "alice", Permission: "owner", Limit: 1}, stream))
+	assert.Equal(t, "PUB3", stream.changes[0].OldPerm.Authority.Keys[0].PublicKey)
+	assert.Equal(t, "PUB4", stream.changes[0].NewPerm.Authority.Keys[0].PublicKey)
+
+	err := server.StreamPermissionHistory(&pbstatedb.StreamPermissionHistoryRequest{Account: "Invalid!"}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func updatePermOp(t *testing.T, accountPermission string, oldKey, newKey string) *pbcodec.PermOp {
+	return ct.PermOp(t, "UPD",
+		ct.OldPerm(ct.Permission(t, accountPermission, ct.PublicKey(oldKey))),
+		ct.NewPerm(ct.Permission(t, accountPermission, ct.PublicKey(newKey))),
+	)
+}
+
+type testPermissionHistoryStream struct {
+	grpc.ServerStream
+
+	ctx     context.Context
+	changes []*pbstatedb.PermissionChange
+}
+
+func (s *testPermissionHistoryStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testPermissionHistoryStream) Send(change *pbstatedb.PermissionChange) error {
+	s.changes = append(s.changes, change)
+	return nil
+}
@@ -1,6 +1,8 @@
 package grpc
 
 import (
+	"bytes"
+
 	"github.com/streamingfast/derr"
 	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
 	"github.com/dfuse-io/dfuse-eosio/statedb"
@@ -37,8 +39,21 @@ func (s *Server) StreamTableRows(request *pbstatedb.StreamTableRowsRequest, stre
 
 	keyConverter := getKeyConverterForType(request.KeyType)
 
+	var afterKey []byte
+	if request.AfterKey != "" {
+		afterKey, err = toContractStatePrimaryKey(request.AfterKey, keyConverter)
+		if err != nil {
+			return derr.Statusf(codes.InvalidArgument, "invalid after key %q: %s", request.AfterKey, err)
+		}
+	}
+
 	stream.SetHeader(newMetadata(upToBlock, lastWrittenBlock))
 	for _, row := range rows {
+		// Rows are sorted by primary key, those up to the after key are skipped before being decoded
+		if afterKey != nil && bytes.Compare(row.PrimaryKey(), afterKey) <= 0 {
+			continue
+		}
+
 		response, err := toTableRowResponse(row.(*statedb.ContractStateRow), keyConverter, serializationInfo, request.WithBlockNum)
 		if err != nil {
 			return derr.Statusf(codes.Internal, "creating table row response failed: %s", err)
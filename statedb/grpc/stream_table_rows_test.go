@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	eos "github.com/eoscanada/eos-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamTableRows_AfterKey(t *testing.T) {
+	abi, err := eos.NewABI(strings.NewReader(`{
+		"version": "eosio::abi/1.1",
+		"structs": [{"name": "account", "base": "", "fields": [{"name": "balance", "type": "asset"}]}],
+		"tables": [{"name": "accounts", "index_type": "i64", "type": "account"}]
+	}`))
+	require.NoError(t, err)
+
+	server := newTestServer(t,
+		ct.Block(t, "00000002aa",
+			ct.TrxTrace(t, ct.ActionTraceSetABI(t, "eosio.token", abi)),
+		),
+		ct.Block(t, "00000003aa",
+			ct.TrxTrace(t,
+				ct.TableOp(t, "insert", "eosio.token/accounts/alice", "alice"),
+				ct.DBOp(t, "insert", "eosio.token/accounts/alice/alice", "/alice", `/{"balance":"1.0000 EOS"}`, abi),
+				ct.DBOp(t, "insert", "eosio.token/accounts/alice/bob", "/bob", `/{"balance":"2.0000 EOS"}`, abi),
+				ct.DBOp(t, "insert", "eosio.token/accounts/alice/carol", "/carol", `/{"balance":"3.0000 EOS"}`, abi),
+				ct.TableOp(t, "insert", "eosio.token/accounts/bob", "bob"),
+				ct.DBOp(t, "insert", "eosio.token/accounts/bob/bob", "/bob", `/{"balance":"4.0000 EOS"}`, abi),
+			),
+		),
+		ct.Block(t, "00000004aa"),
+	)
+
+	tests := []struct {
+		name         string
+		afterKey     string
+		expectedKeys []string
+	}{
+		{"all rows", "", []string{"alice", "bob", "carol"}},
+		{"after existing key", "alice", []string{"bob", "carol"}},
+		{"after missing key", "bill", []string{"bob", "carol"}},
+		{"after last key", "carol", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := &testTableRowsStream{ctx: context.Background()}
+			require.NoError(t, server.StreamTableRows(&pbstatedb.StreamTableRowsRequest{
+				Contract: "eosio.token",
+				Table:    "accounts",
+				Scope:    "alice",
+				KeyType:  "name",
+				AfterKey: test.afterKey,
+			}, stream))
+
+			var keys []string
+			for _, row := range stream.rows {
+				keys = append(keys, row.Key)
+			}
+			assert.Equal(t, test.expectedKeys, keys)
+		})
+	}
+
+	err = server.StreamTableRows(&pbstatedb.StreamTableRowsRequest{Contract: "eosio.token", Table: "accounts", Scope: "alice", KeyType: "uint64", AfterKey: "not-a-number"}, &testTableRowsStream{ctx: context.Background()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	scopesStream := &testTableScopesStream{ctx: context.Background()}
+	require.NoError(t, server.StreamTableScopes(&pbstatedb.StreamTableScopesRequest{Contract: "eosio.token", Table: "accounts", AfterKey: "alice"}, scopesStream))
+	require.Len(t, scopesStream.scopes, 1)
+	assert.Equal(t, "bob", scopesStream.scopes[0].Scope)
+}
+
+type testTableRowsStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	rows []*pbstatedb.TableRowResponse
+}
+
+func (s *testTableRowsStream) Context() context.Context    { return s.ctx }
+func (s *testTableRowsStream) SetHeader(metadata.MD) error { return nil }
+
+func (s *testTableRowsStream) Send(row *pbstatedb.TableRowResponse) error {
+	s.rows = append(s.rows, row)
+	return nil
+}
+
+type testTableScopesStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	scopes []*pbstatedb.TableScopeResponse
+}
+
+func (s *testTableScopesStream) Context() context.Context    { return s.ctx }
+func (s *testTableScopesStream) SetHeader(metadata.MD) error { return nil }
+
+func (s *testTableScopesStream) Send(scope *pbstatedb.TableScopeResponse) error {
+	s.scopes = append(s.scopes, scope)
+	return nil
+}
@@ -46,6 +46,10 @@ func (s *Server) StreamTableScopes(request *pbstatedb.StreamTableScopesRequest,
 	stream.SetHeader(newMetadata(upToBlock, lastWrittenBlock))
 
 	for _, scope := range scopes {
+		if request.AfterKey != "" && scope <= request.AfterKey {
+			continue
+		}
+
 		stream.Send(&pbstatedb.TableScopeResponse{
 			BlockNum: uint64(actualBlockNum),
 			Scope:    string(scope),
@@ -87,6 +87,25 @@ func (m *BlockMapper) Map(rawBlk *bstream.Block) (*fluxdb.WriteRequest, error) {
 			for _, row := range rows {
 				lastTabletRowMap[keyForRow(row)] = row
 			}
+
+			if err := addPermOpToHistory(lastTabletRowMap, blockNum, trx.Id, permOp); err != nil {
+				return nil, err
+			}
+		}
+
+		// Resource limits ops are recorded by nodeos regardless of the actions, so we process them all
+		if err := addRlimitOpsToEntryMap(lastSingletEntryMap, blockNum, trx.RlimitOps); err != nil {
+			return nil, err
+		}
+
+		// Feature ops comes from the protocol itself or from required system actions, so we process them all
+		for _, featureOp := range trx.FeatureOps {
+			row, err := NewProtocolFeatureRow(blockNum, trx.Id, featureOp)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create protocol feature row for feature op: %w", err)
+			}
+
+			lastTabletRowMap[keyForRow(row)] = row
 		}
 
 		for _, tableOp := range trx.TableOps {
@@ -141,6 +160,10 @@ func (m *BlockMapper) Map(rawBlk *bstream.Block) (*fluxdb.WriteRequest, error) {
 		}
 	}
 
+	if err := addRlimitOpsToEntryMap(lastSingletEntryMap, blockNum, blk.RlimitOps); err != nil {
+		return nil, err
+	}
+
 	addSingletEntriesToRequest(req, lastSingletEntryMap)
 	addTabletRowsToRequest(req, lastTabletRowMap)
 
@@ -156,6 +179,45 @@ func isRequiredSystemAction(actTrace *pbcodec.ActionTrace) bool {
 	return actionName == "setabi" || actionName == "newaccount" || actionName == "updateauth" || actionName == "deleteauth" || actionName == "linkauth" || actionName == "unlinkauth"
 }
 
+func addRlimitOpsToEntryMap(singletEntryMap map[string]fluxdb.SingletEntry, blockNum uint64, ops []*pbcodec.RlimitOp) error {
+	for _, op := range ops {
+		entry, err := NewResourceLimitsEntry(blockNum, op)
+		if err != nil {
+			return fmt.Errorf("unable to create resource limits entry for rlimit op: %w", err)
+		}
+
+		if entry != nil {
+			singletEntryMap[keyForEntry(entry)] = entry
+		}
+	}
+
+	return nil
+}
+
+// addPermOpToHistory records the perm op as a change of the permission, the changes of
+// the same permission within the block are merged into a single one.
+func addPermOpToHistory(tabletRowMap map[string]fluxdb.TabletRow, blockNum uint64, trxID string, permOp *pbcodec.PermOp) error {
+	row, err := NewPermissionHistoryRow(blockNum, trxID, permOp)
+	if err != nil {
+		return fmt.Errorf("unable to create permission history row for perm op: %w", err)
+	}
+
+	rowKey := keyForRow(row)
+	if previous, found := tabletRowMap[rowKey]; found {
+		if row, err = MergePermissionHistoryRows(previous.(*PermissionHistoryRow), row); err != nil {
+			return fmt.Errorf("unable to merge permission history rows: %w", err)
+		}
+
+		if row == nil {
+			delete(tabletRowMap, rowKey)
+			return nil
+		}
+	}
+
+	tabletRowMap[rowKey] = row
+	return nil
+}
+
 func addSingletEntriesToRequest(request *fluxdb.WriteRequest, singleEntriesMap map[string]fluxdb.SingletEntry) {
 	for _, entry := range singleEntriesMap {
 		request.AppendSingletEntry(entry)
@@ -142,6 +142,68 @@ func TestBlockMapper(t *testing.T) {
 			)),
 			expectedEntries: nil,
 		},
+
+		{
+			name: "resource limits ops, last one sticks and pending limits are ignored",
+			input: ct.Block(t, "00000001aa",
+				ct.TrxTrace(t,
+					rlimitAccountUsageOp("alice", 10),
+					rlimitAccountUsageOp("alice", 20),
+					&pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_AccountLimits{
+						AccountLimits: &pbcodec.RlimitAccountLimits{Owner: "bob", Pending: true, CpuWeight: 10},
+					}},
+					&pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_AccountLimits{
+						AccountLimits: &pbcodec.RlimitAccountLimits{Owner: "carol", NetWeight: 5, CpuWeight: 5, RamBytes: 8000},
+					}},
+				),
+				&pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_State{
+					State: &pbcodec.RlimitState{TotalCpuWeight: 100, VirtualCpuLimit: 200000},
+				}},
+				&pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_INSERT, Kind: &pbcodec.RlimitOp_Config{
+					Config: &pbcodec.RlimitConfig{AccountCpuUsageAverageWindow: 172800},
+				}},
+			),
+			expectedEntries: []string{
+				`rau:alice:fffffffffffffffe => {"owner":"alice","cpuUsage":{"lastOrdinal":1,"valueEx":"20"}}`,
+				`ral:carol:fffffffffffffffe => {"owner":"carol","netWeight":"5","cpuWeight":"5","ramBytes":"8000"}`,
+				`rst:fffffffffffffffe => {"totalCpuWeight":"100","virtualCpuLimit":"200000"}`,
+				`rcfg:fffffffffffffffe => {"accountCpuUsageAverageWindow":172800}`,
+			},
+		},
+		{
+			name: "feature ops, pre-activation and activation are distinct rows",
+			input: ct.Block(t, "00000001aa",
+				ct.TrxTrace(t, ct.TrxID("a1"),
+					&pbcodec.FeatureOp{Kind: "PRE_ACTIVATE", FeatureDigest: featureDigest, Feature: &pbcodec.Feature{FeatureDigest: featureDigest}},
+				),
+				ct.TrxTrace(t, ct.TrxID("a2"),
+					&pbcodec.FeatureOp{Kind: "ACTIVATE", FeatureDigest: featureDigest, Feature: &pbcodec.Feature{FeatureDigest: featureDigest}},
+				),
+			),
+			expectedRows: []string{
+				`pf:0000000000000001:` + featureDigest + `:pre_activation => {"feature":{"featureDigest":"` + featureDigest + `"},"trxId":"a1"}`,
+				`pf:0000000000000001:` + featureDigest + `:activation => {"feature":{"featureDigest":"` + featureDigest + `"},"trxId":"a2"}`,
+			},
+		},
+		{
+			name: "perm ops, changes of a permission in the same block are merged",
+			input: ct.Block(t, "00000001aa",
+				ct.TrxTrace(t, ct.TrxID("a1"),
+					ct.PermOp(t, "UPD", ct.OldPerm(ct.Permission(t, "alice@owner", ct.PublicKey("PUB1"))), ct.NewPerm(ct.Permission(t, "alice@owner", ct.PublicKey("PUB2")))),
+					ct.PermOp(t, "INS", ct.NewPerm(ct.Permission(t, "alice@temp"))),
+				),
+				ct.TrxTrace(t, ct.TrxID("a2"),
+					ct.PermOp(t, "UPD", ct.OldPerm(ct.Permission(t, "alice@owner", ct.PublicKey("PUB2"))), ct.NewPerm(ct.Permission(t, "alice@owner", ct.PublicKey("PUB3")))),
+					ct.PermOp(t, "REM", ct.OldPerm(ct.Permission(t, "alice@temp"))),
+				),
+			),
+			expectedRows: []string{
+				`ka:PUB1:0000000000000001:alice => `,
+				`ka:PUB2:0000000000000001:alice => `,
+				`ka:PUB3:0000000000000001:alice => 0801`,
+				`ph:alice:0000000000000001:owner => {"operation":"OPERATION_UPDATE","oldPerm":{"owner":"alice","name":"owner","authority":{"keys":[{"publicKey":"PUB1","weight":1}]}},"newPerm":{"owner":"alice","name":"owner","authority":{"keys":[{"publicKey":"PUB3","weight":1}]}},"trxId":"a2"}`,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -168,6 +230,14 @@ func TestBlockMapper(t *testing.T) {
 	}
 }
 
+const featureDigest = "1a99a59d87e06e09ec5b028a9cbb7749b4a5ad8819004365d02dc4379a8b7241"
+
+func rlimitAccountUsageOp(account string, cpuValueEx uint64) *pbcodec.RlimitOp {
+	return &pbcodec.RlimitOp{Operation: pbcodec.RlimitOp_OPERATION_UPDATE, Kind: &pbcodec.RlimitOp_AccountUsage{
+		AccountUsage: &pbcodec.RlimitAccountUsage{Owner: account, CpuUsage: &pbcodec.UsageAccumulator{LastOrdinal: 1, ValueEx: cpuValueEx}},
+	}}
+}
+
 func entryToString(t *testing.T, entry fluxdb.SingletEntry) string {
 	return genericElementToString(t, entry.String(), entry)
 }
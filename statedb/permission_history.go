@@ -0,0 +1,123 @@
+package statedb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streamingfast/fluxdb"
+)
+
+// ReadPermissionHistory calls `onChange` for each change of the permissions of `account`
+// between `lowBlockNum` and `highBlockNum` inclusively, most recent first, up to `limit`
+// changes when it's not 0. When `permission` is not empty, only its changes are read.
+//
+// Each row of the tablet is the last change of its permission at the height it's read
+// at, the history is walked backward by reading the row right below the height of the
+// last change sent.
+func ReadPermissionHistory(
+	ctx context.Context,
+	db *fluxdb.FluxDB,
+	account string,
+	permission string,
+	lowBlockNum uint64,
+	highBlockNum uint64,
+	limit uint32,
+	speculativeWrites []*fluxdb.WriteRequest,
+	onChange func(row *PermissionHistoryRow) error,
+) error {
+	tablet := NewPermissionHistoryTablet(account)
+
+	var rows []*PermissionHistoryRow
+	if permission != "" {
+		row, err := readPermissionHistoryRowAt(ctx, db, tablet, NewPermissionHistoryPrimaryKey(permission), highBlockNum, speculativeWrites)
+		if err != nil {
+			return err
+		}
+
+		if row != nil {
+			rows = append(rows, row)
+		}
+	} else {
+		tabletRows, err := db.ReadTabletAt(ctx, highBlockNum, tablet, SpeculativeWritesUpTo(speculativeWrites, highBlockNum))
+		if err != nil {
+			return fmt.Errorf("unable to read tablet %s at %d: %w", tablet, highBlockNum, err)
+		}
+
+		for _, tabletRow := range tabletRows {
+			rows = append(rows, tabletRow.(*PermissionHistoryRow))
+		}
+	}
+
+	sentCount := uint32(0)
+	for len(rows) > 0 {
+		latest := 0
+		for i, row := range rows {
+			if row.Height() > rows[latest].Height() || (row.Height() == rows[latest].Height() && row.Permission() < rows[latest].Permission()) {
+				latest = i
+			}
+		}
+
+		row := rows[latest]
+		height := row.Height()
+		if height < lowBlockNum {
+			return nil
+		}
+
+		if err := onChange(row); err != nil {
+			return err
+		}
+
+		sentCount++
+		if limit > 0 && sentCount >= limit {
+			return nil
+		}
+
+		var previous *PermissionHistoryRow
+		if height > 0 {
+			var err error
+			if previous, err = readPermissionHistoryRowAt(ctx, db, tablet, PermissionHistoryPrimaryKey(row.PrimaryKey()), height-1, speculativeWrites); err != nil {
+				return err
+			}
+		}
+
+		if previous != nil {
+			rows[latest] = previous
+		} else {
+			rows = append(rows[:latest], rows[latest+1:]...)
+		}
+	}
+
+	return nil
+}
+
+func readPermissionHistoryRowAt(
+	ctx context.Context,
+	db *fluxdb.FluxDB,
+	tablet PermissionHistoryTablet,
+	primaryKey PermissionHistoryPrimaryKey,
+	blockNum uint64,
+	speculativeWrites []*fluxdb.WriteRequest,
+) (*PermissionHistoryRow, error) {
+	row, err := db.ReadTabletRowAt(ctx, blockNum, tablet, primaryKey, SpeculativeWritesUpTo(speculativeWrites, blockNum))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tablet %s row %s at %d: %w", tablet, primaryKey, blockNum, err)
+	}
+
+	if row == nil {
+		return nil, nil
+	}
+
+	return row.(*PermissionHistoryRow), nil
+}
+
+// SpeculativeWritesUpTo returns the speculative writes at or below `blockNum`, all
+// of them are applied by fluxdb on top of what is read from the database
+func SpeculativeWritesUpTo(speculativeWrites []*fluxdb.WriteRequest, blockNum uint64) []*fluxdb.WriteRequest {
+	for i, write := range speculativeWrites {
+		if write.Height > blockNum {
+			return speculativeWrites[:i]
+		}
+	}
+
+	return speculativeWrites
+}
@@ -0,0 +1,110 @@
+package statedb
+
+import (
+	"math"
+	"math/big"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+)
+
+// Same as nodeos `config::rate_limiting_precision`, the usage accumulators
+// values are scaled by it
+const rateLimitingPrecision = 1000 * 1000
+
+// ComputeAccountResourceLimits returns the CPU and NET limits of an account
+// the way nodeos `get_account` does, using the elastic virtual limits.
+//
+// The usage is decayed by `elapsedSlots`, the time slots elapsed since its
+// last update. The slots are not recorded along the usage, so callers
+// approximate them by the count of blocks since the update, which is short
+// of the slots missed by producers.
+//
+// Both limits are `nil` when the limits, the state or the config are unknown.
+func ComputeAccountResourceLimits(
+	limits *pbcodec.RlimitAccountLimits,
+	usage *pbcodec.RlimitAccountUsage,
+	state *pbcodec.RlimitState,
+	config *pbcodec.RlimitConfig,
+	elapsedSlots uint64,
+) (cpu *pbstatedb.AccountResourceLimit, net *pbstatedb.AccountResourceLimit) {
+	if limits == nil || state == nil || config == nil {
+		return nil, nil
+	}
+
+	var cpuUsage, netUsage *pbcodec.UsageAccumulator
+	if usage != nil {
+		cpuUsage = usage.CpuUsage
+		netUsage = usage.NetUsage
+	}
+
+	cpu = computeResourceLimit(limits.CpuWeight, state.TotalCpuWeight, state.VirtualCpuLimit, config.AccountCpuUsageAverageWindow, cpuUsage, elapsedSlots)
+	net = computeResourceLimit(limits.NetWeight, state.TotalNetWeight, state.VirtualNetLimit, config.AccountNetUsageAverageWindow, netUsage, elapsedSlots)
+	return
+}
+
+func computeResourceLimit(weight int64, totalWeight uint64, virtualLimit uint64, window uint32, usage *pbcodec.UsageAccumulator, elapsedSlots uint64) *pbstatedb.AccountResourceLimit {
+	if weight < 0 || totalWeight == 0 {
+		return &pbstatedb.AccountResourceLimit{Used: -1, Available: -1, Max: -1}
+	}
+
+	windowSize := new(big.Int).SetUint64(uint64(window))
+
+	maxInWindow := new(big.Int).SetUint64(virtualLimit)
+	maxInWindow.Mul(maxInWindow, windowSize)
+	maxInWindow.Mul(maxInWindow, big.NewInt(weight))
+	maxInWindow.Quo(maxInWindow, new(big.Int).SetUint64(totalWeight))
+
+	usedInWindow := new(big.Int).SetUint64(decayedValueEx(usage, uint64(window), elapsedSlots))
+	usedInWindow.Mul(usedInWindow, windowSize)
+	usedInWindow = divideCeil(usedInWindow, big.NewInt(rateLimitingPrecision))
+
+	available := new(big.Int)
+	if maxInWindow.Cmp(usedInWindow) > 0 {
+		available.Sub(maxInWindow, usedInWindow)
+	}
+
+	return &pbstatedb.AccountResourceLimit{
+		Used:      clampToInt64(usedInWindow),
+		Available: clampToInt64(available),
+		Max:       clampToInt64(maxInWindow),
+	}
+}
+
+// decayedValueEx is nodeos `exponential_moving_average_accumulator::add` of
+// nothing, `elapsedSlots` after the last update of the accumulator
+func decayedValueEx(usage *pbcodec.UsageAccumulator, window uint64, elapsedSlots uint64) uint64 {
+	if usage == nil {
+		return 0
+	}
+
+	if elapsedSlots == 0 {
+		return usage.ValueEx
+	}
+
+	if elapsedSlots >= window {
+		return 0
+	}
+
+	value := new(big.Int).SetUint64(usage.ValueEx)
+	value.Mul(value, new(big.Int).SetUint64(window-elapsedSlots))
+	value.Quo(value, new(big.Int).SetUint64(window))
+	return value.Uint64()
+}
+
+func divideCeil(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	return quotient
+}
+
+func clampToInt64(value *big.Int) int64 {
+	if !value.IsInt64() {
+		return math.MaxInt64
+	}
+
+	return value.Int64()
+}
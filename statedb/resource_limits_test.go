@@ -0,0 +1,40 @@
+package statedb
+
+import (
+	"testing"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeAccountResourceLimits(t *testing.T) {
+	limits := &pbcodec.RlimitAccountLimits{Owner: "alice", CpuWeight: 5, NetWeight: -1}
+	usage := &pbcodec.RlimitAccountUsage{Owner: "alice", CpuUsage: &pbcodec.UsageAccumulator{LastOrdinal: 10, ValueEx: 1000000}}
+	state := &pbcodec.RlimitState{TotalCpuWeight: 100, TotalNetWeight: 100, VirtualCpuLimit: 200000, VirtualNetLimit: 1048576}
+	config := &pbcodec.RlimitConfig{AccountCpuUsageAverageWindow: 172800, AccountNetUsageAverageWindow: 172800}
+
+	tests := []struct {
+		name         string
+		elapsedSlots uint64
+		expectedCPU  *pbstatedb.AccountResourceLimit
+	}{
+		{"at last update", 0, &pbstatedb.AccountResourceLimit{Used: 172800, Available: 1727827200, Max: 1728000000}},
+		{"decayed by half a window", 86400, &pbstatedb.AccountResourceLimit{Used: 86400, Available: 1727913600, Max: 1728000000}},
+		{"decayed past the window", 172800, &pbstatedb.AccountResourceLimit{Used: 0, Available: 1728000000, Max: 1728000000}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cpu, net := ComputeAccountResourceLimits(limits, usage, state, config, test.elapsedSlots)
+			assert.Equal(t, test.expectedCPU, cpu)
+			assert.Equal(t, &pbstatedb.AccountResourceLimit{Used: -1, Available: -1, Max: -1}, net, "negative weight is unlimited")
+		})
+	}
+
+	t.Run("unknown limits", func(t *testing.T) {
+		cpu, net := ComputeAccountResourceLimits(nil, usage, state, config, 0)
+		assert.Nil(t, cpu)
+		assert.Nil(t, net)
+	})
+}
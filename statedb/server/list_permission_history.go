@@ -0,0 +1,140 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/statedb"
+	eos "github.com/eoscanada/eos-go"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/logging"
+	"github.com/streamingfast/validator"
+	"go.uber.org/zap"
+)
+
+const defaultPermissionHistoryLimit = 100
+const maxPermissionHistoryLimit = 1000
+
+func (srv *EOSServer) listPermissionHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	zlogger := logging.Logger(ctx, zlog)
+
+	errors := validateListPermissionHistoryRequest(r)
+	if len(errors) > 0 {
+		writeError(ctx, w, derr.RequestValidationError(ctx, errors))
+		return
+	}
+
+	request := extractListPermissionHistoryRequest(r)
+	zlogger.Debug("extracted request", zap.Reflect("request", request))
+
+	highBlockNum, lastWrittenBlock, upToBlock, speculativeWrites, err := srv.prepareRead(ctx, request.HighBlockNum, false)
+	if err != nil {
+		writeError(ctx, w, fmt.Errorf("prepare read failed: %w", err))
+		return
+	}
+
+	resp := &listPermissionHistoryResponse{
+		commonStateResponse: newCommonGetResponse(upToBlock, lastWrittenBlock),
+		Changes:             []*permissionChange{},
+	}
+
+	err = statedb.ReadPermissionHistory(ctx, srv.db, string(request.Account), string(request.Permission), request.LowBlockNum, highBlockNum, request.Limit, speculativeWrites, func(row *statedb.PermissionHistoryRow) error {
+		change, err := row.Change()
+		if err != nil {
+			return fmt.Errorf("unable to decode permission history row %q: %w", row, err)
+		}
+
+		resp.Changes = append(resp.Changes, &permissionChange{
+			BlockNum:   row.Height(),
+			TrxID:      change.TrxId,
+			Permission: row.Permission(),
+			Operation:  permOpOperationToString(change.Operation),
+			OldPerm:    change.OldPerm,
+			NewPerm:    change.NewPerm,
+		})
+		return nil
+	})
+	if err != nil {
+		writeError(ctx, w, fmt.Errorf("unable to read permission history at %d: %w", highBlockNum, err))
+		return
+	}
+
+	writeResponse(ctx, w, resp)
+}
+
+type listPermissionHistoryRequest struct {
+	Account      eos.AccountName    `json:"account"`
+	Permission   eos.PermissionName `json:"permission"`
+	LowBlockNum  uint64             `json:"low_block_num"`
+	HighBlockNum uint64             `json:"high_block_num"`
+	Limit        uint32             `json:"limit"`
+}
+
+type listPermissionHistoryResponse struct {
+	*commonStateResponse
+
+	Changes []*permissionChange `json:"changes"`
+}
+
+type permissionChange struct {
+	BlockNum   uint64                    `json:"block_num"`
+	TrxID      string                    `json:"trx_id"`
+	Permission string                    `json:"permission"`
+	Operation  string                    `json:"operation"`
+	OldPerm    *pbcodec.PermissionObject `json:"old_perm,omitempty"`
+	NewPerm    *pbcodec.PermissionObject `json:"new_perm,omitempty"`
+}
+
+func permOpOperationToString(operation pbcodec.PermOp_Operation) string {
+	switch operation {
+	case pbcodec.PermOp_OPERATION_INSERT:
+		return "insert"
+	case pbcodec.PermOp_OPERATION_UPDATE:
+		return "update"
+	case pbcodec.PermOp_OPERATION_REMOVE:
+		return "remove"
+	}
+
+	return "unknown"
+}
+
+func validateListPermissionHistoryRequest(r *http.Request) url.Values {
+	errors := validator.ValidateQueryParams(r, validator.Rules{
+		"account":        []string{"required", "fluxdb.eos.name"},
+		"permission":     []string{"fluxdb.eos.name"},
+		"low_block_num":  []string{"fluxdb.eos.blockNum"},
+		"high_block_num": []string{"fluxdb.eos.blockNum"},
+		"limit":          []string{"numeric", fmt.Sprintf("numeric_between:1,%d", maxPermissionHistoryLimit)},
+	})
+
+	// When not set, the high block num is the head block, a low block num above it yields no changes
+	lowBlockNum, _ := strconv.ParseUint(r.FormValue("low_block_num"), 10, 64)
+	highBlockNum, _ := strconv.ParseUint(r.FormValue("high_block_num"), 10, 64)
+	if highBlockNum != 0 && lowBlockNum > highBlockNum {
+		errors["low_block_num"] = []string{"The low_block_num field must be lower than or equal to high_block_num"}
+	}
+
+	return errors
+}
+
+func extractListPermissionHistoryRequest(r *http.Request) *listPermissionHistoryRequest {
+	lowBlockNum64, _ := strconv.ParseInt(r.FormValue("low_block_num"), 10, 64)
+	highBlockNum64, _ := strconv.ParseInt(r.FormValue("high_block_num"), 10, 64)
+
+	limit := uint32(defaultPermissionHistoryLimit)
+	if limit64, err := strconv.ParseUint(r.FormValue("limit"), 10, 32); err == nil {
+		limit = uint32(limit64)
+	}
+
+	return &listPermissionHistoryRequest{
+		Account:      eos.AccountName(r.FormValue("account")),
+		Permission:   eos.PermissionName(r.FormValue("permission")),
+		LowBlockNum:  uint64(lowBlockNum64),
+		HighBlockNum: uint64(highBlockNum64),
+		Limit:        limit,
+	}
+}
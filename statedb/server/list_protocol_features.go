@@ -0,0 +1,100 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/statedb"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/logging"
+	"github.com/streamingfast/validator"
+	"go.uber.org/zap"
+)
+
+func (srv *EOSServer) listProtocolFeaturesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	zlogger := logging.Logger(ctx, zlog)
+
+	errors := validateListProtocolFeaturesRequest(r)
+	if len(errors) > 0 {
+		writeError(ctx, w, derr.RequestValidationError(ctx, errors))
+		return
+	}
+
+	request := extractListProtocolFeaturesRequest(r)
+	zlogger.Debug("extracted request", zap.Reflect("request", request))
+
+	actualBlockNum, lastWrittenBlock, upToBlock, speculativeWrites, err := srv.prepareRead(ctx, request.BlockNum, false)
+	if err != nil {
+		writeError(ctx, w, fmt.Errorf("prepare read failed: %w", err))
+		return
+	}
+
+	tabletRows, err := srv.db.ReadTabletAt(ctx, actualBlockNum, statedb.ProtocolFeatureTablet{}, speculativeWrites)
+	if err != nil {
+		writeError(ctx, w, fmt.Errorf("unable to read tablet at %d: %w", request.BlockNum, err))
+		return
+	}
+
+	features, err := statedb.ProtocolFeaturesFromRows(tabletRows)
+	if err != nil {
+		writeError(ctx, w, fmt.Errorf("unable to read protocol features at %d: %w", request.BlockNum, err))
+		return
+	}
+
+	resp := &listProtocolFeaturesResponse{
+		commonStateResponse: newCommonGetResponse(upToBlock, lastWrittenBlock),
+		Features:            make([]*protocolFeature, len(features)),
+	}
+
+	for i, feature := range features {
+		resp.Features[i] = &protocolFeature{
+			FeatureDigest:         feature.FeatureDigest,
+			Name:                  feature.Name,
+			PreActivationBlockNum: feature.PreActivationBlockNum,
+			PreActivationTrxID:    feature.PreActivationTrxId,
+			ActivationBlockNum:    feature.ActivationBlockNum,
+			ActivationTrxID:       feature.ActivationTrxId,
+			Feature:               feature.Feature,
+		}
+	}
+
+	writeResponse(ctx, w, resp)
+}
+
+type listProtocolFeaturesRequest struct {
+	BlockNum uint64 `json:"block_num"`
+}
+
+type listProtocolFeaturesResponse struct {
+	*commonStateResponse
+
+	Features []*protocolFeature `json:"features"`
+}
+
+type protocolFeature struct {
+	FeatureDigest         string           `json:"feature_digest"`
+	Name                  string           `json:"name,omitempty"`
+	PreActivationBlockNum uint64           `json:"pre_activation_block_num,omitempty"`
+	PreActivationTrxID    string           `json:"pre_activation_trx_id,omitempty"`
+	ActivationBlockNum    uint64           `json:"activation_block_num,omitempty"`
+	ActivationTrxID       string           `json:"activation_trx_id,omitempty"`
+	Feature               *pbcodec.Feature `json:"feature"`
+}
+
+func validateListProtocolFeaturesRequest(r *http.Request) url.Values {
+	return validator.ValidateQueryParams(r, validator.Rules{
+		"block_num": []string{"fluxdb.eos.blockNum"},
+	})
+}
+
+func extractListProtocolFeaturesRequest(r *http.Request) *listProtocolFeaturesRequest {
+	blockNum64, _ := strconv.ParseInt(r.FormValue("block_num"), 10, 64)
+
+	return &listProtocolFeaturesRequest{
+		BlockNum: uint64(blockNum64),
+	}
+}
@@ -0,0 +1,292 @@
+package statedb
+
+import (
+	"fmt"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/streamingfast/fluxdb"
+)
+
+const ralCollection = 0xA100
+const ralName = "ral"
+
+const rauCollection = 0xA200
+const rauName = "rau"
+
+const rstCollection = 0xA300
+const rstName = "rst"
+
+const rcfgCollection = 0xA400
+const rcfgName = "rcfg"
+
+func init() {
+	fluxdb.RegisterSingletFactory(ralCollection, ralName, func(identifier []byte) (fluxdb.Singlet, error) {
+		if len(identifier) < 8 {
+			return nil, fluxdb.ErrInvalidKeyLengthAtLeast("account limits singlet identifier", 8, len(identifier))
+		}
+
+		return AccountLimitsSinglet(identifier[0:8]), nil
+	})
+
+	fluxdb.RegisterSingletFactory(rauCollection, rauName, func(identifier []byte) (fluxdb.Singlet, error) {
+		if len(identifier) < 8 {
+			return nil, fluxdb.ErrInvalidKeyLengthAtLeast("account usage singlet identifier", 8, len(identifier))
+		}
+
+		return AccountUsageSinglet(identifier[0:8]), nil
+	})
+
+	// The global state and config singlets have no identifier, there is a single one of each
+	fluxdb.RegisterSingletFactory(rstCollection, rstName, func(identifier []byte) (fluxdb.Singlet, error) {
+		return ResourceStateSinglet{}, nil
+	})
+
+	fluxdb.RegisterSingletFactory(rcfgCollection, rcfgName, func(identifier []byte) (fluxdb.Singlet, error) {
+		return ResourceConfigSinglet{}, nil
+	})
+}
+
+// NewResourceLimitsEntry turns a resource limits operation into the entry
+// of its singlet. Pending account limits, staged until the end of the block
+// by nodeos, are not effective yet and yield a `nil` entry.
+func NewResourceLimitsEntry(blockNum uint64, op *pbcodec.RlimitOp) (fluxdb.SingletEntry, error) {
+	switch kind := op.Kind.(type) {
+	case *pbcodec.RlimitOp_AccountLimits:
+		if kind.AccountLimits.Pending {
+			return nil, nil
+		}
+
+		singlet := NewAccountLimitsSinglet(kind.AccountLimits.Owner)
+		value, err := marshalResourceLimitsValue(kind.AccountLimits)
+		if err != nil {
+			return nil, err
+		}
+
+		return &AccountLimitsEntry{baseEntry(singlet, blockNum, value)}, nil
+
+	case *pbcodec.RlimitOp_AccountUsage:
+		singlet := NewAccountUsageSinglet(kind.AccountUsage.Owner)
+		value, err := marshalResourceLimitsValue(kind.AccountUsage)
+		if err != nil {
+			return nil, err
+		}
+
+		return &AccountUsageEntry{baseEntry(singlet, blockNum, value)}, nil
+
+	case *pbcodec.RlimitOp_State:
+		value, err := marshalResourceLimitsValue(kind.State)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ResourceStateEntry{baseEntry(ResourceStateSinglet{}, blockNum, value)}, nil
+
+	case *pbcodec.RlimitOp_Config:
+		value, err := marshalResourceLimitsValue(kind.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ResourceConfigEntry{baseEntry(ResourceConfigSinglet{}, blockNum, value)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown rlimit op kind %T", op.Kind)
+}
+
+func marshalResourceLimitsValue(message proto.Message) ([]byte, error) {
+	value, err := proto.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("marshal proto: %w", err)
+	}
+
+	return value, nil
+}
+
+//
+/// Account Limits
+//
+
+type AccountLimitsSinglet []byte
+
+func NewAccountLimitsSinglet(account string) AccountLimitsSinglet {
+	return AccountLimitsSinglet(standardNameToBytes(account))
+}
+
+func (s AccountLimitsSinglet) Collection() uint16 {
+	return ralCollection
+}
+
+func (s AccountLimitsSinglet) Identifier() []byte {
+	return []byte(s)
+}
+
+func (s AccountLimitsSinglet) Entry(height uint64, data []byte) (fluxdb.SingletEntry, error) {
+	return &AccountLimitsEntry{baseEntry(s, height, data)}, nil
+}
+
+func (s AccountLimitsSinglet) Account() string {
+	return bytesToName(s)
+}
+
+func (s AccountLimitsSinglet) String() string {
+	return ralName + ":" + bytesToName(s)
+}
+
+type AccountLimitsEntry struct {
+	fluxdb.BaseSingletEntry
+}
+
+func (e *AccountLimitsEntry) Limits() (*pbcodec.RlimitAccountLimits, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	pb := &pbcodec.RlimitAccountLimits{}
+	if err := proto.Unmarshal(e.Value(), pb); err != nil {
+		return nil, err
+	}
+
+	return pb, nil
+}
+
+func (e *AccountLimitsEntry) ToProto() (proto.Message, error) {
+	return e.Limits()
+}
+
+//
+/// Account Usage
+//
+
+type AccountUsageSinglet []byte
+
+func NewAccountUsageSinglet(account string) AccountUsageSinglet {
+	return AccountUsageSinglet(standardNameToBytes(account))
+}
+
+func (s AccountUsageSinglet) Collection() uint16 {
+	return rauCollection
+}
+
+func (s AccountUsageSinglet) Identifier() []byte {
+	return []byte(s)
+}
+
+func (s AccountUsageSinglet) Entry(height uint64, data []byte) (fluxdb.SingletEntry, error) {
+	return &AccountUsageEntry{baseEntry(s, height, data)}, nil
+}
+
+func (s AccountUsageSinglet) Account() string {
+	return bytesToName(s)
+}
+
+func (s AccountUsageSinglet) String() string {
+	return rauName + ":" + bytesToName(s)
+}
+
+type AccountUsageEntry struct {
+	fluxdb.BaseSingletEntry
+}
+
+func (e *AccountUsageEntry) Usage() (*pbcodec.RlimitAccountUsage, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	pb := &pbcodec.RlimitAccountUsage{}
+	if err := proto.Unmarshal(e.Value(), pb); err != nil {
+		return nil, err
+	}
+
+	return pb, nil
+}
+
+func (e *AccountUsageEntry) ToProto() (proto.Message, error) {
+	return e.Usage()
+}
+
+//
+/// Global State
+//
+
+type ResourceStateSinglet struct{}
+
+func (s ResourceStateSinglet) Collection() uint16 {
+	return rstCollection
+}
+
+func (s ResourceStateSinglet) Identifier() []byte {
+	return nil
+}
+
+func (s ResourceStateSinglet) Entry(height uint64, data []byte) (fluxdb.SingletEntry, error) {
+	return &ResourceStateEntry{baseEntry(s, height, data)}, nil
+}
+
+func (s ResourceStateSinglet) String() string {
+	return rstName
+}
+
+type ResourceStateEntry struct {
+	fluxdb.BaseSingletEntry
+}
+
+func (e *ResourceStateEntry) State() (*pbcodec.RlimitState, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	pb := &pbcodec.RlimitState{}
+	if err := proto.Unmarshal(e.Value(), pb); err != nil {
+		return nil, err
+	}
+
+	return pb, nil
+}
+
+func (e *ResourceStateEntry) ToProto() (proto.Message, error) {
+	return e.State()
+}
+
+//
+/// Global Config
+//
+
+type ResourceConfigSinglet struct{}
+
+func (s ResourceConfigSinglet) Collection() uint16 {
+	return rcfgCollection
+}
+
+func (s ResourceConfigSinglet) Identifier() []byte {
+	return nil
+}
+
+func (s ResourceConfigSinglet) Entry(height uint64, data []byte) (fluxdb.SingletEntry, error) {
+	return &ResourceConfigEntry{baseEntry(s, height, data)}, nil
+}
+
+func (s ResourceConfigSinglet) String() string {
+	return rcfgName
+}
+
+type ResourceConfigEntry struct {
+	fluxdb.BaseSingletEntry
+}
+
+func (e *ResourceConfigEntry) Config() (*pbcodec.RlimitConfig, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	pb := &pbcodec.RlimitConfig{}
+	if err := proto.Unmarshal(e.Value(), pb); err != nil {
+		return nil, err
+	}
+
+	return pb, nil
+}
+
+func (e *ResourceConfigEntry) ToProto() (proto.Message, error) {
+	return e.Config()
+}
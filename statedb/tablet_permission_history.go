@@ -0,0 +1,148 @@
+package statedb
+
+import (
+	"fmt"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/streamingfast/fluxdb"
+)
+
+const phCollection = 0xB500
+const phPrefix = "ph"
+
+func init() {
+	fluxdb.RegisterTabletFactory(phCollection, phPrefix, func(identifier []byte) (fluxdb.Tablet, error) {
+		if len(identifier) < 8 {
+			return nil, fluxdb.ErrInvalidKeyLengthAtLeast("permission history tablet identifier", 8, len(identifier))
+		}
+
+		return PermissionHistoryTablet(identifier[0:8]), nil
+	})
+}
+
+func NewPermissionHistoryTablet(account string) PermissionHistoryTablet {
+	return PermissionHistoryTablet(standardNameToBytes(account))
+}
+
+// PermissionHistoryTablet tablet is composed of a row per permission of the account,
+// each row version records a change of the permission. Removed permissions are kept
+// as regular rows holding the old permission so the history can be walked backward.
+type PermissionHistoryTablet []byte
+
+func (t PermissionHistoryTablet) Collection() uint16 {
+	return phCollection
+}
+
+func (t PermissionHistoryTablet) Identifier() []byte {
+	return t
+}
+
+func (t PermissionHistoryTablet) Row(height uint64, primaryKey []byte, data []byte) (fluxdb.TabletRow, error) {
+	if len(primaryKey) != 8 {
+		return nil, fluxdb.ErrInvalidKeyLength("permission history primary key", 8, len(primaryKey))
+	}
+
+	return &PermissionHistoryRow{baseRow(t, height, primaryKey, data)}, nil
+}
+
+func (t PermissionHistoryTablet) String() string {
+	return phPrefix + ":" + bytesToName(t)
+}
+
+type PermissionHistoryRow struct {
+	fluxdb.BaseTabletRow
+}
+
+func NewPermissionHistoryRow(blockNum uint64, trxID string, permOp *pbcodec.PermOp) (*PermissionHistoryRow, error) {
+	return newPermissionHistoryRow(blockNum, &pbstatedb.PermissionHistoryValue{
+		Operation: permOp.Operation,
+		OldPerm:   permOp.OldPerm,
+		NewPerm:   permOp.NewPerm,
+		TrxId:     trxID,
+	})
+}
+
+func newPermissionHistoryRow(blockNum uint64, pb *pbstatedb.PermissionHistoryValue) (*PermissionHistoryRow, error) {
+	perm := pb.NewPerm
+	if perm == nil {
+		perm = pb.OldPerm
+	}
+
+	if perm == nil {
+		return nil, fmt.Errorf("perm op %s has neither old nor new permission", pb.Operation)
+	}
+
+	value, err := proto.Marshal(pb)
+	if err != nil {
+		return nil, fmt.Errorf("marshal proto: %w", err)
+	}
+
+	tablet := NewPermissionHistoryTablet(perm.Owner)
+	return &PermissionHistoryRow{baseRow(tablet, blockNum, NewPermissionHistoryPrimaryKey(perm.Name), value)}, nil
+}
+
+// MergePermissionHistoryRows folds the change of `next` into the one of `previous`, both
+// happening in the same block, into a single change going from the old permission of
+// `previous` to the new permission of `next`. A permission inserted then removed in the
+// same block has no change at all, `nil` is returned in this case.
+func MergePermissionHistoryRows(previous, next *PermissionHistoryRow) (*PermissionHistoryRow, error) {
+	previousChange, err := previous.Change()
+	if err != nil {
+		return nil, err
+	}
+
+	nextChange, err := next.Change()
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &pbstatedb.PermissionHistoryValue{
+		Operation: pbcodec.PermOp_OPERATION_UPDATE,
+		OldPerm:   previousChange.OldPerm,
+		NewPerm:   nextChange.NewPerm,
+		TrxId:     nextChange.TrxId,
+	}
+
+	switch {
+	case merged.OldPerm == nil && merged.NewPerm == nil:
+		return nil, nil
+	case merged.OldPerm == nil:
+		merged.Operation = pbcodec.PermOp_OPERATION_INSERT
+	case merged.NewPerm == nil:
+		merged.Operation = pbcodec.PermOp_OPERATION_REMOVE
+	}
+
+	return newPermissionHistoryRow(next.Height(), merged)
+}
+
+func (r *PermissionHistoryRow) Permission() string {
+	return bytesToName(r.PrimaryKey())
+}
+
+func (r *PermissionHistoryRow) Change() (*pbstatedb.PermissionHistoryValue, error) {
+	pb := &pbstatedb.PermissionHistoryValue{}
+	if err := proto.Unmarshal(r.Value(), pb); err != nil {
+		return nil, fmt.Errorf("unmarshal proto: %w", err)
+	}
+
+	return pb, nil
+}
+
+func (r *PermissionHistoryRow) ToProto() (proto.Message, error) {
+	return r.Change()
+}
+
+func (r *PermissionHistoryRow) String() string {
+	return r.Stringify(r.Permission())
+}
+
+type PermissionHistoryPrimaryKey []byte
+
+func NewPermissionHistoryPrimaryKey(permission string) PermissionHistoryPrimaryKey {
+	return PermissionHistoryPrimaryKey(standardNameToBytes(permission))
+}
+
+func (k PermissionHistoryPrimaryKey) Bytes() []byte  { return k }
+func (k PermissionHistoryPrimaryKey) String() string { return bytesToName(k) }
@@ -0,0 +1,183 @@
+package statedb
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/streamingfast/fluxdb"
+)
+
+const pfCollection = 0xB400
+const pfPrefix = "pf"
+
+const featureDigestBytes = 32
+
+const (
+	featurePreActivationKind byte = 0x00
+	featureActivationKind    byte = 0x01
+)
+
+func init() {
+	// There is a single protocol features tablet for the whole chain, it has no identifier
+	fluxdb.RegisterTabletFactory(pfCollection, pfPrefix, func(identifier []byte) (fluxdb.Tablet, error) {
+		return ProtocolFeatureTablet{}, nil
+	})
+}
+
+// ProtocolFeatureTablet tablet is composed of a row per feature pre-activation
+// and a row per feature activation, the row height being the block where it happened.
+type ProtocolFeatureTablet struct{}
+
+func (t ProtocolFeatureTablet) Collection() uint16 {
+	return pfCollection
+}
+
+func (t ProtocolFeatureTablet) Identifier() []byte {
+	return nil
+}
+
+func (t ProtocolFeatureTablet) Row(height uint64, primaryKey []byte, data []byte) (fluxdb.TabletRow, error) {
+	if len(primaryKey) != featureDigestBytes+1 {
+		return nil, fluxdb.ErrInvalidKeyLength("protocol feature primary key", featureDigestBytes+1, len(primaryKey))
+	}
+
+	return &ProtocolFeatureRow{baseRow(t, height, primaryKey, data)}, nil
+}
+
+func (t ProtocolFeatureTablet) String() string {
+	return pfPrefix
+}
+
+type ProtocolFeatureRow struct {
+	fluxdb.BaseTabletRow
+}
+
+func NewProtocolFeatureRow(blockNum uint64, trxID string, op *pbcodec.FeatureOp) (*ProtocolFeatureRow, error) {
+	digest, err := hex.DecodeString(op.FeatureDigest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feature digest %q: %w", op.FeatureDigest, err)
+	}
+
+	if len(digest) != featureDigestBytes {
+		return nil, fmt.Errorf("invalid feature digest %q: expected %d bytes, got %d", op.FeatureDigest, featureDigestBytes, len(digest))
+	}
+
+	var kind byte
+	switch op.Kind {
+	case "PRE_ACTIVATE":
+		kind = featurePreActivationKind
+	case "ACTIVATE":
+		kind = featureActivationKind
+	default:
+		return nil, fmt.Errorf("unknown feature op kind %q", op.Kind)
+	}
+
+	value, err := proto.Marshal(&pbstatedb.ProtocolFeatureValue{Feature: op.Feature, TrxId: trxID})
+	if err != nil {
+		return nil, fmt.Errorf("marshal proto: %w", err)
+	}
+
+	return &ProtocolFeatureRow{baseRow(ProtocolFeatureTablet{}, blockNum, append(digest, kind), value)}, nil
+}
+
+func (r *ProtocolFeatureRow) FeatureDigest() string {
+	return hex.EncodeToString(r.PrimaryKey()[0:featureDigestBytes])
+}
+
+func (r *ProtocolFeatureRow) IsActivation() bool {
+	return r.PrimaryKey()[featureDigestBytes] == featureActivationKind
+}
+
+func (r *ProtocolFeatureRow) Feature() (*pbstatedb.ProtocolFeatureValue, error) {
+	pb := &pbstatedb.ProtocolFeatureValue{}
+	if err := proto.Unmarshal(r.Value(), pb); err != nil {
+		return nil, fmt.Errorf("unmarshal proto: %w", err)
+	}
+
+	return pb, nil
+}
+
+func (r *ProtocolFeatureRow) ToProto() (proto.Message, error) {
+	return r.Feature()
+}
+
+func (r *ProtocolFeatureRow) String() string {
+	kind := "pre_activation"
+	if r.IsActivation() {
+		kind = "activation"
+	}
+
+	return r.Stringify(r.FeatureDigest() + ":" + kind)
+}
+
+// FeatureName returns the builtin codename of the feature, like `ONLY_BILL_FIRST_AUTHORIZER`,
+// or an empty string when the feature is not a builtin one.
+func FeatureName(feature *pbcodec.Feature) string {
+	for _, spec := range feature.GetSpecification() {
+		if spec.Name == "builtin_feature_codename" {
+			return spec.Value
+		}
+	}
+
+	return ""
+}
+
+// ProtocolFeaturesFromRows merges the pre-activation and activation rows of
+// each feature, the features are sorted by activation block, the ones that are
+// only pre-activated coming last by pre-activation block.
+func ProtocolFeaturesFromRows(rows []fluxdb.TabletRow) ([]*pbstatedb.ProtocolFeature, error) {
+	featureByDigest := map[string]*pbstatedb.ProtocolFeature{}
+	for _, tabletRow := range rows {
+		row := tabletRow.(*ProtocolFeatureRow)
+		value, err := row.Feature()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode protocol feature row %q: %w", row, err)
+		}
+
+		digest := row.FeatureDigest()
+		feature := featureByDigest[digest]
+		if feature == nil {
+			feature = &pbstatedb.ProtocolFeature{FeatureDigest: digest}
+			featureByDigest[digest] = feature
+		}
+
+		feature.Name = FeatureName(value.Feature)
+		feature.Feature = value.Feature
+
+		if row.IsActivation() {
+			feature.ActivationBlockNum = row.Height()
+			feature.ActivationTrxId = value.TrxId
+		} else {
+			feature.PreActivationBlockNum = row.Height()
+			feature.PreActivationTrxId = value.TrxId
+		}
+	}
+
+	features := make([]*pbstatedb.ProtocolFeature, 0, len(featureByDigest))
+	for _, feature := range featureByDigest {
+		features = append(features, feature)
+	}
+
+	sort.Slice(features, func(i, j int) bool {
+		left, right := features[i], features[j]
+		if (left.ActivationBlockNum == 0) != (right.ActivationBlockNum == 0) {
+			return left.ActivationBlockNum != 0
+		}
+
+		if left.ActivationBlockNum != right.ActivationBlockNum {
+			return left.ActivationBlockNum < right.ActivationBlockNum
+		}
+
+		if left.PreActivationBlockNum != right.PreActivationBlockNum {
+			return left.PreActivationBlockNum < right.PreActivationBlockNum
+		}
+
+		return left.FeatureDigest < right.FeatureDigest
+	})
+
+	return features, nil
+}
@@ -90,6 +90,14 @@ func runAll(t *testing.T, storeFactory StoreFactory) {
 		"table_row": {
 			testStateTableRowHeadJSON,
 		},
+		"permission_history": {
+			testPermissionHistoryHeadJSON,
+			testPermissionHistoryHistoricalJSON,
+		},
+		"chain_features": {
+			testChainFeaturesHeadJSON,
+			testChainFeaturesHistoricalJSON,
+		},
 	}
 
 	for group, tests := range all {
@@ -242,6 +250,110 @@ func testStateTableRowHeadJSON(ctx context.Context, t *testing.T, feedSourceWith
 	jsonValueEqual(t, "row", `{"key":"SOE","payer":"eosio5","json":{"balance":"5.0000 SOE"}}`, response.Path("$.row"))
 }
 
+func testChainFeaturesHeadJSON(ctx context.Context, t *testing.T, feedSourceWithBlocks blocksFeeder, e *httpexpect.Expect) {
+	feedSourceWithBlocks(featureBlocks(t)...)
+
+	response := okQuery(e, "/v0/chain/features", "")
+
+	assertHeadBlockInfo(response, "00000005aa", "00000004aa")
+	jsonValueEqual(t, "features", `[
+		{"feature_digest":"0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd","name":"PREACTIVATE_FEATURE","activation_block_num":2,"activation_trx_id":"a1","feature":{"feature_digest":"0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd","specification":[{"name":"builtin_feature_codename","value":"PREACTIVATE_FEATURE"}]}},
+		{"feature_digest":"8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405","name":"ONLY_BILL_FIRST_AUTHORIZER","pre_activation_block_num":3,"pre_activation_trx_id":"a2","activation_block_num":5,"activation_trx_id":"a3","feature":{"feature_digest":"8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405","specification":[{"name":"builtin_feature_codename","value":"ONLY_BILL_FIRST_AUTHORIZER"}]}}
+	]`, response.Path("$.features"))
+}
+
+func testChainFeaturesHistoricalJSON(ctx context.Context, t *testing.T, feedSourceWithBlocks blocksFeeder, e *httpexpect.Expect) {
+	feedSourceWithBlocks(featureBlocks(t)...)
+
+	response := okQuery(e, "/v0/chain/features", "block_num=3")
+
+	assertIrrBlockInfo(response, "00000004aa")
+	response.Path("$.features").Array().Length().Equal(2)
+	response.Path("$.features[1]").Object().ValueEqual("pre_activation_block_num", 3).NotContainsKey("activation_block_num")
+}
+
+func testPermissionHistoryHeadJSON(ctx context.Context, t *testing.T, feedSourceWithBlocks blocksFeeder, e *httpexpect.Expect) {
+	feedSourceWithBlocks(permissionBlocks(t)...)
+
+	response := okQuery(e, "/v0/state/permission_history", "account=eosio1&permission=owner")
+
+	assertHeadBlockInfo(response, "00000005aa", "00000004aa")
+	jsonValueEqual(t, "changes", `[
+		{"block_num":5,"trx_id":"a3","permission":"owner","operation":"update","old_perm":{"owner":"eosio1","name":"owner","authority":{"keys":[{"public_key":"EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP","weight":1}]}},"new_perm":{"owner":"eosio1","name":"owner","authority":{"keys":[{"public_key":"EOS7Ne7WRKbUuFF8JpPbwAwCXVVnPfiLCEBNRfwV5iCL8gRh4FJGM","weight":1}]}}},
+		{"block_num":2,"trx_id":"a1","permission":"owner","operation":"insert","new_perm":{"owner":"eosio1","name":"owner","authority":{"keys":[{"public_key":"EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP","weight":1}]}}}
+	]`, response.Path("$.changes"))
+}
+
+func testPermissionHistoryHistoricalJSON(ctx context.Context, t *testing.T, feedSourceWithBlocks blocksFeeder, e *httpexpect.Expect) {
+	feedSourceWithBlocks(permissionBlocks(t)...)
+
+	response := okQuery(e, "/v0/state/permission_history", "account=eosio1&high_block_num=3&limit=1")
+
+	assertIrrBlockInfo(response, "00000004aa")
+	response.Path("$.changes").Array().Length().Equal(1)
+	response.Path("$.changes[0]").Object().ValueEqual("block_num", 3).ValueEqual("permission", "active").ValueEqual("operation", "remove")
+}
+
+func permissionBlocks(t *testing.T) []*pbcodec.Block {
+	ownerKey := ct.PublicKey("EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP")
+	rotatedKey := ct.PublicKey("EOS7Ne7WRKbUuFF8JpPbwAwCXVVnPfiLCEBNRfwV5iCL8gRh4FJGM")
+
+	return []*pbcodec.Block{
+		// Block #2 | Creates `owner` and `active` permissions of `eosio1`
+		ct.Block(t, "00000002aa",
+			ct.TrxTrace(t, ct.TrxID("a1"),
+				ct.PermOp(t, "INS", ct.NewPerm(ct.Permission(t, "eosio1@owner", ownerKey))),
+				ct.PermOp(t, "INS", ct.NewPerm(ct.Permission(t, "eosio1@active", ownerKey))),
+			),
+		),
+
+		// Block #3 | Deletes `active` permission of `eosio1`
+		ct.Block(t, "00000003aa",
+			ct.TrxTrace(t, ct.TrxID("a2"), ct.PermOp(t, "REM", ct.OldPerm(ct.Permission(t, "eosio1@active", ownerKey)))),
+		),
+
+		// Block #4
+		ct.Block(t, "00000004aa"),
+
+		// Block #5 | Rotates `owner` key of `eosio1`, this block will be in the reversible segment, i.e. in the speculative writes
+		ct.Block(t, "00000005aa",
+			ct.TrxTrace(t, ct.TrxID("a3"), ct.PermOp(t, "UPD",
+				ct.OldPerm(ct.Permission(t, "eosio1@owner", ownerKey)),
+				ct.NewPerm(ct.Permission(t, "eosio1@owner", rotatedKey)),
+			)),
+		),
+	}
+}
+
+func featureBlocks(t *testing.T) []*pbcodec.Block {
+	return []*pbcodec.Block{
+		// Block #2 | Activates `PREACTIVATE_FEATURE`
+		ct.Block(t, "00000002aa",
+			ct.TrxTrace(t, ct.TrxID("a1"), featureOp("ACTIVATE", "0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd", "PREACTIVATE_FEATURE")),
+		),
+
+		// Block #3 | Pre-activates `ONLY_BILL_FIRST_AUTHORIZER`
+		ct.Block(t, "00000003aa",
+			ct.TrxTrace(t, ct.TrxID("a2"), featureOp("PRE_ACTIVATE", "8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405", "ONLY_BILL_FIRST_AUTHORIZER")),
+		),
+
+		// Block #4
+		ct.Block(t, "00000004aa"),
+
+		// Block #5 | Activates `ONLY_BILL_FIRST_AUTHORIZER`, this block will be in the reversible segment, i.e. in the speculative writes
+		ct.Block(t, "00000005aa",
+			ct.TrxTrace(t, ct.TrxID("a3"), featureOp("ACTIVATE", "8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405", "ONLY_BILL_FIRST_AUTHORIZER")),
+		),
+	}
+}
+
+func featureOp(kind, digest, codename string) *pbcodec.FeatureOp {
+	return &pbcodec.FeatureOp{Kind: kind, FeatureDigest: digest, Feature: &pbcodec.Feature{
+		FeatureDigest: digest,
+		Specification: []*pbcodec.Specification{{Name: "builtin_feature_codename", Value: codename}},
+	}}
+}
+
 func tableBlocks(t *testing.T) []*pbcodec.Block {
 	eosioTokenABI1 := readABI(t, "eosio.token.1.abi.json")
 	eosioTestABI1 := readABI(t, "eosio.test.1.abi.json")
@@ -31,6 +31,7 @@ import (
 	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
 	"github.com/dfuse-io/dfuse-eosio/tokenmeta"
 	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/pricing"
 	"github.com/streamingfast/dgrpc"
 	"github.com/streamingfast/dstore"
 	pbblockmeta "github.com/streamingfast/pbgo/dfuse/blockmeta/v1"
@@ -51,6 +52,15 @@ type Config struct {
 	BlocksStoreURL       string        // GS path to read blocks archives
 	BootstrapBlockOffset uint64        // Block offset to ensure that we are not bootstrapping from StateDB on a reversible fork
 	ReadinessMaxLatency  time.Duration // we advertise as not-ready if the last processed block is older than this
+	PriceSources         []string      // DEX pool sources used to price tokens, as `<kind>@<contract>`, prices are disabled when empty
+	PriceReferenceToken  string        // Token in which prices are expressed by default and recorded in history, as `<contract>:<symbol>`
+	PriceCacheFile       string        // Path to GOB file containing the price sources pools and price history
+	PriceHistorySize     int           // Number of price changes kept in history for each token
+
+	HolderHistorySampleEveryNBlock uint64 // Record the holders count of every token each N blocks, 0 to disable
+	HolderHistorySize              int    // Number of holders count samples kept for each token, 0 for unbounded
+
+	TokenMappingsFile string // Path to YAML file declaring the table layout of non-standard token contracts
 }
 
 type Modules struct {
@@ -110,6 +120,21 @@ func (a *App) Run() error {
 		}
 	}
 
+	var mappings *tokenmeta.TokenMappings
+	if a.config.TokenMappingsFile != "" {
+		zlog.Info("loading token mappings", zap.String("filename", a.config.TokenMappingsFile))
+		mappings, err = tokenmeta.LoadTokenMappingsFromFile(a.config.TokenMappingsFile)
+		if err != nil {
+			return err
+		}
+
+		if err := tokenmeta.BootstrapMappedContracts(context.Background(), stateClient, tokenCache, mappings); err != nil {
+			return fmt.Errorf("bootstrap mapped token contracts: %w", err)
+		}
+	}
+
+	tokenCache.SetHolderHistorySampling(a.config.HolderHistorySampleEveryNBlock, a.config.HolderHistorySize)
+
 	zlog.Info("setting up blockstore")
 	blocksStore, err := dstore.NewDBinStore(a.config.BlocksStoreURL)
 	derr.Check("failed setting up blocks store", err)
@@ -126,12 +151,23 @@ func (a *App) Run() error {
 	zlog.Info("setting tokenmeta and pipeline")
 	tmeta := tokenmeta.NewTokenMeta(tokenCache, abiCodecCli, a.config.SaveEveryNBlock, stateClient, a.modules.BlockMeta)
 
+	tmeta.SetTokenMappings(mappings)
+
+	var prices *pricing.Hub
+	if len(a.config.PriceSources) > 0 {
+		prices, err = a.setupPriceHub(stateClient, tokenCache)
+		if err != nil {
+			return err
+		}
+		tmeta.SetPriceHub(prices)
+	}
+
 	tmeta.OnTerminated(a.Shutdown)
 	a.OnTerminating(tmeta.Shutdown)
 
 	tmeta.SetupPipeline(startBlock, a.modules.BlockFilter, a.config.BlockStreamAddr, blocksStore)
 
-	server := tokenmeta.NewServer(tokenCache, a.config.ReadinessMaxLatency)
+	server := tokenmeta.NewServer(tokenCache, prices, a.config.ReadinessMaxLatency)
 
 	server.OnTerminated(a.Shutdown)
 	a.OnTerminating(server.Shutdown)
@@ -190,6 +226,57 @@ func (a *App) createTokenMetaCacheFromAbi(stateClient pbstatedb.StateClient) (*c
 	return tokenCache, nil
 }
 
+func (a *App) setupPriceHub(stateClient pbstatedb.StateClient, tokenCache *cache.DefaultCache) (*pricing.Hub, error) {
+	sources, err := pricing.NewSourcesFromSpecs(a.config.PriceSources)
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := pricing.ParseTokenRef(a.config.PriceReferenceToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price reference token: %w", err)
+	}
+
+	startBlock := tokenCache.AtBlockRef()
+
+	var prices *pricing.Hub
+	if a.config.PriceCacheFile != "" {
+		mkdirCacheFileParents(a.config.PriceCacheFile)
+
+		zlog.Info("trying to load from price cache file", zap.String("filename", a.config.PriceCacheFile))
+		prices, err = pricing.LoadHubFromFile(a.config.PriceCacheFile, reference, a.config.PriceHistorySize, sources)
+		if err != nil && !isNotExits(err) {
+			zlog.Warn("cannot load from price cache file", zap.Error(err))
+		}
+
+		if prices != nil && prices.AtBlockRef().Num() == startBlock.Num() {
+			return prices, nil
+		}
+	}
+
+	if prices == nil {
+		prices = pricing.NewHub(reference, a.config.PriceHistorySize, a.config.PriceCacheFile, sources)
+	} else {
+		zlog.Info("price cache file is not at token cache block, bootstrapping its pools again",
+			zap.Uint64("price_cache_block_num", prices.AtBlockRef().Num()),
+			zap.Uint64("token_cache_block_num", startBlock.Num()),
+		)
+		prices.ResetPools()
+	}
+
+	if err := pricing.Bootstrap(context.Background(), stateClient, prices, startBlock, tokenCache.GetHeadBlockTime()); err != nil {
+		return nil, fmt.Errorf("bootstrap price sources: %w", err)
+	}
+
+	if a.config.PriceCacheFile != "" {
+		if err := prices.SaveToFile(); err != nil {
+			zlog.Error("cannot save price cache file", zap.Error(err), zap.String("filename", a.config.PriceCacheFile))
+		}
+	}
+
+	return prices, nil
+}
+
 var TokenmetaAppGeneratCacheFromAbiAborted = fmt.Errorf("getting abi cache file aborted by tokenmeta application")
 
 func (a *App) getAbiCacheFile(store dstore.Store, abiCacheFilename string) ([]byte, error) {
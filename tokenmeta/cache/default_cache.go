@@ -25,6 +25,11 @@ type DefaultCache struct {
 	cacheFilePath  string
 	EOSStake       map[eos.AccountName]*EOSStake `json:"eos_stake"`
 	HeadBlockTime  time.Time
+
+	// eosio.token:EOS -> [holders count every N blocks], see `SetHolderHistorySampling`
+	HolderSamples           map[string][]*HolderCountSample `json:"holder_samples"`
+	holderSampleEveryNBlock uint64
+	holderHistorySize       int
 }
 
 type Block struct {
@@ -434,5 +439,6 @@ func (c *DefaultCache) Apply(mutationsBatch *MutationsBatch, processedBlock bstr
 		Id:  processedBlock.ID(),
 		Num: processedBlock.Num(),
 	}
+	c.sampleHolders(processedBlock)
 	return
 }
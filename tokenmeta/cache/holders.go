@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/bstream"
+)
+
+type HolderCountSample struct {
+	BlockNum  uint64
+	BlockTime time.Time
+	Holders   uint64
+}
+
+// timedBlockRef is implemented by blocks knowing their timestamp, like `pbcodec.Block`
+type timedBlockRef interface {
+	Time() (time.Time, error)
+}
+
+// SetHolderHistorySampling records the holders count of every token each
+// `everyNBlock` applied blocks, keeping at most `historySize` samples per
+// token (0 meaning unbounded). Sampling is disabled when `everyNBlock` is 0.
+func (c *DefaultCache) SetHolderHistorySampling(everyNBlock uint64, historySize int) {
+	c.blocklevelLock.Lock()
+	defer c.blocklevelLock.Unlock()
+
+	c.holderSampleEveryNBlock = everyNBlock
+	c.holderHistorySize = historySize
+}
+
+func (c *DefaultCache) HolderCountHistory(contract eos.AccountName, symbol string, since time.Time) (out []*HolderCountSample) {
+	c.blocklevelLock.RLock()
+	defer c.blocklevelLock.RUnlock()
+
+	for _, sample := range c.HolderSamples[holderSamplesKey(contract, symbol)] {
+		if !sample.BlockTime.Before(since) {
+			out = append(out, sample)
+		}
+	}
+	return
+}
+
+// sampleHolders expects the block level lock to be held
+func (c *DefaultCache) sampleHolders(processedBlock bstream.BlockRef) {
+	if c.holderSampleEveryNBlock == 0 || processedBlock.Num()%c.holderSampleEveryNBlock != 0 {
+		return
+	}
+
+	var blockTime time.Time
+	if blk, ok := processedBlock.(timedBlockRef); ok {
+		blockTime, _ = blk.Time()
+	}
+
+	if c.HolderSamples == nil {
+		c.HolderSamples = map[string][]*HolderCountSample{}
+	}
+
+	for contract, tokens := range c.TokensInContract {
+		for _, token := range tokens {
+			key := holderSamplesKey(contract, token.Symbol)
+			samples := c.HolderSamples[key]
+
+			// A block can be applied in more than one batch, the last one wins
+			if len(samples) > 0 && samples[len(samples)-1].BlockNum == processedBlock.Num() {
+				samples = samples[:len(samples)-1]
+			}
+
+			samples = append(samples, &HolderCountSample{
+				BlockNum:  processedBlock.Num(),
+				BlockTime: blockTime,
+				Holders:   token.Holders,
+			})
+			if c.holderHistorySize > 0 && len(samples) > c.holderHistorySize {
+				samples = samples[len(samples)-c.holderHistorySize:]
+			}
+			c.HolderSamples[key] = samples
+		}
+	}
+}
+
+func holderSamplesKey(contract eos.AccountName, symbol string) string {
+	return string(contract) + ":" + symbol
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/bstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultCache_HolderCountHistory(t *testing.T) {
+	c := NewDefaultCache("")
+	c.SetHolderHistorySampling(2, 2)
+
+	muts := &MutationsBatch{}
+	muts.SetContract("eosio.token")
+	muts.SetToken(&pbtokenmeta.Token{Contract: "eosio.token", Symbol: "EOS", Precision: 4})
+	require.Len(t, c.Apply(muts, bstream.NewBlockRef("00000001a", 1)), 0)
+
+	for i, account := range []string{"eoscanadadad", "johndoemyhero"} {
+		muts := &MutationsBatch{}
+		muts.SetBalance(&pbtokenmeta.AccountBalance{TokenContract: "eosio.token", Symbol: "EOS", Precision: 4, Account: account, Amount: 100})
+		require.Len(t, c.Apply(muts, bstream.NewBlockRef("0000000xa", uint64(i+2))), 0)
+	}
+
+	samples := c.HolderCountHistory(eos.AccountName("eosio.token"), "EOS", time.Time{})
+	require.Len(t, samples, 1)
+	assert.Equal(t, uint64(2), samples[0].BlockNum)
+	assert.Equal(t, uint64(1), samples[0].Holders)
+
+	// Re-applying the same block replaces its sample
+	muts = &MutationsBatch{}
+	muts.SetBalance(&pbtokenmeta.AccountBalance{TokenContract: "eosio.token", Symbol: "EOS", Precision: 4, Account: "whaleaccount", Amount: 100})
+	require.Len(t, c.Apply(muts, bstream.NewBlockRef("00000004a", 4)), 0)
+	require.Len(t, c.Apply(&MutationsBatch{}, bstream.NewBlockRef("00000004a", 4)), 0)
+
+	muts = &MutationsBatch{}
+	muts.SetBalance(&pbtokenmeta.AccountBalance{TokenContract: "eosio.token", Symbol: "EOS", Precision: 4, Account: "anotherwhale", Amount: 100})
+	require.Len(t, c.Apply(muts, bstream.NewBlockRef("00000006a", 6)), 0)
+
+	samples = c.HolderCountHistory(eos.AccountName("eosio.token"), "EOS", time.Time{})
+	require.Len(t, samples, 2)
+	assert.Equal(t, uint64(4), samples[0].BlockNum)
+	assert.Equal(t, uint64(3), samples[0].Holders)
+	assert.Equal(t, uint64(6), samples[1].BlockNum)
+	assert.Equal(t, uint64(4), samples[1].Holders)
+
+	assert.Len(t, c.HolderCountHistory(eos.AccountName("eosio.token"), "WAX", time.Time{}), 0)
+}
@@ -19,6 +19,7 @@ type Cache interface {
 	AtBlockRef() bstream.BlockRef
 	SetHeadBlockTime(t time.Time)
 	GetHeadBlockTime() time.Time
+	HolderCountHistory(contract eos.AccountName, symbol string, since time.Time) []*HolderCountSample
 }
 
 const EOSTokenContract = eos.AccountName("eosio.token")
@@ -0,0 +1,174 @@
+package tokenmeta
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/eoscanada/eos-go"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/streamingfast/derr"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+const defaultTopHoldersCount = 10
+
+func (s *Server) GetHolderDistribution(ctx context.Context, in *pbtokenmeta.GetHolderDistributionRequest) (*pbtokenmeta.HolderDistributionResponse, error) {
+	zlog.Debug("get holder distribution",
+		zap.String("token_contract", in.TokenContract),
+		zap.String("token_symbol", in.TokenSymbol),
+		zap.Float64s("bucket_bounds", in.BucketBounds),
+		zap.Uint32("top_n", in.TopN),
+		zap.Any("options", in.Options),
+	)
+
+	token, err := s.lookupToken(in.TokenContract, in.TokenSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, bound := range in.BucketBounds {
+		if bound < 0 || (i > 0 && bound <= in.BucketBounds[i-1]) {
+			return nil, derr.Status(codes.InvalidArgument, "bucket bounds must be positive and strictly ascending")
+		}
+	}
+
+	options := []cache.TokenBalanceOption{}
+	if hasTokenOption(in.Options, pbtokenmeta.GetTokenBalancesRequest_EOS_INCLUDE_STAKED) {
+		options = append(options, cache.EOSIncludeStakedTokOpt)
+	}
+
+	assets := []*cache.OwnedAsset{}
+	for _, a := range s.cache.TokenBalances(eos.AccountName(in.TokenContract), options...) {
+		if a.Asset.Asset.Symbol.Symbol == in.TokenSymbol {
+			assets = append(assets, a)
+		}
+	}
+
+	topN := int(in.TopN)
+	if topN == 0 {
+		topN = defaultTopHoldersCount
+	}
+
+	blockRef := s.cache.AtBlockRef()
+	out := holderDistribution(assets, token.Precision, in.BucketBounds, topN)
+	out.TokenContract = token.Contract
+	out.TokenSymbol = token.Symbol
+	out.Precision = token.Precision
+	out.AtBlockNum = blockRef.Num()
+	out.AtBlockId = blockRef.ID()
+
+	return out, nil
+}
+
+func (s *Server) GetHolderCountHistory(ctx context.Context, in *pbtokenmeta.GetHolderCountHistoryRequest) (*pbtokenmeta.HolderCountHistoryResponse, error) {
+	zlog.Debug("get holder count history",
+		zap.String("token_contract", in.TokenContract),
+		zap.String("token_symbol", in.TokenSymbol),
+	)
+
+	if _, err := s.lookupToken(in.TokenContract, in.TokenSymbol); err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if in.Since != nil {
+		var err error
+		if since, err = ptypes.Timestamp(in.Since); err != nil {
+			return nil, derr.Statusf(codes.InvalidArgument, "invalid since: %s", err)
+		}
+	}
+
+	blockRef := s.cache.AtBlockRef()
+	out := &pbtokenmeta.HolderCountHistoryResponse{
+		Samples:    []*pbtokenmeta.HolderCountSample{},
+		AtBlockNum: blockRef.Num(),
+		AtBlockId:  blockRef.ID(),
+	}
+
+	for _, sample := range s.cache.HolderCountHistory(eos.AccountName(in.TokenContract), in.TokenSymbol, since) {
+		out.Samples = append(out.Samples, &pbtokenmeta.HolderCountSample{
+			BlockNum:  sample.BlockNum,
+			BlockTime: mustProtoTimestamp(sample.BlockTime),
+			Holders:   sample.Holders,
+		})
+	}
+
+	return out, nil
+}
+
+func (s *Server) lookupToken(contract, symbol string) (*pbtokenmeta.Token, error) {
+	if contract == "" || symbol == "" {
+		return nil, derr.Status(codes.InvalidArgument, "both token contract and symbol are required")
+	}
+
+	symbolCode, err := eos.StringToSymbolCode(symbol)
+	if err != nil {
+		return nil, derr.Statusf(codes.InvalidArgument, "invalid token symbol %q: %s", symbol, err)
+	}
+
+	token := s.cache.TokenContract(eos.AccountName(contract), symbolCode)
+	if token == nil {
+		return nil, derr.Statusf(codes.NotFound, "token %s:%s not found", contract, symbol)
+	}
+
+	return token, nil
+}
+
+// holderDistribution buckets the holders' balances by the lower `bounds`,
+// expressed in token units, and computes the share held by the `topN`
+// largest holders. Powers of ten, up to the largest balance, are used when
+// no bounds are given. Balances below the first bound fall in no bucket.
+func holderDistribution(assets []*cache.OwnedAsset, precision uint32, bounds []float64, topN int) *pbtokenmeta.HolderDistributionResponse {
+	assets = cache.SortOwnedAssetByTokenAmount(assets, cache.DESC)
+
+	unit := math.Pow10(int(precision))
+	if len(bounds) == 0 {
+		bounds = []float64{0}
+		if len(assets) > 0 {
+			maxBalance := float64(assets[0].Asset.Asset.Amount) / unit
+			for bound := 1.0; bound <= maxBalance; bound *= 10 {
+				bounds = append(bounds, bound)
+			}
+		}
+	}
+
+	out := &pbtokenmeta.HolderDistributionResponse{
+		Holders:    uint64(len(assets)),
+		Buckets:    make([]*pbtokenmeta.HolderBucket, len(bounds)),
+		TopHolders: []*pbtokenmeta.AccountBalance{},
+	}
+	for i, bound := range bounds {
+		out.Buckets[i] = &pbtokenmeta.HolderBucket{MinBalance: bound}
+		if i+1 < len(bounds) {
+			out.Buckets[i].MaxBalance = bounds[i+1]
+		}
+	}
+
+	for i, a := range assets {
+		amount := uint64(a.Asset.Asset.Amount)
+		out.TotalAmount += amount
+
+		if i < topN {
+			out.TopHolders = append(out.TopHolders, cache.AssetToProtoAccountBalance(a))
+			out.TopHoldersAmount += amount
+		}
+
+		// Index of the first bound above the balance, the bucket is the one before it
+		index := sort.Search(len(bounds), func(j int) bool { return bounds[j] > float64(amount)/unit })
+		if index > 0 {
+			out.Buckets[index-1].Holders++
+			out.Buckets[index-1].Amount += amount
+		}
+	}
+
+	if out.TotalAmount > 0 {
+		out.TopHoldersShare = float64(out.TopHoldersAmount) / float64(out.TotalAmount)
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+package tokenmeta
+
+import (
+	"testing"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/eoscanada/eos-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_holderDistribution(t *testing.T) {
+	asset := func(owner string, amount eos.Int64) *cache.OwnedAsset {
+		return &cache.OwnedAsset{
+			Owner: eos.AccountName(owner),
+			Asset: &eos.ExtendedAsset{Asset: generateTestAsset(amount, "EOS"), Contract: "eosio.token"},
+		}
+	}
+
+	assets := []*cache.OwnedAsset{
+		asset("eoscanadadad", 5000),
+		asset("johndoemyhero", 2500000),
+		asset("ultrameeting", 100),
+		asset("whaleaccount", 7500000),
+	}
+
+	t.Run("default bounds", func(t *testing.T) {
+		out := holderDistribution(assets, 4, nil, 2)
+
+		assert.Equal(t, uint64(4), out.Holders)
+		assert.Equal(t, uint64(10005100), out.TotalAmount)
+		assert.Equal(t, []*pbtokenmeta.HolderBucket{
+			{MinBalance: 0, MaxBalance: 1, Holders: 2, Amount: 5100},
+			{MinBalance: 1, MaxBalance: 10, Holders: 0, Amount: 0},
+			{MinBalance: 10, MaxBalance: 100, Holders: 0, Amount: 0},
+			{MinBalance: 100, MaxBalance: 0, Holders: 2, Amount: 10000000},
+		}, out.Buckets)
+
+		require.Len(t, out.TopHolders, 2)
+		assert.Equal(t, "whaleaccount", out.TopHolders[0].Account)
+		assert.Equal(t, "johndoemyhero", out.TopHolders[1].Account)
+		assert.Equal(t, uint64(10000000), out.TopHoldersAmount)
+		assert.InDelta(t, 0.99949, out.TopHoldersShare, 0.00001)
+	})
+
+	t.Run("custom bounds", func(t *testing.T) {
+		out := holderDistribution(assets, 4, []float64{0.1, 500}, 10)
+
+		assert.Equal(t, []*pbtokenmeta.HolderBucket{
+			{MinBalance: 0.1, MaxBalance: 500, Holders: 2, Amount: 2505000},
+			{MinBalance: 500, MaxBalance: 0, Holders: 1, Amount: 7500000},
+		}, out.Buckets)
+		assert.Len(t, out.TopHolders, 4)
+		assert.Equal(t, 1.0, out.TopHoldersShare)
+	})
+
+	t.Run("no holders", func(t *testing.T) {
+		out := holderDistribution(nil, 4, nil, 10)
+
+		assert.Equal(t, uint64(0), out.Holders)
+		assert.Equal(t, []*pbtokenmeta.HolderBucket{{MinBalance: 0}}, out.Buckets)
+		assert.Equal(t, 0.0, out.TopHoldersShare)
+	})
+}
@@ -0,0 +1,533 @@
+package tokenmeta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/bstream"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+// TokenMappings declares how to read the tokens of contracts whose tables
+// do not follow the `eosio.token` layout (`accounts` and `stat` tables).
+// Mapped contracts are tracked exclusively through their mapping.
+//
+//     contracts:
+//     - contract: vestingtoken
+//       balances:
+//         table: vestings
+//         owner_field: owner
+//         asset_field: locked.quantity
+//       supply:
+//         table: stat
+//     - contract: stakingtokn1
+//       balances:
+//         table: stakes
+//         owner_from: primary_key
+//         asset_field: staked
+//       tokens:
+//       - symbol: 4,STAKE
+//         issuer: stakingtokn1
+//
+// The balance of an owner is the sum of all its rows of the same token.
+type TokenMappings struct {
+	Contracts []*ContractMapping `yaml:"contracts"`
+
+	byContract map[string]*ContractMapping
+}
+
+type ContractMapping struct {
+	Contract string           `yaml:"contract"`
+	Balances *BalancesMapping `yaml:"balances"`
+
+	// Supply and Tokens declare the contract's tokens, at least one of them is required
+	Supply *SupplyMapping        `yaml:"supply"`
+	Tokens []*StaticTokenMapping `yaml:"tokens"`
+
+	staticTokens []*pbtokenmeta.Token
+}
+
+const (
+	ownerFromScope      = "scope"
+	ownerFromPrimaryKey = "primary_key"
+)
+
+// BalancesMapping declares the table holding the balances, an owner may
+// have many rows of the same token, they are summed. Fields are gjson paths
+// within the ABI decoded row.
+type BalancesMapping struct {
+	Table string `yaml:"table"`
+
+	// OwnerField holds the owner account, the owner is read from `OwnerFrom` when empty
+	OwnerField string `yaml:"owner_field"`
+
+	// OwnerFrom is where the owner account is read when there is no owner
+	// field, `scope` (the default) or `primary_key` (a name)
+	OwnerFrom string `yaml:"owner_from"`
+
+	// AssetField holds the balance, defaults to `balance`
+	AssetField string `yaml:"asset_field"`
+}
+
+// SupplyMapping declares the table holding the supply of each token, one
+// row per token. Fields are gjson paths within the ABI decoded row.
+type SupplyMapping struct {
+	Table string `yaml:"table"`
+
+	// SupplyField holds the current supply, defaults to `supply`
+	SupplyField string `yaml:"supply_field"`
+
+	// MaxSupplyField holds the maximum supply, defaults to `max_supply`, ignored when absent from the row
+	MaxSupplyField string `yaml:"max_supply_field"`
+
+	// IssuerField holds the issuer, defaults to `issuer`, ignored when absent from the row
+	IssuerField string `yaml:"issuer_field"`
+}
+
+// StaticTokenMapping declares a token of a contract without supply table
+type StaticTokenMapping struct {
+	// Symbol with its precision, like `4,EOS`
+	Symbol    string `yaml:"symbol"`
+	Issuer    string `yaml:"issuer"`
+	MaxSupply string `yaml:"max_supply"`
+}
+
+func LoadTokenMappingsFromFile(filename string) (*TokenMappings, error) {
+	cnt, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read token mappings file: %w", err)
+	}
+
+	mappings, err := ParseTokenMappings(cnt)
+	if err != nil {
+		return nil, fmt.Errorf("token mappings file %q: %w", filename, err)
+	}
+	return mappings, nil
+}
+
+func ParseTokenMappings(cnt []byte) (*TokenMappings, error) {
+	mappings := &TokenMappings{}
+	if err := yaml.UnmarshalStrict(cnt, mappings); err != nil {
+		return nil, fmt.Errorf("unable to decode: %w", err)
+	}
+
+	mappings.byContract = map[string]*ContractMapping{}
+	for i, mapping := range mappings.Contracts {
+		if err := mapping.setup(); err != nil {
+			return nil, fmt.Errorf("contract mapping #%d: %w", i, err)
+		}
+
+		if _, found := mappings.byContract[mapping.Contract]; found {
+			return nil, fmt.Errorf("contract %q is mapped more than once", mapping.Contract)
+		}
+		mappings.byContract[mapping.Contract] = mapping
+	}
+
+	return mappings, nil
+}
+
+// Contract returns the mapping of `contract`, `nil` when it is not mapped
+func (m *TokenMappings) Contract(contract string) *ContractMapping {
+	if m == nil {
+		return nil
+	}
+	return m.byContract[contract]
+}
+
+func (c *ContractMapping) setup() error {
+	if _, err := eos.StringToName(c.Contract); err != nil || c.Contract == "" {
+		return fmt.Errorf("invalid contract %q", c.Contract)
+	}
+
+	if c.Balances == nil || c.Balances.Table == "" {
+		return fmt.Errorf("contract %q: balances table is required", c.Contract)
+	}
+	if c.Balances.AssetField == "" {
+		c.Balances.AssetField = "balance"
+	}
+	switch c.Balances.OwnerFrom {
+	case "":
+		c.Balances.OwnerFrom = ownerFromScope
+	case ownerFromScope, ownerFromPrimaryKey:
+	default:
+		return fmt.Errorf("contract %q: invalid balances owner_from %q, expecting %q or %q", c.Contract, c.Balances.OwnerFrom, ownerFromScope, ownerFromPrimaryKey)
+	}
+	if c.Balances.OwnerField != "" && c.Balances.OwnerFrom != ownerFromScope {
+		return fmt.Errorf("contract %q: balances owner_field and owner_from cannot be both set", c.Contract)
+	}
+
+	if c.Supply == nil && len(c.Tokens) == 0 {
+		return fmt.Errorf("contract %q: either a supply table or tokens are required", c.Contract)
+	}
+
+	if c.Supply != nil {
+		if c.Supply.Table == "" {
+			return fmt.Errorf("contract %q: supply table is required", c.Contract)
+		}
+		if c.Supply.Table == c.Balances.Table {
+			return fmt.Errorf("contract %q: supply and balances cannot be read from the same table", c.Contract)
+		}
+		if c.Supply.SupplyField == "" {
+			c.Supply.SupplyField = "supply"
+		}
+		if c.Supply.MaxSupplyField == "" {
+			c.Supply.MaxSupplyField = "max_supply"
+		}
+		if c.Supply.IssuerField == "" {
+			c.Supply.IssuerField = "issuer"
+		}
+	}
+
+	for _, token := range c.Tokens {
+		symbol, err := eos.StringToSymbol(token.Symbol)
+		if err != nil {
+			return fmt.Errorf("contract %q: invalid token symbol %q: %w", c.Contract, token.Symbol, err)
+		}
+
+		out := &pbtokenmeta.Token{
+			Contract:  c.Contract,
+			Symbol:    symbol.Symbol,
+			Precision: uint32(symbol.Precision),
+			Issuer:    token.Issuer,
+		}
+
+		if token.MaxSupply != "" {
+			maxSupply, err := eos.NewAssetFromString(token.MaxSupply)
+			if err != nil {
+				return fmt.Errorf("contract %q: invalid token max supply %q: %w", c.Contract, token.MaxSupply, err)
+			}
+			out.MaximumSupply = uint64(maxSupply.Amount)
+		}
+
+		c.staticTokens = append(c.staticTokens, out)
+	}
+
+	return nil
+}
+
+// balanceFromRow returns the part of the owner's balance held by the row
+func (c *ContractMapping) balanceFromRow(scope, primaryKey string, row json.RawMessage) (*pbtokenmeta.AccountBalance, error) {
+	owner := scope
+	if c.Balances.OwnerFrom == ownerFromPrimaryKey {
+		owner = primaryKey
+	}
+
+	if c.Balances.OwnerField != "" {
+		owner = gjson.GetBytes(row, c.Balances.OwnerField).String()
+		if owner == "" {
+			return nil, fmt.Errorf("owner field %q not found in row: %s", c.Balances.OwnerField, string(row))
+		}
+	}
+
+	asset, err := assetFromRow(row, c.Balances.AssetField)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbtokenmeta.AccountBalance{
+		TokenContract: c.Contract,
+		Account:       owner,
+		Amount:        uint64(asset.Amount),
+		Precision:     uint32(asset.Precision),
+		Symbol:        asset.Symbol.Symbol,
+	}, nil
+}
+
+func (c *ContractMapping) tokenFromRow(row json.RawMessage) (*pbtokenmeta.Token, error) {
+	supply, err := assetFromRow(row, c.Supply.SupplyField)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &pbtokenmeta.Token{
+		Contract:    c.Contract,
+		Symbol:      supply.Symbol.Symbol,
+		Precision:   uint32(supply.Precision),
+		Issuer:      gjson.GetBytes(row, c.Supply.IssuerField).String(),
+		TotalSupply: uint64(supply.Amount),
+	}
+
+	if gjson.GetBytes(row, c.Supply.MaxSupplyField).Exists() {
+		maxSupply, err := assetFromRow(row, c.Supply.MaxSupplyField)
+		if err != nil {
+			return nil, err
+		}
+		token.MaximumSupply = uint64(maxSupply.Amount)
+	}
+
+	return token, nil
+}
+
+func assetFromRow(row json.RawMessage, field string) (eos.Asset, error) {
+	value := gjson.GetBytes(row, field)
+	if value.Type != gjson.String {
+		return eos.Asset{}, fmt.Errorf("asset field %q not found in row: %s", field, string(row))
+	}
+
+	asset, err := eos.NewAssetFromString(value.String())
+	if err != nil {
+		return eos.Asset{}, fmt.Errorf("invalid asset field %q: %w", field, err)
+	}
+	return asset, nil
+}
+
+// processMappedDBOp turns a db operation on one of the tables of a mapped contract into mutations
+func (t *TokenMeta) processMappedDBOp(mapping *ContractMapping, dbop *pbcodec.DBOp, blockNum uint32, muts *cache.MutationsBatch, zlogger *zap.Logger) {
+	isBalance := dbop.TableName == mapping.Balances.Table
+	isSupply := mapping.Supply != nil && dbop.TableName == mapping.Supply.Table
+	if !isBalance && !isSupply {
+		return
+	}
+
+	decodeRow := func(data []byte) json.RawMessage {
+		row, err := t.decodeDBOpToRow(data, eos.TableName(dbop.TableName), eos.AccountName(dbop.Code), blockNum)
+		if err != nil {
+			zlogger.Error("cannot decode mapped table row",
+				zap.String("contract", dbop.Code),
+				zap.String("table_name", dbop.TableName),
+				zap.Error(err))
+			return nil
+		}
+		return row
+	}
+
+	if isSupply {
+		if dbop.NewData == nil {
+			// tokens are never removed from the cache
+			return
+		}
+
+		row := decodeRow(dbop.NewData)
+		if row == nil {
+			return
+		}
+
+		token, err := mapping.tokenFromRow(row)
+		if err != nil {
+			zlogger.Warn("could not create token from mapped dbop row",
+				zap.String("contract", dbop.Code),
+				zap.String("table_name", dbop.TableName),
+				zap.Error(err))
+			return
+		}
+		muts.SetToken(token)
+		return
+	}
+
+	// The old row is taken out of its owner's balance and the new one added to
+	// its owner's balance, both rows possibly belonging to different owners
+	rowBalance := func(data []byte) *pbtokenmeta.AccountBalance {
+		if data == nil {
+			return nil
+		}
+
+		row := decodeRow(data)
+		if row == nil {
+			return nil
+		}
+
+		balance, err := mapping.balanceFromRow(dbop.Scope, dbop.PrimaryKey, row)
+		if err != nil {
+			zlogger.Warn("could not create account balance from mapped dbop row",
+				zap.String("contract", dbop.Code),
+				zap.String("table_name", dbop.TableName),
+				zap.String("scope", dbop.Scope),
+				zap.Error(err))
+			return nil
+		}
+		return balance
+	}
+
+	if oldBalance := rowBalance(dbop.OldData); oldBalance != nil {
+		applyMappedBalanceDelta(t.cache, muts, oldBalance, false, zlogger)
+	}
+	if newBalance := rowBalance(dbop.NewData); newBalance != nil {
+		applyMappedBalanceDelta(t.cache, muts, newBalance, true, zlogger)
+	}
+}
+
+// applyMappedBalanceDelta adds (or subtracts) the amount of the row balance `delta` to the
+// owner's balance, as known from the mutations of the block, or from the cache otherwise.
+func applyMappedBalanceDelta(tokenCache cache.Cache, muts *cache.MutationsBatch, delta *pbtokenmeta.AccountBalance, add bool, zlogger *zap.Logger) {
+	current := currentMappedBalance(tokenCache, muts, delta)
+	amount := current
+	if add {
+		amount += delta.Amount
+	} else if amount >= delta.Amount {
+		amount -= delta.Amount
+	} else {
+		zlogger.Warn("mapped balance row removal exceeds the known balance, resetting it",
+			zap.String("contract", delta.TokenContract),
+			zap.String("account", delta.Account),
+			zap.String("symbol", delta.Symbol),
+			zap.Uint64("balance", amount),
+			zap.Uint64("row_amount", delta.Amount))
+		amount = 0
+	}
+
+	balance := &pbtokenmeta.AccountBalance{
+		TokenContract: delta.TokenContract,
+		Account:       delta.Account,
+		Amount:        amount,
+		Precision:     delta.Precision,
+		Symbol:        delta.Symbol,
+	}
+
+	if amount == 0 {
+		if current != 0 {
+			muts.RemoveBalance(balance)
+		}
+	} else {
+		muts.SetBalance(balance)
+	}
+}
+
+// currentMappedBalance returns the amount of the owner's balance of the token of `balance`,
+// the last mutation of the block on it winning over the cache.
+func currentMappedBalance(tokenCache cache.Cache, muts *cache.MutationsBatch, balance *pbtokenmeta.AccountBalance) uint64 {
+	mutations := muts.Mutations()
+	for i := len(mutations) - 1; i >= 0; i-- {
+		mut := mutations[i]
+		if mut.Type != cache.SetBalanceMutation && mut.Type != cache.RemoveBalanceMutation {
+			continue
+		}
+
+		other, ok := mut.Args[0].(*pbtokenmeta.AccountBalance)
+		if !ok || other.TokenContract != balance.TokenContract || other.Account != balance.Account || other.Symbol != balance.Symbol {
+			continue
+		}
+
+		if mut.Type == cache.RemoveBalanceMutation {
+			return 0
+		}
+		return other.Amount
+	}
+
+	for _, asset := range tokenCache.AccountBalances(eos.AccountName(balance.Account)) {
+		if string(asset.Asset.Contract) == balance.TokenContract && asset.Asset.Asset.Symbol.Symbol == balance.Symbol {
+			return uint64(asset.Asset.Asset.Amount)
+		}
+	}
+
+	return 0
+}
+
+// BootstrapMappedContracts loads from statedb, at the cache's block, the
+// tokens and balances of the mapped contracts the cache does not know yet.
+func BootstrapMappedContracts(ctx context.Context, stateClient pbstatedb.StateClient, tokenCache cache.Cache, mappings *TokenMappings) error {
+	blockRef := tokenCache.AtBlockRef()
+
+	for _, mapping := range mappings.Contracts {
+		contract := eos.AccountName(mapping.Contract)
+		if tokenCache.IsTokenContract(contract) {
+			continue
+		}
+
+		zlog.Info("bootstrapping mapped token contract from statedb",
+			zap.String("contract", mapping.Contract),
+			zap.Uint64("block_num", blockRef.Num()),
+		)
+
+		muts := &cache.MutationsBatch{}
+		muts.SetContract(contract)
+
+		for _, token := range mapping.staticTokens {
+			muts.SetToken(token)
+		}
+
+		if mapping.Supply != nil {
+			err := forEachMappedTableRow(ctx, stateClient, blockRef.Num(), mapping.Contract, mapping.Supply.Table, func(scope, primaryKey string, row json.RawMessage) {
+				token, err := mapping.tokenFromRow(row)
+				if err != nil {
+					zlog.Warn("skipping invalid mapped supply row", zap.String("contract", mapping.Contract), zap.String("scope", scope), zap.Error(err))
+					return
+				}
+				muts.SetToken(token)
+			})
+			if err != nil {
+				return fmt.Errorf("contract %q supply: %w", mapping.Contract, err)
+			}
+		}
+
+		var balances []*pbtokenmeta.AccountBalance
+		balanceByOwnerSymbol := map[string]*pbtokenmeta.AccountBalance{}
+		err := forEachMappedTableRow(ctx, stateClient, blockRef.Num(), mapping.Contract, mapping.Balances.Table, func(scope, primaryKey string, row json.RawMessage) {
+			balance, err := mapping.balanceFromRow(scope, primaryKey, row)
+			if err != nil {
+				zlog.Warn("skipping invalid mapped balance row", zap.String("contract", mapping.Contract), zap.String("scope", scope), zap.Error(err))
+				return
+			}
+
+			key := balance.Account + ":" + balance.Symbol
+			if existing, found := balanceByOwnerSymbol[key]; found {
+				existing.Amount += balance.Amount
+				return
+			}
+
+			balanceByOwnerSymbol[key] = balance
+			balances = append(balances, balance)
+		})
+		if err != nil {
+			return fmt.Errorf("contract %q balances: %w", mapping.Contract, err)
+		}
+
+		balanceCount := 0
+		for _, balance := range balances {
+			if balance.Amount == 0 {
+				continue
+			}
+
+			muts.SetBalance(balance)
+			balanceCount++
+		}
+
+		if errs := tokenCache.Apply(muts, bstream.NewBlockRef(blockRef.ID(), blockRef.Num())); len(errs) != 0 {
+			zlog.Warn("errors applying mapped token contract",
+				zap.String("contract", mapping.Contract),
+				zap.Errors("errors", errs),
+			)
+		}
+
+		zlog.Info("bootstrapped mapped token contract", zap.String("contract", mapping.Contract), zap.Int("balance_count", balanceCount))
+	}
+
+	return nil
+}
+
+func forEachMappedTableRow(ctx context.Context, stateClient pbstatedb.StateClient, blockNum uint64, contract, table string, onRow func(scope, primaryKey string, row json.RawMessage)) error {
+	scopes, err := pbstatedb.FetchTableScopes(ctx, stateClient, blockNum, contract, table)
+	if err != nil {
+		return fmt.Errorf("statedb reading scopes list: %w", err)
+	}
+
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	_, err = pbstatedb.ForEachMultiScopesTableRows(ctx, stateClient, &pbstatedb.StreamMultiScopesTableRowsRequest{
+		BlockNum: blockNum,
+		Contract: contract,
+		Table:    table,
+		Scopes:   scopes,
+		KeyType:  "name",
+		ToJson:   true,
+	}, func(scope string, response *pbstatedb.TableRowResponse) error {
+		if response.Json == "" {
+			zlog.Warn("skipping mapped table row that could not be decoded", zap.String("contract", contract), zap.String("table", table), zap.String("scope", scope))
+			return nil
+		}
+
+		onRow(scope, response.Key, json.RawMessage(response.Json))
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,152 @@
+package tokenmeta
+
+import (
+	"encoding/json"
+	"testing"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/bstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenMappings = `
+contracts:
+- contract: vestingtoken
+  balances:
+    table: vestings
+    owner_field: owner
+    asset_field: locked.quantity
+  supply:
+    table: stat
+- contract: stakingtokn1
+  balances:
+    table: stakes
+    owner_from: primary_key
+    asset_field: staked
+  tokens:
+  - symbol: 4,STAKE
+    issuer: stakingtokn1
+    max_supply: 1000000.0000 STAKE
+`
+
+func TestParseTokenMappings(t *testing.T) {
+	mappings, err := ParseTokenMappings([]byte(testTokenMappings))
+	require.NoError(t, err)
+
+	assert.Nil(t, mappings.Contract("eosio.token"))
+
+	vesting := mappings.Contract("vestingtoken")
+	require.NotNil(t, vesting)
+	assert.Equal(t, "supply", vesting.Supply.SupplyField)
+	assert.Equal(t, "max_supply", vesting.Supply.MaxSupplyField)
+	assert.Equal(t, "issuer", vesting.Supply.IssuerField)
+
+	staking := mappings.Contract("stakingtokn1")
+	require.NotNil(t, staking)
+	assert.Equal(t, []*pbtokenmeta.Token{
+		{Contract: "stakingtokn1", Symbol: "STAKE", Precision: 4, Issuer: "stakingtokn1", MaximumSupply: 10000000000},
+	}, staking.staticTokens)
+
+	var nilMappings *TokenMappings
+	assert.Nil(t, nilMappings.Contract("vestingtoken"))
+}
+
+func TestParseTokenMappings_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings string
+	}{
+		{"unknown field", "contracts:\n- contract: vestingtoken\n  balance:\n    table: vestings\n"},
+		{"missing balances", "contracts:\n- contract: vestingtoken\n  supply:\n    table: stat\n"},
+		{"missing supply", "contracts:\n- contract: vestingtoken\n  balances:\n    table: vestings\n"},
+		{"invalid owner from", "contracts:\n- contract: vestingtoken\n  balances:\n    table: vestings\n    owner_from: payer\n  supply:\n    table: stat\n"},
+		{"owner field and owner from", "contracts:\n- contract: vestingtoken\n  balances:\n    table: vestings\n    owner_field: owner\n    owner_from: primary_key\n  supply:\n    table: stat\n"},
+		{"same tables", "contracts:\n- contract: vestingtoken\n  balances:\n    table: stat\n  supply:\n    table: stat\n"},
+		{"invalid symbol", "contracts:\n- contract: vestingtoken\n  balances:\n    table: vestings\n  tokens:\n  - symbol: STAKE\n"},
+		{"duplicated contract", "contracts:\n- contract: vestingtoken\n  balances:\n    table: vestings\n  supply:\n    table: stat\n- contract: vestingtoken\n  balances:\n    table: vestings\n  supply:\n    table: stat\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseTokenMappings([]byte(test.mappings))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestContractMapping_FromRow(t *testing.T) {
+	mappings, err := ParseTokenMappings([]byte(testTokenMappings))
+	require.NoError(t, err)
+
+	vesting := mappings.Contract("vestingtoken")
+	balance, err := vesting.balanceFromRow("vestingtoken", "1", json.RawMessage(`{"id": 1, "owner": "eoscanadadad", "locked": {"quantity": "12.3400 VEST", "contract": "vestingtoken"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &pbtokenmeta.AccountBalance{TokenContract: "vestingtoken", Account: "eoscanadadad", Amount: 123400, Precision: 4, Symbol: "VEST"}, balance)
+
+	_, err = vesting.balanceFromRow("vestingtoken", "1", json.RawMessage(`{"id": 1, "locked": {"quantity": "12.3400 VEST"}}`))
+	assert.Error(t, err)
+
+	token, err := vesting.tokenFromRow(json.RawMessage(`{"supply": "100.0000 VEST", "max_supply": "1000.0000 VEST", "issuer": "vestingtoken"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &pbtokenmeta.Token{Contract: "vestingtoken", Symbol: "VEST", Precision: 4, Issuer: "vestingtoken", TotalSupply: 1000000, MaximumSupply: 10000000}, token)
+
+	staking := mappings.Contract("stakingtokn1")
+	balance, err = staking.balanceFromRow("stakingtokn1", "johndoemyhero", json.RawMessage(`{"staked": "1.0000 STAKE"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "johndoemyhero", balance.Account)
+	assert.Equal(t, uint64(10000), balance.Amount)
+
+	_, err = staking.balanceFromRow("stakingtokn1", "johndoemyhero", json.RawMessage(`{"staked": 10000}`))
+	assert.Error(t, err)
+}
+
+func TestApplyMappedBalanceDelta(t *testing.T) {
+	vest := &pbtokenmeta.Token{Contract: "vestingtoken", Symbol: "VEST", Precision: 4}
+	tokenCache := cache.NewDefaultCacheWithData(
+		[]*pbtokenmeta.Token{vest},
+		[]*pbtokenmeta.AccountBalance{{TokenContract: "vestingtoken", Account: "eoscanadadad", Amount: 100, Precision: 4, Symbol: "VEST"}},
+		nil,
+		bstream.NewBlockRef("00000001a", 1),
+		"",
+	)
+
+	row := func(account string, amount uint64) *pbtokenmeta.AccountBalance {
+		return &pbtokenmeta.AccountBalance{TokenContract: "vestingtoken", Account: account, Amount: amount, Precision: 4, Symbol: "VEST"}
+	}
+
+	balanceOf := func(account string) uint64 {
+		for _, asset := range tokenCache.AccountBalances(eos.AccountName(account)) {
+			return uint64(asset.Asset.Asset.Amount)
+		}
+		return 0
+	}
+
+	// Rows of the same owner are summed, within the block and with the cached balance
+	muts := &cache.MutationsBatch{}
+	applyMappedBalanceDelta(tokenCache, muts, row("eoscanadadad", 20), true, zlog)
+	applyMappedBalanceDelta(tokenCache, muts, row("eoscanadadad", 5), true, zlog)
+	applyMappedBalanceDelta(tokenCache, muts, row("johndoemyhero", 7), true, zlog)
+	require.Empty(t, tokenCache.Apply(muts, bstream.NewBlockRef("00000002a", 2)))
+	assert.Equal(t, uint64(125), balanceOf("eoscanadadad"))
+	assert.Equal(t, uint64(7), balanceOf("johndoemyhero"))
+
+	// Removing a row only takes its amount out, an update moving a row to another owner
+	muts = &cache.MutationsBatch{}
+	applyMappedBalanceDelta(tokenCache, muts, row("eoscanadadad", 20), false, zlog)
+	applyMappedBalanceDelta(tokenCache, muts, row("eoscanadadad", 5), false, zlog)
+	applyMappedBalanceDelta(tokenCache, muts, row("johndoemyhero", 5), true, zlog)
+	require.Empty(t, tokenCache.Apply(muts, bstream.NewBlockRef("00000003a", 3)))
+	assert.Equal(t, uint64(100), balanceOf("eoscanadadad"))
+	assert.Equal(t, uint64(12), balanceOf("johndoemyhero"))
+
+	// Removing the last rows removes the balance
+	muts = &cache.MutationsBatch{}
+	applyMappedBalanceDelta(tokenCache, muts, row("johndoemyhero", 7), false, zlog)
+	applyMappedBalanceDelta(tokenCache, muts, row("johndoemyhero", 5), false, zlog)
+	applyMappedBalanceDelta(tokenCache, muts, row("johndoemyhero", 0), false, zlog)
+	require.Empty(t, tokenCache.Apply(muts, bstream.NewBlockRef("00000004a", 4)))
+	assert.Empty(t, tokenCache.AccountBalances("johndoemyhero"))
+}
@@ -0,0 +1,156 @@
+package tokenmeta
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/pricing"
+	"github.com/eoscanada/eos-go"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/streamingfast/derr"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *Server) GetTokenPrice(ctx context.Context, in *pbtokenmeta.GetTokenPriceRequest) (*pbtokenmeta.TokenPriceResponse, error) {
+	zlog.Debug("get token price",
+		zap.String("token_contract", in.TokenContract),
+		zap.String("token_symbol", in.TokenSymbol),
+		zap.String("reference_contract", in.ReferenceContract),
+		zap.String("reference_symbol", in.ReferenceSymbol),
+	)
+
+	if s.prices == nil {
+		return nil, derr.Status(codes.Unavailable, "token prices are not enabled on this instance")
+	}
+
+	if in.TokenContract == "" || in.TokenSymbol == "" {
+		return nil, derr.Status(codes.InvalidArgument, "both token contract and symbol are required")
+	}
+
+	reference, err := s.priceReference(in.ReferenceContract, in.ReferenceSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	token := pricing.TokenRef{Contract: in.TokenContract, Symbol: in.TokenSymbol}
+	price := s.prices.Price(token, reference)
+	if price == nil {
+		return nil, derr.Statusf(codes.NotFound, "no pool route prices %s in %s", token, reference)
+	}
+
+	blockRef := s.prices.AtBlockRef()
+	out := &pbtokenmeta.TokenPriceResponse{
+		Price:      priceToProto(price),
+		AtBlockNum: blockRef.Num(),
+		AtBlockId:  blockRef.ID(),
+	}
+
+	if in.HistorySince != nil {
+		if reference != s.prices.Reference() {
+			return nil, derr.Statusf(codes.InvalidArgument, "price history is only kept in reference token %s", s.prices.Reference())
+		}
+
+		since, err := ptypes.Timestamp(in.HistorySince)
+		if err != nil {
+			return nil, derr.Statusf(codes.InvalidArgument, "invalid history since: %s", err)
+		}
+
+		for _, point := range s.prices.History(token, since) {
+			out.History = append(out.History, pricePointToProto(point))
+		}
+	}
+
+	return out, nil
+}
+
+func (s *Server) GetPortfolioValue(ctx context.Context, in *pbtokenmeta.GetPortfolioValueRequest) (*pbtokenmeta.PortfolioValueResponse, error) {
+	zlog.Debug("get portfolio value",
+		zap.String("account", in.Account),
+		zap.String("reference_contract", in.ReferenceContract),
+		zap.String("reference_symbol", in.ReferenceSymbol),
+		zap.Any("options", in.Options),
+	)
+
+	if s.prices == nil {
+		return nil, derr.Status(codes.Unavailable, "token prices are not enabled on this instance")
+	}
+
+	reference, err := s.priceReference(in.ReferenceContract, in.ReferenceSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []cache.AccountBalanceOption{}
+	if hasAccountOption(in.Options, pbtokenmeta.GetAccountBalancesRequest_EOS_INCLUDE_STAKED) {
+		options = append(options, cache.EOSIncludeStakedAccOpt)
+	}
+
+	blockRef := s.cache.AtBlockRef()
+	out := &pbtokenmeta.PortfolioValueResponse{
+		ReferenceContract: reference.Contract,
+		ReferenceSymbol:   reference.Symbol,
+		Balances:          []*pbtokenmeta.ValuedBalance{},
+		AtBlockNum:        blockRef.Num(),
+		AtBlockId:         blockRef.ID(),
+	}
+
+	for _, a := range s.cache.AccountBalances(eos.AccountName(in.Account), options...) {
+		balance := &pbtokenmeta.ValuedBalance{Balance: cache.AssetToProtoAccountBalance(a)}
+
+		token := pricing.TokenRef{Contract: string(a.Asset.Contract), Symbol: a.Asset.Asset.Symbol.Symbol}
+		if price := s.prices.Price(token, reference); price != nil {
+			balance.Price = priceToProto(price)
+			balance.Value = float64(a.Asset.Asset.Amount) / math.Pow10(int(a.Asset.Asset.Precision)) * price.Value
+			out.TotalValue += balance.Value
+		}
+
+		out.Balances = append(out.Balances, balance)
+	}
+
+	return out, nil
+}
+
+func (s *Server) priceReference(contract, symbol string) (pricing.TokenRef, error) {
+	if contract == "" && symbol == "" {
+		return s.prices.Reference(), nil
+	}
+
+	if contract == "" || symbol == "" {
+		return pricing.TokenRef{}, derr.Status(codes.InvalidArgument, "both reference contract and symbol are required when one is set")
+	}
+
+	return pricing.TokenRef{Contract: contract, Symbol: symbol}, nil
+}
+
+func priceToProto(price *pricing.Price) *pbtokenmeta.TokenPrice {
+	return &pbtokenmeta.TokenPrice{
+		TokenContract:     price.Token.Contract,
+		TokenSymbol:       price.Token.Symbol,
+		ReferenceContract: price.Reference.Contract,
+		ReferenceSymbol:   price.Reference.Symbol,
+		Price:             price.Value,
+		Route:             price.Route,
+	}
+}
+
+func pricePointToProto(point *pricing.PricePoint) *pbtokenmeta.PricePoint {
+	return &pbtokenmeta.PricePoint{
+		BlockNum:  point.BlockNum,
+		BlockTime: mustProtoTimestamp(point.BlockTime),
+		Price:     point.Price,
+	}
+}
+
+func mustProtoTimestamp(in time.Time) *timestamp.Timestamp {
+	out, err := ptypes.TimestampProto(in)
+	if err != nil {
+		panic(fmt.Sprintf("invalid timestamp conversion %q: %s", in, err))
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pbstatedb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/statedb/v1"
+	"github.com/streamingfast/bstream"
+	"go.uber.org/zap"
+)
+
+// Bootstrap loads the pools of every configured source from statedb, as
+// they were at `blk`, so the hub can follow them from there on.
+func Bootstrap(ctx context.Context, stateClient pbstatedb.StateClient, hub *Hub, blk bstream.BlockRef, blockTime time.Time) error {
+	for _, source := range hub.Sources() {
+		zlog.Info("bootstrapping price source pools from statedb",
+			zap.String("source", source.Name()),
+			zap.Uint64("block_num", blk.Num()),
+		)
+
+		poolCount := 0
+		_, err := pbstatedb.ForEachTableRows(ctx, stateClient, &pbstatedb.StreamTableRowsRequest{
+			BlockNum: blk.Num(),
+			Contract: source.Contract(),
+			Table:    source.Table(),
+			Scope:    source.Scope(),
+			KeyType:  "uint64",
+			ToJson:   true,
+		}, func(row *pbstatedb.TableRowResponse) error {
+			if row.Json == "" {
+				zlog.Warn("skipping pool row that could not be decoded", zap.String("source", source.Name()), zap.String("key", row.Key))
+				return nil
+			}
+
+			pool, err := source.DecodePool([]byte(row.Json))
+			if err != nil {
+				zlog.Warn("skipping invalid pool row", zap.String("source", source.Name()), zap.String("key", row.Key), zap.Error(err))
+				return nil
+			}
+
+			hub.SetPool(pool)
+			poolCount++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("reading %s pools: %w", source.Name(), err)
+		}
+
+		zlog.Info("bootstrapped price source", zap.String("source", source.Name()), zap.Int("pool_count", poolCount))
+	}
+
+	hub.EndBlock(blk, blockTime)
+	return nil
+}
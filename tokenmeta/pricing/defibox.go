@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/eoscanada/eos-go"
+)
+
+func init() {
+	RegisterSourceFactory("defibox", NewDefiboxSource)
+}
+
+// DefiboxSource reads the constant product pools of a Defibox style swap
+// contract, stored in its `pairs` table, scoped to the contract itself.
+type DefiboxSource struct {
+	contract string
+}
+
+func NewDefiboxSource(contract string) Source {
+	return &DefiboxSource{contract: contract}
+}
+
+func (s *DefiboxSource) Name() string     { return "defibox@" + s.contract }
+func (s *DefiboxSource) Contract() string { return s.contract }
+func (s *DefiboxSource) Table() string    { return "pairs" }
+func (s *DefiboxSource) Scope() string    { return s.contract }
+
+type defiboxToken struct {
+	Contract string `json:"contract"`
+	Symbol   string `json:"symbol"`
+}
+
+type defiboxPair struct {
+	ID       json.Number  `json:"id"`
+	Token0   defiboxToken `json:"token0"`
+	Token1   defiboxToken `json:"token1"`
+	Reserve0 eos.Asset    `json:"reserve0"`
+	Reserve1 eos.Asset    `json:"reserve1"`
+}
+
+func (s *DefiboxSource) DecodePool(row json.RawMessage) (*Pool, error) {
+	var pair defiboxPair
+	if err := json.Unmarshal(row, &pair); err != nil {
+		return nil, fmt.Errorf("unmarshal pair: %w", err)
+	}
+
+	token0, err := defiboxTokenRef(pair.Token0)
+	if err != nil {
+		return nil, fmt.Errorf("token0: %w", err)
+	}
+
+	token1, err := defiboxTokenRef(pair.Token1)
+	if err != nil {
+		return nil, fmt.Errorf("token1: %w", err)
+	}
+
+	return &Pool{
+		Source:   s.Name(),
+		ID:       pair.ID.String(),
+		Token0:   token0,
+		Token1:   token1,
+		Reserve0: assetToFloat(pair.Reserve0),
+		Reserve1: assetToFloat(pair.Reserve1),
+	}, nil
+}
+
+func defiboxTokenRef(token defiboxToken) (TokenRef, error) {
+	// Symbols are serialized as `<precision>,<code>`, the precision is irrelevant here
+	symbol := token.Symbol
+	if i := strings.Index(symbol, ","); i >= 0 {
+		symbol = symbol[i+1:]
+	}
+
+	if token.Contract == "" || symbol == "" {
+		return TokenRef{}, fmt.Errorf("invalid token %s %q", token.Contract, token.Symbol)
+	}
+
+	return TokenRef{Contract: token.Contract, Symbol: symbol}, nil
+}
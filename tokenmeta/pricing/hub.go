@@ -0,0 +1,285 @@
+package pricing
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"go.uber.org/zap"
+)
+
+// maxRouteHops bounds the number of pools a price route can traverse
+const maxRouteHops = 4
+
+// Hub keeps the state of the pools of all configured sources and derives
+// token prices from them. Prices are expressed in the reference token
+// unless asked otherwise and a bounded history of those is kept per token.
+type Hub struct {
+	lock sync.RWMutex
+
+	ReferenceToken TokenRef
+	Pools          map[string]*Pool
+	PriceHistory   map[TokenRef][]*PricePoint
+	AtBlockNum     uint64
+	AtBlockID      string
+
+	sources       map[string]Source
+	historySize   int
+	cacheFilePath string
+	dirty         bool
+}
+
+func NewHub(reference TokenRef, historySize int, cacheFilePath string, sources []Source) *Hub {
+	h := &Hub{
+		ReferenceToken: reference,
+		Pools:          map[string]*Pool{},
+		PriceHistory:   map[TokenRef][]*PricePoint{},
+	}
+	h.setup(historySize, cacheFilePath, sources)
+
+	return h
+}
+
+// LoadHubFromFile restores a hub saved with `SaveToFile`. Pools of sources
+// that are not configured anymore are dropped, as is the whole history when
+// the reference token changed.
+func LoadHubFromFile(filename string, reference TokenRef, historySize int, sources []Source) (*Hub, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file: %w", err)
+	}
+	defer f.Close()
+
+	h := &Hub{}
+	if err := gob.NewDecoder(f).Decode(h); err != nil {
+		return nil, fmt.Errorf("unable decode price hub: %w", err)
+	}
+
+	if h.Pools == nil {
+		h.Pools = map[string]*Pool{}
+	}
+	if h.PriceHistory == nil || h.ReferenceToken != reference {
+		h.PriceHistory = map[TokenRef][]*PricePoint{}
+	}
+	h.ReferenceToken = reference
+	h.setup(historySize, filename, sources)
+
+	configured := map[string]bool{}
+	for _, source := range sources {
+		configured[source.Name()] = true
+	}
+	for key, pool := range h.Pools {
+		if !configured[pool.Source] {
+			delete(h.Pools, key)
+		}
+	}
+
+	return h, nil
+}
+
+func (h *Hub) setup(historySize int, cacheFilePath string, sources []Source) {
+	h.historySize = historySize
+	h.cacheFilePath = cacheFilePath
+	h.sources = map[string]Source{}
+	for _, source := range sources {
+		h.sources[sourceKey(source.Contract(), source.Table(), source.Scope())] = source
+	}
+}
+
+func sourceKey(contract, table, scope string) string {
+	return contract + "/" + table + "/" + scope
+}
+
+func (h *Hub) Reference() TokenRef {
+	return h.ReferenceToken
+}
+
+// Sources returns the configured sources, sorted by name
+func (h *Hub) Sources() (out []Source) {
+	for _, source := range h.sources {
+		out = append(out, source)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return
+}
+
+// Source returns the source reading pools out of the given table, if any
+func (h *Hub) Source(contract, table, scope string) Source {
+	return h.sources[sourceKey(contract, table, scope)]
+}
+
+func (h *Hub) SetPool(pool *Pool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.Pools[pool.Key()] = pool
+	h.dirty = true
+}
+
+func (h *Hub) RemovePool(key string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.Pools, key)
+	h.dirty = true
+}
+
+// ResetPools forgets all known pools, used before bootstrapping them again
+func (h *Hub) ResetPools() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.Pools = map[string]*Pool{}
+	h.dirty = true
+}
+
+// EndBlock marks the end of the pool changes of a block, recording a
+// history point for every token whose reference price changed.
+func (h *Hub) EndBlock(blk bstream.BlockRef, blockTime time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.AtBlockNum = blk.Num()
+	h.AtBlockID = blk.ID()
+	if !h.dirty {
+		return
+	}
+	h.dirty = false
+
+	for token, price := range h.pricesIn(h.ReferenceToken, nil) {
+		if token == h.ReferenceToken {
+			continue
+		}
+
+		points := h.PriceHistory[token]
+		if len(points) > 0 && points[len(points)-1].Price == price.Value {
+			continue
+		}
+
+		points = append(points, &PricePoint{BlockNum: blk.Num(), BlockTime: blockTime, Price: price.Value})
+		if h.historySize > 0 && len(points) > h.historySize {
+			points = points[len(points)-h.historySize:]
+		}
+		h.PriceHistory[token] = points
+	}
+}
+
+func (h *Hub) AtBlockRef() bstream.BlockRef {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return bstream.NewBlockRef(h.AtBlockID, h.AtBlockNum)
+}
+
+// Price returns the price of `token` in `reference`, `nil` when no route
+// exists between the two through the known pools.
+func (h *Hub) Price(token, reference TokenRef) *Price {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.pricesIn(reference, &token)[token]
+}
+
+// History returns the reference prices of `token` recorded at or after `since`
+func (h *Hub) History(token TokenRef, since time.Time) (out []*PricePoint) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, point := range h.PriceHistory[token] {
+		if !point.BlockTime.Before(since) {
+			out = append(out, point)
+		}
+	}
+	return
+}
+
+type poolEdge struct {
+	pool *Pool
+	to   TokenRef
+	// rate is the price of the edge's origin token expressed in `to`
+	rate  float64
+	depth float64
+}
+
+// pricesIn walks the pools breadth first from `reference`, so each token
+// is priced through the shortest route, the deepest pools winning ties.
+// The walk stops as soon as `target` is priced when set.
+func (h *Hub) pricesIn(reference TokenRef, target *TokenRef) map[TokenRef]*Price {
+	edges := map[TokenRef][]*poolEdge{}
+	for _, pool := range h.Pools {
+		if !pool.hasLiquidity() || pool.Token0 == pool.Token1 {
+			continue
+		}
+		edges[pool.Token0] = append(edges[pool.Token0], &poolEdge{pool: pool, to: pool.Token1, rate: pool.Reserve1 / pool.Reserve0, depth: pool.Reserve0})
+		edges[pool.Token1] = append(edges[pool.Token1], &poolEdge{pool: pool, to: pool.Token0, rate: pool.Reserve0 / pool.Reserve1, depth: pool.Reserve1})
+	}
+	for _, tokenEdges := range edges {
+		sort.Slice(tokenEdges, func(i, j int) bool {
+			if tokenEdges[i].depth != tokenEdges[j].depth {
+				return tokenEdges[i].depth > tokenEdges[j].depth
+			}
+			return tokenEdges[i].pool.Key() < tokenEdges[j].pool.Key()
+		})
+	}
+
+	prices := map[TokenRef]*Price{
+		reference: {Token: reference, Reference: reference, Value: 1},
+	}
+	if target != nil && *target == reference {
+		return prices
+	}
+
+	current := []TokenRef{reference}
+	for hop := 0; hop < maxRouteHops && len(current) > 0; hop++ {
+		var next []TokenRef
+		for _, token := range current {
+			price := prices[token]
+			for _, edge := range edges[token] {
+				if _, found := prices[edge.to]; found {
+					continue
+				}
+
+				// `edge.rate` is the price of `token` in `edge.to`, so one `edge.to` is worth `1 / rate` of `token`
+				prices[edge.to] = &Price{
+					Token:     edge.to,
+					Reference: reference,
+					Value:     price.Value / edge.rate,
+					Route:     append([]string{edge.pool.Key()}, price.Route...),
+				}
+				if target != nil && edge.to == *target {
+					return prices
+				}
+				next = append(next, edge.to)
+			}
+		}
+		current = next
+	}
+
+	return prices
+}
+
+func (h *Hub) SaveToFile() error {
+	if h.cacheFilePath == "" {
+		return fmt.Errorf("cannot save price hub no filepath specified")
+	}
+
+	tempfile := fmt.Sprintf("%s.tmp", h.cacheFilePath)
+	zlog.Info("trying to save price hub file", zap.String("filename", h.cacheFilePath), zap.String("temp_filename", tempfile))
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	f, err := os.Create(tempfile)
+	if err != nil {
+		return err
+	}
+	err = gob.NewEncoder(f).Encode(h)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempfile, h.cacheFilePath)
+}
@@ -0,0 +1,154 @@
+package pricing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	eosToken  = TokenRef{Contract: "eosio.token", Symbol: "EOS"}
+	usdtToken = TokenRef{Contract: "tethertether", Symbol: "USDT"}
+	boxToken  = TokenRef{Contract: "token.defi", Symbol: "BOX"}
+	dogeToken = TokenRef{Contract: "dogecoin", Symbol: "DOGE"}
+)
+
+func testHub(t *testing.T, cacheFilePath string) *Hub {
+	t.Helper()
+
+	source, err := NewSourceFromSpec("defibox@swap.defi")
+	require.NoError(t, err)
+
+	hub := NewHub(eosToken, 2, cacheFilePath, []Source{source})
+	hub.SetPool(&Pool{Source: "defibox@swap.defi", ID: "12", Token0: eosToken, Token1: usdtToken, Reserve0: 1000, Reserve1: 4000})
+	hub.SetPool(&Pool{Source: "defibox@swap.defi", ID: "194", Token0: boxToken, Token1: usdtToken, Reserve0: 100, Reserve1: 2000})
+	hub.EndBlock(bstream.NewBlockRef("00000010a", 16), time.Unix(1600000000, 0))
+
+	return hub
+}
+
+func TestHub_Price(t *testing.T) {
+	hub := testHub(t, "")
+
+	price := hub.Price(usdtToken, eosToken)
+	require.NotNil(t, price)
+	assert.Equal(t, 0.25, price.Value)
+	assert.Equal(t, []string{"defibox@swap.defi/12"}, price.Route)
+
+	price = hub.Price(boxToken, eosToken)
+	require.NotNil(t, price)
+	assert.Equal(t, 5.0, price.Value)
+	assert.Equal(t, []string{"defibox@swap.defi/194", "defibox@swap.defi/12"}, price.Route)
+
+	price = hub.Price(eosToken, usdtToken)
+	require.NotNil(t, price)
+	assert.Equal(t, 4.0, price.Value)
+
+	assert.Equal(t, 1.0, hub.Price(eosToken, eosToken).Value)
+	assert.Nil(t, hub.Price(dogeToken, eosToken))
+}
+
+func TestHub_History(t *testing.T) {
+	hub := testHub(t, "")
+
+	// Unchanged reserves do not record a new point
+	hub.SetPool(&Pool{Source: "defibox@swap.defi", ID: "194", Token0: boxToken, Token1: usdtToken, Reserve0: 100, Reserve1: 2000})
+	hub.EndBlock(bstream.NewBlockRef("00000011a", 17), time.Unix(1600000001, 0))
+
+	hub.SetPool(&Pool{Source: "defibox@swap.defi", ID: "194", Token0: boxToken, Token1: usdtToken, Reserve0: 100, Reserve1: 2400})
+	hub.EndBlock(bstream.NewBlockRef("00000012a", 18), time.Unix(1600000002, 0))
+
+	hub.SetPool(&Pool{Source: "defibox@swap.defi", ID: "194", Token0: boxToken, Token1: usdtToken, Reserve0: 100, Reserve1: 2800})
+	hub.EndBlock(bstream.NewBlockRef("00000013a", 19), time.Unix(1600000003, 0))
+
+	points := hub.History(boxToken, time.Time{})
+	require.Len(t, points, 2)
+	assert.Equal(t, uint64(18), points[0].BlockNum)
+	assert.Equal(t, 6.0, points[0].Price)
+	assert.Equal(t, uint64(19), points[1].BlockNum)
+	assert.Equal(t, 7.0, points[1].Price)
+
+	assert.Len(t, hub.History(boxToken, time.Unix(1600000003, 0)), 1)
+	assert.Len(t, hub.History(usdtToken, time.Time{}), 1)
+}
+
+func TestHub_RemovePool(t *testing.T) {
+	hub := testHub(t, "")
+
+	hub.RemovePool("defibox@swap.defi/12")
+	hub.EndBlock(bstream.NewBlockRef("00000011a", 17), time.Unix(1600000001, 0))
+
+	assert.Nil(t, hub.Price(boxToken, eosToken))
+	assert.NotNil(t, hub.Price(boxToken, usdtToken))
+}
+
+func TestHub_SaveAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dfuse-tokenmeta-pricing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "price-cache.gob")
+	hub := testHub(t, filename)
+	require.NoError(t, hub.SaveToFile())
+
+	source, err := NewSourceFromSpec("defibox@swap.defi")
+	require.NoError(t, err)
+
+	loaded, err := LoadHubFromFile(filename, eosToken, 2, []Source{source})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(16), loaded.AtBlockRef().Num())
+	assert.Equal(t, 5.0, loaded.Price(boxToken, eosToken).Value)
+	assert.Len(t, loaded.History(boxToken, time.Time{}), 1)
+	assert.Equal(t, source, loaded.Source("swap.defi", "pairs", "swap.defi"))
+
+	loaded, err = LoadHubFromFile(filename, usdtToken, 2, nil)
+	require.NoError(t, err)
+	assert.Nil(t, loaded.Price(boxToken, usdtToken))
+	assert.Len(t, loaded.History(boxToken, time.Time{}), 0)
+}
+
+func TestNewSourceFromSpec(t *testing.T) {
+	_, err := NewSourceFromSpec("defibox")
+	assert.Error(t, err)
+
+	_, err = NewSourceFromSpec("unknown@swap.defi")
+	assert.Error(t, err)
+
+	source, err := NewSourceFromSpec("defibox@swap.defi")
+	require.NoError(t, err)
+	assert.Equal(t, "defibox@swap.defi", source.Name())
+}
+
+func TestDefiboxSource_DecodePool(t *testing.T) {
+	source := NewDefiboxSource("swap.defi")
+
+	pool, err := source.DecodePool([]byte(`{
+		"id": 12,
+		"token0": {"contract": "eosio.token", "symbol": "4,EOS"},
+		"token1": {"contract": "tethertether", "symbol": "4,USDT"},
+		"reserve0": "1000.0000 EOS",
+		"reserve1": "4000.5000 USDT",
+		"liquidity_token": 12345,
+		"price0_last": "4.00050000000000061",
+		"price1_last": "0.24996875390576177",
+		"block_time_last": "2020-09-13T12:26:40"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, &Pool{
+		Source:   "defibox@swap.defi",
+		ID:       "12",
+		Token0:   eosToken,
+		Token1:   usdtToken,
+		Reserve0: 1000,
+		Reserve1: 4000.5,
+	}, pool)
+
+	_, err = source.DecodePool([]byte(`{"id": 1, "token0": {"contract": "", "symbol": ""}}`))
+	assert.Error(t, err)
+}
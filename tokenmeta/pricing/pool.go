@@ -0,0 +1,70 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/eoscanada/eos-go"
+)
+
+// TokenRef identifies a token by its contract and symbol code, like `eosio.token:EOS`
+type TokenRef struct {
+	Contract string
+	Symbol   string
+}
+
+func ParseTokenRef(in string) (TokenRef, error) {
+	parts := strings.Split(in, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return TokenRef{}, fmt.Errorf("invalid token %q, expected <contract>:<symbol>", in)
+	}
+	return TokenRef{Contract: parts[0], Symbol: parts[1]}, nil
+}
+
+func (t TokenRef) String() string {
+	return t.Contract + ":" + t.Symbol
+}
+
+func (t TokenRef) IsEmpty() bool {
+	return t.Contract == "" && t.Symbol == ""
+}
+
+// Pool is a two tokens liquidity pool, the price of `Token0` in `Token1`
+// is `Reserve1 / Reserve0`
+type Pool struct {
+	Source   string
+	ID       string
+	Token0   TokenRef
+	Token1   TokenRef
+	Reserve0 float64
+	Reserve1 float64
+}
+
+func (p *Pool) Key() string {
+	return p.Source + "/" + p.ID
+}
+
+func (p *Pool) hasLiquidity() bool {
+	return p.Reserve0 > 0 && p.Reserve1 > 0
+}
+
+// Price is the value of one unit of `Token` expressed in `Reference`,
+// `Route` holds the keys of the pools traversed, starting from `Token`.
+type Price struct {
+	Token     TokenRef
+	Reference TokenRef
+	Value     float64
+	Route     []string
+}
+
+type PricePoint struct {
+	BlockNum  uint64
+	BlockTime time.Time
+	Price     float64
+}
+
+func assetToFloat(asset eos.Asset) float64 {
+	return float64(asset.Amount) / math.Pow10(int(asset.Precision))
+}
@@ -0,0 +1,74 @@
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eoscanada/eos-go"
+)
+
+// Source reads liquidity pools out of the rows of a single on-chain
+// table, one pool per row.
+type Source interface {
+	// Name uniquely identifies the source, it prefixes the keys of the
+	// pools it produces and appears in price routes
+	Name() string
+
+	Contract() string
+	Table() string
+	Scope() string
+
+	// DecodePool turns the ABI decoded JSON of a row into a pool
+	DecodePool(row json.RawMessage) (*Pool, error)
+}
+
+type SourceFactory func(contract string) Source
+
+var sourceFactories = map[string]SourceFactory{}
+
+// RegisterSourceFactory makes a source kind available to `NewSourceFromSpec`
+func RegisterSourceFactory(kind string, factory SourceFactory) {
+	sourceFactories[kind] = factory
+}
+
+// SourceKinds returns the registered source kinds, sorted
+func SourceKinds() (out []string) {
+	for kind := range sourceFactories {
+		out = append(out, kind)
+	}
+	sort.Strings(out)
+	return
+}
+
+// NewSourceFromSpec creates a source from a `<kind>@<contract>` spec, like `defibox@swap.defi`
+func NewSourceFromSpec(spec string) (Source, error) {
+	parts := strings.Split(spec, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid price source %q, expected <kind>@<contract>", spec)
+	}
+
+	factory, found := sourceFactories[parts[0]]
+	if !found {
+		return nil, fmt.Errorf("unknown price source kind %q, valid kinds are %s", parts[0], strings.Join(SourceKinds(), ", "))
+	}
+
+	if _, err := eos.StringToName(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid price source contract %q: %w", parts[1], err)
+	}
+
+	return factory(parts[1]), nil
+}
+
+// NewSourcesFromSpecs creates a source for each spec, see `NewSourceFromSpec`
+func NewSourcesFromSpecs(specs []string) (out []Source, err error) {
+	for _, spec := range specs {
+		source, err := NewSourceFromSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, source)
+	}
+	return
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/streamingfast/bstream"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/pricing"
 	"github.com/eoscanada/eos-go"
 	"go.uber.org/zap"
 )
@@ -33,6 +34,11 @@ func (t *TokenMeta) ProcessBlock(block *bstream.Block, obj interface{}) error {
 				account := actTrace.GetData("account").String()
 				hexABI := actTrace.GetData("abi")
 
+				if t.mappings.Contract(account) != nil {
+					zlogger.Debug("skipping abi of mapped token contract", zap.String("account", account))
+					continue
+				}
+
 				if !hexABI.Exists() {
 					zlogger.Warn("'setabi' action data payload not present",
 						zap.String("account", account),
@@ -89,6 +95,20 @@ func (t *TokenMeta) ProcessBlock(block *bstream.Block, obj interface{}) error {
 		}
 
 		for _, dbop := range trx.DbOps {
+			if t.prices != nil && actionMatcher.Matched(dbop.ActionIndex) {
+				if source := t.prices.Source(dbop.Code, dbop.TableName, dbop.Scope); source != nil {
+					t.processPoolDBOp(source, dbop, blk.Number, zlogger)
+					continue
+				}
+			}
+
+			if mapping := t.mappings.Contract(dbop.Code); mapping != nil {
+				if actionMatcher.Matched(dbop.ActionIndex) {
+					t.processMappedDBOp(mapping, dbop, blk.Number, muts, zlogger)
+				}
+				continue
+			}
+
 			if !shouldProcessDbop(dbop, actionMatcher) {
 				continue
 			}
@@ -210,9 +230,48 @@ func (t *TokenMeta) ProcessBlock(block *bstream.Block, obj interface{}) error {
 			zap.Errors("errors", errs),
 		)
 	}
+	if t.prices != nil {
+		t.prices.EndBlock(block, blk.MustTime())
+	}
+
 	if t.saveEveryNBlock != 0 && blk.Number%t.saveEveryNBlock == 0 {
 		// TODO Should this be done async? if so we would need to add locks
 		t.cache.SaveToFile()
+		if t.prices != nil {
+			t.prices.SaveToFile()
+		}
 	}
 	return nil
 }
+
+func (t *TokenMeta) processPoolDBOp(source pricing.Source, dbop *pbcodec.DBOp, blockNum uint32, zlogger *zap.Logger) {
+	rowData := dbop.NewData
+	if rowData == nil {
+		rowData = dbop.OldData
+	}
+
+	row, err := t.decodeDBOpToRow(rowData, eos.TableName(dbop.TableName), eos.AccountName(dbop.Code), blockNum)
+	if err != nil {
+		zlogger.Error("cannot decode pool table row",
+			zap.String("source", source.Name()),
+			zap.String("primary_key", dbop.PrimaryKey),
+			zap.Error(err))
+		return
+	}
+
+	pool, err := source.DecodePool(row)
+	if err != nil {
+		zlogger.Warn("could not create pool from dbop row",
+			zap.String("source", source.Name()),
+			zap.String("primary_key", dbop.PrimaryKey),
+			zap.String("dbop_row", string(row)),
+			zap.Error(err))
+		return
+	}
+
+	if dbop.NewData == nil {
+		t.prices.RemovePool(pool.Key())
+	} else {
+		t.prices.SetPool(pool)
+	}
+}
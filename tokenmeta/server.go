@@ -8,6 +8,7 @@ import (
 
 	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
 	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/pricing"
 	"github.com/streamingfast/dgrpc"
 	pbhealth "github.com/streamingfast/pbgo/grpc/health/v1"
 	"github.com/streamingfast/shutter"
@@ -21,18 +22,22 @@ type Server struct {
 
 	grpcServer          *grpc.Server
 	cache               cache.Cache
+	prices              *pricing.Hub
 	readinessMaxLatency time.Duration
 }
 
-func NewServer(cache cache.Cache, readinessMaxLatency time.Duration) *Server {
+func NewServer(cache cache.Cache, prices *pricing.Hub, readinessMaxLatency time.Duration) *Server {
 	s := &Server{
 		readinessMaxLatency: readinessMaxLatency,
 		Shutter:             shutter.New(),
 		cache:               cache,
+		prices:              prices,
 		grpcServer:          dgrpc.NewServer(dgrpc.WithLogger(zlog)),
 	}
 
 	pbtokenmeta.RegisterTokenMetaServer(s.grpcServer, s)
+	pbtokenmeta.RegisterTokenPricesServer(s.grpcServer, s)
+	pbtokenmeta.RegisterTokenHoldersServer(s.grpcServer, s)
 	pbhealth.RegisterHealthServer(s.grpcServer, s)
 
 	return s
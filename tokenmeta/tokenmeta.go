@@ -11,6 +11,7 @@ import (
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	pbtokenmeta "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/tokenmeta/v1"
 	"github.com/dfuse-io/dfuse-eosio/tokenmeta/cache"
+	"github.com/dfuse-io/dfuse-eosio/tokenmeta/pricing"
 	pbblockmeta "github.com/streamingfast/pbgo/dfuse/blockmeta/v1"
 	"github.com/streamingfast/shutter"
 	"github.com/eoscanada/eos-go"
@@ -33,6 +34,8 @@ type TokenMeta struct {
 	saveEveryNBlock uint32
 	stateClient     pbstatedb.StateClient
 	blockmeta       pbblockmeta.BlockIDClient
+	prices          *pricing.Hub
+	mappings        *TokenMappings
 }
 
 func NewTokenMeta(
@@ -56,6 +59,16 @@ func NewTokenMeta(
 	}
 }
 
+// SetPriceHub enables the tracking of the pools of the hub's price sources
+func (t *TokenMeta) SetPriceHub(prices *pricing.Hub) {
+	t.prices = prices
+}
+
+// SetTokenMappings makes the mapped contracts tracked through their mapping
+func (t *TokenMeta) SetTokenMappings(mappings *TokenMappings) {
+	t.mappings = mappings
+}
+
 func (t *TokenMeta) decodeDBOpToRow(data []byte, tableName eos.TableName, contract eos.AccountName, blocknum uint32) (json.RawMessage, error) {
 	abi, err := t.getABI(contract, blocknum)
 	if err != nil {
@@ -78,6 +91,13 @@ func (i *TokenMeta) Launch() error {
 		zlog.Error("error exporting cache on shutdown", zap.Error(err))
 	}
 
+	if i.prices != nil {
+		zlog.Info("export price hub")
+		if err := i.prices.SaveToFile(); err != nil {
+			zlog.Error("error exporting price hub on shutdown", zap.Error(err))
+		}
+	}
+
 	if err := i.source.Err(); err != nil {
 		zlog.Error("source shutdown with error", zap.Error(err))
 		return err
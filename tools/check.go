@@ -16,8 +16,11 @@ import (
 	"github.com/dfuse-io/dfuse-eosio/accounthist"
 	"github.com/dfuse-io/dfuse-eosio/accounthist/injector"
 	"github.com/dfuse-io/dfuse-eosio/accounthist/keyer"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	"github.com/dfuse-io/dfuse-eosio/statedb"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	trxdbloader "github.com/dfuse-io/dfuse-eosio/trxdb-loader"
 	"github.com/dfuse-io/dfuse-eosio/trxdb/kv"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/jsonpb"
@@ -51,9 +54,25 @@ var checkMergedBlocksCmd = &cobra.Command{
 }
 var checkTrxdbBlocksCmd = &cobra.Command{
 	Use:   "trxdb-blocks <store-dsn>",
-	Short: "Checks for any holes in the trxdb database",
-	Args:  cobra.ExactArgs(1),
-	RunE:  checkTrxdbBlocksE,
+	Short: "Checks for any holes in the trxdb database, optionally verifying and repairing its content against merged blocks",
+	Long: Description(`
+		Checks for any holes in the irreversible blocks of the trxdb database.
+
+		When '--blocks-store-url' is provided, each merged block of the range is also compared
+		with the content of trxdb: block rows, producer index, transactions, transaction traces,
+		deferred transactions and their indexes, implicit transactions, timeline, accounts and
+		irreversibility markers. Missing or mismatched rows are reported and, when '--repair'
+		is set, re-written.
+
+		Merged blocks are filtered like the loader does, the '--*-filter-expr' flags must hence
+		match the ones that were used to load trxdb.
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: checkTrxdbBlocksE,
+	Example: ExamplePrefixed("dfuseeos tools check trxdb-blocks", `
+		--range 1000:2000 "badger://dfuse-data/storage/trxdb-v1"
+		--range 1000:2000 --blocks-store-url dfuse-data/storage/merged-blocks --repair "badger://dfuse-data/storage/trxdb-v1"
+	`),
 }
 var checkStateDBConsistencyCmd = &cobra.Command{
 	Use:   "statedb-consistency <store-dsn> <keys>...",
@@ -87,6 +106,12 @@ func init() {
 
 	checkMergedBlocksCmd.Flags().BoolP("print-stats", "s", false, "Natively decode each block in the segment and print statistics about it, ensuring it contains the required blocks")
 	checkMergedBlocksCmd.Flags().BoolP("print-full", "f", false, "Natively decode each block and print the full JSON representation of the block, should be used with a small range only if you don't want to be overwhelmed")
+
+	checkTrxdbBlocksCmd.Flags().String("blocks-store-url", "", "Merged blocks store to verify trxdb content against, only holes are checked when empty")
+	checkTrxdbBlocksCmd.Flags().Bool("repair", false, "Re-write the missing or mismatched rows found while verifying against merged blocks, rows are only reported otherwise")
+	checkTrxdbBlocksCmd.Flags().String("include-filter-expr", "", "CEL program to determine if a given action should be included, must match the 'common-include-filter-expr' used to load trxdb, no filtering is applied when all filter expressions are empty")
+	checkTrxdbBlocksCmd.Flags().String("exclude-filter-expr", "", "CEL program to determine if an included action should be excluded, must match the 'common-exclude-filter-expr' used to load trxdb")
+	checkTrxdbBlocksCmd.Flags().String("system-actions-include-filter-expr", "", "CEL program to determine which actions to keep regardless of the include or exclude filter expressions, must match the 'common-system-actions-include-filter-expr' used to load trxdb")
 }
 
 func checkStateDBReprocInjectorE(cmd *cobra.Command, args []string) error {
@@ -704,6 +729,89 @@ func checkTrxdbBlocksE(cmd *cobra.Command, args []string) error {
 		fmt.Printf("🆗 No hole found\n")
 	}
 
+	if blocksStoreURL := viper.GetString("blocks-store-url"); blocksStoreURL != "" {
+		blockFilter, err := trxdbBlockFilter(
+			viper.GetString("include-filter-expr"),
+			viper.GetString("exclude-filter-expr"),
+			viper.GetString("system-actions-include-filter-expr"),
+		)
+		if err != nil {
+			return err
+		}
+
+		return verifyTrxdbBlocks(dsn, blocksStoreURL, blockRange, blockFilter, viper.GetBool("repair"))
+	}
+
+	return nil
+}
+
+// trxdbBlockFilter returns the filter trxdb-loader applies to blocks, `nil` when no
+// filter expression is set.
+func trxdbBlockFilter(includeExpr, excludeExpr, systemActionsIncludeExpr string) (func(blk *bstream.Block) error, error) {
+	if includeExpr == "" && excludeExpr == "" && systemActionsIncludeExpr == "" {
+		return nil, nil
+	}
+
+	filter, err := filtering.NewBlockFilter(
+		strings.Split(includeExpr, ";;;"),
+		strings.Split(excludeExpr, ";;;"),
+		strings.Split(systemActionsIncludeExpr, ";;;"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create block filter: %w", err)
+	}
+
+	fmt.Printf("Filtering merged blocks with %s\n", filter)
+	return filter.TransformInPlace, nil
+}
+
+func verifyTrxdbBlocks(dsn string, blocksStoreURL string, blockRange BlockRange, blockFilter func(blk *bstream.Block) error, repair bool) error {
+	if blockRange.Stop <= blockRange.Start {
+		return fmt.Errorf("a non-empty block range is required to verify against merged blocks")
+	}
+
+	blocksStore, err := dstore.NewDBinStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("unable to create blocks store: %w", err)
+	}
+
+	db, err := trxdb.New(dsn, trxdb.WithLogger(zlog))
+	if err != nil {
+		return fmt.Errorf("unable to create trxdb: %w", err)
+	}
+	defer db.Close()
+
+	fmt.Printf("Verifying trxdb content against merged blocks at %s, from %d to %d\n", blocksStoreURL, blockRange.Start, blockRange.Stop)
+
+	patchCount := 0
+	loader := trxdbloader.NewTrxDBLoader("", blocksStore, 1000, db, 2, blockFilter, 0, nil)
+	err = loader.EnableVerifyAndRepair(db, !repair, func(blk *pbcodec.Block, patch *trxdb.BlockPatch) {
+		patchCount++
+		fmt.Printf("❌ Block %s is missing or mismatching: %s\n", blk.AsRef(), patch)
+	})
+	if err != nil {
+		return err
+	}
+
+	loader.StopBeforeBlock(blockRange.Stop)
+	loader.BuildPipelinePatch(blockRange.Start, 300)
+
+	go loader.Launch()
+	<-loader.Terminated()
+
+	if err := loader.Err(); err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	switch {
+	case patchCount == 0:
+		fmt.Printf("🆗 trxdb content matches merged blocks\n")
+	case repair:
+		fmt.Printf("🔧 Applied %d patches\n", patchCount)
+	default:
+		fmt.Printf("🆘 %d patches needed, re-run with '--repair' to apply them\n", patchCount)
+	}
+
 	return nil
 }
 
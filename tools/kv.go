@@ -178,7 +178,7 @@ func printKVEntity(key, val []byte, asHex bool, indented bool) (err error) {
 }
 
 func decodePayload(marshaler jsonpb.Marshaler, obj proto.Message, bytes []byte) (out json.RawMessage, err error) {
-	err = proto.Unmarshal(bytes, obj)
+	err = rowDecoder.Into(bytes, obj)
 	if err != nil {
 		return nil, fmt.Errorf("proto unmarshal: %s", err)
 	}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/dfuse-io/dfuse-eosio/replay"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var recordDeepMindCmd = &cobra.Command{
+	Use:   "record-deepmind <output-file> [-- <command> [args...]]",
+	Short: "Records the deep-mind output of a node to a file, for later use with 'dfuseeos replay'",
+	Long: Description(`
+		Records the deep-mind lines of a 'nodeos' instance started with '--deep-mind' to a file,
+		compressed with gzip when the file ends with '.gz' and with zstd when it ends with '.zst'.
+
+		The node output is read from the standard input, or from the command given after '--',
+		both its standard output and error being recorded.
+	`),
+	Example: ExamplePrefixed("dfuseeos tools record-deepmind", `
+		deep-mind.log.zst -- nodeos --deep-mind --config-dir=./config --data-dir=./data
+		deep-mind.log.gz --stop-block-num=10000 < nodeos.log
+	`),
+	Args: cobra.MinimumNArgs(1),
+	RunE: recordDeepMindE,
+}
+
+func init() {
+	Cmd.AddCommand(recordDeepMindCmd)
+
+	recordDeepMindCmd.Flags().Bool("all-lines", false, "Record all lines of the node output instead of only the deep-mind ones")
+	recordDeepMindCmd.Flags().Uint64("stop-block-num", 0, "Stop recording once this block was accepted, stopping the command if any, 0 to record until the end of the output")
+}
+
+func recordDeepMindE(cmd *cobra.Command, args []string) (err error) {
+	output, err := replay.CreateLog(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := output.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("unable to close deep-mind log: %w", closeErr)
+		}
+	}()
+
+	options := replay.RecordOptions{
+		AllLines:     viper.GetBool("all-lines"),
+		StopBlockNum: viper.GetUint64("stop-block-num"),
+	}
+
+	if len(args) == 1 {
+		stats, err := replay.Record(os.Stdin, output, options)
+		printRecordStats(args[0], stats)
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	node := exec.Command(args[1], args[2:]...)
+	node.Stdout = writer
+	node.Stderr = writer
+	if err := node.Start(); err != nil {
+		return fmt.Errorf("unable to start %q: %w", args[1], err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		err := node.Wait()
+		writer.Close()
+		exited <- err
+	}()
+
+	stats, err := replay.Record(reader, output, options)
+	printRecordStats(args[0], stats)
+	if err != nil {
+		node.Process.Kill()
+		return err
+	}
+
+	if options.StopBlockNum != 0 && stats.LastBlockNum >= options.StopBlockNum {
+		fmt.Printf("Stop block reached, stopping %s\n", args[1])
+		node.Process.Signal(os.Interrupt)
+
+		// Keep draining the output so the node is never blocked on a write while exiting
+		go io.Copy(ioutil.Discard, reader)
+		<-exited
+		return nil
+	}
+
+	if err := <-exited; err != nil {
+		return fmt.Errorf("%s exited: %w", args[1], err)
+	}
+
+	return nil
+}
+
+func printRecordStats(file string, stats replay.RecordStats) {
+	fmt.Printf("Recorded %d lines (%d blocks, last block #%d) to %s\n", stats.Lines, stats.Blocks, stats.LastBlockNum, file)
+}
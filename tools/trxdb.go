@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/dfuse-io/dfuse-eosio/trxdb/kv"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// rowDecoder decodes trxdb rows whatever the row encoding used to write them
+var rowDecoder = trxdb.NewProtoDecoder()
+
+var trxdbCmd = &cobra.Command{Use: "trxdb", Short: "Maintenance operations on the EOS Database (trxdb)"}
+
+var trxdbRecompressCmd = &cobra.Command{
+	Use:   "recompress <dsn>",
+	Short: "Re-encode trxdb rows of a block range using the given row compression",
+	Long: Description(`
+		Re-encodes the block, transaction, transaction trace and implicit transaction rows of
+		every block (forked ones included) found in the given range using the requested row
+		compression. Rows already in the requested encoding are left untouched, so the
+		operation can be safely interrupted and restarted.
+
+		Using '--compression=none' turns compressed rows back to plain protobuf rows.
+
+		The range is of the form '<start>:<stop>' where 'stop' is exclusive.
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: trxdbRecompressE,
+	Example: ExamplePrefixed("dfuseeos tools trxdb", `
+		recompress --range 1000:2000 "badger://dfuse-data/storage/trxdb-v1"
+		recompress --range 1000:2000 --compression=none "bigkv://gcp_project.gcp_bt_instance/eos-mainnet-v1?write=blk"
+	`),
+}
+
+func init() {
+	Cmd.AddCommand(trxdbCmd)
+	trxdbCmd.AddCommand(trxdbRecompressCmd)
+
+	trxdbRecompressCmd.Flags().StringP("range", "r", "", "Block range to recompress, format is of the form '<start>:<stop>' (i.e. '-r 1000:2000')")
+	trxdbRecompressCmd.Flags().String("compression", "zstd", "Row compression to apply, either 'zstd' or 'none'")
+}
+
+func trxdbRecompressE(cmd *cobra.Command, args []string) error {
+	blockRange, err := getBlockRangeFromFlag()
+	if err != nil {
+		return err
+	}
+
+	if blockRange.Stop <= blockRange.Start {
+		return fmt.Errorf("a non-empty block range is required, got %q", viper.GetString("range"))
+	}
+
+	db, err := trxdb.New(args[0], trxdb.WithLogger(zlog), trxdb.WithRowCompression(viper.GetString("compression")))
+	if err != nil {
+		return fmt.Errorf("unable to create trxdb: %w", err)
+	}
+	defer db.Close()
+
+	kvDB, ok := db.(*kv.DB)
+	if !ok {
+		return fmt.Errorf("recompress is only supported by the kv driver, got %T", db)
+	}
+
+	fmt.Printf("Recompressing trxdb blocks %s\n", blockRange)
+
+	t0 := time.Now()
+	lastReport := time.Now()
+	stats, err := kvDB.RecompressBlocks(cmd.Context(), uint32(blockRange.Start), uint32(blockRange.Stop-1), func(blockNum uint32) {
+		if time.Since(lastReport) > 15*time.Second {
+			zlog.Info("recompress progress", zap.Uint32("block_num", blockNum))
+			lastReport = time.Now()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("recompress: %w", err)
+	}
+
+	fmt.Printf("Processed %d blocks, rewrote %d of %d rows in %s\n", stats.BlockCount, stats.RewriteCount, stats.RowCount, time.Since(t0))
+	fmt.Printf("Size went from %s to %s\n", humanize.Bytes(stats.BytesBefore), humanize.Bytes(stats.BytesAfter))
+
+	return nil
+}
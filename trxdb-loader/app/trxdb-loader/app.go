@@ -48,6 +48,7 @@ type Config struct {
 	EnableTruncationMarker    bool   // Enables the storage of truncation markers
 	TruncationWindow          uint64 // Truncate date within this duration
 	PurgerInterval            uint64 // Purger at every X block
+	RowCompression            string // Compression applied to written rows, either `zstd` or `none`
 }
 
 type App struct {
@@ -94,6 +95,9 @@ func (a *App) Run() error {
 	if a.config.EnableTruncationMarker {
 		trxdbOption = append(trxdbOption, trxdb.WithPurgeableStoreOption(a.config.TruncationWindow, a.config.PurgerInterval))
 	}
+	if a.config.RowCompression != "" {
+		trxdbOption = append(trxdbOption, trxdb.WithRowCompression(a.config.RowCompression))
+	}
 
 	db, err := trxdb.New(a.config.KvdbDsn, trxdbOption...)
 	if err != nil {
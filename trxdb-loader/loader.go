@@ -54,6 +54,7 @@ type TrxDBLoader struct {
 	healthy                   bool
 	truncationWindow          uint64
 	blockmeta                 pbblockmeta.BlockIDClient
+	verifier                  *blockVerifier
 }
 
 func NewTrxDBLoader(
@@ -436,7 +437,7 @@ func (l *TrxDBLoader) UpdateIrreversibleData(nowIrreversibleBlocks []*bstream.Pr
 	return nil
 }
 
-// patchDatabase is a "scratch" pad to define patch code that can be applied
+// PatchJob is a "scratch" pad to define patch code that can be applied
 // on an ad-hoc basis. The idea is to leave this function empty when no patch needs
 // to be applied.
 //
@@ -445,10 +446,20 @@ func (l *TrxDBLoader) UpdateIrreversibleData(nowIrreversibleBlocks []*bstream.Pr
 // `patch-<tag>-<date>` where the tag is giving an overview of the patch and the date
 // is the effective date (`<year>-<month>-<day>`): `patch-add-trx-meta-written-2019-06-30`.
 // The branch is then deleted and the tag is pushed to the remote repository.
+//
+// When `EnableVerifyAndRepair` was called, each block is instead cross-checked against
+// the database and the missing or mismatched rows are reported and re-written.
 func (l *TrxDBLoader) PatchJob(blockNum uint64, blk *pbcodec.Block, fObj *forkable.ForkableObject) (err error) {
 	switch fObj.Step {
 	case forkable.StepNew:
 		l.ShowProgress(blockNum)
+
+		if l.verifier != nil && (l.endBlock == 0 || blockNum < l.endBlock) {
+			if err := l.verifier.verify(context.Background(), blk, false); err != nil {
+				return err
+			}
+		}
+
 		return l.FlushIfNeeded(blockNum, blk.MustTime())
 
 	case forkable.StepIrreversible:
@@ -461,6 +472,12 @@ func (l *TrxDBLoader) PatchJob(blockNum uint64, blk *pbcodec.Block, fObj *forkab
 			l.Shutdown(nil)
 			return nil
 		}
+
+		if l.verifier != nil && blk.Num() != 1 && (l.endBlock == 0 || blockNum < l.endBlock) {
+			if err := l.verifier.verify(context.Background(), blk, true); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
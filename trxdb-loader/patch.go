@@ -0,0 +1,409 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb_loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/golang/protobuf/proto"
+	"github.com/streamingfast/kvdb"
+	"github.com/streamingfast/kvdb/store"
+	"go.uber.org/zap"
+)
+
+type PatchHandler func(blk *pbcodec.Block, patch *trxdb.BlockPatch)
+
+type blockVerifier struct {
+	reader  trxdb.DBReader
+	patcher trxdb.DBPatcher
+	onPatch PatchHandler
+
+	// rewrittenBlocks holds the blocks whose row was patched when seen as new, their
+	// irreversibility rows being necessarily missing too, they are not reported again
+	// once irreversible.
+	rewrittenBlocks map[string]uint64
+}
+
+// EnableVerifyAndRepair turns the `PatchJob` into a verification job: each block is
+// compared with what is stored in `reader` and the missing or mismatched rows are
+// reported to `onPatch`, a block being reported at most once. Unless `dryRun` is set,
+// those rows are then re-written, which requires the loader's database to implement
+// `trxdb.DBPatcher`.
+func (l *TrxDBLoader) EnableVerifyAndRepair(reader trxdb.DBReader, dryRun bool, onPatch PatchHandler) error {
+	verifier := &blockVerifier{
+		reader:          reader,
+		onPatch:         onPatch,
+		rewrittenBlocks: map[string]uint64{},
+	}
+
+	if !dryRun {
+		patcher, ok := l.db.(trxdb.DBPatcher)
+		if !ok {
+			return fmt.Errorf("database %T does not support patching blocks", l.db)
+		}
+		verifier.patcher = patcher
+	}
+
+	l.verifier = verifier
+	return nil
+}
+
+func (v *blockVerifier) verify(ctx context.Context, blk *pbcodec.Block, checkIrreversible bool) error {
+	var patch *trxdb.BlockPatch
+	var err error
+	if checkIrreversible {
+		patch, err = VerifyIrreversibility(ctx, v.reader, blk)
+	} else {
+		patch, err = VerifyBlock(ctx, v.reader, blk)
+	}
+
+	if err != nil {
+		return fmt.Errorf("verify block %s: %w", blk.AsRef(), err)
+	}
+
+	alreadyReported := false
+	if checkIrreversible {
+		_, alreadyReported = v.rewrittenBlocks[blk.Id]
+		v.forgetRewrittenBlocks(blk.Num())
+	} else if patch.Block {
+		v.rewrittenBlocks[blk.Id] = blk.Num()
+	}
+
+	if patch.IsEmpty() {
+		return nil
+	}
+
+	if v.onPatch != nil && !alreadyReported {
+		v.onPatch(blk, patch)
+	}
+
+	if v.patcher == nil {
+		return nil
+	}
+
+	zlog.Debug("patching block", zap.Stringer("block", blk.AsRef()), zap.Stringer("patch", patch))
+	if err := v.patcher.PatchBlock(ctx, blk, patch); err != nil {
+		return fmt.Errorf("patch block %s: %w", blk.AsRef(), err)
+	}
+
+	return nil
+}
+
+// forgetRewrittenBlocks drops the blocks up to `irreversibleNum`, forked ones never
+// reaching irreversibility.
+func (v *blockVerifier) forgetRewrittenBlocks(irreversibleNum uint64) {
+	for id, num := range v.rewrittenBlocks {
+		if num <= irreversibleNum {
+			delete(v.rewrittenBlocks, id)
+		}
+	}
+}
+
+// VerifyBlock compares the content of `blk` with what is stored in trxdb and returns
+// the rows that are missing or that do not match, the rows written once the block is
+// irreversible are checked separately by `VerifyIrreversibility`.
+//
+// Deferred transaction rows and index entries are re-written along with the trace that
+// created them, they are hence reported through `TransactionTraceIDs`. The index entries
+// are only checked when `db` implements `trxdb.DeferredTransactionIndexChecker`.
+func VerifyBlock(ctx context.Context, db trxdb.DBReader, blk *pbcodec.Block) (*trxdb.BlockPatch, error) {
+	patch := &trxdb.BlockPatch{}
+
+	stored, err := db.GetBlock(ctx, blk.Id)
+	if err != nil && err != kvdb.ErrNotFound {
+		return nil, fmt.Errorf("get block: %w", err)
+	}
+
+	var traceIDs, receiptIDs, trxIDs, implicitTrxIDs []string
+	for _, trace := range blk.TransactionTraces() {
+		traceIDs = append(traceIDs, trace.Id)
+	}
+	for _, receipt := range blk.Transactions() {
+		receiptIDs = append(receiptIDs, receipt.Id)
+
+		// Deferred transactions receipts have no packed transaction and are not stored as such
+		if receipt.PackedTransaction != nil {
+			trxIDs = append(trxIDs, receipt.Id)
+		}
+	}
+	for _, trxOp := range blk.ImplicitTransactionOps() {
+		implicitTrxIDs = append(implicitTrxIDs, trxOp.TransactionId)
+	}
+
+	if stored == nil || !refsMatch(stored.TransactionRefs, receiptIDs) || !refsMatch(stored.ImplicitTransactionRefs, implicitTrxIDs) {
+		patch.Block = true
+	} else {
+		indexed, err := producerBlockIndexed(ctx, db, blk)
+		if err != nil {
+			return nil, err
+		}
+		patch.Block = !indexed
+	}
+
+	if len(traceIDs) > 0 {
+		traceEvents, err := db.GetTransactionTracesBatch(ctx, traceIDs)
+		if err != nil {
+			return nil, fmt.Errorf("get transaction traces: %w", err)
+		}
+
+		traces := blk.TransactionTraces()
+		for i, events := range traceEvents {
+			ev := findBlockEvent(events, blk.Id)
+			if ev == nil || !traceMatches(traces[i], ev.GetExecution().GetTrace()) {
+				patch.TransactionTraceIDs = append(patch.TransactionTraceIDs, traceIDs[i])
+			}
+		}
+
+		mismatchedTraceIDs, err := verifyDtrxRows(ctx, db, blk)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, traceID := range mismatchedTraceIDs {
+			if !containsID(patch.TransactionTraceIDs, traceID) {
+				patch.TransactionTraceIDs = append(patch.TransactionTraceIDs, traceID)
+			}
+		}
+	}
+
+	if len(trxIDs)+len(implicitTrxIDs) > 0 {
+		ids := append(append([]string{}, trxIDs...), implicitTrxIDs...)
+		trxEvents, err := db.GetTransactionEventsBatch(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("get transaction events: %w", err)
+		}
+
+		for i, events := range trxEvents {
+			if i < len(trxIDs) {
+				if findAdditionEvent(events, blk.Id) == nil {
+					patch.TransactionIDs = append(patch.TransactionIDs, ids[i])
+				}
+				continue
+			}
+
+			if findInternalAdditionEvent(events, blk.Id) == nil {
+				patch.ImplicitTransactionIDs = append(patch.ImplicitTransactionIDs, ids[i])
+			}
+		}
+	}
+
+	return patch, nil
+}
+
+// VerifyIrreversibility returns a patch re-writing the rows written once `blk` is
+// irreversible (the irreversibility marker, the timeline entries and the accounts it
+// created) when one of them is missing, `blk` being known to be irreversible.
+func VerifyIrreversibility(ctx context.Context, db trxdb.DBReader, blk *pbcodec.Block) (*trxdb.BlockPatch, error) {
+	stored, err := db.GetBlock(ctx, blk.Id)
+	if err == kvdb.ErrNotFound {
+		// The block row itself is reported by `VerifyBlock`, the marker is missing too
+		return &trxdb.BlockPatch{Irreversible: true}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get block: %w", err)
+	}
+
+	if !stored.Irreversible {
+		return &trxdb.BlockPatch{Irreversible: true}, nil
+	}
+
+	blockID, err := db.BlockIDAt(ctx, blk.MustTime())
+	if err != nil && err != kvdb.ErrNotFound {
+		return nil, fmt.Errorf("get timeline: %w", err)
+	}
+
+	if blockID != blk.Id {
+		return &trxdb.BlockPatch{Irreversible: true}, nil
+	}
+
+	for _, trace := range blk.TransactionTraces() {
+		for _, act := range trace.ActionTraces {
+			if act.FullName() != "eosio:eosio:newaccount" {
+				continue
+			}
+
+			account, err := db.GetAccount(ctx, act.GetData("name").String())
+			if err != nil && err != kvdb.ErrNotFound {
+				return nil, fmt.Errorf("get account: %w", err)
+			}
+
+			if account == nil || account.BlockId != blk.Id {
+				return &trxdb.BlockPatch{Irreversible: true}, nil
+			}
+		}
+	}
+
+	return &trxdb.BlockPatch{}, nil
+}
+
+func producerBlockIndexed(ctx context.Context, db trxdb.DBReader, blk *pbcodec.Block) (bool, error) {
+	producer := blk.Header.GetProducer()
+	if producer == "" {
+		return true, nil
+	}
+
+	blocks, err := db.ListProducerBlocks(ctx, producer, blk.Number, blk.Number, store.Unlimited)
+	if err != nil {
+		return false, fmt.Errorf("list producer blocks: %w", err)
+	}
+
+	for _, block := range blocks {
+		if block.Id == blk.Id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// verifyDtrxRows returns the ids of the traces of `blk` whose deferred transaction
+// rows or index entries are missing.
+func verifyDtrxRows(ctx context.Context, db trxdb.DBReader, blk *pbcodec.Block) (traceIDs []string, err error) {
+	var dtrxIDs []string
+	var dtrxTraceIDs []string
+	var dtrxOps []*pbcodec.DTrxOp
+	for _, trace := range blk.TransactionTraces() {
+		for _, dtrxOp := range trace.DtrxOps {
+			dtrxIDs = append(dtrxIDs, dtrxOp.TransactionId)
+			dtrxTraceIDs = append(dtrxTraceIDs, trace.Id)
+			dtrxOps = append(dtrxOps, dtrxOp)
+		}
+	}
+
+	if len(dtrxIDs) == 0 {
+		return nil, nil
+	}
+
+	dtrxEvents, err := db.GetTransactionEventsBatch(ctx, dtrxIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get deferred transaction events: %w", err)
+	}
+
+	indexChecker, _ := db.(trxdb.DeferredTransactionIndexChecker)
+	for i, events := range dtrxEvents {
+		if containsID(traceIDs, dtrxTraceIDs[i]) {
+			continue
+		}
+
+		found := findDtrxEvent(events, blk.Id, dtrxOps[i]) != nil
+		if found && indexChecker != nil && dtrxOps[i].IsCreateOperation() {
+			found, err = indexChecker.HasDeferredTransactionIndexes(ctx, blk.Id, dtrxOps[i])
+			if err != nil {
+				return nil, fmt.Errorf("check deferred transaction indexes: %w", err)
+			}
+		}
+
+		if !found {
+			traceIDs = append(traceIDs, dtrxTraceIDs[i])
+		}
+	}
+
+	return traceIDs, nil
+}
+
+func refsMatch(refs *pbcodec.TransactionRefs, ids []string) bool {
+	hashes := refs.GetHashes()
+	if len(hashes) != len(ids) {
+		return false
+	}
+
+	for i, id := range ids {
+		expected, err := hex.DecodeString(id)
+		if err != nil || !bytes.Equal(hashes[i], expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// traceMatches compares the whole traces, `stored` being the re-duplicated version of
+// the stored row, so rows written from a different version of the block's content (or
+// with different filtering rules) are detected.
+func traceMatches(expected, stored *pbcodec.TransactionTrace) bool {
+	if stored == nil {
+		return false
+	}
+
+	return proto.Equal(expected, stored)
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func findBlockEvent(events []*pbcodec.TransactionEvent, blockID string) *pbcodec.TransactionEvent {
+	for _, ev := range events {
+		if ev.BlockId == blockID {
+			return ev
+		}
+	}
+	return nil
+}
+
+// findDtrxEvent returns the event of the deferred transaction row written for `dtrxOp`
+// in block `blockID`, failed operations being surfaced as cancellations without author.
+func findDtrxEvent(events []*pbcodec.TransactionEvent, blockID string, dtrxOp *pbcodec.DTrxOp) *pbcodec.TransactionEvent {
+	for _, ev := range events {
+		if ev.BlockId != blockID {
+			continue
+		}
+
+		switch {
+		case dtrxOp.IsCreateOperation():
+			if ev.GetDtrxScheduling() != nil {
+				return ev
+			}
+		case dtrxOp.IsCancelOperation():
+			if ev.GetDtrxCancellation().GetCanceledBy() != nil {
+				return ev
+			}
+		case dtrxOp.IsFailedOperation():
+			if cancellation := ev.GetDtrxCancellation(); cancellation != nil && cancellation.CanceledBy == nil {
+				return ev
+			}
+		}
+	}
+	return nil
+}
+
+func findAdditionEvent(events []*pbcodec.TransactionEvent, blockID string) *pbcodec.TransactionEvent {
+	for _, ev := range events {
+		if ev.BlockId == blockID && ev.GetAddition() != nil {
+			return ev
+		}
+	}
+	return nil
+}
+
+func findInternalAdditionEvent(events []*pbcodec.TransactionEvent, blockID string) *pbcodec.TransactionEvent {
+	for _, ev := range events {
+		if ev.BlockId == blockID && ev.GetInternalAddition() != nil {
+			return ev
+		}
+	}
+	return nil
+}
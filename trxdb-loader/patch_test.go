@@ -0,0 +1,201 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb_loader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	patchTestBlockID = "00000002aa000000000000000000000000000000000000000000000000000000"
+	patchTestTrxID   = "a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"
+	patchTestDtrxID  = "d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1d1"
+)
+
+func newPatchTestLoader(t *testing.T) (*TrxDBLoader, trxdb.DB, func()) {
+	dir, err := ioutil.TempDir("", "dfuse-trxdb-loader-patch")
+	require.NoError(t, err)
+
+	db, err := trxdb.New(fmt.Sprintf("badger://%s", dir), trxdb.WithLogger(zlog))
+	require.NoError(t, err)
+
+	return NewTrxDBLoader("", nil, 1, db, 1, nil, 0, nil), db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestVerifyAndRepair(t *testing.T) {
+	loader, db, cleanup := newPatchTestLoader(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blk := ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID)))
+
+	patch, err := VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.Block)
+	assert.Equal(t, []string{patchTestTrxID}, patch.TransactionTraceIDs)
+
+	var reported []*trxdb.BlockPatch
+	require.NoError(t, loader.EnableVerifyAndRepair(db, false, func(_ *pbcodec.Block, patch *trxdb.BlockPatch) {
+		reported = append(reported, patch)
+	}))
+
+	require.NoError(t, loader.verifier.verify(ctx, blk, false))
+	require.NoError(t, loader.verifier.verify(ctx, blk, true))
+	require.NoError(t, db.Flush(ctx))
+
+	// The missing block is reported once, its irreversibility rows are repaired silently
+	require.Len(t, reported, 1)
+
+	patch, err = VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty(), "unexpected patch %s", patch)
+
+	patch, err = VerifyIrreversibility(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty(), "unexpected patch %s", patch)
+}
+
+func TestVerifyAndRepair_DryRun(t *testing.T) {
+	loader, db, cleanup := newPatchTestLoader(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blk := ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID)))
+
+	var reported []*trxdb.BlockPatch
+	require.NoError(t, loader.EnableVerifyAndRepair(db, true, func(_ *pbcodec.Block, patch *trxdb.BlockPatch) {
+		reported = append(reported, patch)
+	}))
+
+	require.NoError(t, loader.verifier.verify(ctx, blk, false))
+	require.NoError(t, db.Flush(ctx))
+	require.Len(t, reported, 1)
+
+	patch, err := VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.Equal(t, reported[0], patch)
+}
+
+func TestVerifyBlock_MismatchedTrace(t *testing.T) {
+	_, db, cleanup := newPatchTestLoader(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blk := ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID)))
+	require.NoError(t, db.PutBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	blk = ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID), pbcodec.TransactionStatus_TRANSACTIONSTATUS_HARDFAIL))
+
+	patch, err := VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.False(t, patch.Block)
+	assert.Equal(t, []string{patchTestTrxID}, patch.TransactionTraceIDs)
+}
+
+func TestVerifyBlock_MismatchedActionTraces(t *testing.T) {
+	_, db, cleanup := newPatchTestLoader(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blk := ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID), ct.ActionTrace(t, "eosio.token:transfer")))
+	require.NoError(t, db.PutBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	patch, err := VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty(), "unexpected patch %s", patch)
+
+	// Same receipt and action count, different action
+	blk = ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID), ct.ActionTrace(t, "eosio.token:issue")))
+
+	patch, err = VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.Equal(t, []string{patchTestTrxID}, patch.TransactionTraceIDs)
+}
+
+type missingDtrxIndexesDB struct {
+	trxdb.DB
+}
+
+func (db *missingDtrxIndexesDB) HasDeferredTransactionIndexes(ctx context.Context, blockID string, dtrxOp *pbcodec.DTrxOp) (bool, error) {
+	return false, nil
+}
+
+func TestVerifyBlock_DeferredTransactions(t *testing.T) {
+	_, db, cleanup := newPatchTestLoader(t)
+	defer cleanup()
+
+	dtrxOp := ct.DtrxOp(t, "create", patchTestDtrxID, ct.SignedTrx(t))
+	dtrxOp.Sender = "scheduler"
+	dtrxOp.SenderId = "1"
+	dtrxOp.DelayUntil = "2020-01-01T00:00:04.500"
+
+	ctx := context.Background()
+	blk := ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID), dtrxOp))
+	require.NoError(t, db.PutBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	patch, err := VerifyBlock(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty(), "unexpected patch %s", patch)
+
+	patch, err = VerifyBlock(ctx, &missingDtrxIndexesDB{db}, blk)
+	require.NoError(t, err)
+	assert.Equal(t, []string{patchTestTrxID}, patch.TransactionTraceIDs)
+
+	// A cancellation of the same deferred transaction was never written
+	blk = ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID), dtrxOp, ct.DtrxOp(t, "cancel", patchTestDtrxID)))
+
+	dtrxTraceIDs, err := verifyDtrxRows(ctx, db, blk)
+	require.NoError(t, err)
+	assert.Equal(t, []string{patchTestTrxID}, dtrxTraceIDs)
+}
+
+func TestVerifyIrreversibility_MissingAccount(t *testing.T) {
+	_, db, cleanup := newPatchTestLoader(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blk := ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID)))
+	require.NoError(t, db.PutBlock(ctx, blk))
+	require.NoError(t, db.UpdateNowIrreversibleBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	patch, err := VerifyIrreversibility(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty(), "unexpected patch %s", patch)
+
+	blk = ct.Block(t, patchTestBlockID, ct.TrxTrace(t, ct.TrxID(patchTestTrxID),
+		ct.ActionTrace(t, "eosio:eosio:newaccount", ct.ActionData(`{"creator":"eosio","name":"battlefield"}`)),
+	))
+
+	patch, err = VerifyIrreversibility(ctx, db, blk)
+	require.NoError(t, err)
+	assert.True(t, patch.Irreversible)
+}
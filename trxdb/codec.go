@@ -19,17 +19,45 @@ import (
 
 	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
 	"github.com/golang/protobuf/proto"
+	"github.com/klauspost/compress/zstd"
 	"go.uber.org/zap"
 )
 
-type ProtoDecoder struct{}
+// Rows are stored either as a raw protobuf message (the legacy format) or using a
+// versioned encoding. Since a protobuf field number can never be 0, no marshalled
+// message starts with a 0x00 byte, versioned rows are thus prefixed by
+// `rowEncodingMarker` followed by a single byte identifying the encoding version.
+const (
+	rowEncodingMarker byte = 0x00
+
+	// RowEncodingZstd is a zstd compressed protobuf message
+	RowEncodingZstd byte = 0x01
+)
+
+// rowCompressionThresholdInBytes is the size under which a row is never compressed,
+// the compression overhead outweighing the gains on such small payloads.
+const rowCompressionThresholdInBytes = 256
+
+type ProtoDecoder struct {
+	zstdDecoder *zstd.Decoder
+}
 
 func NewProtoDecoder() *ProtoDecoder {
-	return &ProtoDecoder{}
+	// There can be errors only when using `opts` parameters, so it's safe to ignore them here
+	zstdDecoder, _ := zstd.NewReader(nil)
+
+	return &ProtoDecoder{
+		zstdDecoder: zstdDecoder,
+	}
 }
 
 func (d *ProtoDecoder) Into(cnt []byte, msg proto.Message) error {
-	err := proto.Unmarshal(cnt, msg)
+	data, err := d.DecodeRow(cnt)
+	if err != nil {
+		return err
+	}
+
+	err = proto.Unmarshal(data, msg)
 	if err != nil {
 		return err
 	}
@@ -37,29 +65,87 @@ func (d *ProtoDecoder) Into(cnt []byte, msg proto.Message) error {
 	return nil
 }
 
+// DecodeRow returns the raw protobuf message of a stored row, whatever the encoding
+// that was used to write it.
+func (d *ProtoDecoder) DecodeRow(cnt []byte) ([]byte, error) {
+	if len(cnt) == 0 || cnt[0] != rowEncodingMarker {
+		return cnt, nil
+	}
+
+	if len(cnt) < 2 {
+		return nil, fmt.Errorf("invalid row, missing encoding version")
+	}
+
+	switch cnt[1] {
+	case RowEncodingZstd:
+		data, err := d.zstdDecoder.DecodeAll(cnt[2:], make([]byte, 0, 2*len(cnt)))
+		if err != nil {
+			return nil, fmt.Errorf("zstd decompress: %w", err)
+		}
+
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown row encoding version 0x%02x", cnt[1])
+	}
+}
+
 func (d *ProtoDecoder) MustInto(cnt []byte, msg proto.Message) {
 	if err := d.Into(cnt, msg); err != nil {
 		panic(fmt.Sprintf("proto decode error: %s", err.Error()))
 	}
 }
 
-type ProtoEncoder struct{}
+type ProtoEncoder struct {
+	zstdEncoder *zstd.Encoder
+}
 
 func NewProtoEncoder() *ProtoEncoder {
 	return &ProtoEncoder{}
 }
 
+// NewCompressedProtoEncoder returns an encoder writing rows using the given compression
+// mode, either `zstd` or `none` (which writes legacy raw protobuf rows).
+func NewCompressedProtoEncoder(mode string) (*ProtoEncoder, error) {
+	switch mode {
+	case "zst", "zstd":
+		// There can be errors only when using `opts` parameters, so it's safe to ignore them here
+		zstdEncoder, _ := zstd.NewWriter(nil)
+		return &ProtoEncoder{zstdEncoder: zstdEncoder}, nil
+	case "", "none":
+		return NewProtoEncoder(), nil
+	default:
+		return nil, fmt.Errorf("invalid row compression %q, use 'zstd' or 'none'", mode)
+	}
+}
+
 func (e *ProtoEncoder) MustProto(obj proto.Message) (out []byte) {
 	data, err := proto.Marshal(obj)
 	if err != nil {
 		panic(fmt.Sprintf("proto encode failed: %s", err))
 	}
 
+	out = e.EncodeRow(data)
 	if traceEnabled {
-		zlog.Debug("marshalled protobuf message to binary", zap.String("id", fmt.Sprintf("%s (%T)", messageIdentifier(obj), obj)), zap.Int("payload", len(data)))
+		zlog.Debug("marshalled protobuf message to binary", zap.String("id", fmt.Sprintf("%s (%T)", messageIdentifier(obj), obj)), zap.Int("payload", len(data)), zap.Int("encoded", len(out)))
+	}
+
+	return out
+}
+
+// EncodeRow encodes a raw protobuf message into its stored form. Rows are left
+// untouched when compression is disabled, when they are too small to be worth it
+// or when compressing them would not save any space.
+func (e *ProtoEncoder) EncodeRow(data []byte) []byte {
+	if e.zstdEncoder == nil || len(data) <= rowCompressionThresholdInBytes {
+		return data
+	}
+
+	out := e.zstdEncoder.EncodeAll(data, []byte{rowEncodingMarker, RowEncodingZstd})
+	if len(out) >= len(data) {
+		return data
 	}
 
-	return data
+	return out
 }
 
 func messageIdentifier(obj proto.Message) string {
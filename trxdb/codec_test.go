@@ -0,0 +1,96 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb
+
+import (
+	"strings"
+	"testing"
+
+	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoEncoder_RowEncoding(t *testing.T) {
+	small := &pbtrxdb.ImplicitTrxRow{Name: "onblock"}
+	large := &pbtrxdb.ImplicitTrxRow{Name: strings.Repeat("onblock", 100)}
+
+	tests := []struct {
+		name             string
+		compression      string
+		row              proto.Message
+		expectCompressed bool
+	}{
+		{"none, small row", "none", small, false},
+		{"none, large row", "none", large, false},
+		{"zstd, small row", "zstd", small, false},
+		{"zstd, large row", "zstd", large, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enc, err := NewCompressedProtoEncoder(test.compression)
+			require.NoError(t, err)
+
+			encoded := enc.MustProto(test.row)
+			raw, err := proto.Marshal(test.row)
+			require.NoError(t, err)
+
+			if test.expectCompressed {
+				assert.Equal(t, []byte{rowEncodingMarker, RowEncodingZstd}, encoded[0:2])
+				assert.Less(t, len(encoded), len(raw))
+			} else {
+				assert.Equal(t, raw, encoded)
+			}
+
+			decoded := &pbtrxdb.ImplicitTrxRow{}
+			require.NoError(t, NewProtoDecoder().Into(encoded, decoded))
+			assert.True(t, proto.Equal(test.row, decoded))
+		})
+	}
+}
+
+func TestNewCompressedProtoEncoder_Invalid(t *testing.T) {
+	_, err := NewCompressedProtoEncoder("gzip")
+	require.Error(t, err)
+}
+
+func TestProtoDecoder_DecodeRow(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          []byte
+		expected    []byte
+		expectedErr bool
+	}{
+		{"empty row", []byte{}, []byte{}, false},
+		{"legacy row", []byte{0x0a, 0x01, 0x61}, []byte{0x0a, 0x01, 0x61}, false},
+		{"missing version", []byte{rowEncodingMarker}, nil, true},
+		{"unknown version", []byte{rowEncodingMarker, 0xff, 0x01}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := NewProtoDecoder().DecodeRow(test.in)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, out)
+		})
+	}
+}
@@ -36,6 +36,8 @@ type DBReader interface {
 	TransactionsReader
 	AccountsReader
 	TimelineExplorer
+	DeferredTransactionsReader
+	ProducersReader
 }
 
 // This is the main interface, needed by most subsystems.
@@ -74,6 +76,33 @@ type TransactionsReader interface {
 	GetTransactionEventsBatch(ctx context.Context, idPrefixes []string) ([][]*pbcodec.TransactionEvent, error)
 }
 
+type DeferredTransactionsReader interface {
+	// ListPendingDeferredTransactions returns the scheduling event of each deferred transaction
+	// created by `sender` that was neither executed, cancelled nor failed yet. When `senderID`
+	// is non-empty, only the deferred transactions scheduled with this `sender_id` are returned.
+	// Results are ordered by `sender_id`.
+	//
+	// When `inChain` is non-nil, events occurring in blocks it rejects are ignored, otherwise
+	// events from all blocks (including forked ones) are considered.
+	ListPendingDeferredTransactions(ctx context.Context, sender, senderID string, inChain ChainDiscriminator) ([]*pbcodec.TransactionEvent, error)
+
+	// ListDeferredTransactionsDueBetween returns the scheduling event of each pending deferred
+	// transaction whose `delay_until` is within `[low, high)`, ordered by `delay_until`.
+	ListDeferredTransactionsDueBetween(ctx context.Context, low, high time.Time, inChain ChainDiscriminator) ([]*pbcodec.TransactionEvent, error)
+}
+
+type ProducersReader interface {
+	// ListProducerBlocks retrieves the blocks produced by `producer`, from `highBlockNum`
+	// going down to `lowBlockNum` (both inclusive), returning at most `limit` blocks. Forked
+	// blocks are returned too, use the `Irreversible` field to discriminate them.
+	ListProducerBlocks(ctx context.Context, producer string, lowBlockNum, highBlockNum uint32, limit int) ([]*pbcodec.BlockWithRefs, error)
+
+	// GetProducerStats computes the production statistics of each producer over the
+	// irreversible blocks in `[lowBlockNum, highBlockNum]`. Missed slots are derived from
+	// the active schedule of the blocks, see `ProducerStatsTracker` for details.
+	GetProducerStats(ctx context.Context, lowBlockNum, highBlockNum uint32) ([]*ProducerStats, error)
+}
+
 type TimelineExplorer interface {
 	BlockIDAt(ctx context.Context, start time.Time) (id string, err error)
 	BlockIDAfter(ctx context.Context, start time.Time, inclusive bool) (id string, foundtime time.Time, err error)
@@ -111,6 +140,20 @@ type DBWriter interface {
 	Flush(context.Context) error
 }
 
+// DBPatcher is implemented by drivers able to re-write a subset of the rows of a block,
+// used to repair partial writes without re-processing whole blocks.
+type DBPatcher interface {
+	// PatchBlock writes the rows listed in `patch`, taking their content from `blk`. Like
+	// `PutBlock`, writes are buffered until `Flush` is called.
+	PatchBlock(ctx context.Context, blk *pbcodec.Block, patch *BlockPatch) error
+}
+
+// DeferredTransactionIndexChecker is implemented by drivers able to tell whether the index
+// entries of a deferred transaction creation were written, used to verify partial writes.
+type DeferredTransactionIndexChecker interface {
+	HasDeferredTransactionIndexes(ctx context.Context, blockID string, dtrxOp *pbcodec.DTrxOp) (bool, error)
+}
+
 type Debugeable interface {
 	Dump()
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/eoscanada/eos-go"
@@ -20,8 +21,11 @@ const (
 	TblPrefixAccts     = 0x06
 	TblTTL             = 0x10
 
-	idxPrefixTimelineFwd = 0x80
-	idxPrefixTimelineBck = 0x81
+	idxPrefixTimelineFwd    = 0x80
+	idxPrefixTimelineBck    = 0x81
+	idxPrefixDtrxSender     = 0x82
+	idxPrefixDtrxDelayUntil = 0x83
+	idxPrefixProducerBlocks = 0x84
 
 	dtrxSuffixCreated   = 0x90
 	dtrxSuffixCancelled = 0x91
@@ -244,6 +248,143 @@ func (Keyer) EndOfTimelineIndex(fwd bool) []byte {
 	return []byte{idxPrefixTimelineBck + 1}
 }
 
+// Producer blocks index
+
+func (k Keyer) PackProducerBlocksKey(producer, blockID string) []byte {
+	id, err := hex.DecodeString(kvdb.ReversedBlockID(blockID))
+	if err != nil {
+		panic(fmt.Errorf("invalid block ID %q: %w", blockID, err))
+	}
+	return append(k.PackProducerBlocksPrefix(producer), id...)
+}
+
+func (Keyer) UnpackProducerBlocksKey(key []byte) (producer, blockID string) {
+	producer = eos.NameToString(binary.BigEndian.Uint64(key[1:9]))
+	blockID = kvdb.ReversedBlockID(hex.EncodeToString(key[9:]))
+	return
+}
+
+func (Keyer) PackProducerBlocksPrefix(producer string) []byte {
+	name, err := eos.StringToName(producer)
+	if err != nil {
+		panic(fmt.Errorf("invalid producer name %q: %w", producer, err))
+	}
+	b := make([]byte, 9)
+	b[0] = idxPrefixProducerBlocks
+	binary.BigEndian.PutUint64(b[1:], name)
+	return b
+}
+
+func (k Keyer) PackProducerBlockNumPrefix(producer string, blockNum uint32) []byte {
+	hexBlockNum, err := hex.DecodeString(kvdb.HexRevBlockNum(blockNum))
+	if err != nil {
+		panic(fmt.Errorf("invalid block num %d: %w", blockNum, err))
+	}
+	return append(k.PackProducerBlocksPrefix(producer), hexBlockNum...)
+}
+
+// EndOfProducerBlocks returns the exclusive upper bound of all the keys of `producer`
+func (k Keyer) EndOfProducerBlocks(producer string) []byte {
+	end := k.PackProducerBlocksPrefix(producer)
+	binary.BigEndian.PutUint64(end[1:], binary.BigEndian.Uint64(end[1:])+1)
+	return end
+}
+
+func (Keyer) StartOfProducerBlocksIndex() []byte { return []byte{idxPrefixProducerBlocks} }
+func (Keyer) EndOfProducerBlocksIndex() []byte   { return []byte{idxPrefixProducerBlocks + 1} }
+
+// Deferred transactions indexes
+
+// PackDtrxSenderKey packs the `(sender, sender_id)` index key pointing to a deferred
+// transaction created in `blockID`. The `sender_id` is the uint128 chosen by the
+// contract when scheduling the transaction, in its decimal (or `0x` prefixed hex) form.
+func (k Keyer) PackDtrxSenderKey(sender, senderID, trxID, blockID string) []byte {
+	id, err := hex.DecodeString(trxID + blockID)
+	if err != nil {
+		panic(fmt.Errorf("invalid trx ID %q or block ID %q: %w", trxID, blockID, err))
+	}
+	return append(k.PackDtrxSenderIDPrefix(sender, senderID), id...)
+}
+
+func (Keyer) UnpackDtrxSenderKey(key []byte) (sender, senderID, trxID, blockID string) {
+	if len(key) != 89 {
+		panic(fmt.Errorf("invalid key %q length, expected length 89 got %d", string(key), len(key)))
+	}
+	sender = eos.NameToString(binary.BigEndian.Uint64(key[1:9]))
+	senderID = new(big.Int).SetBytes(key[9:25]).String()
+	return sender, senderID, hex.EncodeToString(key[25:57]), hex.EncodeToString(key[57:89])
+}
+
+func (Keyer) PackDtrxSenderPrefix(sender string) []byte {
+	name, err := eos.StringToName(sender)
+	if err != nil {
+		panic(fmt.Errorf("invalid sender name %q: %w", sender, err))
+	}
+	b := make([]byte, 9)
+	b[0] = idxPrefixDtrxSender
+	binary.BigEndian.PutUint64(b[1:], name)
+	return b
+}
+
+func (k Keyer) PackDtrxSenderIDPrefix(sender, senderID string) []byte {
+	id, err := SenderIDBytes(senderID)
+	if err != nil {
+		panic(err)
+	}
+	return append(k.PackDtrxSenderPrefix(sender), id...)
+}
+
+func (Keyer) StartOfDtrxSenderIndex() []byte { return []byte{idxPrefixDtrxSender} }
+func (Keyer) EndOfDtrxSenderIndex() []byte   { return []byte{idxPrefixDtrxSender + 1} }
+
+func (k Keyer) PackDtrxDelayUntilKey(delayUntil time.Time, trxID, blockID string) []byte {
+	id, err := hex.DecodeString(trxID + blockID)
+	if err != nil {
+		panic(fmt.Errorf("invalid trx ID %q or block ID %q: %w", trxID, blockID, err))
+	}
+	return append(k.PackDtrxDelayUntilPrefix(delayUntil), id...)
+}
+
+func (Keyer) UnpackDtrxDelayUntilKey(key []byte) (delayUntil time.Time, trxID, blockID string) {
+	if len(key) != 73 {
+		panic(fmt.Errorf("invalid key %q length, expected length 73 got %d", string(key), len(key)))
+	}
+	millis := int64(binary.BigEndian.Uint64(key[1:9]))
+	delayUntil = time.Unix(millis/1000, (millis%1000)*int64(time.Millisecond)).UTC()
+	return delayUntil, hex.EncodeToString(key[9:41]), hex.EncodeToString(key[41:73])
+}
+
+func (Keyer) PackDtrxDelayUntilPrefix(delayUntil time.Time) []byte {
+	millis := delayUntil.UnixNano() / int64(time.Millisecond)
+	if millis < 0 {
+		millis = 0
+	}
+
+	b := make([]byte, 9)
+	b[0] = idxPrefixDtrxDelayUntil
+	binary.BigEndian.PutUint64(b[1:], uint64(millis))
+	return b
+}
+
+func (Keyer) StartOfDtrxDelayUntilIndex() []byte { return []byte{idxPrefixDtrxDelayUntil} }
+func (Keyer) EndOfDtrxDelayUntilIndex() []byte   { return []byte{idxPrefixDtrxDelayUntil + 1} }
+
+var maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// SenderIDBytes turns a deferred transaction `sender_id` (an uint128) into its
+// 16 bytes big endian representation used by the sender index.
+func SenderIDBytes(senderID string) ([]byte, error) {
+	value, ok := new(big.Int).SetString(senderID, 0)
+	if !ok || value.Sign() < 0 || value.Cmp(maxUint128) > 0 {
+		return nil, fmt.Errorf("invalid sender id %q: expecting an unsigned 128 bits integer", senderID)
+	}
+
+	out := make([]byte, 16)
+	raw := value.Bytes()
+	copy(out[16-len(raw):], raw)
+	return out, nil
+}
+
 func (Keyer) packTrxBlockIDKey(prefix byte, trxID, blockID string) []byte {
 	id, err := hex.DecodeString(trxID + blockID)
 	if err != nil {
@@ -65,3 +65,68 @@ func TestKeyer_PackDtrxsKeyFailed(t *testing.T) {
 	require.Equal(t, expectedTrxID, trxID)
 
 }
+
+func TestKeyer_PackDtrxSenderKey(t *testing.T) {
+	expectedBlockID := "0000001aafcedbf5e651b27bee47c8a28de01635b5029ac2ce32896a1bcb1615"
+	expectedTrxID := "f2c8602f6d2b8241894383b22614a82740338d3f5c34961c0c82b382ac9e11ae"
+
+	packed := Keys.PackDtrxSenderKey("scheduler", "340282366920938463463374607431768211455", expectedTrxID, expectedBlockID)
+	sender, senderID, trxID, blockID := Keys.UnpackDtrxSenderKey(packed)
+	require.Equal(t, "scheduler", sender)
+	require.Equal(t, "340282366920938463463374607431768211455", senderID)
+	require.Equal(t, expectedBlockID, blockID)
+	require.Equal(t, expectedTrxID, trxID)
+	require.Equal(t, Keys.PackDtrxSenderIDPrefix("scheduler", "0xffffffffffffffffffffffffffffffff"), packed[:25])
+}
+
+func TestKeyer_PackDtrxDelayUntilKey(t *testing.T) {
+	expectedBlockID := "0000001aafcedbf5e651b27bee47c8a28de01635b5029ac2ce32896a1bcb1615"
+	expectedTrxID := "f2c8602f6d2b8241894383b22614a82740338d3f5c34961c0c82b382ac9e11ae"
+	expectedDelayUntil := time.Date(2020, 1, 2, 3, 4, 5, 500000000, time.UTC)
+
+	packed := Keys.PackDtrxDelayUntilKey(expectedDelayUntil, expectedTrxID, expectedBlockID)
+	delayUntil, trxID, blockID := Keys.UnpackDtrxDelayUntilKey(packed)
+	require.Equal(t, expectedDelayUntil, delayUntil)
+	require.Equal(t, expectedBlockID, blockID)
+	require.Equal(t, expectedTrxID, trxID)
+}
+
+func TestSenderIDBytes(t *testing.T) {
+	tests := []struct {
+		name        string
+		senderID    string
+		expected    []byte
+		expectedErr bool
+	}{
+		{"zero", "0", make([]byte, 16), false},
+		{"decimal", "258", append(make([]byte, 14), 0x01, 0x02), false},
+		{"hex", "0x0102", append(make([]byte, 14), 0x01, 0x02), false},
+		{"negative", "-1", nil, true},
+		{"overflow", "340282366920938463463374607431768211456", nil, true},
+		{"garbage", "abc", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := SenderIDBytes(test.senderID)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestKeyer_PackProducerBlocksKey(t *testing.T) {
+	expectedBlockID := "0000001aafcedbf5e651b27bee47c8a28de01635b5029ac2ce32896a1bcb1615"
+
+	packed := Keys.PackProducerBlocksKey("eoscanadacom", expectedBlockID)
+	producer, blockID := Keys.UnpackProducerBlocksKey(packed)
+	require.Equal(t, "eoscanadacom", producer)
+	require.Equal(t, expectedBlockID, blockID)
+	require.Equal(t, Keys.PackProducerBlockNumPrefix("eoscanadacom", 0x1a), packed[:13])
+	require.True(t, string(Keys.PackProducerBlockNumPrefix("eoscanadacom", 0x1b)) < string(packed))
+}
@@ -1,6 +1,7 @@
 package kv
 
 import (
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
 	kvdbstore "github.com/streamingfast/kvdb/store"
 	"go.uber.org/zap"
 )
@@ -21,3 +22,14 @@ func (db *DB) SetPurgeableStore(ttl, purgeInterval uint64) error {
 	db.purgeInterval = purgeInterval
 	return nil
 }
+
+func (db *DB) SetRowCompression(mode string) error {
+	enc, err := trxdb.NewCompressedProtoEncoder(mode)
+	if err != nil {
+		return err
+	}
+
+	zlog.Info("applying row compression option", zap.String("mode", mode))
+	db.enc = enc
+	return nil
+}
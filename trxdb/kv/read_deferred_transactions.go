@@ -0,0 +1,157 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/kvdb/store"
+	"go.uber.org/zap"
+)
+
+// dtrxTimeLayout is the layout used by deep mind to output `published_at`, `delay_until`
+// and `expiration_at` fields of a `DTrxOp`.
+const dtrxTimeLayout = "2006-01-02T15:04:05.999"
+
+func (db *DB) ListPendingDeferredTransactions(ctx context.Context, sender, senderID string, inChain trxdb.ChainDiscriminator) ([]*pbcodec.TransactionEvent, error) {
+	if _, err := eos.StringToName(sender); err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", sender, err)
+	}
+
+	prefix := Keys.PackDtrxSenderPrefix(sender)
+	if senderID != "" {
+		if _, err := SenderIDBytes(senderID); err != nil {
+			return nil, err
+		}
+		prefix = Keys.PackDtrxSenderIDPrefix(sender, senderID)
+	}
+
+	db.logger.Debug("list pending deferred transactions", zap.String("sender", sender), zap.String("sender_id", senderID))
+
+	var trxIDs []string
+	seen := map[string]bool{}
+	it := db.trxReadStore.Prefix(ctx, prefix, store.Unlimited)
+	for it.Next() {
+		_, _, trxID, _ := Keys.UnpackDtrxSenderKey(it.Item().Key)
+		if !seen[trxID] {
+			seen[trxID] = true
+			trxIDs = append(trxIDs, trxID)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return db.pendingDeferredTransactions(ctx, trxIDs, inChain)
+}
+
+func (db *DB) ListDeferredTransactionsDueBetween(ctx context.Context, low, high time.Time, inChain trxdb.ChainDiscriminator) ([]*pbcodec.TransactionEvent, error) {
+	if !high.After(low) {
+		return nil, fmt.Errorf("invalid time window: high bound %s must be after low bound %s", high, low)
+	}
+
+	db.logger.Debug("list deferred transactions due between", zap.Time("low", low), zap.Time("high", high))
+
+	var trxIDs []string
+	seen := map[string]bool{}
+	it := db.trxReadStore.Scan(ctx, Keys.PackDtrxDelayUntilPrefix(low), Keys.PackDtrxDelayUntilPrefix(high), store.Unlimited)
+	for it.Next() {
+		_, trxID, _ := Keys.UnpackDtrxDelayUntilKey(it.Item().Key)
+		if !seen[trxID] {
+			seen[trxID] = true
+			trxIDs = append(trxIDs, trxID)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return db.pendingDeferredTransactions(ctx, trxIDs, inChain)
+}
+
+// pendingDeferredTransactions resolves the lifecycle of each of the deferred transaction
+// ids and returns the scheduling event of those that were not executed, cancelled nor
+// failed yet, in the same order as `trxIDs`.
+func (db *DB) pendingDeferredTransactions(ctx context.Context, trxIDs []string, inChain trxdb.ChainDiscriminator) (out []*pbcodec.TransactionEvent, err error) {
+	if len(trxIDs) == 0 {
+		return nil, nil
+	}
+
+	events, err := db.getTransactionEvents(ctx, trxIDs, DtrxEvent, TrxExecutionEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := map[string]*pbcodec.TransactionEvent{}
+	completed := map[string]bool{}
+	for _, ev := range events {
+		if inChain != nil && !inChain(ev.BlockId) {
+			continue
+		}
+
+		switch e := ev.Event.(type) {
+		case *pbcodec.TransactionEvent_DtrxScheduling:
+			if previous := scheduled[ev.Id]; previous == nil || previous.BlockNum < ev.BlockNum {
+				scheduled[ev.Id] = ev
+			}
+		case *pbcodec.TransactionEvent_DtrxCancellation:
+			// Failed deferred transactions are also surfaced as a cancellation
+			completed[ev.Id] = true
+		case *pbcodec.TransactionEvent_Execution:
+			// A transaction pushed with a delay gets a `delayed` trace when scheduled, only
+			// subsequent traces mean the transaction actually ran.
+			if e.Execution.Trace.GetReceipt().GetStatus() != pbcodec.TransactionStatus_TRANSACTIONSTATUS_DELAYED {
+				completed[ev.Id] = true
+			}
+		}
+	}
+
+	for _, trxID := range trxIDs {
+		if ev := scheduled[trxID]; ev != nil && !completed[trxID] {
+			out = append(out, ev)
+		}
+	}
+
+	if err := db.fillIrreversibilityData(ctx, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// HasDeferredTransactionIndexes reports whether the `(sender, sender_id)` and `delay_until`
+// index entries of the deferred transaction creation `dtrxOp` made in block `blockID` are
+// all present, operations lacking the information being never indexed.
+func (db *DB) HasDeferredTransactionIndexes(ctx context.Context, blockID string, dtrxOp *pbcodec.DTrxOp) (bool, error) {
+	var keys [][]byte
+	if dtrxOp.Sender != "" {
+		if _, err := SenderIDBytes(dtrxOp.SenderId); err != nil {
+			return false, fmt.Errorf("sender of dtrx %s: %w", dtrxOp.TransactionId, err)
+		}
+		keys = append(keys, Keys.PackDtrxSenderKey(dtrxOp.Sender, dtrxOp.SenderId, dtrxOp.TransactionId, blockID))
+	}
+
+	if dtrxOp.DelayUntil != "" {
+		delayUntil, err := time.Parse(dtrxTimeLayout, dtrxOp.DelayUntil)
+		if err != nil {
+			return false, fmt.Errorf("delay until of dtrx %s: invalid time %q: %w", dtrxOp.TransactionId, dtrxOp.DelayUntil, err)
+		}
+		keys = append(keys, Keys.PackDtrxDelayUntilKey(delayUntil, dtrxOp.TransactionId, blockID))
+	}
+
+	for _, key := range keys {
+		_, err := db.trxReadStore.Get(ctx, key)
+		if err == store.ErrNotFound {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("get dtrx index: %w", err)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/eoscanada/eos-go"
+	"github.com/streamingfast/kvdb/store"
+	"go.uber.org/zap"
+)
+
+func (db *DB) ListProducerBlocks(ctx context.Context, producer string, lowBlockNum, highBlockNum uint32, limit int) (out []*pbcodec.BlockWithRefs, err error) {
+	if _, err := eos.StringToName(producer); err != nil {
+		return nil, fmt.Errorf("invalid producer %q: %w", producer, err)
+	}
+
+	if lowBlockNum > highBlockNum {
+		return nil, fmt.Errorf("invalid block range: low block num %d is higher than high block num %d", lowBlockNum, highBlockNum)
+	}
+
+	db.logger.Debug("list producer blocks", zap.String("producer", producer), zap.Uint32("low_block_num", lowBlockNum), zap.Uint32("high_block_num", highBlockNum), zap.Int("limit", limit))
+
+	end := Keys.EndOfProducerBlocks(producer)
+	if lowBlockNum > 0 {
+		end = Keys.PackProducerBlockNumPrefix(producer, lowBlockNum-1)
+	}
+
+	var keys [][]byte
+	it := db.blkReadStore.Scan(ctx, Keys.PackProducerBlockNumPrefix(producer, highBlockNum), end, limit)
+	for it.Next() {
+		_, blockID := Keys.UnpackProducerBlocksKey(it.Item().Key)
+		keys = append(keys, Keys.PackBlocksKey(blockID))
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	blockRows, err := db.getBlockRows(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blockRow := range blockRows {
+		blk, err := db.blockRowToBlockWithRef(ctx, blockRow)
+		if err != nil {
+			return nil, fmt.Errorf("block with ref: %w", err)
+		}
+		out = append(out, blk)
+	}
+
+	return
+}
+
+func (db *DB) GetProducerStats(ctx context.Context, lowBlockNum, highBlockNum uint32) ([]*trxdb.ProducerStats, error) {
+	if lowBlockNum > highBlockNum {
+		return nil, fmt.Errorf("invalid block range: low block num %d is higher than high block num %d", lowBlockNum, highBlockNum)
+	}
+
+	db.logger.Debug("get producer stats", zap.Uint32("low_block_num", lowBlockNum), zap.Uint32("high_block_num", highBlockNum))
+
+	end := Keys.EndOfIrrBlockTable()
+	if lowBlockNum > 0 {
+		end = Keys.PackIrrBlockNumPrefix(lowBlockNum - 1)
+	}
+
+	var keys [][]byte
+	it := db.blkReadStore.Scan(ctx, Keys.PackIrrBlockNumPrefix(highBlockNum), end, store.Unlimited)
+	for it.Next() {
+		keys = append(keys, Keys.PackBlocksKey(Keys.UnpackIrrBlocksKey(it.Item().Key)))
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	blockRows, err := db.getBlockRows(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(blockRows, func(i, j int) bool { return blockRows[i].Block.Number < blockRows[j].Block.Number })
+
+	tracker := trxdb.NewProducerStatsTracker()
+	for _, blockRow := range blockRows {
+		tracker.Track(blockRow.Block)
+	}
+
+	return tracker.Stats(), nil
+}
+
+// getBlockRows fetches the block rows of the given blocks table keys, preserving the
+// order of `keys`. Keys that are not found are skipped.
+func (db *DB) getBlockRows(ctx context.Context, keys [][]byte) (out []*pbtrxdb.BlockRow, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	rows := map[string]*pbtrxdb.BlockRow{}
+	it := db.blkReadStore.BatchGet(ctx, keys)
+	for it.Next() {
+		blockRow := &pbtrxdb.BlockRow{}
+		db.dec.MustInto(it.Item().Value, blockRow)
+		rows[string(it.Item().Key)] = blockRow
+	}
+	if err := it.Err(); err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if row, found := rows[string(key)]; found {
+			out = append(out, row)
+		}
+	}
+
+	return
+}
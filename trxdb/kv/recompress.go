@@ -0,0 +1,151 @@
+package kv
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
+	"github.com/streamingfast/kvdb/store"
+	"go.uber.org/zap"
+)
+
+// recompressFlushInterval is the number of blocks after which pending writes are flushed
+const recompressFlushInterval = 100
+
+type RecompressStats struct {
+	BlockCount   uint64
+	RowCount     uint64
+	RewriteCount uint64
+	BytesBefore  uint64
+	BytesAfter   uint64
+}
+
+// RecompressBlocks re-encodes, using the row compression currently configured, the rows
+// of every block (forked ones included) between `lowBlockNum` and `highBlockNum` (both
+// inclusive) along with the rows of their transactions, transaction traces and implicit
+// transactions. Only the tables this instance is allowed to write to are rewritten.
+//
+// The optional `progress` callback is invoked with the block number of each processed block.
+func (db *DB) RecompressBlocks(ctx context.Context, lowBlockNum, highBlockNum uint32, progress func(blockNum uint32)) (*RecompressStats, error) {
+	if db.writeStore == nil {
+		return nil, fmt.Errorf("recompress requires a writable store")
+	}
+
+	if lowBlockNum > highBlockNum {
+		return nil, fmt.Errorf("invalid block range: low block num %d is higher than high block num %d", lowBlockNum, highBlockNum)
+	}
+
+	db.logger.Info("recompressing blocks", zap.Uint32("low_block_num", lowBlockNum), zap.Uint32("high_block_num", highBlockNum))
+
+	end := Keys.EndOfBlocksTable()
+	if lowBlockNum > 0 {
+		end = Keys.PackBlockNumPrefix(lowBlockNum - 1)
+	}
+
+	stats := &RecompressStats{}
+	it := db.blkReadStore.Scan(ctx, Keys.PackBlockNumPrefix(highBlockNum), end, store.Unlimited)
+	for it.Next() {
+		item := it.Item()
+
+		blockRow := &pbtrxdb.BlockRow{}
+		if err := db.dec.Into(item.Value, blockRow); err != nil {
+			return nil, fmt.Errorf("decode block row %s: %w", Keys.UnpackBlocksKey(item.Key), err)
+		}
+
+		if db.enableBlkWrite {
+			if err := db.recompressRow(ctx, item.Key, item.Value, stats); err != nil {
+				return nil, err
+			}
+		}
+
+		if db.enableTrxWrite {
+			if err := db.recompressTransactionRows(ctx, blockRow, stats); err != nil {
+				return nil, fmt.Errorf("block %s: %w", blockRow.Block.Id, err)
+			}
+		}
+
+		stats.BlockCount++
+		if stats.BlockCount%recompressFlushInterval == 0 {
+			if err := db.writeStore.FlushPuts(ctx); err != nil {
+				return nil, fmt.Errorf("flush: %w", err)
+			}
+		}
+
+		if progress != nil {
+			progress(blockRow.Block.Number)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := db.writeStore.FlushPuts(ctx); err != nil {
+		return nil, fmt.Errorf("flush: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (db *DB) recompressTransactionRows(ctx context.Context, blockRow *pbtrxdb.BlockRow, stats *RecompressStats) error {
+	blockID := blockRow.Block.Id
+
+	var keys [][]byte
+	for _, trxID := range refsToIDs(blockRow.TrxRefs) {
+		keys = append(keys, Keys.PackTrxsKey(trxID, blockID))
+	}
+	for _, trxID := range refsToIDs(blockRow.TraceRefs) {
+		keys = append(keys, Keys.PackTrxTracesKey(trxID, blockID))
+	}
+	for _, trxID := range refsToIDs(blockRow.ImplicitTrxRefs) {
+		keys = append(keys, Keys.PackImplicitTrxsKey(trxID, blockID))
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	it := db.trxReadStore.BatchGet(ctx, keys)
+	for it.Next() {
+		if err := db.recompressRow(ctx, it.Item().Key, it.Item().Value, stats); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil && err != store.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) recompressRow(ctx context.Context, key, value []byte, stats *RecompressStats) error {
+	data, err := db.dec.DecodeRow(value)
+	if err != nil {
+		return fmt.Errorf("decode row %x: %w", key, err)
+	}
+
+	encoded := db.enc.EncodeRow(data)
+
+	stats.RowCount++
+	stats.BytesBefore += uint64(len(value))
+	stats.BytesAfter += uint64(len(encoded))
+
+	if string(encoded) == string(value) {
+		return nil
+	}
+
+	stats.RewriteCount++
+	if err := db.writeStore.Put(ctx, key, encoded); err != nil {
+		return fmt.Errorf("write row %x: %w", key, err)
+	}
+
+	return nil
+}
+
+func refsToIDs(refs *pbcodec.TransactionRefs) (out []string) {
+	for _, hash := range refs.GetHashes() {
+		out = append(out, hex.EncodeToString(hash))
+	}
+	return
+}
@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecompressBlocks(t *testing.T) {
+	ctx := context.Background()
+	factory := newTestDBFactory(t)
+	driver, clean := factory()
+	defer clean()
+
+	db := driver.(*DB)
+
+	trxID := "a1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	actTrace := ct.ActionTrace(t, "eosio.token:eosio.token:transfer")
+	actTrace.Console = strings.Repeat("transfer processed ", 100)
+
+	blk := ct.Block(t, "00000002aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		ct.TrxTrace(t, ct.TrxID(trxID), actTrace),
+	)
+	require.NoError(t, db.PutBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	traceKey := Keys.PackTrxTracesKey(trxID, blk.Id)
+	rawValue, err := db.trxReadStore.Get(ctx, traceKey)
+	require.NoError(t, err)
+
+	require.NoError(t, db.SetRowCompression("zstd"))
+	stats, err := db.RecompressBlocks(ctx, 0, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.BlockCount)
+	assert.Equal(t, uint64(2), stats.RowCount)
+	assert.Equal(t, uint64(1), stats.RewriteCount)
+	assert.Less(t, stats.BytesAfter, stats.BytesBefore)
+
+	compressedValue, err := db.trxReadStore.Get(ctx, traceKey)
+	require.NoError(t, err)
+	assert.Less(t, len(compressedValue), len(rawValue))
+	assertTraceConsole(t, db, trxID, actTrace.Console)
+
+	stats, err = db.RecompressBlocks(ctx, 0, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), stats.RewriteCount, "rows already compressed must be left untouched")
+
+	require.NoError(t, db.SetRowCompression("none"))
+	_, err = db.RecompressBlocks(ctx, 0, 10, nil)
+	require.NoError(t, err)
+
+	value, err := db.trxReadStore.Get(ctx, traceKey)
+	require.NoError(t, err)
+	assert.Equal(t, rawValue, value)
+	assertTraceConsole(t, db, trxID, actTrace.Console)
+}
+
+func TestRecompressBlocks_OutOfRange(t *testing.T) {
+	ctx := context.Background()
+	factory := newTestDBFactory(t)
+	driver, clean := factory()
+	defer clean()
+
+	db := driver.(*DB)
+	require.NoError(t, db.PutBlock(ctx, ct.Block(t, "00000002aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")))
+	require.NoError(t, db.Flush(ctx))
+
+	stats, err := db.RecompressBlocks(ctx, 3, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), stats.BlockCount)
+
+	_, err = db.RecompressBlocks(ctx, 10, 3, nil)
+	require.Error(t, err)
+}
+
+func assertTraceConsole(t *testing.T, db trxdb.DBReader, trxID string, expected string) {
+	events, err := db.GetTransactionTraces(context.Background(), trxID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, expected, events[0].GetExecution().Trace.ActionTraces[0].Console)
+}
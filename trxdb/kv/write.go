@@ -17,6 +17,7 @@ package kv
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/dfuse-io/dfuse-eosio/codec"
 	"github.com/dfuse-io/dfuse-eosio/codec/eosio"
@@ -94,35 +95,43 @@ func (db *DB) PutBlock(ctx context.Context, blk *pbcodec.Block) error {
 
 func (db *DB) putTransactions(ctx context.Context, blk *pbcodec.Block) error {
 	for _, trxReceipt := range blk.Transactions() {
-		if trxReceipt.PackedTransaction == nil {
-			// This means we deal with a deferred transaction receipt, and that it
-			// has been handled through DtrxOps already
-			continue
+		if err := db.putTransaction(ctx, blk, trxReceipt); err != nil {
+			return err
 		}
+	}
 
-		signedTransaction, err := codec.ExtractEOSSignedTransactionFromReceipt(trxReceipt)
-		if err != nil {
-			return fmt.Errorf("unable to extract EOS signed transaction from transaction receipt: %w", err)
-		}
+	return nil
+}
 
-		signedTrx := eosio.SignedTransactionToDEOS(signedTransaction)
-		pubKeyProto := &pbcodec.PublicKeys{
-			PublicKeys: eosio.GetPublicKeysFromSignedTransaction(db.writerChainID, signedTransaction),
-		}
+func (db *DB) putTransaction(ctx context.Context, blk *pbcodec.Block, trxReceipt *pbcodec.TransactionReceipt) error {
+	if trxReceipt.PackedTransaction == nil {
+		// This means we deal with a deferred transaction receipt, and that it
+		// has been handled through DtrxOps already
+		return nil
+	}
 
-		trxRow := &pbtrxdb.TrxRow{
-			Receipt:    trxReceipt,
-			SignedTrx:  signedTrx,
-			PublicKeys: pubKeyProto,
-		}
+	signedTransaction, err := codec.ExtractEOSSignedTransactionFromReceipt(trxReceipt)
+	if err != nil {
+		return fmt.Errorf("unable to extract EOS signed transaction from transaction receipt: %w", err)
+	}
 
-		key := Keys.PackTrxsKey(trxReceipt.Id, blk.Id)
-		// NOTE: This function is guarded by the parent with db.enableTrxWrite
-		err = db.writeStore.Put(ctx, key, db.enc.MustProto(trxRow))
+	signedTrx := eosio.SignedTransactionToDEOS(signedTransaction)
+	pubKeyProto := &pbcodec.PublicKeys{
+		PublicKeys: eosio.GetPublicKeysFromSignedTransaction(db.writerChainID, signedTransaction),
+	}
 
-		if err != nil {
-			return fmt.Errorf("put trx: write to db: %w", err)
-		}
+	trxRow := &pbtrxdb.TrxRow{
+		Receipt:    trxReceipt,
+		SignedTrx:  signedTrx,
+		PublicKeys: pubKeyProto,
+	}
+
+	key := Keys.PackTrxsKey(trxReceipt.Id, blk.Id)
+	// NOTE: This function is guarded by the parent with db.enableTrxWrite
+	err = db.writeStore.Put(ctx, key, db.enc.MustProto(trxRow))
+
+	if err != nil {
+		return fmt.Errorf("put trx: write to db: %w", err)
 	}
 
 	return nil
@@ -130,50 +139,94 @@ func (db *DB) putTransactions(ctx context.Context, blk *pbcodec.Block) error {
 
 func (db *DB) putTransactionTraces(ctx context.Context, blk *pbcodec.Block) error {
 	for _, trxTrace := range blk.TransactionTraces() {
-		// CHECK: can we have multiple dtrxops for the same transactionId in the same block?
-		for _, dtrxOp := range trxTrace.DtrxOps {
-			extDtrxOp := dtrxOp.ToExtDTrxOp(blk, trxTrace)
-
-			dtrxRow := &pbtrxdb.DtrxRow{}
-
-			var key []byte
-			if dtrxOp.IsCreateOperation() {
-				dtrxRow.SignedTrx = dtrxOp.Transaction
-				dtrxRow.CreatedBy = extDtrxOp
-				key = Keys.PackDtrxsKeyCreated(dtrxOp.TransactionId, blk.Id)
-			} else if dtrxOp.IsCancelOperation() {
-				dtrxRow.CanceledBy = extDtrxOp
-				key = Keys.PackDtrxsKeyCancelled(dtrxOp.TransactionId, blk.Id)
-			} else if dtrxOp.IsFailedOperation() {
-				key = Keys.PackDtrxsKeyFailed(dtrxOp.TransactionId, blk.Id)
-			} else {
-				return fmt.Errorf("put dtrxRow: handle dtrxOp Operation: unknown dtrxOp operation for trx id %s at action %d", trxTrace.Id, dtrxOp.ActionIndex)
-			}
+		if err := db.putTransactionTrace(ctx, blk, trxTrace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) putTransactionTrace(ctx context.Context, blk *pbcodec.Block, trxTrace *pbcodec.TransactionTrace) error {
+	// CHECK: can we have multiple dtrxops for the same transactionId in the same block?
+	for _, dtrxOp := range trxTrace.DtrxOps {
+		extDtrxOp := dtrxOp.ToExtDTrxOp(blk, trxTrace)
+
+		dtrxRow := &pbtrxdb.DtrxRow{}
+
+		var key []byte
+		if dtrxOp.IsCreateOperation() {
+			dtrxRow.SignedTrx = dtrxOp.Transaction
+			dtrxRow.CreatedBy = extDtrxOp
+			key = Keys.PackDtrxsKeyCreated(dtrxOp.TransactionId, blk.Id)
+		} else if dtrxOp.IsCancelOperation() {
+			dtrxRow.CanceledBy = extDtrxOp
+			key = Keys.PackDtrxsKeyCancelled(dtrxOp.TransactionId, blk.Id)
+		} else if dtrxOp.IsFailedOperation() {
+			key = Keys.PackDtrxsKeyFailed(dtrxOp.TransactionId, blk.Id)
+		} else {
+			return fmt.Errorf("put dtrxRow: handle dtrxOp Operation: unknown dtrxOp operation for trx id %s at action %d", trxTrace.Id, dtrxOp.ActionIndex)
+		}
 
-			// NOTE: This function is guarded by the parent with db.enableTrxWrite
-			if err := db.writeStore.Put(ctx, key, db.enc.MustProto(dtrxRow)); err != nil {
-				return fmt.Errorf("put dtrxRow: write to db: %w", err)
+		// NOTE: This function is guarded by the parent with db.enableTrxWrite
+		if err := db.writeStore.Put(ctx, key, db.enc.MustProto(dtrxRow)); err != nil {
+			return fmt.Errorf("put dtrxRow: write to db: %w", err)
+		}
+
+		if dtrxOp.IsCreateOperation() {
+			if err := db.putDtrxIndexes(ctx, blk, dtrxOp); err != nil {
+				return fmt.Errorf("put dtrxRow: %w", err)
 			}
 		}
+	}
+
+	codec.DeduplicateTransactionTrace(trxTrace)
 
-		codec.DeduplicateTransactionTrace(trxTrace)
+	trxTraceRow := &pbtrxdb.TrxTraceRow{
+		BlockHeader: blk.Header,
+		TrxTrace:    trxTrace,
+	}
+
+	if traceEnabled {
+		db.logger.Debug("put transaction trace row", zap.String("trx_id", trxTrace.Id), zap.String("block_id", blk.Id))
+	}
+
+	key := Keys.PackTrxTracesKey(trxTrace.Id, blk.Id)
+	// NOTE: This function is guarded by the parent with db.enableTrxWrite
+	if err := db.writeStore.Put(ctx, key, db.enc.MustProto(trxTraceRow)); err != nil {
+		return fmt.Errorf("put trxTraceRow: write to db: %w", err)
+	}
 
-		trxTraceRow := &pbtrxdb.TrxTraceRow{
-			BlockHeader: blk.Header,
-			TrxTrace:    trxTrace,
+	codec.ReduplicateTransactionTrace(trxTrace)
+
+	return nil
+}
+
+// putDtrxIndexes writes the `(sender, sender_id)` and `delay_until` index entries
+// of a deferred transaction creation. Operations lacking the information (like
+// transactions pushed with a delay, which have no sender) are simply not indexed.
+func (db *DB) putDtrxIndexes(ctx context.Context, blk *pbcodec.Block, dtrxOp *pbcodec.DTrxOp) error {
+	if dtrxOp.Sender != "" {
+		if _, err := SenderIDBytes(dtrxOp.SenderId); err != nil {
+			return fmt.Errorf("index sender of dtrx %s: %w", dtrxOp.TransactionId, err)
 		}
 
-		if traceEnabled {
-			db.logger.Debug("put transaction trace row", zap.String("trx_id", trxTrace.Id), zap.String("block_id", blk.Id))
+		key := Keys.PackDtrxSenderKey(dtrxOp.Sender, dtrxOp.SenderId, dtrxOp.TransactionId, blk.Id)
+		if err := db.writeStore.Put(ctx, key, oneByte); err != nil {
+			return fmt.Errorf("index sender: write to db: %w", err)
 		}
+	}
 
-		key := Keys.PackTrxTracesKey(trxTrace.Id, blk.Id)
-		// NOTE: This function is guarded by the parent with db.enableTrxWrite
-		if err := db.writeStore.Put(ctx, key, db.enc.MustProto(trxTraceRow)); err != nil {
-			return fmt.Errorf("put trxTraceRow: write to db: %w", err)
+	if dtrxOp.DelayUntil != "" {
+		delayUntil, err := time.Parse(dtrxTimeLayout, dtrxOp.DelayUntil)
+		if err != nil {
+			return fmt.Errorf("index delay until of dtrx %s: invalid time %q: %w", dtrxOp.TransactionId, dtrxOp.DelayUntil, err)
 		}
 
-		codec.ReduplicateTransactionTrace(trxTrace)
+		key := Keys.PackDtrxDelayUntilKey(delayUntil, dtrxOp.TransactionId, blk.Id)
+		if err := db.writeStore.Put(ctx, key, oneByte); err != nil {
+			return fmt.Errorf("index delay until: write to db: %w", err)
+		}
 	}
 
 	return nil
@@ -208,16 +261,24 @@ func (db *DB) putNewAccount(ctx context.Context, blk *pbcodec.Block, trace *pbco
 
 func (db *DB) putImplicitTransactions(ctx context.Context, blk *pbcodec.Block) error {
 	for _, trxOp := range blk.ImplicitTransactionOps() {
-		implTrxRow := &pbtrxdb.ImplicitTrxRow{
-			Name:      trxOp.Name,
-			SignedTrx: trxOp.Transaction,
+		if err := db.putImplicitTransaction(ctx, blk, trxOp); err != nil {
+			return err
 		}
+	}
 
-		key := Keys.PackImplicitTrxsKey(trxOp.TransactionId, blk.Id)
-		// NOTE: This function is guarded by the parent with db.enableTrxWrite
-		if err := db.writeStore.Put(ctx, key, db.enc.MustProto(implTrxRow)); err != nil {
-			return fmt.Errorf("put implTrx: write to db: %w", err)
-		}
+	return nil
+}
+
+func (db *DB) putImplicitTransaction(ctx context.Context, blk *pbcodec.Block, trxOp *pbcodec.TrxOp) error {
+	implTrxRow := &pbtrxdb.ImplicitTrxRow{
+		Name:      trxOp.Name,
+		SignedTrx: trxOp.Transaction,
+	}
+
+	key := Keys.PackImplicitTrxsKey(trxOp.TransactionId, blk.Id)
+	// NOTE: This function is guarded by the parent with db.enableTrxWrite
+	if err := db.writeStore.Put(ctx, key, db.enc.MustProto(implTrxRow)); err != nil {
+		return fmt.Errorf("put implTrx: write to db: %w", err)
 	}
 
 	return nil
@@ -275,6 +336,12 @@ func (db *DB) putBlock(ctx context.Context, blk *pbcodec.Block) error {
 		return fmt.Errorf("put block: write to db: %w", err)
 	}
 
+	if producer := blk.Header.GetProducer(); producer != "" {
+		if err := db.writeStore.Put(ctx, Keys.PackProducerBlocksKey(producer, blk.Id), oneByte); err != nil {
+			return fmt.Errorf("put block: write producer index: %w", err)
+		}
+	}
+
 	blk.UnfilteredTransactions = holdUnfilteredTransactions
 	blk.UnfilteredTransactionTraces = holdUnfilteredTransactionTraces
 	blk.UnfilteredImplicitTransactionOps = holdUnfilteredImplicitTransactionOps
@@ -332,3 +399,58 @@ func (db *DB) UpdateNowIrreversibleBlock(ctx context.Context, blk *pbcodec.Block
 
 	return nil
 }
+
+func (db *DB) PatchBlock(ctx context.Context, blk *pbcodec.Block, patch *trxdb.BlockPatch) error {
+	db.logger.Debug("patch block", zap.Stringer("block", blk.AsRef()), zap.Stringer("patch", patch))
+
+	if db.enableTrxWrite {
+		trxIDs := toSet(patch.TransactionIDs)
+		for _, trxReceipt := range blk.Transactions() {
+			if trxIDs[trxReceipt.Id] {
+				if err := db.putTransaction(ctx, blk, trxReceipt); err != nil {
+					return fmt.Errorf("patch block: %w", err)
+				}
+			}
+		}
+
+		traceIDs := toSet(patch.TransactionTraceIDs)
+		for _, trxTrace := range blk.TransactionTraces() {
+			if traceIDs[trxTrace.Id] {
+				if err := db.putTransactionTrace(ctx, blk, trxTrace); err != nil {
+					return fmt.Errorf("patch block: %w", err)
+				}
+			}
+		}
+
+		implicitTrxIDs := toSet(patch.ImplicitTransactionIDs)
+		for _, trxOp := range blk.ImplicitTransactionOps() {
+			if implicitTrxIDs[trxOp.TransactionId] {
+				if err := db.putImplicitTransaction(ctx, blk, trxOp); err != nil {
+					return fmt.Errorf("patch block: %w", err)
+				}
+			}
+		}
+	}
+
+	if db.enableBlkWrite && patch.Block {
+		if err := db.putBlock(ctx, blk); err != nil {
+			return fmt.Errorf("patch block: %w", err)
+		}
+	}
+
+	if patch.Irreversible {
+		if err := db.UpdateNowIrreversibleBlock(ctx, blk); err != nil {
+			return fmt.Errorf("patch block: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toSet(values []string) map[string]bool {
+	out := make(map[string]bool, len(values))
+	for _, value := range values {
+		out[value] = true
+	}
+	return out
+}
@@ -43,3 +43,18 @@ func WithPurgeableStoreOption(ttl, purgeInterval uint64) Option {
 		return nil
 	}
 }
+
+// WithRowCompression configures the compression applied to rows written from now on,
+// rows are always decoded transparently whatever the compression used to write them.
+func WithRowCompression(mode string) Option {
+	type compressibleStore interface {
+		SetRowCompression(mode string) error
+	}
+
+	return func(db DB) error {
+		if d, ok := db.(compressibleStore); ok {
+			return d.SetRowCompression(mode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlockPatch lists the rows of a single block that are missing or that do not
+// match the block's content, and that must be re-written.
+type BlockPatch struct {
+	// Block is true when the block row itself must be re-written
+	Block bool
+	// Irreversible is true when the rows written once the block is irreversible (the
+	// irreversibility marker, the timeline entries and the created accounts) must be written
+	Irreversible bool
+
+	TransactionIDs         []string
+	TransactionTraceIDs    []string
+	ImplicitTransactionIDs []string
+}
+
+func (p *BlockPatch) IsEmpty() bool {
+	return !p.Block && !p.Irreversible && len(p.TransactionIDs) == 0 && len(p.TransactionTraceIDs) == 0 && len(p.ImplicitTransactionIDs) == 0
+}
+
+func (p *BlockPatch) String() string {
+	var parts []string
+	if p.Block {
+		parts = append(parts, "block")
+	}
+	if p.Irreversible {
+		parts = append(parts, "irreversibility rows")
+	}
+	if len(p.TransactionIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("%d transactions", len(p.TransactionIDs)))
+	}
+	if len(p.TransactionTraceIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("%d transaction traces", len(p.TransactionTraceIDs)))
+	}
+	if len(p.ImplicitTransactionIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("%d implicit transactions", len(p.ImplicitTransactionIDs)))
+	}
+
+	if len(parts) == 0 {
+		return "nothing"
+	}
+
+	return strings.Join(parts, ", ")
+}
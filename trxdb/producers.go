@@ -0,0 +1,113 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb
+
+import (
+	"sort"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+)
+
+const (
+	// blockIntervalMs is the duration of a single block production slot
+	blockIntervalMs = 500
+	// blockTimestampEpochMs is the EOSIO `block_timestamp_type` epoch (2000-01-01T00:00:00Z)
+	blockTimestampEpochMs = 946684800000
+	// producerRepetitions is the number of consecutive slots given to a producer on each round
+	producerRepetitions = 12
+)
+
+// ProducerStats holds the block production statistics of a single producer
+// over a range of blocks.
+type ProducerStats struct {
+	Producer       string
+	ProducedBlocks uint32
+	MissedSlots    uint32
+}
+
+// ProducerStatsTracker derives per-producer statistics from a sequence of blocks
+// of the same chain. Missed slots are computed from the gaps between the
+// timestamps of two consecutive blocks, each empty slot being attributed to
+// the producer that was scheduled for it according to the active schedule of
+// the block closing the gap.
+type ProducerStatsTracker struct {
+	lastSlot uint64
+	stats    map[string]*ProducerStats
+}
+
+func NewProducerStatsTracker() *ProducerStatsTracker {
+	return &ProducerStatsTracker{
+		stats: map[string]*ProducerStats{},
+	}
+}
+
+// Track accounts for the given block, blocks must be tracked in increasing
+// block number order without holes, otherwise missed slots are over-counted.
+func (t *ProducerStatsTracker) Track(blk *pbcodec.Block) {
+	slot := BlockSlot(blk.MustTime())
+	if producer := blk.Header.GetProducer(); producer != "" {
+		t.get(producer).ProducedBlocks++
+	}
+
+	if t.lastSlot != 0 && slot > t.lastSlot+1 {
+		schedule := blk.ActiveScheduleProducers()
+		if len(schedule) > 0 {
+			for missed := t.lastSlot + 1; missed < slot; missed++ {
+				t.get(ScheduledProducer(schedule, missed)).MissedSlots++
+			}
+		}
+	}
+
+	t.lastSlot = slot
+}
+
+// Stats returns the accumulated statistics, sorted by producer name.
+func (t *ProducerStatsTracker) Stats() (out []*ProducerStats) {
+	for _, stats := range t.stats {
+		out = append(out, stats)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Producer < out[j].Producer })
+	return
+}
+
+func (t *ProducerStatsTracker) get(producer string) *ProducerStats {
+	stats, found := t.stats[producer]
+	if !found {
+		stats = &ProducerStats{Producer: producer}
+		t.stats[producer] = stats
+	}
+
+	return stats
+}
+
+// BlockSlot returns the block production slot matching the block time
+func BlockSlot(blockTime time.Time) uint64 {
+	ms := blockTime.UnixNano() / int64(time.Millisecond)
+	if ms < blockTimestampEpochMs {
+		return 0
+	}
+
+	return uint64(ms-blockTimestampEpochMs) / blockIntervalMs
+}
+
+// ScheduledProducer returns the producer of `schedule` that is expected to produce
+// the block at `slot`, following EOSIO round-robin of `producerRepetitions` slots
+// per producer.
+func ScheduledProducer(schedule []string, slot uint64) string {
+	index := (slot % uint64(len(schedule)*producerRepetitions)) / producerRepetitions
+	return schedule[index]
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb
+
+import (
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundStart is aligned on the start of a production round for a schedule of 2 producers
+var roundStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestProducerStatsTracker(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   []*pbcodec.Block
+		expected []*ProducerStats
+	}{
+		{
+			name: "no missed slots",
+			blocks: []*pbcodec.Block{
+				producedBlock(t, "bp1", 0, "bp1", "bp2"),
+				producedBlock(t, "bp1", 1, "bp1", "bp2"),
+				producedBlock(t, "bp1", 2, "bp1", "bp2"),
+			},
+			expected: []*ProducerStats{
+				{Producer: "bp1", ProducedBlocks: 3},
+			},
+		},
+		{
+			name: "missed slots across producers",
+			blocks: []*pbcodec.Block{
+				producedBlock(t, "bp1", 0, "bp1", "bp2"),
+				producedBlock(t, "bp1", 1, "bp1", "bp2"),
+				producedBlock(t, "bp2", 14, "bp1", "bp2"),
+			},
+			expected: []*ProducerStats{
+				{Producer: "bp1", ProducedBlocks: 2, MissedSlots: 10},
+				{Producer: "bp2", ProducedBlocks: 1, MissedSlots: 2},
+			},
+		},
+		{
+			name: "missed full round",
+			blocks: []*pbcodec.Block{
+				producedBlock(t, "bp2", 23, "bp1", "bp2"),
+				producedBlock(t, "bp2", 36, "bp1", "bp2"),
+			},
+			expected: []*ProducerStats{
+				{Producer: "bp1", MissedSlots: 12},
+				{Producer: "bp2", ProducedBlocks: 2},
+			},
+		},
+		{
+			name: "no schedule",
+			blocks: []*pbcodec.Block{
+				producedBlock(t, "bp1", 0),
+				producedBlock(t, "bp1", 5),
+			},
+			expected: []*ProducerStats{
+				{Producer: "bp1", ProducedBlocks: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewProducerStatsTracker()
+			for _, blk := range test.blocks {
+				tracker.Track(blk)
+			}
+
+			assert.Equal(t, test.expected, tracker.Stats())
+		})
+	}
+}
+
+func TestScheduledProducer(t *testing.T) {
+	schedule := []string{"bp1", "bp2", "bp3"}
+	start := BlockSlot(roundStart)
+
+	assert.Equal(t, "bp1", ScheduledProducer(schedule, start))
+	assert.Equal(t, "bp1", ScheduledProducer(schedule, start+11))
+	assert.Equal(t, "bp2", ScheduledProducer(schedule, start+12))
+	assert.Equal(t, "bp3", ScheduledProducer(schedule, start+35))
+	assert.Equal(t, "bp1", ScheduledProducer(schedule, start+36))
+}
+
+func producedBlock(t *testing.T, producer string, slotOffset int, schedule ...string) *pbcodec.Block {
+	timestamp, err := ptypes.TimestampProto(roundStart.Add(time.Duration(slotOffset) * 500 * time.Millisecond))
+	require.NoError(t, err)
+
+	blk := &pbcodec.Block{
+		Header: &pbcodec.BlockHeader{
+			Producer:  producer,
+			Timestamp: timestamp,
+		},
+	}
+
+	if len(schedule) > 0 {
+		blk.ActiveScheduleV1 = &pbcodec.ProducerSchedule{}
+		for _, name := range schedule {
+			blk.ActiveScheduleV1.Producers = append(blk.ActiveScheduleV1.Producers, &pbcodec.ProducerKey{AccountName: name})
+		}
+	}
+
+	return blk
+}
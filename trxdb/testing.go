@@ -95,6 +95,22 @@ func (db *testDriver) GetTransactionEventsBatch(ctx context.Context, idPrefixes
 	panic("test driver, not callable")
 }
 
+func (db *testDriver) ListPendingDeferredTransactions(ctx context.Context, sender, senderID string, inChain ChainDiscriminator) ([]*pbcodec.TransactionEvent, error) {
+	panic("test driver, not callable")
+}
+
+func (db *testDriver) ListDeferredTransactionsDueBetween(ctx context.Context, low, high time.Time, inChain ChainDiscriminator) ([]*pbcodec.TransactionEvent, error) {
+	panic("test driver, not callable")
+}
+
+func (db *testDriver) ListProducerBlocks(ctx context.Context, producer string, lowBlockNum, highBlockNum uint32, limit int) ([]*pbcodec.BlockWithRefs, error) {
+	panic("test driver, not callable")
+}
+
+func (db *testDriver) GetProducerStats(ctx context.Context, lowBlockNum, highBlockNum uint32) ([]*ProducerStats, error) {
+	panic("test driver, not callable")
+}
+
 func (db *testDriver) BlockIDAt(ctx context.Context, start time.Time) (id string, err error) {
 	panic("test driver, not callable")
 }
@@ -0,0 +1,240 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var deferredTransactionsReaderTests = []DriverTestFunc{
+	TestListPendingDeferredTransactions,
+	TestListDeferredTransactionsDueBetween,
+	TestHasDeferredTransactionIndexes,
+}
+
+const (
+	dtrxBlock2  = "00000002aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	dtrxBlock3  = "00000003aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	dtrxBlock3b = "00000003bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	dtrxPending   = "d1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	dtrxCancelled = "d2aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	dtrxExecuted  = "d3aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	dtrxOther     = "d4aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+func TestListPendingDeferredTransactions(t *testing.T, driverFactory DriverFactory) {
+	tests := []struct {
+		name        string
+		sender      string
+		senderID    string
+		inChain     trxdb.ChainDiscriminator
+		expectedIDs []string
+		expectedErr bool
+	}{
+		{
+			name:        "all sender ids",
+			sender:      "scheduler",
+			expectedIDs: []string{dtrxPending},
+		},
+		{
+			name:        "specific sender id",
+			sender:      "scheduler",
+			senderID:    "4",
+			expectedIDs: []string{dtrxPending},
+		},
+		{
+			name:     "specific sender id, nothing pending",
+			sender:   "scheduler",
+			senderID: "2",
+		},
+		{
+			name:        "other sender",
+			sender:      "other",
+			expectedIDs: []string{dtrxOther},
+		},
+		{
+			name:        "forked cancellation ignored",
+			sender:      "scheduler",
+			inChain:     func(blockID string) bool { return blockID != dtrxBlock3b },
+			expectedIDs: []string{dtrxCancelled, dtrxPending},
+		},
+		{
+			name:   "unknown sender",
+			sender: "unknown",
+		},
+		{
+			name:        "invalid sender id",
+			sender:      "scheduler",
+			senderID:    "-1",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, clean := driverFactory()
+			defer clean()
+
+			putDeferredTransactionsBlocks(t, db)
+
+			events, err := db.ListPendingDeferredTransactions(ctx, test.sender, test.senderID, test.inChain)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedIDs, eventIDs(events))
+			for _, ev := range events {
+				assert.NotNil(t, ev.GetDtrxScheduling())
+			}
+		})
+	}
+}
+
+func TestListDeferredTransactionsDueBetween(t *testing.T, driverFactory DriverFactory) {
+	tests := []struct {
+		name        string
+		low         time.Time
+		high        time.Time
+		expectedIDs []string
+		expectedErr bool
+	}{
+		{
+			name:        "whole range",
+			low:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			high:        time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			expectedIDs: []string{dtrxOther, dtrxPending},
+		},
+		{
+			name:        "high bound is exclusive",
+			low:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			high:        time.Date(2020, 1, 1, 0, 0, 4, 0, time.UTC),
+			expectedIDs: []string{dtrxOther},
+		},
+		{
+			name: "nothing due",
+			low:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			high: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "invalid window",
+			low:         time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			high:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, clean := driverFactory()
+			defer clean()
+
+			putDeferredTransactionsBlocks(t, db)
+
+			events, err := db.ListDeferredTransactionsDueBetween(ctx, test.low, test.high, nil)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedIDs, eventIDs(events))
+		})
+	}
+}
+
+func TestHasDeferredTransactionIndexes(t *testing.T, driverFactory DriverFactory) {
+	ctx := context.Background()
+	db, clean := driverFactory()
+	defer clean()
+
+	checker, ok := db.(trxdb.DeferredTransactionIndexChecker)
+	if !ok {
+		t.Skip("driver does not implement trxdb.DeferredTransactionIndexChecker")
+	}
+
+	putDeferredTransactionsBlocks(t, db)
+
+	indexed, err := checker.HasDeferredTransactionIndexes(ctx, dtrxBlock2, scheduledDtrxOp(t, dtrxPending, "scheduler", "4", "2020-01-01T00:00:04.500"))
+	require.NoError(t, err)
+	assert.True(t, indexed)
+
+	indexed, err = checker.HasDeferredTransactionIndexes(ctx, dtrxBlock3, scheduledDtrxOp(t, dtrxPending, "scheduler", "4", "2020-01-01T00:00:04.500"))
+	require.NoError(t, err)
+	assert.False(t, indexed)
+
+	indexed, err = checker.HasDeferredTransactionIndexes(ctx, dtrxBlock2, scheduledDtrxOp(t, dtrxPending, "scheduler", "4", "2020-01-01T00:00:09"))
+	require.NoError(t, err)
+	assert.False(t, indexed)
+
+	_, err = checker.HasDeferredTransactionIndexes(ctx, dtrxBlock2, scheduledDtrxOp(t, dtrxPending, "scheduler", "-1", ""))
+	require.Error(t, err)
+}
+
+func putDeferredTransactionsBlocks(t *testing.T, db trxdb.DB) {
+	ctx := context.Background()
+
+	blocks := []*pbcodec.Block{
+		ct.Block(t, dtrxBlock2,
+			ct.TrxTrace(t, ct.TrxID("c1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+				scheduledDtrxOp(t, dtrxPending, "scheduler", "4", "2020-01-01T00:00:04.500"),
+				scheduledDtrxOp(t, dtrxCancelled, "scheduler", "2", "2020-01-01T00:00:05"),
+				scheduledDtrxOp(t, dtrxExecuted, "scheduler", "3", "2020-01-01T00:00:03"),
+				scheduledDtrxOp(t, dtrxOther, "other", "1", "2020-01-01T00:00:02"),
+			),
+		),
+		ct.Block(t, dtrxBlock3,
+			ct.TrxTrace(t, ct.TrxID(dtrxExecuted)),
+		),
+		ct.Block(t, dtrxBlock3b,
+			ct.TrxTrace(t, ct.TrxID("c2aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+				ct.DtrxOp(t, "cancel", dtrxCancelled),
+			),
+		),
+	}
+
+	for _, blk := range blocks {
+		require.NoError(t, db.PutBlock(ctx, blk))
+	}
+	require.NoError(t, db.Flush(ctx))
+}
+
+func scheduledDtrxOp(t *testing.T, trxID, sender, senderID, delayUntil string) *pbcodec.DTrxOp {
+	op := ct.DtrxOp(t, "create", trxID, ct.SignedTrx(t))
+	op.Sender = sender
+	op.SenderId = senderID
+	op.DelayUntil = delayUntil
+
+	return op
+}
+
+func eventIDs(events []*pbcodec.TransactionEvent) (out []string) {
+	for _, ev := range events {
+		out = append(out, ev.Id)
+	}
+	return
+}
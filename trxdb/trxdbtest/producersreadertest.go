@@ -0,0 +1,218 @@
+// Copyright 2019 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdbtest
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var producersReaderTests = []DriverTestFunc{
+	TestListProducerBlocks,
+	TestGetProducerStats,
+}
+
+const (
+	producerBlock2  = "00000002aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	producerBlock3  = "00000003aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	producerBlock3b = "00000003bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	producerBlock4  = "00000004aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+func TestListProducerBlocks(t *testing.T, driverFactory DriverFactory) {
+	tests := []struct {
+		name        string
+		producer    string
+		low         uint32
+		high        uint32
+		limit       int
+		expectedIDs []string
+		expectedErr bool
+	}{
+		{
+			name:        "all blocks",
+			producer:    "bp1",
+			high:        math.MaxUint32,
+			limit:       10,
+			expectedIDs: []string{producerBlock3, producerBlock2},
+		},
+		{
+			name:        "includes forked blocks",
+			producer:    "bp2",
+			high:        math.MaxUint32,
+			limit:       10,
+			expectedIDs: []string{producerBlock4, producerBlock3b},
+		},
+		{
+			name:        "bounds are inclusive",
+			producer:    "bp2",
+			low:         3,
+			high:        3,
+			limit:       10,
+			expectedIDs: []string{producerBlock3b},
+		},
+		{
+			name:        "limited",
+			producer:    "bp1",
+			high:        math.MaxUint32,
+			limit:       1,
+			expectedIDs: []string{producerBlock3},
+		},
+		{
+			name:     "out of range",
+			producer: "bp1",
+			low:      4,
+			high:     10,
+			limit:    10,
+		},
+		{
+			name:     "unknown producer",
+			producer: "bp3",
+			high:     math.MaxUint32,
+			limit:    10,
+		},
+		{
+			name:        "invalid range",
+			producer:    "bp1",
+			low:         4,
+			high:        2,
+			limit:       10,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, clean := driverFactory()
+			defer clean()
+
+			putProducerBlocks(t, db)
+
+			blocks, err := db.ListProducerBlocks(ctx, test.producer, test.low, test.high, test.limit)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var ids []string
+			for _, blk := range blocks {
+				ids = append(ids, blk.Id)
+			}
+			assert.Equal(t, test.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGetProducerStats(t *testing.T, driverFactory DriverFactory) {
+	tests := []struct {
+		name        string
+		low         uint32
+		high        uint32
+		expected    []*trxdb.ProducerStats
+		expectedErr bool
+	}{
+		{
+			name: "whole range",
+			high: 10,
+			expected: []*trxdb.ProducerStats{
+				{Producer: "bp1", ProducedBlocks: 2, MissedSlots: 10},
+				{Producer: "bp2", ProducedBlocks: 1, MissedSlots: 2},
+			},
+		},
+		{
+			name: "no gap in range",
+			low:  2,
+			high: 3,
+			expected: []*trxdb.ProducerStats{
+				{Producer: "bp1", ProducedBlocks: 2},
+			},
+		},
+		{
+			name: "empty range",
+			low:  5,
+			high: 10,
+		},
+		{
+			name:        "invalid range",
+			low:         4,
+			high:        2,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, clean := driverFactory()
+			defer clean()
+
+			putProducerBlocks(t, db)
+
+			stats, err := db.GetProducerStats(ctx, test.low, test.high)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, stats)
+		})
+	}
+}
+
+// putProducerBlocks writes a small chain produced by `bp1` and `bp2`, the first block
+// being aligned on the start of a production round so that `bp2` misses slots 2 to 11
+// of `bp1` and the first 2 of its own before producing block #4.
+func putProducerBlocks(t *testing.T, db trxdb.DB) {
+	ctx := context.Background()
+
+	blocks := []*pbcodec.Block{
+		producerBlock(t, producerBlock2, "bp1", "2020-01-01T00:00:00Z"),
+		producerBlock(t, producerBlock3, "bp1", "2020-01-01T00:00:00.5Z"),
+		producerBlock(t, producerBlock3b, "bp2", "2020-01-01T00:00:01Z"),
+		producerBlock(t, producerBlock4, "bp2", "2020-01-01T00:00:07Z"),
+	}
+
+	for _, blk := range blocks {
+		require.NoError(t, db.PutBlock(ctx, blk))
+		if blk.Id != producerBlock3b {
+			require.NoError(t, db.UpdateNowIrreversibleBlock(ctx, blk))
+		}
+	}
+	require.NoError(t, db.Flush(ctx))
+}
+
+func producerBlock(t *testing.T, blockID, producer, blockTime string) *pbcodec.Block {
+	blk := ct.Block(t, blockID, ct.BlockTime(blockTime))
+	blk.Header.Producer = producer
+	blk.ActiveScheduleV1 = &pbcodec.ProducerSchedule{
+		Producers: []*pbcodec.ProducerKey{
+			{AccountName: "bp1"},
+			{AccountName: "bp2"},
+		},
+	}
+
+	return blk
+}
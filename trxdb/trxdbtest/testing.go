@@ -36,6 +36,8 @@ func TestAll(t *testing.T, driverName string, driverFactory DriverFactory) {
 		"accounts_reader":    accountsReaderTest,
 		"db_reader":          dbReaderTests,
 		"db_writer":          dbWritterTests,
+		"deferred_reader":    deferredTransactionsReaderTests,
+		"producers_reader":   producersReaderTests,
 		"timeline_exporter":  timelineExplorerTests,
 		"transaction_reader": transactionReaderTests,
 	}